@@ -3,12 +3,19 @@ package main
 import (
 	"os"
 
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
 	"github.com/JulienBreux/run-cli/internal/run/command"
 )
 
 func main() {
+	// API clients are shared by all calls, and closed on exit.
+	client.EnablePool()
+
 	cmd := command.New(os.Stdin, os.Stdout, os.Stderr)
-	if err := cmd.Execute(); err != nil {
+	err := cmd.Execute()
+	_ = client.ClosePool()
+	if err != nil {
 		_ = command.PrintError(os.Stderr, err)
+		os.Exit(command.ExitCode(err))
 	}
 }
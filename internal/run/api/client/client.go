@@ -3,12 +3,11 @@ package client
 import (
 	"fmt"
 	"strings"
-
-	"golang.org/x/oauth2/google"
 )
 
 // FindDefaultCredentials is a variable for dependency injection.
-var FindDefaultCredentials = google.FindDefaultCredentials
+// It returns the application default credentials, impersonating the service account set with Impersonate.
+var FindDefaultCredentials = findCredentials
 
 // WrapError wraps the error with a user-friendly message if it's an authentication error.
 func WrapError(err error) error {
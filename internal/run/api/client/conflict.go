@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// conflictRetries is the number of times an update is applied again after a concurrent change.
+const conflictRetries = 3
+
+// IsConflict returns whether an update failed with err because the resource was changed
+// concurrently, i.e. its etag is stale.
+func IsConflict(err error) bool {
+	switch status.Code(err) {
+	case codes.Aborted, codes.FailedPrecondition:
+		return true
+	}
+	return false
+}
+
+// ReadModifyWrite reads a resource, modifies it and writes it, returning the result of the write,
+// e.g. the operation of the update. The etag read with the resource makes the write fail when the
+// resource was changed concurrently, in which case it is read and modified again, at most
+// conflictRetries times. modify returns a *ConflictError when the concurrent change can't be
+// merged, see MergeField.
+func ReadModifyWrite[T, R any](ctx context.Context, read func(context.Context) (T, error), modify func(T) error, write func(context.Context, T) (R, error)) (R, error) {
+	var zero R
+	for retry := 0; ; retry++ {
+		resource, err := read(ctx)
+		if err != nil {
+			return zero, err
+		}
+		if err := modify(resource); err != nil {
+			return zero, err
+		}
+		result, err := write(ctx, resource)
+		if err == nil || retry >= conflictRetries || !IsConflict(err) {
+			return result, err
+		}
+	}
+}
+
+// FieldConflict is a field changed both by an update and concurrently, to different values.
+type FieldConflict struct {
+	Field string
+	// Base is the value the update was made from.
+	Base string
+	// Theirs is the value of the concurrent change.
+	Theirs string
+	// Mine is the value of the update.
+	Mine string
+}
+
+// ConflictError is the error of an update whose fields were changed concurrently.
+type ConflictError struct {
+	Resource string
+	Fields   []FieldConflict
+}
+
+func (e *ConflictError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		fields = append(fields, f.Field)
+	}
+	return fmt.Sprintf("%s was changed concurrently: %s", e.Resource, strings.Join(fields, ", "))
+}
+
+// Diff returns the three-way diff of the conflicting fields, one line per field.
+func (e *ConflictError) Diff() string {
+	var b strings.Builder
+	for _, f := range e.Fields {
+		fmt.Fprintf(&b, "%s: was %s, now %s, yours %s\n", f.Field, f.Base, f.Theirs, f.Mine)
+	}
+	return b.String()
+}
+
+// MergeField merges the value of a field set by an update, made from base, with its current value,
+// possibly changed concurrently: the changed value wins. When both changed it to different values,
+// the update wins and the field is added to conflicts.
+func MergeField[T comparable](conflicts *[]FieldConflict, field string, base, current, update T) T {
+	switch {
+	case update == base:
+		return current
+	case current == base, current == update:
+		return update
+	}
+	*conflicts = append(*conflicts, FieldConflict{
+		Field:  field,
+		Base:   fmt.Sprint(base),
+		Theirs: fmt.Sprint(current),
+		Mine:   fmt.Sprint(update),
+	})
+	return update
+}
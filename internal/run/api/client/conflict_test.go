@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsConflict(t *testing.T) {
+	assert.True(t, IsConflict(status.Error(codes.Aborted, "etag mismatch")))
+	assert.True(t, IsConflict(status.Error(codes.FailedPrecondition, "etag mismatch")))
+	assert.False(t, IsConflict(status.Error(codes.NotFound, "not found")))
+	assert.False(t, IsConflict(assert.AnError))
+}
+
+func TestReadModifyWrite(t *testing.T) {
+	reads, writes := 0, 0
+	read := func(ctx context.Context) (int, error) {
+		reads++
+		return reads, nil
+	}
+	modify := func(v int) error { return nil }
+	write := func(ctx context.Context, v int) (int, error) {
+		writes++
+		if v < 3 {
+			return 0, status.Error(codes.Aborted, "etag mismatch")
+		}
+		return v, nil
+	}
+
+	// The resource is read and modified again on conflict.
+	v, err := ReadModifyWrite(context.Background(), read, modify, write)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 3, writes)
+
+	// The retries are bounded.
+	reads, writes = 0, 0
+	alwaysConflict := func(ctx context.Context, v int) (int, error) {
+		writes++
+		return 0, status.Error(codes.FailedPrecondition, "etag mismatch")
+	}
+	_, err = ReadModifyWrite(context.Background(), read, modify, alwaysConflict)
+	assert.True(t, IsConflict(err))
+	assert.Equal(t, conflictRetries+1, writes)
+
+	// The other errors aren't retried.
+	reads, writes = 0, 0
+	failing := func(ctx context.Context, v int) (int, error) {
+		writes++
+		return 0, assert.AnError
+	}
+	_, err = ReadModifyWrite(context.Background(), read, modify, failing)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, writes)
+
+	_, err = ReadModifyWrite(context.Background(), read, func(v int) error { return &ConflictError{} }, write)
+	assert.IsType(t, &ConflictError{}, err)
+	_, err = ReadModifyWrite(context.Background(), func(ctx context.Context) (int, error) { return 0, assert.AnError }, modify, write)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMergeField(t *testing.T) {
+	var conflicts []FieldConflict
+
+	assert.Equal(t, 3, MergeField(&conflicts, "f", 1, 3, 1)) // only theirs changed
+	assert.Equal(t, 2, MergeField(&conflicts, "f", 1, 1, 2)) // only mine changed
+	assert.Equal(t, 2, MergeField(&conflicts, "f", 1, 2, 2)) // both changed alike
+	assert.Empty(t, conflicts)
+
+	assert.Equal(t, 4, MergeField(&conflicts, "f", 1, 3, 4))
+	assert.Equal(t, []FieldConflict{{Field: "f", Base: "1", Theirs: "3", Mine: "4"}}, conflicts)
+
+	err := &ConflictError{Resource: "service s1", Fields: append(conflicts, FieldConflict{Field: "g", Base: "a", Theirs: "b", Mine: "c"})}
+	assert.Equal(t, "service s1 was changed concurrently: f, g", err.Error())
+	assert.Equal(t, "f: was 1, now 3, yours 4\ng: was a, now b, yours c\n", err.Diff())
+}
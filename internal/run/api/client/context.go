@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// ErrReadOnly is returned by the calls changing resources when the current context is read-only.
+var ErrReadOnly = errors.New("the current context is read-only")
+
+// Variables for dependency injection
+var (
+	findDefaultCredentials = google.FindDefaultCredentials
+	credentialsTokenSource = impersonate.CredentialsTokenSource
+)
+
+var (
+	// settingsMu guards the settings of the API calls, changed while calls are made, e.g. when
+	// switching contexts in the TUI.
+	settingsMu                 sync.Mutex
+	impersonatedServiceAccount string
+	readOnly                   bool
+)
+
+// Impersonate makes all API calls impersonate the given service account, none when empty.
+func Impersonate(serviceAccount string) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	impersonatedServiceAccount = serviceAccount
+}
+
+// SetReadOnly prevents, or allows again, the API calls changing resources.
+func SetReadOnly(enabled bool) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	readOnly = enabled
+}
+
+// CheckWritable returns ErrReadOnly if the API calls changing resources are prevented.
+func CheckWritable() error {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	if readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// impersonated returns the service account impersonated by the API calls, see Impersonate.
+func impersonated() string {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	return impersonatedServiceAccount
+}
+
+func findCredentials(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+	serviceAccount := impersonated()
+	if serviceAccount == "" {
+		return findDefaultCredentials(ctx, scopes...)
+	}
+
+	// The source credentials must be allowed to call the IAM Credentials API.
+	creds, err := findDefaultCredentials(ctx, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) == 0 {
+		scopes = []string{cloudPlatformScope}
+	}
+	ts, err := credentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: serviceAccount,
+		Scopes:          scopes,
+	}, option.WithCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &google.Credentials{ProjectID: creds.ProjectID, TokenSource: ts}, nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+)
+
+func mockCredentials(t *testing.T) (gotScopes *[]string, gotConfig *impersonate.CredentialsConfig) {
+	origFind, origTokenSource := findDefaultCredentials, credentialsTokenSource
+	t.Cleanup(func() {
+		findDefaultCredentials, credentialsTokenSource = origFind, origTokenSource
+		Impersonate("")
+	})
+
+	gotScopes, gotConfig = new([]string), new(impersonate.CredentialsConfig)
+	findDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		*gotScopes = scopes
+		return &google.Credentials{ProjectID: "p"}, nil
+	}
+	credentialsTokenSource = func(ctx context.Context, config impersonate.CredentialsConfig, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+		*gotConfig = config
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "impersonated"}), nil
+	}
+	return
+}
+
+func TestFindCredentials(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		gotScopes, gotConfig := mockCredentials(t)
+
+		creds, err := findCredentials(context.Background(), "scope-a")
+		assert.NoError(t, err)
+		assert.Equal(t, "p", creds.ProjectID)
+		assert.Equal(t, []string{"scope-a"}, *gotScopes)
+		assert.Empty(t, gotConfig.TargetPrincipal)
+	})
+
+	t.Run("Impersonated", func(t *testing.T) {
+		gotScopes, gotConfig := mockCredentials(t)
+		Impersonate("deployer@p.iam.gserviceaccount.com")
+
+		creds, err := findCredentials(context.Background(), "scope-a")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{cloudPlatformScope}, *gotScopes)
+		assert.Equal(t, "deployer@p.iam.gserviceaccount.com", gotConfig.TargetPrincipal)
+		assert.Equal(t, []string{"scope-a"}, gotConfig.Scopes)
+
+		token, err := creds.TokenSource.Token()
+		assert.NoError(t, err)
+		assert.Equal(t, "impersonated", token.AccessToken)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockCredentials(t)
+		Impersonate("deployer@p.iam.gserviceaccount.com")
+		credentialsTokenSource = func(ctx context.Context, config impersonate.CredentialsConfig, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+			return nil, assert.AnError
+		}
+
+		_, err := findCredentials(context.Background())
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestCheckWritable(t *testing.T) {
+	defer SetReadOnly(false)
+
+	assert.NoError(t, CheckWritable())
+
+	SetReadOnly(true)
+	assert.ErrorIs(t, CheckWritable(), ErrReadOnly)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Names of the APIs whose endpoint can be overridden, see SetEndpoints.
+const (
+	APIRun             = "run"
+	APIResourceManager = "cloudresourcemanager"
+	APILogging         = "logging"
+)
+
+// APIs are the names of the APIs whose endpoint can be overridden.
+var APIs = []string{APIRun, APIResourceManager, APILogging}
+
+var endpoints map[string]string
+
+// SetEndpoints overrides the endpoints of the APIs, keyed by API name, e.g. run=localhost:8080.
+// Endpoints starting with http:// are called without TLS nor authentication, e.g. an emulator
+// or the fake server.
+func SetEndpoints(overrides map[string]string) error {
+	for api := range overrides {
+		if !slices.Contains(APIs, api) {
+			return fmt.Errorf("unknown API %q in endpoint overrides, expected one of %s", api, strings.Join(APIs, ", "))
+		}
+	}
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	endpoints = overrides
+	return nil
+}
+
+// endpoint returns the overridden endpoint of an API, empty if none.
+func endpoint(api string) string {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	return endpoints[api]
+}
+
+// endpointOptions returns the options connecting a client to the overridden endpoint of its API, if any.
+// plaintext is true when the endpoint is called without TLS nor authentication.
+func endpointOptions(key Key) (opts []option.ClientOption, plaintext bool) {
+	override := endpoint(key.API)
+	if override == "" {
+		return nil, false
+	}
+
+	host, plaintext := strings.CutPrefix(override, "http://")
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "https://"), "/")
+	switch {
+	case key.REST && plaintext:
+		return []option.ClientOption{option.WithEndpoint("http://" + host + "/"), option.WithoutAuthentication()}, true
+	case key.REST:
+		return []option.ClientOption{option.WithEndpoint("https://" + host + "/")}, false
+	case plaintext:
+		return []option.ClientOption{
+			option.WithEndpoint(host),
+			option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			option.WithoutAuthentication(),
+		}, true
+	default:
+		return []option.ClientOption{option.WithEndpoint(host)}, false
+	}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEndpoints(t *testing.T) {
+	t.Cleanup(func() { _ = SetEndpoints(nil) })
+
+	assert.NoError(t, SetEndpoints(map[string]string{APIRun: "localhost:8080"}))
+	assert.Equal(t, "localhost:8080", endpoint(APIRun))
+
+	err := SetEndpoints(map[string]string{"storage": "localhost:8080"})
+	assert.EqualError(t, err, `unknown API "storage" in endpoint overrides, expected one of run, cloudresourcemanager, logging`)
+	assert.Equal(t, "localhost:8080", endpoint(APIRun))
+}
+
+func TestEndpointOptions(t *testing.T) {
+	t.Cleanup(func() { _ = SetEndpoints(nil) })
+
+	opts, plaintext := endpointOptions(Key{API: APIRun})
+	assert.Empty(t, opts)
+	assert.False(t, plaintext)
+
+	assert.NoError(t, SetEndpoints(map[string]string{APIRun: "http://localhost:8080/", APILogging: "https://logging.example.com"}))
+
+	tests := []struct {
+		name          string
+		key           Key
+		wantOpts      int
+		wantPlaintext bool
+	}{
+		{"gRPC plaintext", Key{API: APIRun}, 3, true},
+		{"REST plaintext", Key{API: APIRun, REST: true}, 2, true},
+		{"gRPC TLS", Key{API: APILogging}, 1, false},
+		{"REST TLS", Key{API: APILogging, REST: true}, 1, false},
+		{"Not overridden", Key{API: APIResourceManager}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, plaintext := endpointOptions(tt.key)
+			assert.Len(t, opts, tt.wantOpts)
+			assert.Equal(t, tt.wantPlaintext, plaintext)
+		})
+	}
+}
+
+func TestGet_Plaintext(t *testing.T) {
+	create, credsCalls, _ := mockPool(t, false)
+	assert.NoError(t, SetEndpoints(map[string]string{APIRun: "http://localhost:8080"}))
+	t.Cleanup(func() { _ = SetEndpoints(nil) })
+
+	// No credentials are needed to call a fake server
+	c, _, err := Get(context.Background(), Key{API: APIRun}, nil, create)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, c.opts)
+	assert.Zero(t, *credsCalls)
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limits bound the API calls made with Call.
+type Limits struct {
+	// Parallelism is the maximum number of concurrent calls.
+	Parallelism int
+	// Retries is the number of retries of a call failing with a transient error, negative for none.
+	Retries int
+	// Backoff is the delay before the first retry, doubled on each retry up to MaxBackoff.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay between two retries.
+	MaxBackoff time.Duration
+	// Rate is the maximum number of calls per second.
+	Rate float64
+}
+
+// DefaultLimits are the limits used when none is set, see SetLimits.
+var DefaultLimits = Limits{
+	Parallelism: 8,
+	Retries:     3,
+	Backoff:     250 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+	Rate:        20,
+}
+
+// executor runs the API calls within limits.
+type executor struct {
+	limits  Limits
+	slots   chan struct{}
+	limiter *rate.Limiter
+}
+
+func newExecutor(l Limits) *executor {
+	return &executor{
+		limits:  l,
+		slots:   make(chan struct{}, l.Parallelism),
+		limiter: rate.NewLimiter(rate.Limit(l.Rate), max(l.Parallelism, 1)),
+	}
+}
+
+// Variables for dependency injection
+var (
+	sleep  = sleepContext
+	jitter = func(d time.Duration) time.Duration { return d/2 + rand.N(d/2+1) }
+)
+
+var (
+	executorMu      sync.Mutex
+	currentExecutor = newExecutor(DefaultLimits)
+)
+
+// SetLimits replaces the limits of the API calls. The zero values keep the default ones.
+func SetLimits(l Limits) {
+	if l.Parallelism <= 0 {
+		l.Parallelism = DefaultLimits.Parallelism
+	}
+	if l.Retries == 0 {
+		l.Retries = DefaultLimits.Retries
+	}
+	if l.Backoff <= 0 {
+		l.Backoff = DefaultLimits.Backoff
+	}
+	if l.MaxBackoff <= 0 {
+		l.MaxBackoff = DefaultLimits.MaxBackoff
+	}
+	if l.Rate <= 0 {
+		l.Rate = DefaultLimits.Rate
+	}
+
+	executorMu.Lock()
+	defer executorMu.Unlock()
+	currentExecutor = newExecutor(l)
+}
+
+// CurrentLimits returns the limits of the API calls, see SetLimits.
+func CurrentLimits() Limits {
+	executorMu.Lock()
+	defer executorMu.Unlock()
+	return currentExecutor.limits
+}
+
+// Call calls fn once a slot is free and the rate limit allows it, see SetLimits. Calls failing
+// with a transient error, e.g. UNAVAILABLE or RESOURCE_EXHAUSTED, are retried with an exponential
+// backoff and jitter. Calls must not be nested, the inner one could wait for a slot forever.
+func Call[T any](ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	executorMu.Lock()
+	e := currentExecutor
+	executorMu.Unlock()
+
+	var (
+		v   T
+		err error
+	)
+	backoff := e.limits.Backoff
+	for retry := 0; ; retry++ {
+		v, err = attempt(ctx, e, fn)
+		if err == nil || retry >= e.limits.Retries || !Retryable(err) {
+			return v, err
+		}
+		if sleepErr := sleep(ctx, jitter(backoff)); sleepErr != nil {
+			return v, err
+		}
+		backoff = min(backoff*2, e.limits.MaxBackoff)
+	}
+}
+
+// attempt calls fn once, holding a slot.
+func attempt[T any](ctx context.Context, e *executor, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if err := e.limiter.Wait(ctx); err != nil {
+		return zero, err
+	}
+	select {
+	case e.slots <- struct{}{}:
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+	defer func() { <-e.slots }()
+	return fn(ctx)
+}
+
+// Retryable returns whether a call failing with err may succeed when retried. ABORTED isn't, an
+// update failing with a stale etag is read and modified again instead, see ReadModifyWrite.
+func Retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockExecutor sets the limits and records the backoff delays instead of sleeping.
+func mockExecutor(t *testing.T, l Limits) *[]time.Duration {
+	origSleep, origJitter := sleep, jitter
+	t.Cleanup(func() {
+		sleep, jitter = origSleep, origJitter
+		SetLimits(Limits{})
+	})
+
+	var delays []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	jitter = func(d time.Duration) time.Duration { return d }
+	SetLimits(l)
+	return &delays
+}
+
+func TestSetLimits(t *testing.T) {
+	mockExecutor(t, Limits{Parallelism: 2})
+
+	limits := CurrentLimits()
+	assert.Equal(t, 2, limits.Parallelism)
+	assert.Equal(t, DefaultLimits.Retries, limits.Retries)
+	assert.Equal(t, DefaultLimits.Rate, limits.Rate)
+
+	SetLimits(Limits{})
+	assert.Equal(t, DefaultLimits, CurrentLimits())
+}
+
+func TestCall_Retries(t *testing.T) {
+	delays := mockExecutor(t, Limits{Retries: 4, Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Rate: 1000})
+
+	calls := 0
+	v, err := Call(context.Background(), func(ctx context.Context) (string, error) {
+		calls++
+		if calls < 4 {
+			return "", status.Error(codes.Unavailable, "unavailable")
+		}
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", v)
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}, *delays)
+}
+
+func TestCall_RetriesExhausted(t *testing.T) {
+	delays := mockExecutor(t, Limits{Retries: 2, Rate: 1000})
+
+	calls := 0
+	_, err := Call(context.Background(), func(ctx context.Context) (int, error) {
+		calls++
+		return 0, status.Error(codes.ResourceExhausted, "quota exceeded")
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 3, calls)
+	assert.Len(t, *delays, 2)
+}
+
+func TestCall_NotRetryable(t *testing.T) {
+	for _, retries := range []int{3, -1} {
+		t.Run(fmt.Sprint(retries), func(t *testing.T) {
+			delays := mockExecutor(t, Limits{Retries: retries, Rate: 1000})
+
+			calls := 0
+			_, err := Call(context.Background(), func(ctx context.Context) (int, error) {
+				calls++
+				if retries < 0 {
+					return 0, status.Error(codes.Unavailable, "unavailable")
+				}
+				return 0, status.Error(codes.NotFound, "not found")
+			})
+			assert.Error(t, err)
+			assert.Equal(t, 1, calls)
+			assert.Empty(t, *delays)
+		})
+	}
+}
+
+func TestCall_Cancelled(t *testing.T) {
+	mockExecutor(t, Limits{Rate: 1000})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := Call(ctx, func(ctx context.Context) (int, error) {
+		calls++
+		cancel()
+		return 0, status.Error(codes.Unavailable, "unavailable")
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestCall_Parallelism(t *testing.T) {
+	mockExecutor(t, Limits{Parallelism: 2, Rate: 1000})
+
+	var running, maxRunning atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = Call(context.Background(), func(ctx context.Context) (int, error) {
+				n := running.Add(1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return 0, nil
+			})
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, Retryable(status.Error(codes.Unavailable, "x")))
+	assert.True(t, Retryable(fmt.Errorf("wrapped: %w", status.Error(codes.ResourceExhausted, "x"))))
+	assert.True(t, Retryable(&googleapi.Error{Code: 503}))
+	assert.True(t, Retryable(&googleapi.Error{Code: 429}))
+	assert.False(t, Retryable(&googleapi.Error{Code: 404}))
+	assert.False(t, Retryable(status.Error(codes.PermissionDenied, "x")))
+	assert.False(t, Retryable(status.Error(codes.Aborted, "etag mismatch")))
+	assert.False(t, Retryable(errors.New("x")))
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PageSize is the number of resources of a page of the paginated lists, e.g. service.ListPage.
+const PageSize = 50
+
+// Page is a page of a paginated list.
+type Page[T any] struct {
+	Items []T
+	// Next is the token of the next page, empty for the last one.
+	Next string
+}
+
+// Paginate returns the page of items starting at the offset of token, empty for the first page,
+// and the token of the next page, empty for the last one. It paginates the lists made in memory,
+// e.g. in the demo mode, as the API does.
+func Paginate[T any](items []T, size int, token string) ([]T, string, error) {
+	offset := 0
+	if token != "" {
+		var err error
+		offset, err = strconv.Atoi(token)
+		if err != nil || offset < 0 || offset > len(items) {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+		}
+	}
+	if size <= 0 {
+		size = PageSize
+	}
+
+	end := min(offset+size, len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[offset:end], next, nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	page, next, err := Paginate(items, 2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, page)
+	assert.Equal(t, "2", next)
+
+	page, next, err = Paginate(items, 2, next)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, page)
+	assert.Equal(t, "4", next)
+
+	page, next, err = Paginate(items, 2, next)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5}, page)
+	assert.Empty(t, next)
+
+	page, next, err = Paginate(items, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, items, page)
+	assert.Empty(t, next)
+
+	for _, token := range []string{"x", "-1", "6"} {
+		_, _, err = Paginate(items, 2, token)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), token)
+	}
+}
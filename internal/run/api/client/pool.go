@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+)
+
+// Key identifies the clients of an API in the pool.
+type Key struct {
+	// API is the name of the API, e.g. run, see SetEndpoints.
+	API string
+	// Client is the name of the client, e.g. services.
+	Client string
+	// REST is true for the clients using HTTP and JSON instead of gRPC.
+	REST bool
+}
+
+// pool caches credentials and clients, so that their connections are reused between calls.
+type pool struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// entry is a pooled value, ready is closed once it is created.
+type entry struct {
+	ready chan struct{}
+	value any
+	err   error
+}
+
+var (
+	poolMu     sync.Mutex
+	clientPool *pool
+)
+
+// EnablePool makes Get reuse credentials and clients until ClosePool is called.
+func EnablePool() {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if clientPool == nil {
+		clientPool = &pool{entries: map[string]*entry{}}
+	}
+}
+
+// ClosePool closes the pooled clients and disables the pool.
+func ClosePool() error {
+	poolMu.Lock()
+	p := clientPool
+	clientPool = nil
+	poolMu.Unlock()
+	if p == nil {
+		return nil
+	}
+	return p.close()
+}
+
+func currentPool() *pool {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	return clientPool
+}
+
+// Get returns a client of the API authenticated with the default credentials, see FindDefaultCredentials.
+// The client is created with the credentials and endpoint options, see SetEndpoints, and release must be called once
+// it is no longer used. When the pool is enabled, the credentials and the client are created on first
+// use, shared by all callers using the same key and account, and only closed by ClosePool. Otherwise,
+// release closes the client.
+func Get[T any](ctx context.Context, key Key, scopes []string, create func(context.Context, ...option.ClientOption) (T, error)) (c T, release func() error, err error) {
+	p := currentPool()
+	if p == nil {
+		c, err = newClient(ctx, key, scopes, create)
+		if err != nil {
+			return c, nil, err
+		}
+		return c, func() error { return closeClient(c) }, nil
+	}
+
+	v, err := p.get(ctx, "client:"+impersonated()+"|"+key.API+"|"+key.Client+"|"+endpoint(key.API), func() (any, error) {
+		// Pooled clients outlive the call which creates them.
+		return newClient(context.WithoutCancel(ctx), key, scopes, func(ctx context.Context, opts ...option.ClientOption) (any, error) {
+			return create(ctx, opts...)
+		})
+	})
+	if err != nil {
+		return c, nil, err
+	}
+	return v.(T), func() error { return nil }, nil
+}
+
+func newClient[T any](ctx context.Context, key Key, scopes []string, create func(context.Context, ...option.ClientOption) (T, error)) (T, error) {
+	opts, plaintext := endpointOptions(key)
+	if plaintext {
+		return create(ctx, opts...)
+	}
+
+	var zero T
+	creds, err := credentials(ctx, scopes)
+	if err != nil {
+		return zero, fmt.Errorf("failed to find default credentials: %w. Tip: Try running 'gcloud auth application-default login' to authenticate the Go client", err)
+	}
+	return create(ctx, append([]option.ClientOption{option.WithCredentials(creds)}, opts...)...)
+}
+
+// credentials returns the default credentials, cached by the pool when it is enabled.
+func credentials(ctx context.Context, scopes []string) (*google.Credentials, error) {
+	p := currentPool()
+	if p == nil {
+		return FindDefaultCredentials(ctx, scopes...)
+	}
+
+	v, err := p.get(ctx, "credentials:"+impersonated()+"|"+strings.Join(scopes, " "), func() (any, error) {
+		return FindDefaultCredentials(context.WithoutCancel(ctx), scopes...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*google.Credentials), nil
+}
+
+// get returns the value of the key, created once by create. Failures are not cached.
+func (p *pool) get(ctx context.Context, key string, create func() (any, error)) (any, error) {
+	p.mu.Lock()
+	e, ok := p.entries[key]
+	if !ok {
+		e = &entry{ready: make(chan struct{})}
+		p.entries[key] = e
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		e.value, e.err = create()
+		if e.err != nil {
+			p.mu.Lock()
+			if p.entries[key] == e {
+				delete(p.entries, key)
+			}
+			p.mu.Unlock()
+		}
+		close(e.ready)
+	}
+
+	select {
+	case <-e.ready:
+		return e.value, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *pool) close() error {
+	p.mu.Lock()
+	entries := p.entries
+	p.entries = map[string]*entry{}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, e := range entries {
+		<-e.ready
+		if err := closeClient(e.value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func closeClient(c any) error {
+	if closer, ok := c.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
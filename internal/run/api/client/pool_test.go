@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+)
+
+type mockClient struct {
+	id     int
+	opts   int
+	closed bool
+}
+
+func (c *mockClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+// mockPool mocks the credentials and returns a creator of numbered clients, with the number of calls.
+func mockPool(t *testing.T, enabled bool) (create func(context.Context, ...option.ClientOption) (*mockClient, error), credsCalls, createCalls *int) {
+	origFind := FindDefaultCredentials
+	t.Cleanup(func() {
+		FindDefaultCredentials = origFind
+		Impersonate("")
+		_ = ClosePool()
+	})
+	if enabled {
+		EnablePool()
+	}
+
+	var mu sync.Mutex
+	credsCalls, createCalls = new(int), new(int)
+	FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*credsCalls++
+		return &google.Credentials{}, nil
+	}
+	create = func(ctx context.Context, opts ...option.ClientOption) (*mockClient, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*createCalls++
+		return &mockClient{id: *createCalls, opts: len(opts)}, nil
+	}
+	return
+}
+
+func TestGet_WithoutPool(t *testing.T) {
+	create, credsCalls, createCalls := mockPool(t, false)
+
+	c1, release, err := Get(context.Background(), Key{API: "a"}, nil, create)
+	assert.NoError(t, err)
+	assert.NoError(t, release())
+	assert.True(t, c1.closed)
+
+	c2, _, err := Get(context.Background(), Key{API: "a"}, nil, create)
+	assert.NoError(t, err)
+	assert.NotSame(t, c1, c2)
+	assert.Equal(t, 2, *credsCalls)
+	assert.Equal(t, 2, *createCalls)
+}
+
+func TestGet_Pool(t *testing.T) {
+	t.Run("Reuse", func(t *testing.T) {
+		create, credsCalls, createCalls := mockPool(t, true)
+
+		c1, release, err := Get(context.Background(), Key{API: "a"}, []string{"s"}, create)
+		assert.NoError(t, err)
+		assert.NoError(t, release())
+		assert.False(t, c1.closed)
+
+		c2, _, err := Get(context.Background(), Key{API: "a"}, []string{"s"}, create)
+		assert.NoError(t, err)
+		assert.Same(t, c1, c2)
+
+		// Another API shares the credentials
+		c3, _, err := Get(context.Background(), Key{API: "b"}, []string{"s"}, create)
+		assert.NoError(t, err)
+		assert.NotSame(t, c1, c3)
+		assert.Equal(t, 1, *credsCalls)
+		assert.Equal(t, 2, *createCalls)
+
+		assert.NoError(t, ClosePool())
+		assert.True(t, c1.closed)
+		assert.True(t, c3.closed)
+	})
+
+	t.Run("Endpoint", func(t *testing.T) {
+		create, _, _ := mockPool(t, true)
+
+		c1, _, err := Get(context.Background(), Key{API: "run"}, nil, create)
+		assert.NoError(t, err)
+		assert.NoError(t, SetEndpoints(map[string]string{APIRun: "europe-west1-run.googleapis.com"}))
+		t.Cleanup(func() { _ = SetEndpoints(nil) })
+		c2, _, err := Get(context.Background(), Key{API: "run"}, nil, create)
+		assert.NoError(t, err)
+		assert.NotSame(t, c1, c2)
+		assert.Equal(t, 1, c1.opts)
+		assert.Equal(t, 2, c2.opts)
+	})
+
+	t.Run("Impersonation", func(t *testing.T) {
+		create, credsCalls, _ := mockPool(t, true)
+
+		c1, _, err := Get(context.Background(), Key{API: "a"}, nil, create)
+		assert.NoError(t, err)
+		Impersonate("deployer@p.iam.gserviceaccount.com")
+		c2, _, err := Get(context.Background(), Key{API: "a"}, nil, create)
+		assert.NoError(t, err)
+		assert.NotSame(t, c1, c2)
+		assert.Equal(t, 2, *credsCalls)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		create, _, createCalls := mockPool(t, true)
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, err := Get(context.Background(), Key{API: "a"}, nil, create)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, *createCalls)
+	})
+
+	t.Run("Concurrent settings", func(t *testing.T) {
+		// The settings are changed while clients are got, e.g. when switching contexts in the TUI:
+		// run with -race to detect the unguarded accesses.
+		create, _, _ := mockPool(t, true)
+		t.Cleanup(func() { _ = SetEndpoints(nil) })
+
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if i%2 == 0 {
+					Impersonate("deployer@p.iam.gserviceaccount.com")
+				} else {
+					Impersonate("")
+				}
+				SetReadOnly(i%2 == 0)
+				_ = SetEndpoints(map[string]string{APIRun: "europe-west1-run.googleapis.com"})
+			}()
+			go func() {
+				defer wg.Done()
+				_, _, err := Get(context.Background(), Key{API: APIRun}, nil, create)
+				assert.NoError(t, err)
+				_ = CheckWritable()
+			}()
+		}
+		wg.Wait()
+		SetReadOnly(false)
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		create, _, createCalls := mockPool(t, true)
+
+		FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+			return nil, errors.New("no credentials")
+		}
+		_, _, err := Get(context.Background(), Key{API: "a"}, nil, create)
+		assert.ErrorContains(t, err, "failed to find default credentials: no credentials")
+
+		FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+			return &google.Credentials{}, nil
+		}
+		_, _, err = Get(context.Background(), Key{API: "a"}, nil, create)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *createCalls)
+	})
+}
+
+func TestClosePool(t *testing.T) {
+	assert.NoError(t, ClosePool())
+
+	EnablePool()
+	p := currentPool()
+	p.entries["k"] = &entry{ready: make(chan struct{}), value: closerFunc(func() error { return errors.New("close") })}
+	close(p.entries["k"].ready)
+
+	assert.EqualError(t, ClosePool(), "close")
+	assert.Nil(t, currentPool())
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	model_condition "github.com/JulienBreux/run-cli/internal/run/model/common/condition"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conditions maps the conditions of a resource.
+func Conditions(conditions []*runpb.Condition) []*model_condition.Condition {
+	var result []*model_condition.Condition
+	for _, c := range conditions {
+		result = append(result, Condition(c))
+	}
+	return result
+}
+
+// Condition maps a condition, nil when there is none, e.g. the terminal condition of a resource being
+// created.
+func Condition(c *runpb.Condition) *model_condition.Condition {
+	if c == nil {
+		return nil
+	}
+	return &model_condition.Condition{
+		Type:               c.Type,
+		State:              c.State.String(),
+		Message:            c.Message,
+		LastTransitionTime: Time(c.LastTransitionTime),
+		Severity:           c.Severity.String(),
+		Reason:             reason(c),
+	}
+}
+
+// reason returns the reason of a condition, common or specific to revisions or executions, empty when
+// there is none.
+func reason(c *runpb.Condition) string {
+	switch r := c.Reasons.(type) {
+	case *runpb.Condition_Reason:
+		return r.Reason.String()
+	case *runpb.Condition_RevisionReason_:
+		return r.RevisionReason.String()
+	case *runpb.Condition_ExecutionReason_:
+		return r.ExecutionReason.String()
+	}
+	return ""
+}
+
+// Time maps a timestamp, the zero time when it is unset, e.g. the deletion time of a resource which
+// isn't deleted.
+func Time(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	model_condition "github.com/JulienBreux/run-cli/internal/run/model/common/condition"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestConditions(t *testing.T) {
+	now := time.Now().UTC()
+	resp := []*runpb.Condition{
+		{
+			Type:               "Ready",
+			State:              runpb.Condition_CONDITION_FAILED,
+			Message:            "Container failed to start",
+			LastTransitionTime: timestamppb.New(now),
+			Severity:           runpb.Condition_ERROR,
+			Reasons:            &runpb.Condition_Reason{Reason: runpb.Condition_CONTAINER_MISSING},
+		},
+		{
+			Type:    "ContainerHealthy",
+			State:   runpb.Condition_CONDITION_SUCCEEDED,
+			Reasons: &runpb.Condition_RevisionReason_{RevisionReason: runpb.Condition_PENDING},
+		},
+		{
+			Type:    "Completed",
+			State:   runpb.Condition_CONDITION_FAILED,
+			Reasons: &runpb.Condition_ExecutionReason_{ExecutionReason: runpb.Condition_NON_ZERO_EXIT_CODE},
+		},
+	}
+
+	result := Conditions(resp)
+
+	assert.Equal(t, &model_condition.Condition{
+		Type:               "Ready",
+		State:              "CONDITION_FAILED",
+		Message:            "Container failed to start",
+		LastTransitionTime: now,
+		Severity:           "ERROR",
+		Reason:             "CONTAINER_MISSING",
+	}, result[0])
+	assert.Equal(t, "PENDING", result[1].Reason)
+	assert.True(t, result[1].LastTransitionTime.IsZero())
+	assert.Equal(t, "NON_ZERO_EXIT_CODE", result[2].Reason)
+}
+
+func TestCondition_Nil(t *testing.T) {
+	assert.Nil(t, Condition(nil))
+	assert.Empty(t, Condition(&runpb.Condition{Type: "Ready"}).Reason)
+}
+
+func TestTime(t *testing.T) {
+	now := time.Now().UTC()
+
+	assert.True(t, Time(nil).IsZero())
+	assert.Equal(t, now, Time(timestamppb.New(now)))
+}
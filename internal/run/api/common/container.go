@@ -0,0 +1,117 @@
+// Package common maps the parts shared by the Cloud Run resources, e.g. the containers of a revision
+// or of a job, from the API into the models.
+package common
+
+import (
+	"cloud.google.com/go/run/apiv2/runpb"
+	model_container "github.com/JulienBreux/run-cli/internal/run/model/common/container"
+	model_env "github.com/JulienBreux/run-cli/internal/run/model/common/env"
+	model_resources "github.com/JulienBreux/run-cli/internal/run/model/common/resources"
+	model_secret "github.com/JulienBreux/run-cli/internal/run/model/common/secret"
+)
+
+// Containers maps the containers of a revision or a task.
+func Containers(containers []*runpb.Container) []*model_container.Container {
+	var result []*model_container.Container
+	for _, c := range containers {
+		result = append(result, Container(c))
+	}
+	return result
+}
+
+// Container maps a container, with its environment, volume mounts and probes.
+func Container(c *runpb.Container) *model_container.Container {
+	var ports []*model_container.Port
+	for _, p := range c.Ports {
+		ports = append(ports, &model_container.Port{
+			Name:          p.Name,
+			ContainerPort: p.ContainerPort,
+		})
+	}
+
+	var resources *model_resources.Resources
+	if c.Resources != nil {
+		resources = &model_resources.Resources{
+			Limits:          c.Resources.Limits,
+			CPUIdle:         c.Resources.CpuIdle,
+			StartupCPUBoost: c.Resources.StartupCpuBoost,
+		}
+	}
+
+	var mounts []*model_container.VolumeMount
+	for _, m := range c.VolumeMounts {
+		mounts = append(mounts, &model_container.VolumeMount{
+			Name:      m.Name,
+			MountPath: m.MountPath,
+		})
+	}
+
+	return &model_container.Container{
+		Name:             c.Name,
+		Image:            c.Image,
+		Command:          c.Command,
+		Args:             c.Args,
+		Env:              envVars(c.Env),
+		Resources:        resources,
+		VolumeMounts:     mounts,
+		Ports:            ports,
+		LivenessProbe:    probe(c.LivenessProbe),
+		StartupProbe:     probe(c.StartupProbe),
+		WorkingDirectory: c.WorkingDir,
+		DependsOn:        c.DependsOn,
+	}
+}
+
+// envVars maps the environment variables of a container, either a value or a secret reference, whose
+// version is the key.
+func envVars(vars []*runpb.EnvVar) []*model_env.EnvVar {
+	var result []*model_env.EnvVar
+	for _, v := range vars {
+		e := &model_env.EnvVar{
+			Name:  v.Name,
+			Value: v.GetValue(),
+		}
+		if ref := v.GetValueSource().GetSecretKeyRef(); ref != nil {
+			e.Source = &model_env.EnvVarSource{
+				SecretKeyRef: &model_secret.SecretKeySelector{
+					Secret: ref.Secret,
+					Key:    ref.Version,
+				},
+			}
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// probe maps a probe, nil when the container has none. The gRPC probes have no action in the model.
+func probe(p *runpb.Probe) *model_container.Probe {
+	if p == nil {
+		return nil
+	}
+
+	result := &model_container.Probe{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		TimeoutSeconds:      p.TimeoutSeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		FailureThreshold:    p.FailureThreshold,
+	}
+	if h := p.GetHttpGet(); h != nil {
+		var headers []*model_container.HTTPHeader
+		for _, header := range h.HttpHeaders {
+			headers = append(headers, &model_container.HTTPHeader{
+				Name:  header.Name,
+				Value: header.Value,
+			})
+		}
+		result.HTTPGet = &model_container.HTTPGetAction{
+			Path:        h.Path,
+			HTTPHeaders: headers,
+			Port:        h.Port,
+		}
+	}
+	if s := p.GetTcpSocket(); s != nil {
+		result.TCPSocket = &model_container.TCPSocketAction{Port: s.Port}
+	}
+	return result
+}
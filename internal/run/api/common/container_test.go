@@ -0,0 +1,107 @@
+package common
+
+import (
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	model_container "github.com/JulienBreux/run-cli/internal/run/model/common/container"
+	model_env "github.com/JulienBreux/run-cli/internal/run/model/common/env"
+	model_resources "github.com/JulienBreux/run-cli/internal/run/model/common/resources"
+	model_secret "github.com/JulienBreux/run-cli/internal/run/model/common/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer(t *testing.T) {
+	resp := &runpb.Container{
+		Name:    "app",
+		Image:   "gcr.io/p/app:latest",
+		Command: []string{"/app"},
+		Args:    []string{"--verbose"},
+		Env: []*runpb.EnvVar{
+			{Name: "MODE", Values: &runpb.EnvVar_Value{Value: "prod"}},
+			{Name: "TOKEN", Values: &runpb.EnvVar_ValueSource{ValueSource: &runpb.EnvVarSource{
+				SecretKeyRef: &runpb.SecretKeySelector{Secret: "token", Version: "latest"},
+			}}},
+		},
+		Resources: &runpb.ResourceRequirements{
+			Limits:          map[string]string{"cpu": "1", "memory": "512Mi"},
+			CpuIdle:         true,
+			StartupCpuBoost: true,
+		},
+		Ports:        []*runpb.ContainerPort{{Name: "http1", ContainerPort: 8080}},
+		VolumeMounts: []*runpb.VolumeMount{{Name: "data", MountPath: "/data"}},
+		WorkingDir:   "/srv",
+		LivenessProbe: &runpb.Probe{
+			InitialDelaySeconds: 1,
+			TimeoutSeconds:      2,
+			PeriodSeconds:       3,
+			FailureThreshold:    4,
+			ProbeType: &runpb.Probe_HttpGet{HttpGet: &runpb.HTTPGetAction{
+				Path:        "/healthz",
+				HttpHeaders: []*runpb.HTTPHeader{{Name: "X-Probe", Value: "1"}},
+				Port:        8080,
+			}},
+		},
+		StartupProbe: &runpb.Probe{
+			PeriodSeconds: 5,
+			ProbeType:     &runpb.Probe_TcpSocket{TcpSocket: &runpb.TCPSocketAction{Port: 8080}},
+		},
+		DependsOn: []string{"sidecar"},
+	}
+
+	assert.Equal(t, &model_container.Container{
+		Name:    "app",
+		Image:   "gcr.io/p/app:latest",
+		Command: []string{"/app"},
+		Args:    []string{"--verbose"},
+		Env: []*model_env.EnvVar{
+			{Name: "MODE", Value: "prod"},
+			{Name: "TOKEN", Source: &model_env.EnvVarSource{
+				SecretKeyRef: &model_secret.SecretKeySelector{Secret: "token", Key: "latest"},
+			}},
+		},
+		Resources: &model_resources.Resources{
+			Limits:          map[string]string{"cpu": "1", "memory": "512Mi"},
+			CPUIdle:         true,
+			StartupCPUBoost: true,
+		},
+		VolumeMounts: []*model_container.VolumeMount{{Name: "data", MountPath: "/data"}},
+		Ports:        []*model_container.Port{{Name: "http1", ContainerPort: 8080}},
+		LivenessProbe: &model_container.Probe{
+			InitialDelaySeconds: 1,
+			TimeoutSeconds:      2,
+			PeriodSeconds:       3,
+			FailureThreshold:    4,
+			HTTPGet: &model_container.HTTPGetAction{
+				Path:        "/healthz",
+				HTTPHeaders: []*model_container.HTTPHeader{{Name: "X-Probe", Value: "1"}},
+				Port:        8080,
+			},
+		},
+		StartupProbe: &model_container.Probe{
+			PeriodSeconds: 5,
+			TCPSocket:     &model_container.TCPSocketAction{Port: 8080},
+		},
+		WorkingDirectory: "/srv",
+		DependsOn:        []string{"sidecar"},
+	}, Container(resp))
+}
+
+func TestContainer_NilFields(t *testing.T) {
+	result := Container(&runpb.Container{Image: "img"})
+
+	assert.Equal(t, "img", result.Image)
+	assert.Nil(t, result.Resources)
+	assert.Nil(t, result.LivenessProbe)
+	assert.Nil(t, result.StartupProbe)
+	assert.Empty(t, result.Env)
+}
+
+func TestContainers(t *testing.T) {
+	assert.Nil(t, Containers(nil))
+
+	result := Containers([]*runpb.Container{{Name: "app"}, {Name: "sidecar"}})
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "sidecar", result[1].Name)
+}
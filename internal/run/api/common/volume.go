@@ -0,0 +1,64 @@
+package common
+
+import (
+	"cloud.google.com/go/run/apiv2/runpb"
+	model_keytopath "github.com/JulienBreux/run-cli/internal/run/model/common/keytopath"
+	model_secret "github.com/JulienBreux/run-cli/internal/run/model/common/secret"
+	model_volume "github.com/JulienBreux/run-cli/internal/run/model/common/volume"
+)
+
+// Volumes maps the volumes of a revision or a task: secrets, Cloud SQL instances, in-memory
+// directories, Cloud Storage buckets and NFS shares.
+func Volumes(volumes []*runpb.Volume) []*model_volume.Volume {
+	var result []*model_volume.Volume
+	for _, v := range volumes {
+		result = append(result, volume(v))
+	}
+	return result
+}
+
+func volume(v *runpb.Volume) *model_volume.Volume {
+	result := &model_volume.Volume{Name: v.Name}
+
+	if s := v.GetSecret(); s != nil {
+		// The version of a secret is its key.
+		var items []*model_keytopath.KeyToPath
+		for _, item := range s.Items {
+			items = append(items, &model_keytopath.KeyToPath{
+				Key:  item.Version,
+				Path: item.Path,
+			})
+		}
+		result.Secret = &model_secret.SecretSource{
+			Secret:      s.Secret,
+			Items:       items,
+			DefaultMode: s.DefaultMode,
+		}
+	}
+	if sql := v.GetCloudSqlInstance(); sql != nil {
+		result.CloudSQLInstance = &model_volume.CloudSQLInstanceVolumeSource{
+			Instances: sql.Instances,
+		}
+	}
+	if dir := v.GetEmptyDir(); dir != nil {
+		result.EmptyDir = &model_volume.EmptyDirVolumeSource{
+			Medium:    dir.Medium.String(),
+			SizeLimit: dir.SizeLimit,
+		}
+	}
+	if gcs := v.GetGcs(); gcs != nil {
+		result.GCS = &model_volume.GCSVolumeSource{
+			Bucket:   gcs.Bucket,
+			ReadOnly: gcs.ReadOnly,
+		}
+	}
+	if nfs := v.GetNfs(); nfs != nil {
+		result.NFS = &model_volume.NFSVolumeSource{
+			Server:   nfs.Server,
+			Path:     nfs.Path,
+			ReadOnly: nfs.ReadOnly,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	model_keytopath "github.com/JulienBreux/run-cli/internal/run/model/common/keytopath"
+	model_secret "github.com/JulienBreux/run-cli/internal/run/model/common/secret"
+	model_volume "github.com/JulienBreux/run-cli/internal/run/model/common/volume"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumes(t *testing.T) {
+	resp := []*runpb.Volume{
+		{Name: "secret", VolumeType: &runpb.Volume_Secret{Secret: &runpb.SecretVolumeSource{
+			Secret:      "config",
+			Items:       []*runpb.VersionToPath{{Path: "config.yaml", Version: "2"}},
+			DefaultMode: 0444,
+		}}},
+		{Name: "sql", VolumeType: &runpb.Volume_CloudSqlInstance{CloudSqlInstance: &runpb.CloudSqlInstance{
+			Instances: []string{"p:us-central1:db"},
+		}}},
+		{Name: "tmp", VolumeType: &runpb.Volume_EmptyDir{EmptyDir: &runpb.EmptyDirVolumeSource{
+			Medium:    runpb.EmptyDirVolumeSource_MEMORY,
+			SizeLimit: "1Gi",
+		}}},
+		{Name: "bucket", VolumeType: &runpb.Volume_Gcs{Gcs: &runpb.GCSVolumeSource{
+			Bucket:   "my-bucket",
+			ReadOnly: true,
+		}}},
+		{Name: "share", VolumeType: &runpb.Volume_Nfs{Nfs: &runpb.NFSVolumeSource{
+			Server:   "10.0.0.2",
+			Path:     "/exports",
+			ReadOnly: true,
+		}}},
+	}
+
+	assert.Equal(t, []*model_volume.Volume{
+		{Name: "secret", Secret: &model_secret.SecretSource{
+			Secret:      "config",
+			Items:       []*model_keytopath.KeyToPath{{Key: "2", Path: "config.yaml"}},
+			DefaultMode: 0444,
+		}},
+		{Name: "sql", CloudSQLInstance: &model_volume.CloudSQLInstanceVolumeSource{
+			Instances: []string{"p:us-central1:db"},
+		}},
+		{Name: "tmp", EmptyDir: &model_volume.EmptyDirVolumeSource{
+			Medium:    "MEMORY",
+			SizeLimit: "1Gi",
+		}},
+		{Name: "bucket", GCS: &model_volume.GCSVolumeSource{
+			Bucket:   "my-bucket",
+			ReadOnly: true,
+		}},
+		{Name: "share", NFS: &model_volume.NFSVolumeSource{
+			Server:   "10.0.0.2",
+			Path:     "/exports",
+			ReadOnly: true,
+		}},
+	}, Volumes(resp))
+	assert.Nil(t, Volumes(nil))
+}
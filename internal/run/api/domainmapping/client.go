@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
-	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 	"google.golang.org/api/run/v1"
 )
@@ -13,17 +12,23 @@ import (
 // DomainMappingsClientWrapper defines the interface for the DomainMappings API interactions.
 type DomainMappingsClientWrapper interface {
 	List(parent string, pageToken string) (*run.ListDomainMappingsResponse, error)
+	Get(name string) (*run.DomainMapping, error)
 }
 
 // variable for dependency injection
-var createClient = func(ctx context.Context, creds *google.Credentials) (DomainMappingsClientWrapper, error) {
-	s, err := run.NewService(ctx, option.WithCredentials(creds))
+var createClient = func(ctx context.Context, opts ...option.ClientOption) (DomainMappingsClientWrapper, error) {
+	s, err := run.NewService(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return &GCPDomainMappingsClient{service: s}, nil
 }
 
+// domainMappingsClient returns a domain mappings client from the pool, release must be called once it is no longer used.
+func domainMappingsClient(ctx context.Context) (c DomainMappingsClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "domainmappings", REST: true}, []string{run.CloudPlatformScope}, createClient)
+}
+
 // GCPDomainMappingsClient is the real implementation using the Google Cloud Run API.
 type GCPDomainMappingsClient struct {
 	service *run.APIService
@@ -37,9 +42,14 @@ func (c *GCPDomainMappingsClient) List(parent string, pageToken string) (*run.Li
 	return call.Do()
 }
 
+func (c *GCPDomainMappingsClient) Get(name string) (*run.DomainMapping, error) {
+	return c.service.Projects.Locations.Domainmappings.Get(name).Do()
+}
+
 // Client defines the interface for the DomainMapping API client.
 type Client interface {
 	ListDomainMappings(ctx context.Context, project, region string) ([]*run.DomainMapping, error)
+	GetDomainMapping(ctx context.Context, name string) (*run.DomainMapping, error)
 }
 
 // GCPClient is the Google Cloud Platform implementation of the Client interface.
@@ -47,15 +57,13 @@ type GCPClient struct{}
 
 // ListDomainMappings lists domain mappings for a given project and region.
 func (c *GCPClient) ListDomainMappings(ctx context.Context, project, region string) ([]*run.DomainMapping, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.CloudPlatformScope)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	dmClient, err := createClient(ctx, creds)
+	dmClient, release, err := domainMappingsClient(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create domain mappings client: %w", err)
 	}
+	defer func() {
+		_ = release()
+	}()
 
 	parent := fmt.Sprintf("projects/%s/locations/%s", project, region)
 
@@ -82,4 +90,21 @@ func (c *GCPClient) ListDomainMappings(ctx context.Context, project, region stri
 	}
 
 	return domainMappings, nil
-}
\ No newline at end of file
+}
+
+// GetDomainMapping gets a single domain mapping.
+func (c *GCPClient) GetDomainMapping(ctx context.Context, name string) (*run.DomainMapping, error) {
+	dmClient, release, err := domainMappingsClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create domain mappings client: %w", err)
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	resp, err := dmClient.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain mapping: %w", client.WrapError(err))
+	}
+	return resp, nil
+}
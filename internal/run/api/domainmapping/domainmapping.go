@@ -2,10 +2,12 @@ package domainmapping
 
 import (
 	"context"
-	"sync"
+	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/api/run/v1"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
 	model "github.com/JulienBreux/run-cli/internal/run/model/domainmapping"
 	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
@@ -13,14 +15,20 @@ import (
 
 var apiClient Client = &GCPClient{}
 
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
 // List returns a list of domain mappings for the given project and region.
-func List(project, region string) ([]model.DomainMapping, error) {
+func List(ctx context.Context, project, region string) ([]model.DomainMapping, error) {
 	if region == api_region.ALL {
-		return listAllRegions(project)
+		return listAllRegions(ctx, project)
 	}
 
-	ctx := context.Background()
-	pbDomainMappings, err := apiClient.ListDomainMappings(ctx, project, region)
+	pbDomainMappings, err := client.Call(ctx, func(ctx context.Context) ([]*run.DomainMapping, error) {
+		return apiClient.ListDomainMappings(ctx, project, region)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -33,27 +41,34 @@ func List(project, region string) ([]model.DomainMapping, error) {
 	return domainMappings, nil
 }
 
-func listAllRegions(project string) ([]model.DomainMapping, error) {
-	var (
-		mu             sync.Mutex
-		domainMappings []model.DomainMapping
-		wg             sync.WaitGroup
-	)
+// Get returns a single domain mapping.
+// The domain mapping name can be either a domain or a fully qualified resource name.
+func Get(ctx context.Context, project, region, domain string) (*model.DomainMapping, error) {
+	resp, err := GetRaw(ctx, project, region, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	dm := mapDomainMapping(resp, project, region)
+	return &dm, nil
+}
 
-	for _, region := range api_region.List() {
-		wg.Add(1)
-		go func(r string) {
-			defer wg.Done()
-			if dms, err := List(project, r); err == nil {
-				mu.Lock()
-				domainMappings = append(domainMappings, dms...)
-				mu.Unlock()
-			}
-		}(region)
+// GetRaw returns a single domain mapping as returned by the API.
+func GetRaw(ctx context.Context, project, region, domain string) (*run.DomainMapping, error) {
+	name := domain
+	if !strings.HasPrefix(domain, "projects/") {
+		name = fmt.Sprintf("projects/%s/locations/%s/domainmappings/%s", project, region, domain)
 	}
+	return client.Call(ctx, func(ctx context.Context) (*run.DomainMapping, error) {
+		return apiClient.GetDomainMapping(ctx, name)
+	})
+}
 
-	wg.Wait()
-	return domainMappings, nil
+// listAllRegions lists the domain mappings of all regions, see api_region.FanOut.
+func listAllRegions(ctx context.Context, project string) ([]model.DomainMapping, error) {
+	return api_region.FanOut(ctx, project, func(region string) ([]model.DomainMapping, error) {
+		return List(ctx, project, region)
+	})
 }
 
 func mapDomainMapping(resp *run.DomainMapping, project, region string) model.DomainMapping {
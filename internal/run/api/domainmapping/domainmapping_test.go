@@ -7,14 +7,17 @@ import (
 	"time"
 
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
 	"google.golang.org/api/run/v1"
 )
 
 // MockClient is a mock implementation of the Client interface.
 type MockClient struct {
 	ListDomainMappingsFunc func(ctx context.Context, project, region string) ([]*run.DomainMapping, error)
+	GetDomainMappingFunc   func(ctx context.Context, name string) (*run.DomainMapping, error)
 }
 
 func (m *MockClient) ListDomainMappings(ctx context.Context, project, region string) ([]*run.DomainMapping, error) {
@@ -24,6 +27,13 @@ func (m *MockClient) ListDomainMappings(ctx context.Context, project, region str
 	return nil, nil
 }
 
+func (m *MockClient) GetDomainMapping(ctx context.Context, name string) (*run.DomainMapping, error) {
+	if m.GetDomainMappingFunc != nil {
+		return m.GetDomainMappingFunc(ctx, name)
+	}
+	return nil, nil
+}
+
 func TestMapDomainMapping(t *testing.T) {
 	now := time.Now().Format(time.RFC3339)
 	resp := &run.DomainMapping{
@@ -90,7 +100,7 @@ func TestList(t *testing.T) {
 		}, nil
 	}
 
-	dms, err := List("p", "r")
+	dms, err := List(context.Background(), "p", "r")
 
 	assert.NoError(t, err)
 	assert.Len(t, dms, 2)
@@ -110,12 +120,45 @@ func TestList_Error(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	dms, err := List("p", "r")
+	dms, err := List(context.Background(), "p", "r")
 	assert.Error(t, err)
 	assert.Nil(t, dms)
 }
 
+func TestGet(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		GetDomainMappingFunc: func(ctx context.Context, name string) (*run.DomainMapping, error) {
+			gotName = name
+			return &run.DomainMapping{
+				Metadata: &run.ObjectMeta{Name: "example.com"},
+				Spec:     &run.DomainMappingSpec{RouteName: "my-service"},
+			}, nil
+		},
+	}
+
+	dm, err := Get(context.Background(), "p", "r", "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/domainmappings/example.com", gotName)
+	assert.Equal(t, "example.com", dm.Name)
+	assert.Equal(t, "my-service", dm.RouteName)
+
+	apiClient = &MockClient{
+		GetDomainMappingFunc: func(ctx context.Context, name string) (*run.DomainMapping, error) {
+			return nil, assert.AnError
+		},
+	}
+	_, err = Get(context.Background(), "p", "r", "example.com")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
 func TestList_AllRegions(t *testing.T) {
+	api_region.SetAllowed([]string{"europe-west1", "us-central1"})
+	t.Cleanup(func() { api_region.SetAllowed(nil) })
+
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
 
@@ -131,10 +174,10 @@ func TestList_AllRegions(t *testing.T) {
 		return nil, nil
 	}
 
-	dms, err := List("p", "all")
+	dms, err := List(context.Background(), "p", "all")
 	assert.NoError(t, err)
-	
-	// Since api_region.List() returns many regions, we just want to ensure we called List for them and aggregated results.
+
+	// Since api_region.List returns several regions, we just want to ensure we called List for them and aggregated results.
 	// We mocked return for "us-central1".
 	found := false
 	for _, dm := range dms {
@@ -150,6 +193,7 @@ func TestList_AllRegions(t *testing.T) {
 
 type MockDomainMappingsClientWrapper struct {
 	ListFunc func(parent string, pageToken string) (*run.ListDomainMappingsResponse, error)
+	GetFunc  func(name string) (*run.DomainMapping, error)
 }
 
 func (m *MockDomainMappingsClientWrapper) List(parent string, pageToken string) (*run.ListDomainMappingsResponse, error) {
@@ -159,6 +203,54 @@ func (m *MockDomainMappingsClientWrapper) List(parent string, pageToken string)
 	return nil, nil
 }
 
+func (m *MockDomainMappingsClientWrapper) Get(name string) (*run.DomainMapping, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(name)
+	}
+	return nil, nil
+}
+
+func TestGCPClient_GetDomainMapping(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		createClient = func(ctx context.Context, opts ...option.ClientOption) (DomainMappingsClientWrapper, error) {
+			return &MockDomainMappingsClientWrapper{
+				GetFunc: func(name string) (*run.DomainMapping, error) {
+					return &run.DomainMapping{Metadata: &run.ObjectMeta{Name: name}}, nil
+				},
+			}, nil
+		}
+
+		dm, err := (&GCPClient{}).GetDomainMapping(context.Background(), "dm1")
+		assert.NoError(t, err)
+		assert.Equal(t, "dm1", dm.Metadata.Name)
+	})
+
+	t.Run("GetError", func(t *testing.T) {
+		createClient = func(ctx context.Context, opts ...option.ClientOption) (DomainMappingsClientWrapper, error) {
+			return &MockDomainMappingsClientWrapper{
+				GetFunc: func(name string) (*run.DomainMapping, error) {
+					return nil, errors.New("not found")
+				},
+			}, nil
+		}
+
+		_, err := (&GCPClient{}).GetDomainMapping(context.Background(), "dm1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get domain mapping")
+	})
+}
+
 func TestGCPClient_ListDomainMappings(t *testing.T) {
 	// Mock dependencies
 	origFindCreds := client.FindDefaultCredentials
@@ -173,7 +265,7 @@ func TestGCPClient_ListDomainMappings(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		createClient = func(ctx context.Context, creds *google.Credentials) (DomainMappingsClientWrapper, error) {
+		createClient = func(ctx context.Context, opts ...option.ClientOption) (DomainMappingsClientWrapper, error) {
 			return &MockDomainMappingsClientWrapper{
 				ListFunc: func(parent string, pageToken string) (*run.ListDomainMappingsResponse, error) {
 					return &run.ListDomainMappingsResponse{
@@ -191,12 +283,12 @@ func TestGCPClient_ListDomainMappings(t *testing.T) {
 	})
 
 	t.Run("Pagination", func(t *testing.T) {
-		createClient = func(ctx context.Context, creds *google.Credentials) (DomainMappingsClientWrapper, error) {
+		createClient = func(ctx context.Context, opts ...option.ClientOption) (DomainMappingsClientWrapper, error) {
 			return &MockDomainMappingsClientWrapper{
 				ListFunc: func(parent string, pageToken string) (*run.ListDomainMappingsResponse, error) {
 					if pageToken == "" {
 						return &run.ListDomainMappingsResponse{
-							Items: []*run.DomainMapping{{Metadata: &run.ObjectMeta{Name: "dm1"}}},
+							Items:    []*run.DomainMapping{{Metadata: &run.ObjectMeta{Name: "dm1"}}},
 							Metadata: &run.ListMeta{Continue: "next-page"},
 						}, nil
 					}
@@ -229,11 +321,11 @@ func TestGCPClient_ListDomainMappings(t *testing.T) {
 	})
 
 	t.Run("ClientCreationError", func(t *testing.T) {
-        // Reset auth mock for this test
+		// Reset auth mock for this test
 		client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
 			return &google.Credentials{}, nil
 		}
-		createClient = func(ctx context.Context, creds *google.Credentials) (DomainMappingsClientWrapper, error) {
+		createClient = func(ctx context.Context, opts ...option.ClientOption) (DomainMappingsClientWrapper, error) {
 			return nil, errors.New("creation failed")
 		}
 		c := &GCPClient{}
@@ -243,11 +335,11 @@ func TestGCPClient_ListDomainMappings(t *testing.T) {
 	})
 
 	t.Run("ListError", func(t *testing.T) {
-        // Reset auth mock for this test
+		// Reset auth mock for this test
 		client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
 			return &google.Credentials{}, nil
 		}
-		createClient = func(ctx context.Context, creds *google.Credentials) (DomainMappingsClientWrapper, error) {
+		createClient = func(ctx context.Context, opts ...option.ClientOption) (DomainMappingsClientWrapper, error) {
 			return &MockDomainMappingsClientWrapper{
 				ListFunc: func(parent string, pageToken string) (*run.ListDomainMappingsResponse, error) {
 					return nil, errors.New("list failed")
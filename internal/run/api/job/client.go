@@ -7,6 +7,7 @@ import (
 	run "cloud.google.com/go/run/apiv2"
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	"github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
@@ -15,6 +16,9 @@ import (
 // Interfaces for mocking
 type JobsClientWrapper interface {
 	ListJobs(ctx context.Context, req *runpb.ListJobsRequest, opts ...gax.CallOption) JobIteratorWrapper
+	GetJob(ctx context.Context, req *runpb.GetJobRequest, opts ...gax.CallOption) (*runpb.Job, error)
+	CreateJob(ctx context.Context, req *runpb.CreateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error)
+	UpdateJob(ctx context.Context, req *runpb.UpdateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error)
 	RunJob(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error)
 	Close() error
 }
@@ -23,7 +27,13 @@ type JobIteratorWrapper interface {
 	Next() (*runpb.Job, error)
 }
 
+// JobOperationWrapper is the operation of a job creation or update.
+type JobOperationWrapper interface {
+	Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Job, error)
+}
+
 type RunJobOperationWrapper interface {
+	operation.Poller[*runpb.Execution]
 	Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error)
 }
 
@@ -36,6 +46,11 @@ var createJobsClient = func(ctx context.Context, opts ...option.ClientOption) (J
 	return &GCPJobsClientWrapper{client: c}, nil
 }
 
+// jobsClient returns a jobs client from the pool, release must be called once it is no longer used.
+func jobsClient(ctx context.Context) (c JobsClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "jobs"}, run.DefaultAuthScopes(), createJobsClient)
+}
+
 // Real implementations
 type GCPJobsClientWrapper struct {
 	client *run.JobsClient
@@ -45,6 +60,26 @@ func (w *GCPJobsClientWrapper) ListJobs(ctx context.Context, req *runpb.ListJobs
 	return &GCPJobIteratorWrapper{it: w.client.ListJobs(ctx, req, opts...)}
 }
 
+func (w *GCPJobsClientWrapper) GetJob(ctx context.Context, req *runpb.GetJobRequest, opts ...gax.CallOption) (*runpb.Job, error) {
+	return w.client.GetJob(ctx, req, opts...)
+}
+
+func (w *GCPJobsClientWrapper) CreateJob(ctx context.Context, req *runpb.CreateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error) {
+	op, err := w.client.CreateJob(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (w *GCPJobsClientWrapper) UpdateJob(ctx context.Context, req *runpb.UpdateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error) {
+	op, err := w.client.UpdateJob(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
 func (w *GCPJobsClientWrapper) RunJob(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error) {
 	op, err := w.client.RunJob(ctx, req, opts...)
 	if err != nil {
@@ -73,10 +108,33 @@ func (w *GCPRunJobOperationWrapper) Wait(ctx context.Context, opts ...gax.CallOp
 	return w.op.Wait(ctx, opts...)
 }
 
+func (w *GCPRunJobOperationWrapper) Metadata() (*runpb.Execution, error) {
+	return w.op.Metadata()
+}
+
+func (w *GCPRunJobOperationWrapper) Name() string {
+	return w.op.Name()
+}
+
+func (w *GCPRunJobOperationWrapper) Done() bool {
+	return w.op.Done()
+}
+
+func (w *GCPRunJobOperationWrapper) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+	return w.op.Poll(ctx, opts...)
+}
+
 // Client defines the interface for Cloud Run Job operations.
 type Client interface {
 	ListJobs(ctx context.Context, project, region string) ([]*runpb.Job, error)
+	GetJob(ctx context.Context, name string) (*runpb.Job, error)
+	CreateJob(ctx context.Context, parent, jobID string, job *runpb.Job) (*runpb.Job, error)
+	UpdateJob(ctx context.Context, job *runpb.Job) (*runpb.Job, error)
 	RunJob(ctx context.Context, name string) (*runpb.Execution, error)
+	StartJob(ctx context.Context, name string) (*runpb.Execution, error)
+	// StartRunJob runs a job, with the overrides if any, and returns the operation of its execution
+	// without waiting for it.
+	StartRunJob(ctx context.Context, name string, overrides *runpb.RunJobRequest_Overrides) (operation.Poller[*runpb.Execution], error)
 }
 
 var _ Client = (*GCPClient)(nil)
@@ -86,17 +144,12 @@ type GCPClient struct{}
 
 // ListJobs lists jobs for a project and region.
 func (c *GCPClient) ListJobs(ctx context.Context, project, region string) ([]*runpb.Job, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	cClient, err := createJobsClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := jobsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	req := &runpb.ListJobsRequest{
@@ -119,25 +172,117 @@ func (c *GCPClient) ListJobs(ctx context.Context, project, region string) ([]*ru
 	return jobs, nil
 }
 
+// GetJob gets a single job.
+func (c *GCPClient) GetJob(ctx context.Context, name string) (*runpb.Job, error) {
+	cClient, release, err := jobsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	resp, err := cClient.GetJob(ctx, &runpb.GetJobRequest{Name: name})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+	return resp, nil
+}
+
+// CreateJob creates a job and waits for the operation to complete.
+func (c *GCPClient) CreateJob(ctx context.Context, parent, jobID string, job *runpb.Job) (*runpb.Job, error) {
+	cClient, release, err := jobsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	op, err := cClient.CreateJob(ctx, &runpb.CreateJobRequest{Parent: parent, JobId: jobID, Job: job})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+
+	return op.Wait(ctx)
+}
+
+// UpdateJob updates a job and waits for the operation to complete.
+func (c *GCPClient) UpdateJob(ctx context.Context, job *runpb.Job) (*runpb.Job, error) {
+	cClient, release, err := jobsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	op, err := cClient.UpdateJob(ctx, &runpb.UpdateJobRequest{Job: job})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+
+	return op.Wait(ctx)
+}
+
 // RunJob runs a job.
 func (c *GCPClient) RunJob(ctx context.Context, name string) (*runpb.Execution, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
+	cClient, release, err := jobsClient(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
+		return nil, err
 	}
+	defer func() {
+		_ = release()
+	}()
 
-	cClient, err := createJobsClient(ctx, option.WithCredentials(creds))
+	op, err := cClient.RunJob(ctx, &runpb.RunJobRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return op.Wait(ctx)
+}
+
+// StartJob starts a job and returns the created execution without waiting for its completion.
+func (c *GCPClient) StartJob(ctx context.Context, name string) (*runpb.Execution, error) {
+	cClient, release, err := jobsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	op, err := cClient.RunJob(ctx, &runpb.RunJobRequest{Name: name})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+
+	// The operation metadata holds the execution as soon as it is created.
+	execution, err := op.Metadata()
 	if err != nil {
 		return nil, err
 	}
+	if execution == nil || execution.Name == "" {
+		return nil, fmt.Errorf("execution of job %s started but its name is not available yet", name)
+	}
 
-	return op.Wait(ctx)
+	return execution, nil
+}
+
+// StartRunJob runs a job, with the overrides if any, and returns the operation of its execution.
+func (c *GCPClient) StartRunJob(ctx context.Context, name string, overrides *runpb.RunJobRequest_Overrides) (operation.Poller[*runpb.Execution], error) {
+	cClient, release, err := jobsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := cClient.RunJob(ctx, &runpb.RunJobRequest{Name: name, Overrides: overrides})
+	if err != nil {
+		_ = release()
+		return nil, client.WrapError(err)
+	}
+
+	// The client is released once the operation is done, as it polls it.
+	return operation.Release[*runpb.Execution](op, release), nil
 }
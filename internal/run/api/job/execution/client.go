@@ -5,13 +5,20 @@ import (
 
 	run "cloud.google.com/go/run/apiv2"
 	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
 // Interfaces for mocking
 type ExecutionsClientWrapper interface {
 	ListExecutions(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ExecutionIteratorWrapper
+	ListExecutionsPage(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ([]*runpb.Execution, string, error)
+	GetExecution(ctx context.Context, req *runpb.GetExecutionRequest, opts ...gax.CallOption) (*runpb.Execution, error)
+	CancelExecution(ctx context.Context, req *runpb.CancelExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error)
+	DeleteExecution(ctx context.Context, req *runpb.DeleteExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error)
 	Close() error
 }
 
@@ -19,6 +26,12 @@ type ExecutionIteratorWrapper interface {
 	Next() (*runpb.Execution, error)
 }
 
+// ExecutionOperationWrapper is the operation of an execution cancellation or deletion.
+type ExecutionOperationWrapper interface {
+	operation.Poller[*runpb.Execution]
+	Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error)
+}
+
 // Variables for dependency injection
 var createExecutionsClient = func(ctx context.Context, opts ...option.ClientOption) (ExecutionsClientWrapper, error) {
 	c, err := run.NewExecutionsClient(ctx, opts...)
@@ -28,6 +41,11 @@ var createExecutionsClient = func(ctx context.Context, opts ...option.ClientOpti
 	return &GCPExecutionsClientWrapper{client: c}, nil
 }
 
+// executionsClient returns an executions client from the pool, release must be called once it is no longer used.
+func executionsClient(ctx context.Context) (c ExecutionsClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "executions"}, run.DefaultAuthScopes(), createExecutionsClient)
+}
+
 // Real implementations
 type GCPExecutionsClientWrapper struct {
 	client *run.ExecutionsClient
@@ -37,6 +55,33 @@ func (w *GCPExecutionsClientWrapper) ListExecutions(ctx context.Context, req *ru
 	return &GCPExecutionIteratorWrapper{it: w.client.ListExecutions(ctx, req, opts...)}
 }
 
+// ListExecutionsPage returns the page of executions of the page token and size of req, and the token of the next page.
+func (w *GCPExecutionsClientWrapper) ListExecutionsPage(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ([]*runpb.Execution, string, error) {
+	var executions []*runpb.Execution
+	next, err := iterator.NewPager(w.client.ListExecutions(ctx, req, opts...), int(req.PageSize), req.PageToken).NextPage(&executions)
+	return executions, next, err
+}
+
+func (w *GCPExecutionsClientWrapper) GetExecution(ctx context.Context, req *runpb.GetExecutionRequest, opts ...gax.CallOption) (*runpb.Execution, error) {
+	return w.client.GetExecution(ctx, req, opts...)
+}
+
+func (w *GCPExecutionsClientWrapper) CancelExecution(ctx context.Context, req *runpb.CancelExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+	op, err := w.client.CancelExecution(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (w *GCPExecutionsClientWrapper) DeleteExecution(ctx context.Context, req *runpb.DeleteExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+	op, err := w.client.DeleteExecution(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
 func (w *GCPExecutionsClientWrapper) Close() error {
 	return w.client.Close()
 }
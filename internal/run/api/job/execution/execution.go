@@ -5,21 +5,27 @@ import (
 	"fmt"
 	"strings"
 
-	run "cloud.google.com/go/run/apiv2"
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/common"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
 	model "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
 	"google.golang.org/api/iterator"
-	"google.golang.org/api/option"
 )
 
 var apiClient Client = &GCPClient{}
 
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
 // List returns a list of executions for the given project, region and job.
-func List(project, region, jobName string) ([]model.Execution, error) {
-	ctx := context.Background()
-	pbExecutions, err := apiClient.ListExecutions(ctx, project, region, jobName)
+func List(ctx context.Context, project, region, jobName string) ([]model.Execution, error) {
+	pbExecutions, err := client.Call(ctx, func(ctx context.Context) ([]*runpb.Execution, error) {
+		return apiClient.ListExecutions(ctx, project, region, jobName)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +38,98 @@ func List(project, region, jobName string) ([]model.Execution, error) {
 	return executions, nil
 }
 
+// ListPage returns a page of executions for the given project, region and job, the latest first, and
+// the token of the next page, empty for the last one. The first page is returned for an empty token.
+func ListPage(ctx context.Context, project, region, jobName, pageToken string) ([]model.Execution, string, error) {
+	page, err := client.Call(ctx, func(ctx context.Context) (client.Page[*runpb.Execution], error) {
+		executions, next, err := apiClient.ListExecutionsPage(ctx, project, region, jobName, client.PageSize, pageToken)
+		return client.Page[*runpb.Execution]{Items: executions, Next: next}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var executions []model.Execution
+	for _, resp := range page.Items {
+		executions = append(executions, mapExecution(resp, region))
+	}
+
+	return executions, page.Next, nil
+}
+
+// Get returns a single execution.
+// The execution name can be either a short name or a fully qualified resource name.
+func Get(ctx context.Context, project, region, jobName, executionName string) (*model.Execution, error) {
+	resp, err := GetRaw(ctx, project, region, jobName, executionName)
+	if err != nil {
+		return nil, err
+	}
+
+	e := mapExecution(resp, region)
+	return &e, nil
+}
+
+// GetRaw returns a single execution as returned by the API.
+func GetRaw(ctx context.Context, project, region, jobName, executionName string) (*runpb.Execution, error) {
+	name := fullName(project, region, jobName, executionName)
+	return client.Call(ctx, func(ctx context.Context) (*runpb.Execution, error) {
+		return apiClient.GetExecution(ctx, name)
+	})
+}
+
+// Cancel cancels a running execution and returns the operation of its cancellation.
+// The execution name can be either a short name or a fully qualified resource name.
+func Cancel(ctx context.Context, project, region, jobName, executionName string) (*operation.Operation, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	op, err := apiClient.CancelExecution(ctx, fullName(project, region, jobName, executionName))
+	if err != nil {
+		return nil, err
+	}
+	return operation.New("Cancel", fmt.Sprintf("execution %s (%s)", shortName(executionName), region), op, describeExecution), nil
+}
+
+// Delete deletes an execution, cancelling it if it is running, and returns the operation of its deletion.
+// The execution name can be either a short name or a fully qualified resource name.
+func Delete(ctx context.Context, project, region, jobName, executionName string) (*operation.Operation, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	op, err := apiClient.DeleteExecution(ctx, fullName(project, region, jobName, executionName))
+	if err != nil {
+		return nil, err
+	}
+	return operation.New("Delete", fmt.Sprintf("execution %s (%s)", shortName(executionName), region), op, describeDeletion), nil
+}
+
+// describeExecution describes the progress of the cancellation of an execution from the counts of its tasks.
+func describeExecution(execution *runpb.Execution) string {
+	return fmt.Sprintf("%d running, %d cancelled", execution.GetRunningCount(), execution.GetCancelledCount())
+}
+
+// describeDeletion describes the progress of the deletion of an execution.
+func describeDeletion(execution *runpb.Execution) string {
+	if execution.GetDeleteTime() != nil {
+		return "deleted"
+	}
+	return "deleting"
+}
+
+// fullName returns the resource name of an execution, which name can be either a short name or a
+// fully qualified resource name.
+func fullName(project, region, jobName, executionName string) string {
+	if strings.HasPrefix(executionName, "projects/") {
+		return executionName
+	}
+	return fmt.Sprintf("%s/executions/%s", jobParent(project, region, jobName), executionName)
+}
+
+// shortName returns the last segment of a resource name.
+func shortName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
 func mapExecution(resp *runpb.Execution, region string) model.Execution {
 	var terminalCondition *condition.Condition
 	// Cloud Run v2 API usually puts conditions in Conditions list.
@@ -42,12 +140,7 @@ func mapExecution(resp *runpb.Execution, region string) model.Execution {
 	// Helper to find latest relevant condition or just map all of them.
 	var conditions []*condition.Condition
 	for _, c := range resp.Conditions {
-		cond := &condition.Condition{
-			Type:               c.Type,
-			State:              c.State.String(),
-			Message:            c.Message,
-			LastTransitionTime: c.LastTransitionTime.AsTime(),
-		}
+		cond := common.Condition(c)
 		conditions = append(conditions, cond)
 		
 		// Heuristic: If condition is "Completed", treat as terminal status for summary
@@ -80,24 +173,28 @@ func mapExecution(resp *runpb.Execution, region string) model.Execution {
 // Client defines the interface for Cloud Run Execution operations.
 type Client interface {
 	ListExecutions(ctx context.Context, project, region, jobName string) ([]*runpb.Execution, error)
+	// ListExecutionsPage returns a page of executions and the token of the next page, empty for the last one.
+	ListExecutionsPage(ctx context.Context, project, region, jobName string, pageSize int, pageToken string) ([]*runpb.Execution, string, error)
+	GetExecution(ctx context.Context, name string) (*runpb.Execution, error)
+	// CancelExecution cancels an execution and returns the operation of its cancellation without waiting for it.
+	CancelExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error)
+	// DeleteExecution deletes an execution and returns the operation of its deletion without waiting for it.
+	DeleteExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error)
 }
 
+var _ Client = (*GCPClient)(nil)
+
 // GCPClient is the Google Cloud Platform implementation of Client.
 type GCPClient struct{}
 
 // ListExecutions lists executions for a project, region and job.
 func (c *GCPClient) ListExecutions(ctx context.Context, project, region, jobName string) ([]*runpb.Execution, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	cClient, err := createExecutionsClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := executionsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	// Filter by job name
@@ -115,13 +212,8 @@ func (c *GCPClient) ListExecutions(ctx context.Context, project, region, jobName
 	// Wait, `runpb.ListExecutionsRequest` expects Parent = `projects/{project}/locations/{location}/jobs/{job}` OR `projects/{project}/locations/{location}`.
 	// If we can pass the job as parent, we get filtered list!
 	
-	parent := jobName
-	if !strings.HasPrefix(jobName, "projects/") {
-		parent = fmt.Sprintf("projects/%s/locations/%s/jobs/%s", project, region, jobName)
-	}
-
 	req := &runpb.ListExecutionsRequest{
-		Parent: parent,
+		Parent: jobParent(project, region, jobName),
 	}
 
 	var executions []*runpb.Execution
@@ -143,3 +235,86 @@ func (c *GCPClient) ListExecutions(ctx context.Context, project, region, jobName
 
 	return executions, nil
 }
+
+// ListExecutionsPage lists a page of executions for a project, region and job.
+func (c *GCPClient) ListExecutionsPage(ctx context.Context, project, region, jobName string, pageSize int, pageToken string) ([]*runpb.Execution, string, error) {
+	cClient, release, err := executionsClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	req := &runpb.ListExecutionsRequest{
+		Parent:    jobParent(project, region, jobName),
+		PageSize:  int32(pageSize),
+		PageToken: pageToken,
+	}
+
+	executions, next, err := cClient.ListExecutionsPage(ctx, req)
+	if err != nil {
+		return nil, "", client.WrapError(err)
+	}
+	return executions, next, nil
+}
+
+// jobParent returns the parent of the executions of a job, which name can be either a short name or a
+// fully qualified resource name.
+func jobParent(project, region, jobName string) string {
+	if strings.HasPrefix(jobName, "projects/") {
+		return jobName
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/jobs/%s", project, region, jobName)
+}
+
+// GetExecution gets a single execution.
+func (c *GCPClient) GetExecution(ctx context.Context, name string) (*runpb.Execution, error) {
+	cClient, release, err := executionsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	resp, err := cClient.GetExecution(ctx, &runpb.GetExecutionRequest{Name: name})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+	return resp, nil
+}
+
+// CancelExecution cancels an execution and returns the operation of its cancellation.
+func (c *GCPClient) CancelExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+	cClient, release, err := executionsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := cClient.CancelExecution(ctx, &runpb.CancelExecutionRequest{Name: name})
+	if err != nil {
+		_ = release()
+		return nil, client.WrapError(err)
+	}
+
+	// The client is released once the operation is done, as it polls it.
+	return operation.Release[*runpb.Execution](op, release), nil
+}
+
+// DeleteExecution deletes an execution and returns the operation of its deletion.
+func (c *GCPClient) DeleteExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+	cClient, release, err := executionsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := cClient.DeleteExecution(ctx, &runpb.DeleteExecutionRequest{Name: name})
+	if err != nil {
+		_ = release()
+		return nil, client.WrapError(err)
+	}
+
+	// The client is released once the operation is done, as it polls it.
+	return operation.Release[*runpb.Execution](op, release), nil
+}
@@ -0,0 +1,463 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MockClient is a mock implementation of the Client interface.
+type MockClient struct {
+	ListExecutionsFunc     func(ctx context.Context, project, region, jobName string) ([]*runpb.Execution, error)
+	ListExecutionsPageFunc func(ctx context.Context, project, region, jobName string, pageSize int, pageToken string) ([]*runpb.Execution, string, error)
+	GetExecutionFunc       func(ctx context.Context, name string) (*runpb.Execution, error)
+	CancelExecutionFunc    func(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error)
+	DeleteExecutionFunc    func(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error)
+}
+
+func (m *MockClient) ListExecutions(ctx context.Context, project, region, jobName string) ([]*runpb.Execution, error) {
+	if m.ListExecutionsFunc != nil {
+		return m.ListExecutionsFunc(ctx, project, region, jobName)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ListExecutionsPage(ctx context.Context, project, region, jobName string, pageSize int, pageToken string) ([]*runpb.Execution, string, error) {
+	if m.ListExecutionsPageFunc != nil {
+		return m.ListExecutionsPageFunc(ctx, project, region, jobName, pageSize, pageToken)
+	}
+	return nil, "", nil
+}
+
+func (m *MockClient) GetExecution(ctx context.Context, name string) (*runpb.Execution, error) {
+	if m.GetExecutionFunc != nil {
+		return m.GetExecutionFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CancelExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+	if m.CancelExecutionFunc != nil {
+		return m.CancelExecutionFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+	if m.DeleteExecutionFunc != nil {
+		return m.DeleteExecutionFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func TestMapExecution(t *testing.T) {
+	resp := &runpb.Execution{
+		Name:           "projects/p/locations/r/jobs/j/executions/e1",
+		TaskCount:      3,
+		SucceededCount: 2,
+		FailedCount:    1,
+		Conditions: []*runpb.Condition{
+			{Type: "Ready", State: runpb.Condition_CONDITION_SUCCEEDED},
+			{Type: "Completed", State: runpb.Condition_CONDITION_FAILED, Message: "1 task failed"},
+		},
+	}
+
+	e := mapExecution(resp, "r")
+
+	assert.Equal(t, "r", e.Region)
+	assert.Equal(t, int32(3), e.TaskCount)
+	assert.Len(t, e.Conditions, 2)
+	assert.NotNil(t, e.TerminalCondition)
+	assert.Equal(t, "CONDITION_FAILED", e.TerminalCondition.State)
+	assert.Equal(t, "1 task failed", e.TerminalCondition.Message)
+}
+
+func TestList(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	apiClient = &MockClient{
+		ListExecutionsFunc: func(ctx context.Context, project, region, jobName string) ([]*runpb.Execution, error) {
+			return []*runpb.Execution{{Name: "e1"}, {Name: "e2"}}, nil
+		},
+	}
+
+	executions, err := List(context.Background(), "p", "r", "j")
+	assert.NoError(t, err)
+	assert.Len(t, executions, 2)
+}
+
+func TestListPage(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotSize int
+	apiClient = &MockClient{
+		ListExecutionsPageFunc: func(ctx context.Context, project, region, jobName string, pageSize int, pageToken string) ([]*runpb.Execution, string, error) {
+			gotSize = pageSize
+			return client.Paginate([]*runpb.Execution{{Name: "e1"}, {Name: "e2"}, {Name: "e3"}}, 2, pageToken)
+		},
+	}
+
+	executions, next, err := ListPage(context.Background(), "p", "r", "j", "")
+	assert.NoError(t, err)
+	assert.Equal(t, client.PageSize, gotSize)
+	assert.Len(t, executions, 2)
+	assert.Equal(t, "r", executions[0].Region)
+	assert.NotEmpty(t, next)
+
+	executions, next, err = ListPage(context.Background(), "p", "r", "j", next)
+	assert.NoError(t, err)
+	assert.Len(t, executions, 1)
+	assert.Empty(t, next)
+
+	apiClient = &MockClient{
+		ListExecutionsPageFunc: func(ctx context.Context, project, region, jobName string, pageSize int, pageToken string) ([]*runpb.Execution, string, error) {
+			return nil, "", assert.AnError
+		},
+	}
+	_, _, err = ListPage(context.Background(), "p", "r", "j", "")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGet(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		GetExecutionFunc: func(ctx context.Context, name string) (*runpb.Execution, error) {
+			gotName = name
+			return &runpb.Execution{Name: name, RunningCount: 1}, nil
+		},
+	}
+
+	e, err := Get(context.Background(), "p", "r", "j", "e1")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1", gotName)
+	assert.Equal(t, int32(1), e.RunningCount)
+
+	_, err = Get(context.Background(), "p", "r", "j", "projects/p/locations/r/jobs/j/executions/e2")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e2", gotName)
+
+	apiClient = &MockClient{
+		GetExecutionFunc: func(ctx context.Context, name string) (*runpb.Execution, error) {
+			return nil, assert.AnError
+		},
+	}
+	_, err = Get(context.Background(), "p", "r", "j", "e1")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestCancel(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		CancelExecutionFunc: func(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+			gotName = name
+			return operation.Completed(name, &runpb.Execution{Name: name, SucceededCount: 1, CancelledCount: 2}), nil
+		},
+	}
+
+	op, err := Cancel(context.Background(), "p", "r", "j", "e1")
+	assert.NoError(t, err)
+	if !assert.NotNil(t, op) {
+		return
+	}
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1", gotName)
+	assert.Equal(t, "Cancel", op.Kind)
+	assert.Equal(t, "execution e1 (r)", op.Target)
+	assert.NoError(t, op.Wait(context.Background()))
+	assert.Equal(t, "0 running, 2 cancelled", op.Status().Progress)
+
+	apiClient = &MockClient{
+		CancelExecutionFunc: func(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+			return nil, assert.AnError
+		},
+	}
+	_, err = Cancel(context.Background(), "p", "r", "j", "e1")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestDelete(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		DeleteExecutionFunc: func(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+			gotName = name
+			return operation.Completed(name, &runpb.Execution{Name: name, DeleteTime: timestamppb.Now()}), nil
+		},
+	}
+
+	op, err := Delete(context.Background(), "p", "r", "projects/p/locations/r/jobs/j", "projects/p/locations/r/jobs/j/executions/e1")
+	assert.NoError(t, err)
+	if !assert.NotNil(t, op) {
+		return
+	}
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1", gotName)
+	assert.Equal(t, "Delete", op.Kind)
+	assert.Equal(t, "execution e1 (r)", op.Target)
+	assert.NoError(t, op.Wait(context.Background()))
+	assert.Equal(t, "deleted", op.Status().Progress)
+	assert.Equal(t, "deleting", describeDeletion(&runpb.Execution{}))
+}
+
+func TestReadOnly(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = &MockClient{}
+
+	client.SetReadOnly(true)
+	defer client.SetReadOnly(false)
+
+	_, err := Cancel(context.Background(), "p", "r", "j", "e1")
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+	_, err = Delete(context.Background(), "p", "r", "j", "e1")
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+}
+
+// --- Mocks for GCPClient testing ---
+
+type MockExecutionsClientWrapper struct {
+	ListExecutionsFunc     func(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ExecutionIteratorWrapper
+	ListExecutionsPageFunc func(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ([]*runpb.Execution, string, error)
+	GetExecutionFunc       func(ctx context.Context, req *runpb.GetExecutionRequest, opts ...gax.CallOption) (*runpb.Execution, error)
+	CancelExecutionFunc    func(ctx context.Context, req *runpb.CancelExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error)
+	DeleteExecutionFunc    func(ctx context.Context, req *runpb.DeleteExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error)
+}
+
+func (m *MockExecutionsClientWrapper) ListExecutions(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ExecutionIteratorWrapper {
+	if m.ListExecutionsFunc != nil {
+		return m.ListExecutionsFunc(ctx, req, opts...)
+	}
+	return &MockExecutionIteratorWrapper{}
+}
+
+func (m *MockExecutionsClientWrapper) ListExecutionsPage(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ([]*runpb.Execution, string, error) {
+	if m.ListExecutionsPageFunc != nil {
+		return m.ListExecutionsPageFunc(ctx, req, opts...)
+	}
+	return nil, "", nil
+}
+
+func (m *MockExecutionsClientWrapper) GetExecution(ctx context.Context, req *runpb.GetExecutionRequest, opts ...gax.CallOption) (*runpb.Execution, error) {
+	if m.GetExecutionFunc != nil {
+		return m.GetExecutionFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockExecutionsClientWrapper) CancelExecution(ctx context.Context, req *runpb.CancelExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+	if m.CancelExecutionFunc != nil {
+		return m.CancelExecutionFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockExecutionsClientWrapper) DeleteExecution(ctx context.Context, req *runpb.DeleteExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+	if m.DeleteExecutionFunc != nil {
+		return m.DeleteExecutionFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockExecutionsClientWrapper) Close() error {
+	return nil
+}
+
+// MockExecutionOperationWrapper is an operation done once polled.
+type MockExecutionOperationWrapper struct {
+	Result    *runpb.Execution
+	Completed bool
+}
+
+func (m *MockExecutionOperationWrapper) Name() string { return "operation-1" }
+
+func (m *MockExecutionOperationWrapper) Done() bool { return m.Completed }
+
+func (m *MockExecutionOperationWrapper) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+	m.Completed = true
+	return m.Result, nil
+}
+
+func (m *MockExecutionOperationWrapper) Metadata() (*runpb.Execution, error) {
+	return m.Result, nil
+}
+
+func (m *MockExecutionOperationWrapper) Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+	panic("the operations must be polled, not waited for")
+}
+
+type MockExecutionIteratorWrapper struct {
+	Items []*runpb.Execution
+	Index int
+	Err   error
+}
+
+func (m *MockExecutionIteratorWrapper) Next() (*runpb.Execution, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	if m.Index >= len(m.Items) {
+		return nil, iterator.Done
+	}
+	item := m.Items[m.Index]
+	m.Index++
+	return item, nil
+}
+
+func mockGCP(t *testing.T, wrapper ExecutionsClientWrapper) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createExecutionsClient
+	t.Cleanup(func() {
+		client.FindDefaultCredentials = origFindCreds
+		createExecutionsClient = origCreateClient
+	})
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+	createExecutionsClient = func(ctx context.Context, opts ...option.ClientOption) (ExecutionsClientWrapper, error) {
+		return wrapper, nil
+	}
+}
+
+func TestGCPClient_ListExecutions(t *testing.T) {
+	var gotParent string
+	mockGCP(t, &MockExecutionsClientWrapper{
+		ListExecutionsFunc: func(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ExecutionIteratorWrapper {
+			gotParent = req.Parent
+			return &MockExecutionIteratorWrapper{Items: []*runpb.Execution{{Name: "e1"}}}
+		},
+	})
+
+	executions, err := (&GCPClient{}).ListExecutions(context.Background(), "p", "r", "j")
+	assert.NoError(t, err)
+	assert.Len(t, executions, 1)
+	assert.Equal(t, "projects/p/locations/r/jobs/j", gotParent)
+}
+
+func TestGCPClient_ListExecutionsPage(t *testing.T) {
+	var gotReq *runpb.ListExecutionsRequest
+	mockGCP(t, &MockExecutionsClientWrapper{
+		ListExecutionsPageFunc: func(ctx context.Context, req *runpb.ListExecutionsRequest, opts ...gax.CallOption) ([]*runpb.Execution, string, error) {
+			gotReq = req
+			return []*runpb.Execution{{Name: "e1"}}, "next", nil
+		},
+	})
+
+	executions, next, err := (&GCPClient{}).ListExecutionsPage(context.Background(), "p", "r", "j", 10, "token")
+	assert.NoError(t, err)
+	assert.Len(t, executions, 1)
+	assert.Equal(t, "next", next)
+	assert.Equal(t, "projects/p/locations/r/jobs/j", gotReq.Parent)
+	assert.Equal(t, int32(10), gotReq.PageSize)
+	assert.Equal(t, "token", gotReq.PageToken)
+}
+
+func TestGCPClient_GetExecution(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockGCP(t, &MockExecutionsClientWrapper{
+			GetExecutionFunc: func(ctx context.Context, req *runpb.GetExecutionRequest, opts ...gax.CallOption) (*runpb.Execution, error) {
+				return &runpb.Execution{Name: req.Name}, nil
+			},
+		})
+
+		e, err := (&GCPClient{}).GetExecution(context.Background(), "e1")
+		assert.NoError(t, err)
+		assert.Equal(t, "e1", e.Name)
+	})
+
+	t.Run("Permission Error", func(t *testing.T) {
+		mockGCP(t, &MockExecutionsClientWrapper{
+			GetExecutionFunc: func(ctx context.Context, req *runpb.GetExecutionRequest, opts ...gax.CallOption) (*runpb.Execution, error) {
+				return nil, errors.New("PermissionDenied")
+			},
+		})
+
+		_, err := (&GCPClient{}).GetExecution(context.Background(), "e1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication failed")
+	})
+}
+
+func TestGCPClient_CancelExecution(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var gotName string
+		mockGCP(t, &MockExecutionsClientWrapper{
+			CancelExecutionFunc: func(ctx context.Context, req *runpb.CancelExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+				gotName = req.Name
+				return &MockExecutionOperationWrapper{Result: &runpb.Execution{Name: req.Name}}, nil
+			},
+		})
+
+		poller, err := (&GCPClient{}).CancelExecution(context.Background(), "e1")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "e1", gotName)
+		assert.False(t, poller.Done())
+		e, err := poller.Poll(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "e1", e.Name)
+		assert.True(t, poller.Done())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockGCP(t, &MockExecutionsClientWrapper{
+			CancelExecutionFunc: func(ctx context.Context, req *runpb.CancelExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+				return nil, errors.New("cancel failed")
+			},
+		})
+
+		_, err := (&GCPClient{}).CancelExecution(context.Background(), "e1")
+		assert.ErrorContains(t, err, "cancel failed")
+	})
+}
+
+func TestGCPClient_DeleteExecution(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var gotName string
+		mockGCP(t, &MockExecutionsClientWrapper{
+			DeleteExecutionFunc: func(ctx context.Context, req *runpb.DeleteExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+				gotName = req.Name
+				return &MockExecutionOperationWrapper{Result: &runpb.Execution{Name: req.Name}}, nil
+			},
+		})
+
+		poller, err := (&GCPClient{}).DeleteExecution(context.Background(), "e1")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "e1", gotName)
+		_, err = poller.Poll(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, poller.Done())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockGCP(t, &MockExecutionsClientWrapper{
+			DeleteExecutionFunc: func(ctx context.Context, req *runpb.DeleteExecutionRequest, opts ...gax.CallOption) (ExecutionOperationWrapper, error) {
+				return nil, errors.New("delete failed")
+			},
+		})
+
+		_, err := (&GCPClient{}).DeleteExecution(context.Background(), "e1")
+		assert.ErrorContains(t, err, "delete failed")
+	})
+}
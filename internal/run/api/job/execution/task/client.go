@@ -0,0 +1,70 @@
+package task
+
+import (
+	"context"
+
+	run "cloud.google.com/go/run/apiv2"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// Interfaces for mocking
+type TasksClientWrapper interface {
+	ListTasks(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) TaskIteratorWrapper
+	ListTasksPage(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) ([]*runpb.Task, string, error)
+	GetTask(ctx context.Context, req *runpb.GetTaskRequest, opts ...gax.CallOption) (*runpb.Task, error)
+	Close() error
+}
+
+type TaskIteratorWrapper interface {
+	Next() (*runpb.Task, error)
+}
+
+// Variables for dependency injection
+var createTasksClient = func(ctx context.Context, opts ...option.ClientOption) (TasksClientWrapper, error) {
+	c, err := run.NewTasksClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GCPTasksClientWrapper{client: c}, nil
+}
+
+// tasksClient returns a tasks client from the pool, release must be called once it is no longer used.
+func tasksClient(ctx context.Context) (c TasksClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "tasks"}, run.DefaultAuthScopes(), createTasksClient)
+}
+
+// Real implementations
+type GCPTasksClientWrapper struct {
+	client *run.TasksClient
+}
+
+func (w *GCPTasksClientWrapper) ListTasks(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) TaskIteratorWrapper {
+	return &GCPTaskIteratorWrapper{it: w.client.ListTasks(ctx, req, opts...)}
+}
+
+// ListTasksPage returns the page of tasks of the page token and size of req, and the token of the next page.
+func (w *GCPTasksClientWrapper) ListTasksPage(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) ([]*runpb.Task, string, error) {
+	var tasks []*runpb.Task
+	next, err := iterator.NewPager(w.client.ListTasks(ctx, req, opts...), int(req.PageSize), req.PageToken).NextPage(&tasks)
+	return tasks, next, err
+}
+
+func (w *GCPTasksClientWrapper) GetTask(ctx context.Context, req *runpb.GetTaskRequest, opts ...gax.CallOption) (*runpb.Task, error) {
+	return w.client.GetTask(ctx, req, opts...)
+}
+
+func (w *GCPTasksClientWrapper) Close() error {
+	return w.client.Close()
+}
+
+type GCPTaskIteratorWrapper struct {
+	it *run.TaskIterator
+}
+
+func (w *GCPTaskIteratorWrapper) Next() (*runpb.Task, error) {
+	return w.it.Next()
+}
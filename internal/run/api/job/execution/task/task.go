@@ -0,0 +1,203 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/common"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
+	model "github.com/JulienBreux/run-cli/internal/run/model/job/task"
+	"google.golang.org/api/iterator"
+)
+
+var apiClient Client = &GCPClient{}
+
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
+// List returns the tasks of the given execution of a job.
+func List(ctx context.Context, project, region, jobName, executionName string) ([]model.Task, error) {
+	pbTasks, err := client.Call(ctx, func(ctx context.Context) ([]*runpb.Task, error) {
+		return apiClient.ListTasks(ctx, executionParent(project, region, jobName, executionName))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []model.Task
+	for _, resp := range pbTasks {
+		tasks = append(tasks, mapTask(resp, region))
+	}
+
+	return tasks, nil
+}
+
+// ListPage returns a page of tasks of the given execution of a job, by index, and the token of the
+// next page, empty for the last one. The first page is returned for an empty token.
+func ListPage(ctx context.Context, project, region, jobName, executionName, pageToken string) ([]model.Task, string, error) {
+	page, err := client.Call(ctx, func(ctx context.Context) (client.Page[*runpb.Task], error) {
+		tasks, next, err := apiClient.ListTasksPage(ctx, executionParent(project, region, jobName, executionName), client.PageSize, pageToken)
+		return client.Page[*runpb.Task]{Items: tasks, Next: next}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var tasks []model.Task
+	for _, resp := range page.Items {
+		tasks = append(tasks, mapTask(resp, region))
+	}
+
+	return tasks, page.Next, nil
+}
+
+// Get returns a single task.
+// The task name can be either a short name or a fully qualified resource name.
+func Get(ctx context.Context, project, region, jobName, executionName, taskName string) (*model.Task, error) {
+	name := taskName
+	if !strings.HasPrefix(taskName, "projects/") {
+		name = executionParent(project, region, jobName, executionName) + "/tasks/" + taskName
+	}
+
+	resp, err := client.Call(ctx, func(ctx context.Context) (*runpb.Task, error) {
+		return apiClient.GetTask(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := mapTask(resp, region)
+	return &t, nil
+}
+
+// executionParent returns the parent of the tasks of an execution, which name can be either a short name
+// or a fully qualified resource name.
+func executionParent(project, region, jobName, executionName string) string {
+	if strings.HasPrefix(executionName, "projects/") {
+		return executionName
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/jobs/%s/executions/%s", project, region, jobName, executionName)
+}
+
+func mapTask(resp *runpb.Task, region string) model.Task {
+	var lastAttemptResult *model.AttemptResult
+	if resp.LastAttemptResult != nil {
+		lastAttemptResult = &model.AttemptResult{
+			ExitCode:   resp.LastAttemptResult.ExitCode,
+			TermSignal: resp.LastAttemptResult.TermSignal,
+			Message:    resp.LastAttemptResult.GetStatus().GetMessage(),
+		}
+	}
+
+	// As for executions, the "Completed" condition is the status of the task.
+	var terminalCondition *condition.Condition
+	conditions := common.Conditions(resp.Conditions)
+	for _, c := range conditions {
+		if c.Type == "Completed" {
+			terminalCondition = c
+		}
+	}
+
+	return model.Task{
+		Name:              resp.Name,
+		Job:               resp.Job,
+		Execution:         resp.Execution,
+		Index:             resp.Index,
+		Retried:           resp.Retried,
+		MaxRetries:        resp.MaxRetries,
+		CreateTime:        common.Time(resp.CreateTime),
+		ScheduledTime:     common.Time(resp.ScheduledTime),
+		StartTime:         common.Time(resp.StartTime),
+		CompletionTime:    common.Time(resp.CompletionTime),
+		LastAttemptResult: lastAttemptResult,
+		LogURI:            resp.LogUri,
+		Region:            region,
+		Conditions:        conditions,
+		TerminalCondition: terminalCondition,
+	}
+}
+
+// Client defines the interface for Cloud Run Task operations.
+type Client interface {
+	// ListTasks lists the tasks of an execution, given by its fully qualified resource name.
+	ListTasks(ctx context.Context, execution string) ([]*runpb.Task, error)
+	// ListTasksPage returns a page of tasks and the token of the next page, empty for the last one.
+	ListTasksPage(ctx context.Context, execution string, pageSize int, pageToken string) ([]*runpb.Task, string, error)
+	GetTask(ctx context.Context, name string) (*runpb.Task, error)
+}
+
+var _ Client = (*GCPClient)(nil)
+
+// GCPClient is the Google Cloud Platform implementation of Client.
+type GCPClient struct{}
+
+// ListTasks lists the tasks of an execution.
+func (c *GCPClient) ListTasks(ctx context.Context, execution string) ([]*runpb.Task, error) {
+	cClient, release, err := tasksClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	var tasks []*runpb.Task
+	it := cClient.ListTasks(ctx, &runpb.ListTasksRequest{Parent: execution})
+	for {
+		resp, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, client.WrapError(err)
+		}
+		tasks = append(tasks, resp)
+	}
+
+	return tasks, nil
+}
+
+// ListTasksPage lists a page of tasks of an execution.
+func (c *GCPClient) ListTasksPage(ctx context.Context, execution string, pageSize int, pageToken string) ([]*runpb.Task, string, error) {
+	cClient, release, err := tasksClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	req := &runpb.ListTasksRequest{
+		Parent:    execution,
+		PageSize:  int32(pageSize),
+		PageToken: pageToken,
+	}
+
+	tasks, next, err := cClient.ListTasksPage(ctx, req)
+	if err != nil {
+		return nil, "", client.WrapError(err)
+	}
+	return tasks, next, nil
+}
+
+// GetTask gets a single task.
+func (c *GCPClient) GetTask(ctx context.Context, name string) (*runpb.Task, error) {
+	cClient, release, err := tasksClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	resp, err := cClient.GetTask(ctx, &runpb.GetTaskRequest{Name: name})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+	return resp, nil
+}
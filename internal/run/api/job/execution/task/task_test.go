@@ -0,0 +1,310 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MockClient is a mock implementation of the Client interface.
+type MockClient struct {
+	ListTasksFunc     func(ctx context.Context, execution string) ([]*runpb.Task, error)
+	ListTasksPageFunc func(ctx context.Context, execution string, pageSize int, pageToken string) ([]*runpb.Task, string, error)
+	GetTaskFunc       func(ctx context.Context, name string) (*runpb.Task, error)
+}
+
+func (m *MockClient) ListTasks(ctx context.Context, execution string) ([]*runpb.Task, error) {
+	if m.ListTasksFunc != nil {
+		return m.ListTasksFunc(ctx, execution)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ListTasksPage(ctx context.Context, execution string, pageSize int, pageToken string) ([]*runpb.Task, string, error) {
+	if m.ListTasksPageFunc != nil {
+		return m.ListTasksPageFunc(ctx, execution, pageSize, pageToken)
+	}
+	return nil, "", nil
+}
+
+func (m *MockClient) GetTask(ctx context.Context, name string) (*runpb.Task, error) {
+	if m.GetTaskFunc != nil {
+		return m.GetTaskFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func TestMapTask(t *testing.T) {
+	now := time.Now().UTC()
+	resp := &runpb.Task{
+		Name:           "projects/p/locations/r/jobs/j/executions/e1/tasks/e1-task3",
+		Job:            "j",
+		Execution:      "e1",
+		Index:          3,
+		Retried:        2,
+		MaxRetries:     3,
+		StartTime:      timestamppb.New(now),
+		CompletionTime: timestamppb.New(now.Add(time.Minute)),
+		LastAttemptResult: &runpb.TaskAttemptResult{
+			ExitCode: 1,
+			Status:   &rpcstatus.Status{Code: 2, Message: "The container exited with an error."},
+		},
+		LogUri: "https://console.cloud.google.com/logs",
+		Conditions: []*runpb.Condition{
+			{Type: "Started", State: runpb.Condition_CONDITION_SUCCEEDED},
+			{Type: "Completed", State: runpb.Condition_CONDITION_FAILED, Message: "Task failed"},
+		},
+	}
+
+	task := mapTask(resp, "r")
+
+	assert.Equal(t, resp.Name, task.Name)
+	assert.Equal(t, "j", task.Job)
+	assert.Equal(t, "e1", task.Execution)
+	assert.Equal(t, int32(3), task.Index)
+	assert.Equal(t, int32(2), task.Retried)
+	assert.Equal(t, int32(3), task.MaxRetries)
+	assert.Equal(t, now, task.StartTime)
+	assert.Equal(t, now.Add(time.Minute), task.CompletionTime)
+	assert.True(t, task.CreateTime.IsZero())
+	assert.Equal(t, int32(1), task.LastAttemptResult.ExitCode)
+	assert.Equal(t, "The container exited with an error.", task.LastAttemptResult.Message)
+	assert.Equal(t, resp.LogUri, task.LogURI)
+	assert.Equal(t, "r", task.Region)
+	assert.Len(t, task.Conditions, 2)
+	assert.Equal(t, "CONDITION_FAILED", task.TerminalCondition.State)
+
+	task = mapTask(&runpb.Task{Name: "t"}, "r")
+	assert.Nil(t, task.LastAttemptResult)
+	assert.Nil(t, task.TerminalCondition)
+}
+
+func TestList(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotExecution string
+	apiClient = &MockClient{
+		ListTasksFunc: func(ctx context.Context, execution string) ([]*runpb.Task, error) {
+			gotExecution = execution
+			return []*runpb.Task{{Name: "t1"}, {Name: "t2"}}, nil
+		},
+	}
+
+	tasks, err := List(context.Background(), "p", "r", "j", "e1")
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1", gotExecution)
+
+	_, err = List(context.Background(), "p", "r", "j", "projects/p/locations/r/jobs/j/executions/e2")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e2", gotExecution)
+}
+
+func TestListPage(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotSize int
+	apiClient = &MockClient{
+		ListTasksPageFunc: func(ctx context.Context, execution string, pageSize int, pageToken string) ([]*runpb.Task, string, error) {
+			gotSize = pageSize
+			return client.Paginate([]*runpb.Task{{Name: "t1"}, {Name: "t2"}, {Name: "t3"}}, 2, pageToken)
+		},
+	}
+
+	tasks, next, err := ListPage(context.Background(), "p", "r", "j", "e1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, client.PageSize, gotSize)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, "r", tasks[0].Region)
+	assert.NotEmpty(t, next)
+
+	tasks, next, err = ListPage(context.Background(), "p", "r", "j", "e1", next)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Empty(t, next)
+
+	apiClient = &MockClient{
+		ListTasksPageFunc: func(ctx context.Context, execution string, pageSize int, pageToken string) ([]*runpb.Task, string, error) {
+			return nil, "", assert.AnError
+		},
+	}
+	_, _, err = ListPage(context.Background(), "p", "r", "j", "e1", "")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGet(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		GetTaskFunc: func(ctx context.Context, name string) (*runpb.Task, error) {
+			gotName = name
+			return &runpb.Task{Name: name, Index: 4}, nil
+		},
+	}
+
+	task, err := Get(context.Background(), "p", "r", "j", "e1", "e1-task4")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1/tasks/e1-task4", gotName)
+	assert.Equal(t, int32(4), task.Index)
+
+	_, err = Get(context.Background(), "p", "r", "j", "e1", "projects/p/locations/r/jobs/j/executions/e1/tasks/e1-task5")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1/tasks/e1-task5", gotName)
+
+	apiClient = &MockClient{
+		GetTaskFunc: func(ctx context.Context, name string) (*runpb.Task, error) {
+			return nil, assert.AnError
+		},
+	}
+	_, err = Get(context.Background(), "p", "r", "j", "e1", "e1-task4")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+// --- Mocks for GCPClient testing ---
+
+type MockTasksClientWrapper struct {
+	ListTasksFunc     func(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) TaskIteratorWrapper
+	ListTasksPageFunc func(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) ([]*runpb.Task, string, error)
+	GetTaskFunc       func(ctx context.Context, req *runpb.GetTaskRequest, opts ...gax.CallOption) (*runpb.Task, error)
+}
+
+func (m *MockTasksClientWrapper) ListTasks(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) TaskIteratorWrapper {
+	if m.ListTasksFunc != nil {
+		return m.ListTasksFunc(ctx, req, opts...)
+	}
+	return &MockTaskIteratorWrapper{}
+}
+
+func (m *MockTasksClientWrapper) ListTasksPage(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) ([]*runpb.Task, string, error) {
+	if m.ListTasksPageFunc != nil {
+		return m.ListTasksPageFunc(ctx, req, opts...)
+	}
+	return nil, "", nil
+}
+
+func (m *MockTasksClientWrapper) GetTask(ctx context.Context, req *runpb.GetTaskRequest, opts ...gax.CallOption) (*runpb.Task, error) {
+	if m.GetTaskFunc != nil {
+		return m.GetTaskFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockTasksClientWrapper) Close() error {
+	return nil
+}
+
+type MockTaskIteratorWrapper struct {
+	Items []*runpb.Task
+	Index int
+	Err   error
+}
+
+func (m *MockTaskIteratorWrapper) Next() (*runpb.Task, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	if m.Index >= len(m.Items) {
+		return nil, iterator.Done
+	}
+	item := m.Items[m.Index]
+	m.Index++
+	return item, nil
+}
+
+func mockGCP(t *testing.T, wrapper TasksClientWrapper) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createTasksClient
+	t.Cleanup(func() {
+		client.FindDefaultCredentials = origFindCreds
+		createTasksClient = origCreateClient
+	})
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+	createTasksClient = func(ctx context.Context, opts ...option.ClientOption) (TasksClientWrapper, error) {
+		return wrapper, nil
+	}
+}
+
+func TestGCPClient_ListTasks(t *testing.T) {
+	var gotParent string
+	mockGCP(t, &MockTasksClientWrapper{
+		ListTasksFunc: func(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) TaskIteratorWrapper {
+			gotParent = req.Parent
+			return &MockTaskIteratorWrapper{Items: []*runpb.Task{{Name: "t1"}}}
+		},
+	})
+
+	tasks, err := (&GCPClient{}).ListTasks(context.Background(), "projects/p/locations/r/jobs/j/executions/e1")
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1", gotParent)
+
+	mockGCP(t, &MockTasksClientWrapper{
+		ListTasksFunc: func(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) TaskIteratorWrapper {
+			return &MockTaskIteratorWrapper{Err: errors.New("PermissionDenied")}
+		},
+	})
+	_, err = (&GCPClient{}).ListTasks(context.Background(), "e1")
+	assert.Error(t, err)
+}
+
+func TestGCPClient_ListTasksPage(t *testing.T) {
+	var gotReq *runpb.ListTasksRequest
+	mockGCP(t, &MockTasksClientWrapper{
+		ListTasksPageFunc: func(ctx context.Context, req *runpb.ListTasksRequest, opts ...gax.CallOption) ([]*runpb.Task, string, error) {
+			gotReq = req
+			return []*runpb.Task{{Name: "t1"}}, "next", nil
+		},
+	})
+
+	tasks, next, err := (&GCPClient{}).ListTasksPage(context.Background(), "projects/p/locations/r/jobs/j/executions/e1", 10, "token")
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "next", next)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/e1", gotReq.Parent)
+	assert.Equal(t, int32(10), gotReq.PageSize)
+	assert.Equal(t, "token", gotReq.PageToken)
+}
+
+func TestGCPClient_GetTask(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockGCP(t, &MockTasksClientWrapper{
+			GetTaskFunc: func(ctx context.Context, req *runpb.GetTaskRequest, opts ...gax.CallOption) (*runpb.Task, error) {
+				return &runpb.Task{Name: req.Name}, nil
+			},
+		})
+
+		task, err := (&GCPClient{}).GetTask(context.Background(), "t1")
+		assert.NoError(t, err)
+		assert.Equal(t, "t1", task.Name)
+	})
+
+	t.Run("Permission Error", func(t *testing.T) {
+		mockGCP(t, &MockTasksClientWrapper{
+			GetTaskFunc: func(ctx context.Context, req *runpb.GetTaskRequest, opts ...gax.CallOption) (*runpb.Task, error) {
+				return nil, errors.New("PermissionDenied")
+			},
+		})
+
+		_, err := (&GCPClient{}).GetTask(context.Background(), "t1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication failed")
+	})
+}
@@ -2,25 +2,34 @@ package job
 
 import (
 	"context"
-	"sync"
+	"fmt"
+	"strings"
 
 	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/common"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
-	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
 	model "github.com/JulienBreux/run-cli/internal/run/model/job"
 )
 
 var apiClient Client = &GCPClient{}
 
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
 // List returns a list of jobs for the given project and region.
 // If region is api_region.ALL, it lists jobs from all supported Cloud Run regions.
-func List(project, region string) ([]model.Job, error) {
+func List(ctx context.Context, project, region string) ([]model.Job, error) {
 	if region == api_region.ALL {
-		return listAllRegions(project)
+		return listAllRegions(ctx, project)
 	}
 
-	ctx := context.Background()
-	pbJobs, err := apiClient.ListJobs(ctx, project, region)
+	pbJobs, err := client.Call(ctx, func(ctx context.Context) ([]*runpb.Job, error) {
+		return apiClient.ListJobs(ctx, project, region)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -33,67 +42,226 @@ func List(project, region string) ([]model.Job, error) {
 	return jobs, nil
 }
 
+// Get returns a single job.
+// The job name can be either a short name or a fully qualified resource name.
+func Get(ctx context.Context, project, region, jobName string) (*model.Job, error) {
+	resp, err := GetRaw(ctx, project, region, jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	j := mapJob(resp, region)
+	return &j, nil
+}
+
+// GetRaw returns a single job as returned by the API.
+func GetRaw(ctx context.Context, project, region, jobName string) (*runpb.Job, error) {
+	name := jobName
+	if !strings.HasPrefix(jobName, "projects/") {
+		name = "projects/" + project + "/locations/" + region + "/jobs/" + jobName
+	}
+	return client.Call(ctx, func(ctx context.Context) (*runpb.Job, error) {
+		return apiClient.GetJob(ctx, name)
+	})
+}
+
+// Create creates a job with the given name.
+func Create(ctx context.Context, project, region, jobName string, job *runpb.Job) (*runpb.Job, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	job.Name = ""
+	return apiClient.CreateJob(ctx, "projects/"+project+"/locations/"+region, jobName, job)
+}
+
+// Update replaces a job.
+// The etag of the job, if any, is used to detect concurrent modifications.
+func Update(ctx context.Context, job *runpb.Job) (*runpb.Job, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	return apiClient.UpdateJob(ctx, job)
+}
+
 func mapJob(resp *runpb.Job, region string) model.Job {
-	// Map LatestCreatedExecution
 	var latestExecution *model.ExecutionReference
 	if resp.LatestCreatedExecution != nil {
 		latestExecution = &model.ExecutionReference{
-			Name:       resp.LatestCreatedExecution.Name,
-			CreateTime: resp.LatestCreatedExecution.CreateTime.AsTime(),
+			Name:           resp.LatestCreatedExecution.Name,
+			CreateTime:     common.Time(resp.LatestCreatedExecution.CreateTime),
+			CompletionTime: common.Time(resp.LatestCreatedExecution.CompletionTime),
 		}
 	}
 
-	// Map TerminalCondition
-	var terminalCondition *condition.Condition
-	if resp.TerminalCondition != nil {
-		terminalCondition = &condition.Condition{
-			State:              resp.TerminalCondition.State.String(),
-			Message:            resp.TerminalCondition.Message,
-			LastTransitionTime: resp.TerminalCondition.LastTransitionTime.AsTime(),
+	var binaryAuthorization *model.BinaryAuthorization
+	if resp.BinaryAuthorization != nil {
+		binaryAuthorization = &model.BinaryAuthorization{
+			UseDefault:              resp.BinaryAuthorization.GetUseDefault(),
+			Policy:                  resp.BinaryAuthorization.GetPolicy(),
+			BreakglassJustification: resp.BinaryAuthorization.BreakglassJustification,
 		}
 	}
 
 	return model.Job{
 		Name:                   resp.Name,
-		LatestCreatedExecution: latestExecution,
-		TerminalCondition:      terminalCondition,
+		UID:                    resp.Uid,
+		Generation:             resp.Generation,
+		Labels:                 resp.Labels,
+		Annotations:            resp.Annotations,
+		CreateTime:             common.Time(resp.CreateTime),
+		UpdateTime:             common.Time(resp.UpdateTime),
+		DeleteTime:             common.Time(resp.DeleteTime),
+		ExpireTime:             common.Time(resp.ExpireTime),
 		Creator:                resp.Creator,
+		LastModifier:           resp.LastModifier,
+		Client:                 resp.Client,
+		ClientVersion:          resp.ClientVersion,
+		LaunchStage:            resp.LaunchStage.String(),
+		BinaryAuthorization:    binaryAuthorization,
+		Template:               mapExecutionTemplate(resp.Template),
+		ObservedGeneration:     resp.ObservedGeneration,
+		TerminalCondition:      common.Condition(resp.TerminalCondition),
+		Conditions:             common.Conditions(resp.Conditions),
+		ExecutionCount:         int64(resp.ExecutionCount),
+		LatestCreatedExecution: latestExecution,
+		Reconciling:            resp.Reconciling,
+		SatisfiesPZS:           resp.SatisfiesPzs,
 		Region:                 region,
 	}
 }
 
-func listAllRegions(project string) ([]model.Job, error) {
-	var (
-		mu   sync.Mutex
-		jobs []model.Job
-		wg   sync.WaitGroup
-	)
-
-	for _, region := range api_region.List() {
-		wg.Add(1)
-		go func(r string) {
-			defer wg.Done()
-			// Call List recursively for each region
-			// We ignore errors here to allow partial success (e.g. if one region is down or disabled)
-			if j, err := List(project, r); err == nil {
-				mu.Lock()
-				jobs = append(jobs, j...)
-				mu.Unlock()
-			}
-		}(region)
-	}
-
-	wg.Wait()
-	return jobs, nil
+// mapExecutionTemplate maps the template of the executions of a job, nil when there is none.
+func mapExecutionTemplate(template *runpb.ExecutionTemplate) *model.ExecutionTemplate {
+	if template == nil {
+		return nil
+	}
+
+	result := &model.ExecutionTemplate{
+		Labels:      template.Labels,
+		Annotations: template.Annotations,
+		Parallelism: template.Parallelism,
+		TaskCount:   template.TaskCount,
+	}
+	if task := template.Template; task != nil {
+		var timeout string
+		if task.Timeout != nil {
+			timeout = task.Timeout.AsDuration().String()
+		}
+		result.Template = &model.TaskTemplate{
+			Containers:           common.Containers(task.Containers),
+			Volumes:              common.Volumes(task.Volumes),
+			MaxRetries:           task.GetMaxRetries(),
+			Timeout:              timeout,
+			ServiceAccount:       task.ServiceAccount,
+			ExecutionEnvironment: task.ExecutionEnvironment.String(),
+			EncryptionKey:        task.EncryptionKey,
+			VPCAccess:            mapVPCAccess(task.VpcAccess),
+		}
+	}
+	return result
 }
 
-// Execute executes a Cloud Run job.
-func Execute(project, region, jobName string) (*runpb.Execution, error) {
-	ctx := context.Background()
+// mapVPCAccess maps the VPC access of the tasks of a job, through a connector or network interfaces,
+// nil when there is none.
+func mapVPCAccess(access *runpb.VpcAccess) *model.VPCAccess {
+	if access == nil {
+		return nil
+	}
+
+	var interfaces []*model.NetworkInterface
+	for _, i := range access.NetworkInterfaces {
+		interfaces = append(interfaces, &model.NetworkInterface{
+			Network:    i.Network,
+			Subnetwork: i.Subnetwork,
+			Tags:       i.Tags,
+		})
+	}
+	return &model.VPCAccess{
+		Connector:         access.Connector,
+		Egress:            access.Egress.String(),
+		NetworkInterfaces: interfaces,
+	}
+}
+
+// listAllRegions lists the jobs of all regions, see api_region.FanOut.
+func listAllRegions(ctx context.Context, project string) ([]model.Job, error) {
+	return api_region.FanOut(ctx, project, func(region string) ([]model.Job, error) {
+		return List(ctx, project, region)
+	})
+}
+
+// Execute executes a Cloud Run job and returns the operation of its execution.
+func Execute(ctx context.Context, project, region, jobName string) (*operation.Operation, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
 	// Name format: projects/{project}/locations/{region}/jobs/{job}
-	// The client's RunJob usually takes the full name resource string or the method handles it.
-	// My GCPClient.RunJob takes just 'name'.
-	// I should construct the full name here before passing it.
 	fullName := "projects/" + project + "/locations/" + region + "/jobs/" + jobName
-	return apiClient.RunJob(ctx, fullName)
+	op, err := apiClient.StartRunJob(ctx, fullName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return operation.New("Execute", fmt.Sprintf("job %s (%s)", jobName, region), op, describeExecution), nil
+}
+
+// Rerun executes the job of an execution again, with the same overrides, and returns the operation of
+// the new execution.
+func Rerun(ctx context.Context, project, region string, execution *runpb.Execution) (*operation.Operation, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	jobName := execution.GetJob()
+	fullName := "projects/" + project + "/locations/" + region + "/jobs/" + jobName
+	op, err := apiClient.StartRunJob(ctx, fullName, overrides(execution))
+	if err != nil {
+		return nil, err
+	}
+	return operation.New("Re-run", fmt.Sprintf("job %s (%s)", jobName, region), op, describeExecution), nil
+}
+
+// overrides returns the overrides of an execution. As an execution only holds the template it ran
+// with, the task count, timeout, and the arguments and environment of all the containers are overridden,
+// the arguments being cleared for the containers which had none.
+func overrides(execution *runpb.Execution) *runpb.RunJobRequest_Overrides {
+	result := &runpb.RunJobRequest_Overrides{TaskCount: execution.GetTaskCount()}
+	task := execution.GetTemplate()
+	if task == nil {
+		return result
+	}
+	result.Timeout = task.Timeout
+	for _, c := range task.Containers {
+		result.ContainerOverrides = append(result.ContainerOverrides, &runpb.RunJobRequest_Overrides_ContainerOverride{
+			Name:      c.Name,
+			Args:      c.Args,
+			Env:       c.Env,
+			ClearArgs: len(c.Args) == 0,
+		})
+	}
+	return result
+}
+
+// describeExecution describes the progress of an execution from the counts of its tasks.
+func describeExecution(execution *runpb.Execution) string {
+	if execution.GetTaskCount() == 0 {
+		return "starting"
+	}
+	progress := fmt.Sprintf("%d/%d tasks succeeded", execution.GetSucceededCount(), execution.GetTaskCount())
+	if failed := execution.GetFailedCount(); failed > 0 {
+		progress += fmt.Sprintf(", %d failed", failed)
+	}
+	return progress
+}
+
+// Start starts a Cloud Run job and returns the name of the created execution.
+// Unlike Execute, it does not wait for the execution to complete.
+func Start(ctx context.Context, project, region, jobName string) (string, error) {
+	if err := client.CheckWritable(); err != nil {
+		return "", err
+	}
+	fullName := "projects/" + project + "/locations/" + region + "/jobs/" + jobName
+	execution, err := apiClient.StartJob(ctx, fullName)
+	if err != nil {
+		return "", err
+	}
+	return execution.Name, nil
 }
@@ -8,19 +8,29 @@ import (
 
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	model "github.com/JulienBreux/run-cli/internal/run/model/job"
 	"github.com/googleapis/gax-go/v2"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/api"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MockClient is a mock implementation of the Client interface (High Level).
 type MockClient struct {
-	ListJobsFunc func(ctx context.Context, project, region string) ([]*runpb.Job, error)
-	RunJobFunc   func(ctx context.Context, name string) (*runpb.Execution, error)
+	ListJobsFunc  func(ctx context.Context, project, region string) ([]*runpb.Job, error)
+	GetJobFunc    func(ctx context.Context, name string) (*runpb.Job, error)
+	CreateJobFunc func(ctx context.Context, parent, jobID string, job *runpb.Job) (*runpb.Job, error)
+	UpdateJobFunc func(ctx context.Context, job *runpb.Job) (*runpb.Job, error)
+	RunJobFunc    func(ctx context.Context, name string) (*runpb.Execution, error)
+	StartJobFunc  func(ctx context.Context, name string) (*runpb.Execution, error)
+	// StartRunJobFunc defaults to RunJobFunc, whose operation is done at once.
+	StartRunJobFunc func(ctx context.Context, name string, overrides *runpb.RunJobRequest_Overrides) (operation.Poller[*runpb.Execution], error)
 }
 
 func (m *MockClient) ListJobs(ctx context.Context, project, region string) ([]*runpb.Job, error) {
@@ -30,6 +40,27 @@ func (m *MockClient) ListJobs(ctx context.Context, project, region string) ([]*r
 	return nil, nil
 }
 
+func (m *MockClient) GetJob(ctx context.Context, name string) (*runpb.Job, error) {
+	if m.GetJobFunc != nil {
+		return m.GetJobFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreateJob(ctx context.Context, parent, jobID string, job *runpb.Job) (*runpb.Job, error) {
+	if m.CreateJobFunc != nil {
+		return m.CreateJobFunc(ctx, parent, jobID, job)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) UpdateJob(ctx context.Context, job *runpb.Job) (*runpb.Job, error) {
+	if m.UpdateJobFunc != nil {
+		return m.UpdateJobFunc(ctx, job)
+	}
+	return nil, nil
+}
+
 func (m *MockClient) RunJob(ctx context.Context, name string) (*runpb.Execution, error) {
 	if m.RunJobFunc != nil {
 		return m.RunJobFunc(ctx, name)
@@ -37,6 +68,24 @@ func (m *MockClient) RunJob(ctx context.Context, name string) (*runpb.Execution,
 	return nil, nil
 }
 
+func (m *MockClient) StartJob(ctx context.Context, name string) (*runpb.Execution, error) {
+	if m.StartJobFunc != nil {
+		return m.StartJobFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) StartRunJob(ctx context.Context, name string, overrides *runpb.RunJobRequest_Overrides) (operation.Poller[*runpb.Execution], error) {
+	if m.StartRunJobFunc != nil {
+		return m.StartRunJobFunc(ctx, name, overrides)
+	}
+	execution, err := m.RunJob(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return operation.Completed(name, execution), nil
+}
+
 func TestMapJob(t *testing.T) {
 	now := time.Now()
 	resp := &runpb.Job{
@@ -70,6 +119,123 @@ func TestMapJob(t *testing.T) {
 	assert.Equal(t, "All good", result.TerminalCondition.Message)
 }
 
+func TestMapJob_FullConfig(t *testing.T) {
+	now := time.Now().UTC()
+	maxRetries := int32(3)
+	resp := &runpb.Job{
+		Name:          "projects/my-project/locations/us-central1/jobs/my-job",
+		Uid:           "uid-1",
+		Generation:    2,
+		Labels:        map[string]string{"team": "data"},
+		Annotations:   map[string]string{"note": "nightly"},
+		CreateTime:    timestamppb.New(now),
+		UpdateTime:    timestamppb.New(now),
+		ExpireTime:    timestamppb.New(now),
+		Creator:       "user@example.com",
+		LastModifier:  "admin@example.com",
+		Client:        "gcloud",
+		ClientVersion: "500.0.0",
+		LaunchStage:   api.LaunchStage_GA,
+		BinaryAuthorization: &runpb.BinaryAuthorization{
+			BinauthzMethod:          &runpb.BinaryAuthorization_UseDefault{UseDefault: true},
+			BreakglassJustification: "incident",
+		},
+		Template: &runpb.ExecutionTemplate{
+			Labels:      map[string]string{"run": "batch"},
+			Annotations: map[string]string{"run.googleapis.com/vpc-access-egress": "all-traffic"},
+			Parallelism: 2,
+			TaskCount:   10,
+			Template: &runpb.TaskTemplate{
+				Containers: []*runpb.Container{
+					{
+						Image: "gcr.io/my-project/batch",
+						Env:   []*runpb.EnvVar{{Name: "MODE", Values: &runpb.EnvVar_Value{Value: "full"}}},
+					},
+				},
+				Volumes: []*runpb.Volume{
+					{Name: "bucket", VolumeType: &runpb.Volume_Gcs{Gcs: &runpb.GCSVolumeSource{Bucket: "my-bucket"}}},
+					{Name: "share", VolumeType: &runpb.Volume_Nfs{Nfs: &runpb.NFSVolumeSource{Server: "10.0.0.2", Path: "/exports"}}},
+				},
+				Retries:              &runpb.TaskTemplate_MaxRetries{MaxRetries: maxRetries},
+				Timeout:              durationpb.New(10 * time.Minute),
+				ServiceAccount:       "batch@my-project.iam.gserviceaccount.com",
+				ExecutionEnvironment: runpb.ExecutionEnvironment_EXECUTION_ENVIRONMENT_GEN2,
+				EncryptionKey:        "projects/my-project/locations/us-central1/keyRings/k/cryptoKeys/c",
+				VpcAccess: &runpb.VpcAccess{
+					Egress: runpb.VpcAccess_ALL_TRAFFIC,
+					NetworkInterfaces: []*runpb.VpcAccess_NetworkInterface{
+						{Network: "default", Subnetwork: "default", Tags: []string{"batch"}},
+					},
+				},
+			},
+		},
+		ObservedGeneration: 2,
+		TerminalCondition: &runpb.Condition{
+			Type:    "Ready",
+			State:   runpb.Condition_CONDITION_SUCCEEDED,
+			Reasons: &runpb.Condition_Reason{Reason: runpb.Condition_WAITING_FOR_OPERATION},
+		},
+		Conditions:     []*runpb.Condition{{Type: "ExecutionsCompleted", State: runpb.Condition_CONDITION_SUCCEEDED}},
+		ExecutionCount: 7,
+		LatestCreatedExecution: &runpb.ExecutionReference{
+			Name:           "my-job-exec",
+			CreateTime:     timestamppb.New(now),
+			CompletionTime: timestamppb.New(now),
+		},
+		Reconciling:  true,
+		SatisfiesPzs: true,
+	}
+
+	result := mapJob(resp, "us-central1")
+
+	// Metadata
+	assert.Equal(t, "uid-1", result.UID)
+	assert.Equal(t, int64(2), result.Generation)
+	assert.Equal(t, resp.Labels, result.Labels)
+	assert.Equal(t, resp.Annotations, result.Annotations)
+	assert.Equal(t, now, result.CreateTime)
+	assert.Equal(t, now, result.UpdateTime)
+	assert.True(t, result.DeleteTime.IsZero())
+	assert.Equal(t, now, result.ExpireTime)
+	assert.Equal(t, "admin@example.com", result.LastModifier)
+	assert.Equal(t, "gcloud", result.Client)
+	assert.Equal(t, "500.0.0", result.ClientVersion)
+	assert.Equal(t, "GA", result.LaunchStage)
+	assert.Equal(t, &model.BinaryAuthorization{UseDefault: true, BreakglassJustification: "incident"}, result.BinaryAuthorization)
+
+	// Template
+	template := result.Template
+	assert.Equal(t, resp.Template.Labels, template.Labels)
+	assert.Equal(t, resp.Template.Annotations, template.Annotations)
+	assert.Equal(t, int32(2), template.Parallelism)
+	assert.Equal(t, int32(10), template.TaskCount)
+	assert.Equal(t, "gcr.io/my-project/batch", template.Template.Containers[0].Image)
+	assert.Equal(t, "full", template.Template.Containers[0].Env[0].Value)
+	assert.Equal(t, "my-bucket", template.Template.Volumes[0].GCS.Bucket)
+	assert.Equal(t, "10.0.0.2", template.Template.Volumes[1].NFS.Server)
+	assert.Equal(t, int32(3), template.Template.MaxRetries)
+	assert.Equal(t, "10m0s", template.Template.Timeout)
+	assert.Equal(t, "batch@my-project.iam.gserviceaccount.com", template.Template.ServiceAccount)
+	assert.Equal(t, "EXECUTION_ENVIRONMENT_GEN2", template.Template.ExecutionEnvironment)
+	assert.Equal(t, resp.Template.Template.EncryptionKey, template.Template.EncryptionKey)
+	assert.Equal(t, &model.VPCAccess{
+		Egress: "ALL_TRAFFIC",
+		NetworkInterfaces: []*model.NetworkInterface{
+			{Network: "default", Subnetwork: "default", Tags: []string{"batch"}},
+		},
+	}, template.Template.VPCAccess)
+
+	// Status
+	assert.Equal(t, int64(2), result.ObservedGeneration)
+	assert.Equal(t, "Ready", result.TerminalCondition.Type)
+	assert.Equal(t, "WAITING_FOR_OPERATION", result.TerminalCondition.Reason)
+	assert.Equal(t, "ExecutionsCompleted", result.Conditions[0].Type)
+	assert.Equal(t, int64(7), result.ExecutionCount)
+	assert.Equal(t, now, result.LatestCreatedExecution.CompletionTime)
+	assert.True(t, result.Reconciling)
+	assert.True(t, result.SatisfiesPZS)
+}
+
 func TestMapJob_NilFields(t *testing.T) {
 	resp := &runpb.Job{
 		Name:    "projects/my-project/locations/us-central1/jobs/my-job",
@@ -82,6 +248,9 @@ func TestMapJob_NilFields(t *testing.T) {
 	assert.Equal(t, "user@example.com", result.Creator)
 	assert.Nil(t, result.LatestCreatedExecution)
 	assert.Nil(t, result.TerminalCondition)
+	assert.Nil(t, result.BinaryAuthorization)
+	assert.Nil(t, result.Template)
+	assert.True(t, result.CreateTime.IsZero())
 }
 
 func TestList(t *testing.T) {
@@ -98,7 +267,7 @@ func TestList(t *testing.T) {
 		}, nil
 	}
 
-	jobs, err := List("p", "r")
+	jobs, err := List(context.Background(), "p", "r")
 	assert.NoError(t, err)
 	assert.Len(t, jobs, 2)
 	assert.Equal(t, "job1", jobs[0].Name)
@@ -115,7 +284,7 @@ func TestList_Error(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	jobs, err := List("p", "r")
+	jobs, err := List(context.Background(), "p", "r")
 	assert.Error(t, err)
 	assert.Nil(t, jobs)
 }
@@ -129,13 +298,20 @@ func TestExecute(t *testing.T) {
 
 	mock.RunJobFunc = func(ctx context.Context, name string) (*runpb.Execution, error) {
 		assert.Equal(t, "projects/p/locations/r/jobs/myjob", name)
-		return &runpb.Execution{Name: "exec1"}, nil
+		return &runpb.Execution{Name: "exec1", TaskCount: 3, SucceededCount: 2, FailedCount: 1}, nil
 	}
 
-	exec, err := Execute("p", "r", "myjob")
+	op, err := Execute(context.Background(), "p", "r", "myjob")
 	assert.NoError(t, err)
-	assert.NotNil(t, exec)
-	assert.Equal(t, "exec1", exec.Name)
+	if !assert.NotNil(t, op) {
+		return
+	}
+	assert.Equal(t, "Execute", op.Kind)
+	assert.Equal(t, "job myjob (r)", op.Target)
+	assert.NoError(t, op.Wait(context.Background()))
+	assert.Equal(t, "2/3 tasks succeeded, 1 failed", op.Status().Progress)
+
+	assert.Equal(t, "starting", describeExecution(&runpb.Execution{}))
 }
 
 func TestExecute_Error(t *testing.T) {
@@ -149,12 +325,122 @@ func TestExecute_Error(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	exec, err := Execute("p", "r", "myjob")
+	exec, err := Execute(context.Background(), "p", "r", "myjob")
 	assert.Error(t, err)
 	assert.Nil(t, exec)
 }
 
+func TestRerun(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	mock := &MockClient{}
+	apiClient = mock
+
+	var gotName string
+	var gotOverrides *runpb.RunJobRequest_Overrides
+	mock.StartRunJobFunc = func(ctx context.Context, name string, overrides *runpb.RunJobRequest_Overrides) (operation.Poller[*runpb.Execution], error) {
+		gotName, gotOverrides = name, overrides
+		return operation.Completed(name, &runpb.Execution{Name: "exec2", TaskCount: 2, SucceededCount: 2}), nil
+	}
+
+	execution := &runpb.Execution{
+		Name:      "projects/p/locations/r/jobs/myjob/executions/exec1",
+		Job:       "myjob",
+		TaskCount: 2,
+		Template: &runpb.TaskTemplate{
+			Timeout: durationpb.New(time.Minute),
+			Containers: []*runpb.Container{
+				{Name: "main", Args: []string{"--date", "2026-01-01"}, Env: []*runpb.EnvVar{{Name: "MODE", Values: &runpb.EnvVar_Value{Value: "full"}}}},
+				{Name: "sidecar"},
+			},
+		},
+	}
+	op, err := Rerun(context.Background(), "p", "r", execution)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, op) {
+		return
+	}
+	assert.Equal(t, "Re-run", op.Kind)
+	assert.Equal(t, "job myjob (r)", op.Target)
+	assert.NoError(t, op.Wait(context.Background()))
+	assert.Equal(t, "2/2 tasks succeeded", op.Status().Progress)
+
+	assert.Equal(t, "projects/p/locations/r/jobs/myjob", gotName)
+	assert.Equal(t, int32(2), gotOverrides.TaskCount)
+	assert.Equal(t, time.Minute, gotOverrides.Timeout.AsDuration())
+	if assert.Len(t, gotOverrides.ContainerOverrides, 2) {
+		container := gotOverrides.ContainerOverrides[0]
+		assert.Equal(t, "main", container.Name)
+		assert.Equal(t, []string{"--date", "2026-01-01"}, container.Args)
+		assert.False(t, container.ClearArgs)
+		assert.Equal(t, "full", container.Env[0].GetValue())
+		// The arguments of the job are cleared for the containers which ran without any
+		assert.True(t, gotOverrides.ContainerOverrides[1].ClearArgs)
+	}
+
+	// An execution without template only overrides its task count
+	assert.Equal(t, &runpb.RunJobRequest_Overrides{TaskCount: 3}, overrides(&runpb.Execution{TaskCount: 3}))
+}
+
+func TestStart(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	mock := &MockClient{}
+	apiClient = mock
+
+	mock.StartJobFunc = func(ctx context.Context, name string) (*runpb.Execution, error) {
+		assert.Equal(t, "projects/p/locations/r/jobs/myjob", name)
+		return &runpb.Execution{Name: "projects/p/locations/r/jobs/myjob/executions/exec1"}, nil
+	}
+
+	name, err := Start(context.Background(), "p", "r", "myjob")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/myjob/executions/exec1", name)
+
+	mock.StartJobFunc = func(ctx context.Context, name string) (*runpb.Execution, error) {
+		return nil, assert.AnError
+	}
+	_, err = Start(context.Background(), "p", "r", "myjob")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGet(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		GetJobFunc: func(ctx context.Context, name string) (*runpb.Job, error) {
+			gotName = name
+			return &runpb.Job{Name: name, Creator: "me"}, nil
+		},
+	}
+
+	j, err := Get(context.Background(), "p", "r", "myjob")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/myjob", gotName)
+	assert.Equal(t, "me", j.Creator)
+	assert.Equal(t, "r", j.Region)
+
+	_, err = GetRaw(context.Background(), "p", "r", "projects/p/locations/r/jobs/other")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/other", gotName)
+
+	apiClient = &MockClient{
+		GetJobFunc: func(ctx context.Context, name string) (*runpb.Job, error) {
+			return nil, assert.AnError
+		},
+	}
+	_, err = Get(context.Background(), "p", "r", "myjob")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
 func TestList_AllRegions(t *testing.T) {
+	api_region.SetAllowed([]string{"europe-west1", "us-central1"})
+	t.Cleanup(func() { api_region.SetAllowed(nil) })
+
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
 
@@ -168,7 +454,7 @@ func TestList_AllRegions(t *testing.T) {
 		return []*runpb.Job{}, nil
 	}
 
-	jobs, err := List("p", api_region.ALL)
+	jobs, err := List(context.Background(), "p", api_region.ALL)
 	assert.NoError(t, err)
 	
 	found := false
@@ -184,9 +470,12 @@ func TestList_AllRegions(t *testing.T) {
 // --- Mocks for GCPClient testing ---
 
 type MockJobsClientWrapper struct {
-	ListJobsFunc func(ctx context.Context, req *runpb.ListJobsRequest, opts ...gax.CallOption) JobIteratorWrapper
-	RunJobFunc   func(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error)
-	CloseFunc    func() error
+	ListJobsFunc  func(ctx context.Context, req *runpb.ListJobsRequest, opts ...gax.CallOption) JobIteratorWrapper
+	GetJobFunc    func(ctx context.Context, req *runpb.GetJobRequest, opts ...gax.CallOption) (*runpb.Job, error)
+	CreateJobFunc func(ctx context.Context, req *runpb.CreateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error)
+	UpdateJobFunc func(ctx context.Context, req *runpb.UpdateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error)
+	RunJobFunc    func(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error)
+	CloseFunc     func() error
 }
 
 func (m *MockJobsClientWrapper) ListJobs(ctx context.Context, req *runpb.ListJobsRequest, opts ...gax.CallOption) JobIteratorWrapper {
@@ -196,6 +485,27 @@ func (m *MockJobsClientWrapper) ListJobs(ctx context.Context, req *runpb.ListJob
 	return &MockJobIteratorWrapper{}
 }
 
+func (m *MockJobsClientWrapper) GetJob(ctx context.Context, req *runpb.GetJobRequest, opts ...gax.CallOption) (*runpb.Job, error) {
+	if m.GetJobFunc != nil {
+		return m.GetJobFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockJobsClientWrapper) CreateJob(ctx context.Context, req *runpb.CreateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error) {
+	if m.CreateJobFunc != nil {
+		return m.CreateJobFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockJobsClientWrapper) UpdateJob(ctx context.Context, req *runpb.UpdateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error) {
+	if m.UpdateJobFunc != nil {
+		return m.UpdateJobFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
 func (m *MockJobsClientWrapper) RunJob(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error) {
 	if m.RunJobFunc != nil {
 		return m.RunJobFunc(ctx, req, opts...)
@@ -229,7 +539,29 @@ func (m *MockJobIteratorWrapper) Next() (*runpb.Job, error) {
 }
 
 type MockRunJobOperationWrapper struct {
-	WaitFunc func(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error)
+	WaitFunc     func(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error)
+	MetadataFunc func() (*runpb.Execution, error)
+	PollFunc     func(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error)
+	// Completed is whether the operation is done.
+	Completed bool
+}
+
+func (m *MockRunJobOperationWrapper) Name() string { return "operation-1" }
+
+func (m *MockRunJobOperationWrapper) Done() bool { return m.Completed }
+
+func (m *MockRunJobOperationWrapper) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+	if m.PollFunc != nil {
+		return m.PollFunc(ctx, opts...)
+	}
+	return nil, nil
+}
+
+func (m *MockRunJobOperationWrapper) Metadata() (*runpb.Execution, error) {
+	if m.MetadataFunc != nil {
+		return m.MetadataFunc()
+	}
+	return nil, nil
 }
 
 func (m *MockRunJobOperationWrapper) Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
@@ -394,12 +726,285 @@ func TestGCPClient_RunJob(t *testing.T) {
 	})
 }
 
+func TestGCPClient_StartRunJob(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createJobsClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createJobsClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		op := &MockRunJobOperationWrapper{}
+		op.PollFunc = func(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+			op.Completed = true
+			return &runpb.Execution{Name: "exec-1"}, nil
+		}
+		op.WaitFunc = func(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+			t.Fatal("StartRunJob must not wait for the operation")
+			return nil, nil
+		}
+		var gotReq *runpb.RunJobRequest
+		createJobsClient = func(ctx context.Context, opts ...option.ClientOption) (JobsClientWrapper, error) {
+			return &MockJobsClientWrapper{
+				RunJobFunc: func(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error) {
+					gotReq = req
+					return op, nil
+				},
+				CloseFunc: func() error { return nil },
+			}, nil
+		}
+
+		overrides := &runpb.RunJobRequest_Overrides{TaskCount: 2}
+		poller, err := (&GCPClient{}).StartRunJob(context.Background(), "job1", overrides)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "job1", gotReq.Name)
+		assert.Equal(t, overrides, gotReq.Overrides)
+		assert.False(t, poller.Done())
+		exec, err := poller.Poll(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "exec-1", exec.Name)
+		assert.True(t, poller.Done())
+	})
+
+	t.Run("Run Error", func(t *testing.T) {
+		createJobsClient = func(ctx context.Context, opts ...option.ClientOption) (JobsClientWrapper, error) {
+			return &MockJobsClientWrapper{
+				RunJobFunc: func(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error) {
+					return nil, errors.New("run failed")
+				},
+				CloseFunc: func() error { return nil },
+			}, nil
+		}
+
+		_, err := (&GCPClient{}).StartRunJob(context.Background(), "job1", nil)
+		assert.ErrorContains(t, err, "run failed")
+	})
+}
+
+func TestGCPClient_StartJob(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createJobsClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createJobsClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	mockOperation := func(metadata *runpb.Execution, err error) {
+		createJobsClient = func(ctx context.Context, opts ...option.ClientOption) (JobsClientWrapper, error) {
+			return &MockJobsClientWrapper{
+				RunJobFunc: func(ctx context.Context, req *runpb.RunJobRequest, opts ...gax.CallOption) (RunJobOperationWrapper, error) {
+					return &MockRunJobOperationWrapper{
+						WaitFunc: func(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+							t.Fatal("StartJob must not wait for the operation")
+							return nil, nil
+						},
+						MetadataFunc: func() (*runpb.Execution, error) {
+							return metadata, err
+						},
+					}, nil
+				},
+				CloseFunc: func() error { return nil },
+			}, nil
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockOperation(&runpb.Execution{Name: "exec-1"}, nil)
+
+		exec, err := (&GCPClient{}).StartJob(context.Background(), "job1")
+		assert.NoError(t, err)
+		assert.Equal(t, "exec-1", exec.Name)
+	})
+
+	t.Run("Missing Metadata", func(t *testing.T) {
+		mockOperation(nil, nil)
+
+		_, err := (&GCPClient{}).StartJob(context.Background(), "job1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "name is not available")
+	})
+
+	t.Run("Metadata Error", func(t *testing.T) {
+		mockOperation(nil, errors.New("metadata error"))
+
+		_, err := (&GCPClient{}).StartJob(context.Background(), "job1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "metadata error")
+	})
+
+	t.Run("Auth Error", func(t *testing.T) {
+		client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+			return nil, errors.New("auth failed")
+		}
+
+		_, err := (&GCPClient{}).StartJob(context.Background(), "job1")
+		assert.Error(t, err)
+	})
+}
+
+func TestGCPClient_GetJob(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createJobsClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createJobsClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		createJobsClient = func(ctx context.Context, opts ...option.ClientOption) (JobsClientWrapper, error) {
+			return &MockJobsClientWrapper{
+				GetJobFunc: func(ctx context.Context, req *runpb.GetJobRequest, opts ...gax.CallOption) (*runpb.Job, error) {
+					return &runpb.Job{Name: req.Name}, nil
+				},
+			}, nil
+		}
+
+		j, err := (&GCPClient{}).GetJob(context.Background(), "job1")
+		assert.NoError(t, err)
+		assert.Equal(t, "job1", j.Name)
+	})
+
+	t.Run("Permission Error", func(t *testing.T) {
+		createJobsClient = func(ctx context.Context, opts ...option.ClientOption) (JobsClientWrapper, error) {
+			return &MockJobsClientWrapper{
+				GetJobFunc: func(ctx context.Context, req *runpb.GetJobRequest, opts ...gax.CallOption) (*runpb.Job, error) {
+					return nil, errors.New("PermissionDenied")
+				},
+			}, nil
+		}
+
+		_, err := (&GCPClient{}).GetJob(context.Background(), "job1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication failed")
+	})
+}
+
+type MockJobOperationWrapper struct {
+	WaitFunc func(ctx context.Context, opts ...gax.CallOption) (*runpb.Job, error)
+}
+
+func (m *MockJobOperationWrapper) Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Job, error) {
+	if m.WaitFunc != nil {
+		return m.WaitFunc(ctx, opts...)
+	}
+	return nil, nil
+}
+
+func TestGCPClient_CreateAndUpdateJob(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createJobsClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createJobsClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	done := func(job *runpb.Job) JobOperationWrapper {
+		return &MockJobOperationWrapper{
+			WaitFunc: func(ctx context.Context, opts ...gax.CallOption) (*runpb.Job, error) {
+				return job, nil
+			},
+		}
+	}
+	createJobsClient = func(ctx context.Context, opts ...option.ClientOption) (JobsClientWrapper, error) {
+		return &MockJobsClientWrapper{
+			CreateJobFunc: func(ctx context.Context, req *runpb.CreateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error) {
+				return done(&runpb.Job{Name: req.Parent + "/jobs/" + req.JobId}), nil
+			},
+			UpdateJobFunc: func(ctx context.Context, req *runpb.UpdateJobRequest, opts ...gax.CallOption) (JobOperationWrapper, error) {
+				if req.Job.Etag != "1" {
+					return nil, errors.New("Aborted: etag mismatch")
+				}
+				return done(req.Job), nil
+			},
+		}, nil
+	}
+
+	j, err := (&GCPClient{}).CreateJob(context.Background(), "projects/p/locations/r", "j1", &runpb.Job{})
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/jobs/j1", j.Name)
+
+	j, err = (&GCPClient{}).UpdateJob(context.Background(), &runpb.Job{Name: "j1", Etag: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "j1", j.Name)
+
+	_, err = (&GCPClient{}).UpdateJob(context.Background(), &runpb.Job{Name: "j1", Etag: "0"})
+	assert.ErrorContains(t, err, "etag mismatch")
+}
+
+func TestCreateAndUpdate(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotParent, gotID string
+	apiClient = &MockClient{
+		CreateJobFunc: func(ctx context.Context, parent, jobID string, job *runpb.Job) (*runpb.Job, error) {
+			gotParent, gotID = parent, jobID
+			assert.Empty(t, job.Name)
+			return job, nil
+		},
+		UpdateJobFunc: func(ctx context.Context, job *runpb.Job) (*runpb.Job, error) {
+			return job, nil
+		},
+	}
+
+	_, err := Create(context.Background(), "p", "r", "j1", &runpb.Job{Name: "ignored"})
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r", gotParent)
+	assert.Equal(t, "j1", gotID)
+
+	j, err := Update(context.Background(), &runpb.Job{Name: "j1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "j1", j.Name)
+}
+
+func TestReadOnly(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = &MockClient{}
+
+	client.SetReadOnly(true)
+	defer client.SetReadOnly(false)
+
+	_, err := Create(context.Background(), "p", "r", "j1", &runpb.Job{})
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+	_, err = Update(context.Background(), &runpb.Job{Name: "j1"})
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+	_, err = Execute(context.Background(), "p", "r", "j1")
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+	_, err = Start(context.Background(), "p", "r", "j1")
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+	_, err = Rerun(context.Background(), "p", "r", &runpb.Execution{Job: "j1"})
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+}
+
 func TestWrappers_Delegation(t *testing.T) {
 	// Expect panics because nil clients are used
 	
 	t.Run("GCPJobsClientWrapper", func(t *testing.T) {
 		w := &GCPJobsClientWrapper{client: nil}
 		assert.Panics(t, func() { _ = w.ListJobs(context.Background(), nil) })
+		assert.Panics(t, func() { _, _ = w.GetJob(context.Background(), nil) })
+		assert.Panics(t, func() { _, _ = w.CreateJob(context.Background(), nil) })
+		assert.Panics(t, func() { _, _ = w.UpdateJob(context.Background(), nil) })
 		assert.Panics(t, func() { _, _ = w.RunJob(context.Background(), nil) })
 		assert.Panics(t, func() { _ = w.Close() })
 	})
@@ -412,5 +1017,6 @@ func TestWrappers_Delegation(t *testing.T) {
 	t.Run("GCPRunJobOperationWrapper", func(t *testing.T) {
 		op := &GCPRunJobOperationWrapper{op: nil}
 		assert.Panics(t, func() { _, _ = op.Wait(context.Background()) })
+		assert.Panics(t, func() { _, _ = op.Metadata() })
 	})
 }
\ No newline at end of file
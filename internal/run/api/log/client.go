@@ -2,7 +2,6 @@ package log
 
 import (
 	"context"
-	"fmt"
 
 	"cloud.google.com/go/logging"
 	"cloud.google.com/go/logging/logadmin"
@@ -26,6 +25,11 @@ type ClientFactory func(ctx context.Context, projectID string) (Client, error)
 
 var clientFactory ClientFactory = NewGCPClient
 
+// SetClientFactory replaces the factory of the logging clients, e.g. with the in-memory one of the demo mode.
+func SetClientFactory(f ClientFactory) {
+	clientFactory = f
+}
+
 // Interfaces for mocking
 type LogAdminClientWrapper interface {
 	Entries(ctx context.Context, opts ...logadmin.EntriesOption) EntryIterator
@@ -56,22 +60,21 @@ func (w *RealLogAdminClient) Close() error {
 
 // GCPClient is the Google Cloud Platform implementation of Client.
 type GCPClient struct {
-	client LogAdminClientWrapper
+	client  LogAdminClientWrapper
+	release func() error
 }
 
-// NewGCPClient creates a new GCPClient.
+// NewGCPClient creates a new GCPClient, with a client from the pool.
 func NewGCPClient(ctx context.Context, projectID string) (Client, error) {
-	creds, err := client.FindDefaultCredentials(ctx, logging.ReadScope)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	c, err := createLogAdminClient(ctx, projectID, option.WithCredentials(creds))
+	c, release, err := client.Get(ctx, client.Key{API: client.APILogging, Client: "logadmin/" + projectID}, []string{logging.ReadScope},
+		func(ctx context.Context, opts ...option.ClientOption) (LogAdminClientWrapper, error) {
+			return createLogAdminClient(ctx, projectID, opts...)
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GCPClient{client: c}, nil
+	return &GCPClient{client: c, release: release}, nil
 }
 
 func (c *GCPClient) Entries(ctx context.Context, opts ...interface{}) EntryIterator {
@@ -84,8 +87,9 @@ func (c *GCPClient) Entries(ctx context.Context, opts ...interface{}) EntryItera
 	return c.client.Entries(ctx, logOpts...)
 }
 
+// Close releases the client, see client.Get.
 func (c *GCPClient) Close() error {
-	return c.client.Close()
+	return c.release()
 }
 
 // GCPEntryIterator wraps logadmin.EntryIterator.
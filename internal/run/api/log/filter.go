@@ -0,0 +1,64 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+)
+
+// Kinds of resources having logs.
+const (
+	KindService    = "service"
+	KindJob        = "job"
+	KindWorkerPool = "workerpool"
+)
+
+// Kinds returns the kinds of resources having logs.
+func Kinds() []string {
+	return []string{KindService, KindJob, KindWorkerPool}
+}
+
+// Filter returns the filter selecting the logs of a resource.
+// The location is not filtered when the region is empty or all regions.
+func Filter(kind, name, region string) (string, error) {
+	var filter string
+	switch kind {
+	case KindService:
+		filter = fmt.Sprintf(`resource.type="cloud_run_revision" resource.labels.service_name="%s"`, name)
+	case KindJob:
+		filter = fmt.Sprintf(`resource.type="cloud_run_job" resource.labels.job_name="%s"`, name)
+	case KindWorkerPool:
+		filter = fmt.Sprintf(`resource.type="cloud_run_worker_pool" resource.labels.worker_pool_name="%s"`, name)
+	default:
+		return "", fmt.Errorf("unsupported log kind %q, expected one of %s", kind, strings.Join(Kinds(), ", "))
+	}
+
+	if region != "" && region != api_region.ALL {
+		filter += fmt.Sprintf(` resource.labels.location="%s"`, region)
+	}
+
+	return filter, nil
+}
+
+// TaskFilter returns the filter selecting the logs of a task of a job execution, given by the short name
+// of the execution and the index of the task, to be combined with the filter of the job.
+func TaskFilter(execution string, index int32) string {
+	return fmt.Sprintf(`labels."run.googleapis.com/execution_name"="%s" labels."run.googleapis.com/task_index"="%d"`, execution, index)
+}
+
+// SinceFilter returns the filter selecting the logs written since t.
+func SinceFilter(t time.Time) string {
+	return fmt.Sprintf(`timestamp>="%s"`, t.UTC().Format(time.RFC3339Nano))
+}
+
+// SeverityFilter returns the filter selecting the logs of at least the given severity.
+func SeverityFilter(severity string) (string, error) {
+	s := logging.ParseSeverity(severity)
+	if s == logging.Default && !strings.EqualFold(severity, logging.Default.String()) {
+		return "", fmt.Errorf("unsupported severity %q", severity)
+	}
+	return fmt.Sprintf("severity>=%s", strings.ToUpper(s.String())), nil
+}
@@ -0,0 +1,47 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := Filter(KindService, "s1", "us-central1")
+	assert.NoError(t, err)
+	assert.Equal(t, `resource.type="cloud_run_revision" resource.labels.service_name="s1" resource.labels.location="us-central1"`, f)
+
+	f, err = Filter(KindJob, "j1", "all")
+	assert.NoError(t, err)
+	assert.Equal(t, `resource.type="cloud_run_job" resource.labels.job_name="j1"`, f)
+
+	f, err = Filter(KindWorkerPool, "wp1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, `resource.type="cloud_run_worker_pool" resource.labels.worker_pool_name="wp1"`, f)
+
+	_, err = Filter("domainmapping", "d1", "")
+	assert.EqualError(t, err, `unsupported log kind "domainmapping", expected one of service, job, workerpool`)
+}
+
+func TestTaskFilter(t *testing.T) {
+	assert.Equal(t, `labels."run.googleapis.com/execution_name"="j1-abcde" labels."run.googleapis.com/task_index"="3"`, TaskFilter("j1-abcde", 3))
+}
+
+func TestSinceFilter(t *testing.T) {
+	ts := time.Date(2023, 10, 27, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	assert.Equal(t, `timestamp>="2023-10-27T10:00:00Z"`, SinceFilter(ts))
+}
+
+func TestSeverityFilter(t *testing.T) {
+	f, err := SeverityFilter("error")
+	assert.NoError(t, err)
+	assert.Equal(t, "severity>=ERROR", f)
+
+	f, err = SeverityFilter("DEFAULT")
+	assert.NoError(t, err)
+	assert.Equal(t, "severity>=DEFAULT", f)
+
+	_, err = SeverityFilter("loud")
+	assert.Error(t, err)
+}
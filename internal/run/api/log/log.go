@@ -12,6 +12,9 @@ import (
 
 var pollInterval = 2 * time.Second
 
+// backlogSize is the number of entries sent before polling by StreamLogs.
+const backlogSize = 50
+
 // StreamLogs streams logs for a given project and filter to the provided channel.
 // It first sends the last 50 logs, then polls for new ones.
 func StreamLogs(ctx context.Context, projectID, filter string, logChan chan<- string) error {
@@ -25,11 +28,36 @@ func StreamLogs(ctx context.Context, projectID, filter string, logChan chan<- st
 		}
 	}()
 
-	// 1. Fetch Initial Backlog (Last 50)
-	// We use NewestFirst to get the 50 most recent, but we need to reverse them for display.
+	return tail(ctx, client, filter, backlogSize, true, func(entry *logging.Entry) {
+		sendEntry(logChan, entry)
+	})
+}
+
+// Tail calls fn with the last limit entries matching the filter, oldest first.
+// A limit of 0 or less means no limit. When follow is true, it then polls for
+// new entries until the context is done.
+func Tail(ctx context.Context, projectID, filter string, limit int, follow bool, fn func(*logging.Entry)) (err error) {
+	client, err := clientFactory(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to create logging client: %w", err)
+	}
+	defer func() {
+		if cerr := client.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close logging client: %w", cerr)
+		}
+	}()
+
+	return tail(ctx, client, filter, limit, follow, fn)
+}
+
+func tail(ctx context.Context, client Client, filter string, limit int, follow bool, fn func(*logging.Entry)) error {
+	start := time.Now()
+
+	// 1. Fetch Initial Backlog
+	// We use NewestFirst to get the most recent, but we need to reverse them for display.
 	iter := client.Entries(ctx, logadmin.Filter(filter), logadmin.NewestFirst())
 	var backlog []*logging.Entry
-	for len(backlog) < 50 {
+	for limit <= 0 || len(backlog) < limit {
 		entry, err := iter.Next()
 		if err == iterator.Done {
 			break
@@ -45,12 +73,21 @@ func StreamLogs(ctx context.Context, projectID, filter string, logChan chan<- st
 	// Send backlog (Reverse order: Oldest -> Newest)
 	for i := len(backlog) - 1; i >= 0; i-- {
 		entry := backlog[i]
-		sendEntry(logChan, entry)
+		fn(entry)
 		if entry.Timestamp.After(lastTimestamp) {
 			lastTimestamp = entry.Timestamp
 		}
 	}
 
+	if !follow {
+		return nil
+	}
+
+	// Without backlog, only poll for the logs written from now on.
+	if lastTimestamp.IsZero() {
+		lastTimestamp = start
+	}
+
 	// 2. Poll for new logs
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
@@ -77,7 +114,7 @@ func StreamLogs(ctx context.Context, projectID, filter string, logChan chan<- st
 					break
 				}
 
-				sendEntry(logChan, entry)
+				fn(entry)
 				if entry.Timestamp.After(lastTimestamp) {
 					lastTimestamp = entry.Timestamp
 				}
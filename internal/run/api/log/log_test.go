@@ -300,4 +300,120 @@ func TestWrappers_Delegation(t *testing.T) {
 		it := &GCPEntryIterator{it: nil}
 		assert.Panics(t, func() { _, _ = it.Next() })
 	})
-}
\ No newline at end of file
+}
+func TestTail(t *testing.T) {
+	origFactory := clientFactory
+	defer func() { clientFactory = origFactory }()
+
+	ts1, _ := time.Parse(time.RFC3339, "2023-10-27T10:00:00Z")
+	ts2, _ := time.Parse(time.RFC3339, "2023-10-27T10:00:01Z")
+	ts3, _ := time.Parse(time.RFC3339, "2023-10-27T10:00:02Z")
+
+	t.Run("Limit without follow", func(t *testing.T) {
+		closed := false
+		clientFactory = func(ctx context.Context, projectID string) (Client, error) {
+			return &MockClient{
+				EntriesFunc: func(ctx context.Context, opts ...interface{}) EntryIterator {
+					// Newest first
+					return &MockEntryIterator{Items: []*logging.Entry{
+						{Timestamp: ts3, Payload: "Log 3"},
+						{Timestamp: ts2, Payload: "Log 2"},
+						{Timestamp: ts1, Payload: "Log 1"},
+					}}
+				},
+				CloseFunc: func() error {
+					closed = true
+					return nil
+				},
+			}, nil
+		}
+
+		var got []string
+		err := Tail(context.Background(), "p", "f", 2, false, func(e *logging.Entry) {
+			got = append(got, e.Payload.(string))
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Log 2", "Log 3"}, got)
+		assert.True(t, closed)
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		clientFactory = func(ctx context.Context, projectID string) (Client, error) {
+			return &MockClient{
+				EntriesFunc: func(ctx context.Context, opts ...interface{}) EntryIterator {
+					return &MockEntryIterator{Items: []*logging.Entry{
+						{Timestamp: ts2, Payload: "Log 2"},
+						{Timestamp: ts1, Payload: "Log 1"},
+					}}
+				},
+			}, nil
+		}
+
+		count := 0
+		err := Tail(context.Background(), "p", "f", 0, false, func(e *logging.Entry) { count++ })
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Follow", func(t *testing.T) {
+		origInterval := pollInterval
+		pollInterval = 10 * time.Millisecond
+		defer func() { pollInterval = origInterval }()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		callCount := 0
+		clientFactory = func(ctx context.Context, projectID string) (Client, error) {
+			return &MockClient{
+				EntriesFunc: func(ctx context.Context, opts ...interface{}) EntryIterator {
+					callCount++
+					if callCount == 2 {
+						return &MockEntryIterator{Items: []*logging.Entry{{Timestamp: ts1, Payload: "Log 1"}}}
+					}
+					return &MockEntryIterator{}
+				},
+			}, nil
+		}
+
+		var got []string
+		err := Tail(ctx, "p", "f", 10, true, func(e *logging.Entry) {
+			got = append(got, e.Payload.(string))
+			cancel()
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Log 1"}, got)
+	})
+
+	t.Run("Backlog Error", func(t *testing.T) {
+		clientFactory = func(ctx context.Context, projectID string) (Client, error) {
+			return &MockClient{
+				EntriesFunc: func(ctx context.Context, opts ...interface{}) EntryIterator {
+					return &MockEntryIterator{Err: errors.New("denied")}
+				},
+			}, nil
+		}
+
+		err := Tail(context.Background(), "p", "f", 10, false, func(e *logging.Entry) {})
+		assert.EqualError(t, err, "denied")
+	})
+
+	t.Run("Close Error", func(t *testing.T) {
+		clientFactory = func(ctx context.Context, projectID string) (Client, error) {
+			return &MockClient{CloseFunc: func() error { return errors.New("close") }}, nil
+		}
+
+		err := Tail(context.Background(), "p", "f", 10, false, func(e *logging.Entry) {})
+		assert.ErrorContains(t, err, "failed to close logging client")
+	})
+
+	t.Run("Client Creation Error", func(t *testing.T) {
+		expectedErr := errors.New("client error")
+		clientFactory = func(ctx context.Context, projectID string) (Client, error) {
+			return nil, expectedErr
+		}
+
+		err := Tail(context.Background(), "p", "f", 10, false, func(e *logging.Entry) {})
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -0,0 +1,190 @@
+// Package operation tracks the long-running operations of the API, e.g. the rollout of an update,
+// for them to be followed in the background instead of blocking until they are done.
+package operation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/googleapis/gax-go/v2"
+)
+
+const (
+	// maxRecent is the number of operations kept by Track, the oldest done ones are dropped.
+	maxRecent = 50
+	// maxPollErrors is the number of consecutive failed polls after which an operation is failed.
+	maxPollErrors = 5
+)
+
+// Variables for dependency injection
+var pollInterval = 2 * time.Second
+
+// Poller is a long-running operation of the generated clients, e.g. *run.UpdateServiceOperation.
+type Poller[T any] interface {
+	Name() string
+	Done() bool
+	// Poll fetches the latest state of the operation and returns its result once it is done.
+	Poll(ctx context.Context, opts ...gax.CallOption) (T, error)
+	// Metadata returns the metadata of the operation, e.g. the resource being updated.
+	Metadata() (T, error)
+}
+
+// Operation is a long-running operation of a kind, e.g. Scale, on a target, e.g. service s1.
+type Operation struct {
+	Kind    string
+	Target  string
+	Started time.Time
+
+	// poll fetches the latest state of the operation.
+	poll func(ctx context.Context) (progress string, done bool, err error)
+
+	mu       sync.Mutex
+	progress string
+	done     bool
+	err      error
+	ended    time.Time
+}
+
+// Status is the state of an operation.
+type Status struct {
+	// Progress describes the progress of the operation, e.g. from its metadata.
+	Progress string
+	Done     bool
+	// Err is the error of the failed operation.
+	Err error
+	// Ended is when the operation was done.
+	Ended time.Time
+}
+
+// New returns the operation of p, whose progress is described from its metadata, and from its result
+// once done, by describe.
+func New[T any](kind, target string, p Poller[T], describe func(T) string) *Operation {
+	return &Operation{
+		Kind:    kind,
+		Target:  target,
+		Started: time.Now(),
+		poll: func(ctx context.Context) (string, bool, error) {
+			result, err := p.Poll(ctx)
+			if p.Done() {
+				if err != nil {
+					return "", true, err
+				}
+				return describe(result), true, nil
+			}
+			if err != nil {
+				return "", false, err
+			}
+			metadata, err := p.Metadata()
+			if err != nil {
+				return "", false, nil
+			}
+			return describe(metadata), false, nil
+		},
+	}
+}
+
+// Status returns the state of the operation as of its last poll.
+func (o *Operation) Status() Status {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return Status{Progress: o.progress, Done: o.done, Err: o.err, Ended: o.ended}
+}
+
+// Wait polls the operation until it is done and returns its error.
+func (o *Operation) Wait(ctx context.Context) error {
+	failed := 0
+	for {
+		progress, done, err := o.poll(ctx)
+		switch {
+		case done:
+			o.finish(progress, err)
+			return err
+		case err != nil:
+			// The state could not be fetched, the operation may still be running.
+			failed++
+			if failed >= maxPollErrors || ctx.Err() != nil {
+				o.finish("", err)
+				return err
+			}
+		default:
+			failed = 0
+			o.update(progress)
+		}
+
+		t := time.NewTimer(pollInterval)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			o.finish("", ctx.Err())
+			return ctx.Err()
+		}
+	}
+}
+
+func (o *Operation) update(progress string) {
+	o.mu.Lock()
+	o.progress = progress
+	o.mu.Unlock()
+	notify(o)
+}
+
+func (o *Operation) finish(progress string, err error) {
+	o.mu.Lock()
+	if progress != "" {
+		o.progress = progress
+	}
+	o.done = true
+	o.err = err
+	o.ended = time.Now()
+	o.mu.Unlock()
+	notify(o)
+}
+
+var (
+	mu sync.Mutex
+	// tracked are the operations of Track, newest first.
+	tracked []*Operation
+	// onChange is called when a tracked operation changes, see OnChange.
+	onChange func(*Operation)
+)
+
+// Track adds an operation to the list of the operations, see List, and waits for it in the background.
+func Track(o *Operation) {
+	mu.Lock()
+	tracked = append([]*Operation{o}, tracked...)
+	// The oldest done operations are dropped, the in-flight ones are always listed.
+	for i := len(tracked) - 1; i >= 0 && len(tracked) > maxRecent; i-- {
+		if tracked[i].Status().Done {
+			tracked = append(tracked[:i], tracked[i+1:]...)
+		}
+	}
+	mu.Unlock()
+	notify(o)
+
+	go func() { _ = o.Wait(context.Background()) }()
+}
+
+// List returns the in-flight and recent operations, newest first.
+func List() []*Operation {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]*Operation(nil), tracked...)
+}
+
+// OnChange sets the function called, from any goroutine, when an operation is tracked or changes.
+func OnChange(fn func(*Operation)) {
+	mu.Lock()
+	defer mu.Unlock()
+	onChange = fn
+}
+
+func notify(o *Operation) {
+	mu.Lock()
+	fn := onChange
+	mu.Unlock()
+	if fn != nil {
+		fn(o)
+	}
+}
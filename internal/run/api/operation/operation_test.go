@@ -0,0 +1,191 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePoller is an operation done after polls polls, with err, whose first pollErrs polls fail.
+type fakePoller struct {
+	polls    int
+	err      error
+	pollErrs int
+	done     bool
+}
+
+func (p *fakePoller) Name() string { return "operation-1" }
+
+func (p *fakePoller) Done() bool { return p.done }
+
+func (p *fakePoller) Poll(ctx context.Context, opts ...gax.CallOption) (int, error) {
+	if p.pollErrs > 0 {
+		p.pollErrs--
+		return 0, assert.AnError
+	}
+	p.polls--
+	if p.polls <= 0 {
+		p.done = true
+		return 100, p.err
+	}
+	return 0, nil
+}
+
+func (p *fakePoller) Metadata() (int, error) {
+	return 100 - 10*p.polls, nil
+}
+
+// runningPoller is an operation running until stop is closed.
+type runningPoller struct {
+	stop chan struct{}
+	done bool
+}
+
+func (p *runningPoller) Name() string { return "operation-2" }
+
+func (p *runningPoller) Done() bool { return p.done }
+
+func (p *runningPoller) Poll(ctx context.Context, opts ...gax.CallOption) (int, error) {
+	select {
+	case <-p.stop:
+		p.done = true
+	default:
+	}
+	return 0, nil
+}
+
+func (p *runningPoller) Metadata() (int, error) {
+	return 0, nil
+}
+
+func describe(percent int) string {
+	return fmt.Sprintf("%d%%", percent)
+}
+
+func setup(t *testing.T) {
+	originalInterval := pollInterval
+	pollInterval = time.Millisecond
+	t.Cleanup(func() {
+		pollInterval = originalInterval
+		mu.Lock()
+		tracked, onChange = nil, nil
+		mu.Unlock()
+	})
+}
+
+func TestWait(t *testing.T) {
+	setup(t)
+
+	var progress []string
+	p := &fakePoller{polls: 3, pollErrs: 2}
+	o := New("Scale", "service s1", p, func(percent int) string {
+		s := describe(percent)
+		progress = append(progress, s)
+		return s
+	})
+	assert.Equal(t, "Scale", o.Kind)
+	assert.False(t, o.Started.IsZero())
+	assert.False(t, o.Status().Done)
+
+	// The failed polls are retried.
+	assert.NoError(t, o.Wait(context.Background()))
+	assert.Equal(t, []string{"80%", "90%", "100%"}, progress)
+	status := o.Status()
+	assert.True(t, status.Done)
+	assert.NoError(t, status.Err)
+	assert.Equal(t, "100%", status.Progress)
+	assert.False(t, status.Ended.IsZero())
+}
+
+func TestWait_Failed(t *testing.T) {
+	setup(t)
+
+	o := New("Scale", "service s1", &fakePoller{polls: 1, err: assert.AnError}, describe)
+	assert.ErrorIs(t, o.Wait(context.Background()), assert.AnError)
+	assert.ErrorIs(t, o.Status().Err, assert.AnError)
+
+	// The operation is failed after too many failed polls.
+	o = New("Scale", "service s1", &fakePoller{polls: 1, pollErrs: maxPollErrors}, describe)
+	assert.ErrorIs(t, o.Wait(context.Background()), assert.AnError)
+	assert.True(t, o.Status().Done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o = New("Scale", "service s1", &fakePoller{polls: 2}, describe)
+	assert.ErrorIs(t, o.Wait(ctx), context.Canceled)
+}
+
+func TestTrack(t *testing.T) {
+	setup(t)
+
+	changed := make(chan *Operation, 1)
+	OnChange(func(o *Operation) {
+		select {
+		case changed <- o:
+		default:
+		}
+	})
+
+	o := New("Scale", "service s1", Completed("operation-1", 100), describe)
+	Track(o)
+	assert.Equal(t, []*Operation{o}, List())
+	assert.Same(t, o, <-changed)
+
+	// It is waited for in the background.
+	assert.Eventually(t, func() bool { return o.Status().Done }, time.Second, time.Millisecond)
+	OnChange(nil)
+
+	// The oldest done operations are dropped.
+	stop := make(chan struct{})
+	running := New("Scale", "service s2", &runningPoller{stop: stop}, describe)
+	Track(running)
+	for i := 0; i < maxRecent; i++ {
+		Track(New("Scale", "service s1", Completed("operation-1", 100), describe))
+	}
+	assert.Eventually(t, func() bool {
+		for _, o := range List() {
+			if o != running && !o.Status().Done {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+	Track(New("Scale", "service s1", Completed("operation-1", 100), describe))
+	list := List()
+	assert.Len(t, list, maxRecent)
+	assert.Contains(t, list, running)
+	assert.NotContains(t, list, o)
+
+	close(stop)
+	assert.Eventually(t, func() bool { return running.Status().Done }, time.Second, time.Millisecond)
+}
+
+func TestConditions(t *testing.T) {
+	assert.Equal(t, "in progress", Conditions(nil, nil))
+	assert.Equal(t, "1/2 conditions ready", Conditions(nil, []*runpb.Condition{
+		{State: runpb.Condition_CONDITION_SUCCEEDED},
+		{State: runpb.Condition_CONDITION_PENDING},
+	}))
+	assert.Equal(t, "ready", Conditions(&runpb.Condition{State: runpb.Condition_CONDITION_SUCCEEDED}, nil))
+	assert.Equal(t, "revision failed", Conditions(&runpb.Condition{State: runpb.Condition_CONDITION_FAILED, Message: "revision failed"}, nil))
+}
+
+func TestRelease(t *testing.T) {
+	released := 0
+	p := Release[int](&fakePoller{polls: 2}, func() error {
+		released++
+		return nil
+	})
+
+	_, _ = p.Poll(context.Background())
+	assert.Equal(t, 0, released)
+	_, _ = p.Poll(context.Background())
+	_, _ = p.Poll(context.Background())
+	assert.Equal(t, 1, released)
+	assert.Equal(t, "operation-1", p.Name())
+}
@@ -0,0 +1,72 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/googleapis/gax-go/v2"
+)
+
+// Conditions describes the progress of a resource from its conditions, e.g. "2/3 conditions ready",
+// or from its terminal condition once it is ready or failed.
+func Conditions(terminal *runpb.Condition, conditions []*runpb.Condition) string {
+	switch terminal.GetState() {
+	case runpb.Condition_CONDITION_SUCCEEDED:
+		return "ready"
+	case runpb.Condition_CONDITION_FAILED:
+		return terminal.GetMessage()
+	}
+	if len(conditions) == 0 {
+		return "in progress"
+	}
+	ready := 0
+	for _, c := range conditions {
+		if c.GetState() == runpb.Condition_CONDITION_SUCCEEDED {
+			ready++
+		}
+	}
+	return fmt.Sprintf("%d/%d conditions ready", ready, len(conditions))
+}
+
+// Completed returns a done operation of result, e.g. for the in-memory clients.
+func Completed[T any](name string, result T) Poller[T] {
+	return &completed[T]{name: name, result: result}
+}
+
+type completed[T any] struct {
+	name   string
+	result T
+}
+
+func (c *completed[T]) Name() string { return c.name }
+
+func (c *completed[T]) Done() bool { return true }
+
+func (c *completed[T]) Poll(ctx context.Context, opts ...gax.CallOption) (T, error) {
+	return c.result, nil
+}
+
+func (c *completed[T]) Metadata() (T, error) {
+	return c.result, nil
+}
+
+// Release returns p, calling release once it is done, e.g. to release the client polling it.
+func Release[T any](p Poller[T], release func() error) Poller[T] {
+	return &released[T]{Poller: p, release: release}
+}
+
+type released[T any] struct {
+	Poller[T]
+	release func() error
+	once    sync.Once
+}
+
+func (r *released[T]) Poll(ctx context.Context, opts ...gax.CallOption) (T, error) {
+	result, err := r.Poller.Poll(ctx, opts...)
+	if r.Done() {
+		r.once.Do(func() { _ = r.release() })
+	}
+	return result, err
+}
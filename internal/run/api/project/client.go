@@ -2,7 +2,6 @@ package project
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -18,6 +17,7 @@ import (
 // Interfaces for mocking
 type ProjectsClientWrapper interface {
 	SearchProjects(ctx context.Context, req *resourcemanagerpb.SearchProjectsRequest, opts ...gax.CallOption) ProjectIteratorWrapper
+	GetProject(ctx context.Context, req *resourcemanagerpb.GetProjectRequest, opts ...gax.CallOption) (*resourcemanagerpb.Project, error)
 	Close() error
 }
 
@@ -34,6 +34,11 @@ var createProjectsClient = func(ctx context.Context, opts ...option.ClientOption
 	return &GCPProjectsClientWrapper{client: c}, nil
 }
 
+// projectsClient returns a projects client from the pool, release must be called once it is no longer used.
+func projectsClient(ctx context.Context) (c ProjectsClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIResourceManager, Client: "projects"}, resourcemanager.DefaultAuthScopes(), createProjectsClient)
+}
+
 // Real implementations
 type GCPProjectsClientWrapper struct {
 	client *resourcemanager.ProjectsClient
@@ -43,6 +48,10 @@ func (w *GCPProjectsClientWrapper) SearchProjects(ctx context.Context, req *reso
 	return &GCPProjectIteratorWrapper{it: w.client.SearchProjects(ctx, req, opts...)}
 }
 
+func (w *GCPProjectsClientWrapper) GetProject(ctx context.Context, req *resourcemanagerpb.GetProjectRequest, opts ...gax.CallOption) (*resourcemanagerpb.Project, error) {
+	return w.client.GetProject(ctx, req, opts...)
+}
+
 func (w *GCPProjectsClientWrapper) Close() error {
 	return w.client.Close()
 }
@@ -58,6 +67,7 @@ func (w *GCPProjectIteratorWrapper) Next() (*resourcemanagerpb.Project, error) {
 // Client defines the interface for Cloud Resource Manager operations.
 type Client interface {
 	ListProjects(ctx context.Context) ([]model.Project, error)
+	GetProject(ctx context.Context, projectID string) (model.Project, error)
 }
 
 var _ Client = (*GCPClient)(nil)
@@ -68,17 +78,12 @@ type GCPClient struct{}
 // ListProjects lists projects for the current user.
 func (c *GCPClient) ListProjects(ctx context.Context) ([]model.Project, error) {
 	// Explicitly find default credentials
-	creds, err := client.FindDefaultCredentials(ctx, resourcemanager.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w. Tip: Try running 'gcloud auth application-default login' to authenticate the Go client", err)
-	}
-
-	cClient, err := createProjectsClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := projectsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	req := &resourcemanagerpb.SearchProjectsRequest{
@@ -101,7 +106,22 @@ func (c *GCPClient) ListProjects(ctx context.Context) ([]model.Project, error) {
 
 	return projects, nil
 }
+// GetProject gets a project by ID.
+func (c *GCPClient) GetProject(ctx context.Context, projectID string) (model.Project, error) {
+	cClient, release, err := projectsClient(ctx)
+	if err != nil {
+		return model.Project{}, err
+	}
+	defer func() {
+		_ = release()
+	}()
 
+	resp, err := cClient.GetProject(ctx, &resourcemanagerpb.GetProjectRequest{Name: "projects/" + projectID})
+	if err != nil {
+		return model.Project{}, client.WrapError(err)
+	}
+	return mapProject(resp), nil
+}
 
 func mapProject(resp *resourcemanagerpb.Project) model.Project {
 	// Parse Project Number from Name "projects/123456"
@@ -3,13 +3,26 @@ package project
 import (
 	"context"
 
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
 	model "github.com/JulienBreux/run-cli/internal/run/model/common/project"
 )
 
 var apiClient Client = &GCPClient{}
 
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
 // List returns a list of projects for the current user.
-func List() ([]model.Project, error) {
-	ctx := context.Background()
-	return apiClient.ListProjects(ctx)
-}
\ No newline at end of file
+func List(ctx context.Context) ([]model.Project, error) {
+	return client.Call(ctx, func(ctx context.Context) ([]model.Project, error) {
+		return apiClient.ListProjects(ctx)
+	})
+}
+// Get returns a project by ID.
+func Get(ctx context.Context, projectID string) (model.Project, error) {
+	return client.Call(ctx, func(ctx context.Context) (model.Project, error) {
+		return apiClient.GetProject(ctx, projectID)
+	})
+}
@@ -18,12 +18,17 @@ import (
 // MockClient is a mock implementation of Client.
 type MockClient struct {
 	ListProjectsFunc func(ctx context.Context) ([]model.Project, error)
+	GetProjectFunc   func(ctx context.Context, projectID string) (model.Project, error)
 }
 
 func (m *MockClient) ListProjects(ctx context.Context) ([]model.Project, error) {
 	return m.ListProjectsFunc(ctx)
 }
 
+func (m *MockClient) GetProject(ctx context.Context, projectID string) (model.Project, error) {
+	return m.GetProjectFunc(ctx, projectID)
+}
+
 func TestList(t *testing.T) {
 	// Backup original client and restore after test
 	origClient := apiClient
@@ -41,7 +46,7 @@ func TestList(t *testing.T) {
 			},
 		}
 
-		projects, err := List()
+		projects, err := List(context.Background())
 		assert.NoError(t, err)
 		assert.Equal(t, expectedProjects, projects)
 	})
@@ -55,7 +60,7 @@ func TestList(t *testing.T) {
 			},
 		}
 
-		projects, err := List()
+		projects, err := List(context.Background())
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		assert.Nil(t, projects)
@@ -66,9 +71,14 @@ func TestList(t *testing.T) {
 
 type MockProjectsClientWrapper struct {
 	SearchProjectsFunc func(ctx context.Context, req *resourcemanagerpb.SearchProjectsRequest, opts ...gax.CallOption) ProjectIteratorWrapper
+	GetProjectFunc     func(ctx context.Context, req *resourcemanagerpb.GetProjectRequest, opts ...gax.CallOption) (*resourcemanagerpb.Project, error)
 	CloseFunc          func() error
 }
 
+func (m *MockProjectsClientWrapper) GetProject(ctx context.Context, req *resourcemanagerpb.GetProjectRequest, opts ...gax.CallOption) (*resourcemanagerpb.Project, error) {
+	return m.GetProjectFunc(ctx, req, opts...)
+}
+
 func (m *MockProjectsClientWrapper) SearchProjects(ctx context.Context, req *resourcemanagerpb.SearchProjectsRequest, opts ...gax.CallOption) ProjectIteratorWrapper {
 	if m.SearchProjectsFunc != nil {
 		return m.SearchProjectsFunc(ctx, req, opts...)
@@ -101,6 +111,76 @@ func (m *MockProjectIteratorWrapper) Next() (*resourcemanagerpb.Project, error)
 	return item, nil
 }
 
+func TestGet(t *testing.T) {
+	origClient := apiClient
+	defer func() { apiClient = origClient }()
+
+	var gotID string
+	apiClient = &MockClient{
+		GetProjectFunc: func(ctx context.Context, projectID string) (model.Project, error) {
+			gotID = projectID
+			return model.Project{Name: projectID, Number: 123}, nil
+		},
+	}
+
+	p, err := Get(context.Background(), "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", gotID)
+	assert.Equal(t, model.Project{Name: "p1", Number: 123}, p)
+}
+
+func TestGCPClient_GetProject(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createProjectsClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createProjectsClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		var gotName string
+		createProjectsClient = func(ctx context.Context, opts ...option.ClientOption) (ProjectsClientWrapper, error) {
+			return &MockProjectsClientWrapper{
+				GetProjectFunc: func(ctx context.Context, req *resourcemanagerpb.GetProjectRequest, opts ...gax.CallOption) (*resourcemanagerpb.Project, error) {
+					gotName = req.Name
+					return &resourcemanagerpb.Project{ProjectId: "p1", Name: "projects/1"}, nil
+				},
+			}, nil
+		}
+
+		p, err := (&GCPClient{}).GetProject(context.Background(), "p1")
+		assert.NoError(t, err)
+		assert.Equal(t, "projects/p1", gotName)
+		assert.Equal(t, model.Project{Name: "p1", Number: 1}, p)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		createProjectsClient = func(ctx context.Context, opts ...option.ClientOption) (ProjectsClientWrapper, error) {
+			return &MockProjectsClientWrapper{
+				GetProjectFunc: func(ctx context.Context, req *resourcemanagerpb.GetProjectRequest, opts ...gax.CallOption) (*resourcemanagerpb.Project, error) {
+					return nil, errors.New("rpc error: code = PermissionDenied")
+				},
+			}, nil
+		}
+
+		_, err := (&GCPClient{}).GetProject(context.Background(), "p1")
+		assert.ErrorContains(t, err, "authentication failed")
+	})
+
+	t.Run("Auth Error", func(t *testing.T) {
+		client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+			return nil, errors.New("auth failed")
+		}
+
+		_, err := (&GCPClient{}).GetProject(context.Background(), "p1")
+		assert.ErrorContains(t, err, "failed to find default credentials")
+	})
+}
+
 func TestGCPClient_ListProjects(t *testing.T) {
 	// Mock dependencies
 	origFindCreds := client.FindDefaultCredentials
@@ -0,0 +1,82 @@
+package region
+
+import (
+	"context"
+	"slices"
+
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"google.golang.org/api/option"
+	"google.golang.org/api/run/v1"
+)
+
+// LocationsClientWrapper defines the interface for the Locations API interactions.
+type LocationsClientWrapper interface {
+	List(ctx context.Context, name string, pageToken string) (*run.ListLocationsResponse, error)
+}
+
+// variable for dependency injection
+var createClient = func(ctx context.Context, opts ...option.ClientOption) (LocationsClientWrapper, error) {
+	s, err := run.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GCPLocationsClient{service: s}, nil
+}
+
+// locationsClient returns a locations client from the pool, release must be called once it is no longer used.
+func locationsClient(ctx context.Context) (c LocationsClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "locations", REST: true}, []string{run.CloudPlatformScope}, createClient)
+}
+
+// GCPLocationsClient is the real implementation using the Google Cloud Run API.
+type GCPLocationsClient struct {
+	service *run.APIService
+}
+
+func (c *GCPLocationsClient) List(ctx context.Context, name string, pageToken string) (*run.ListLocationsResponse, error) {
+	call := c.service.Projects.Locations.List(name).Context(ctx)
+	if pageToken != "" {
+		call.PageToken(pageToken)
+	}
+	return call.Do()
+}
+
+// Client defines the interface for the Cloud Run locations.
+type Client interface {
+	ListRegions(ctx context.Context, project string) ([]string, error)
+}
+
+var _ Client = (*GCPClient)(nil)
+
+// GCPClient is the Google Cloud Platform implementation of Client.
+type GCPClient struct{}
+
+// ListRegions lists the regions where Cloud Run is available for a project, sorted by name.
+func (c *GCPClient) ListRegions(ctx context.Context, project string) ([]string, error) {
+	lClient, release, err := locationsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	var regions []string
+	pageToken := ""
+	for {
+		resp, err := lClient.List(ctx, "projects/"+project, pageToken)
+		if err != nil {
+			return nil, client.WrapError(err)
+		}
+		for _, l := range resp.Locations {
+			regions = append(regions, l.LocationId)
+		}
+		pageToken = resp.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	slices.Sort(regions)
+	return regions, nil
+}
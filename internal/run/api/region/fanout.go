@@ -0,0 +1,95 @@
+package region
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+)
+
+// PartialError reports the regions which could not be listed when listing all regions,
+// the resources of the other regions being returned along with it.
+type PartialError struct {
+	// Errors are the errors of the failed regions, keyed by region.
+	Errors map[string]error
+	// Total is the number of listed regions, failed ones included.
+	Total int
+}
+
+// Error returns human readable error
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d of %d regions could not be listed: %s", len(e.Errors), e.Total, strings.Join(e.Regions(), ", "))
+}
+
+// Unwrap returns the errors of the failed regions.
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, region := range e.Regions() {
+		errs = append(errs, e.Errors[region])
+	}
+	return errs
+}
+
+// Regions returns the failed regions, sorted by name.
+func (e *PartialError) Regions() []string {
+	return slices.Sorted(maps.Keys(e.Errors))
+}
+
+// Failed returns the regions which could not be listed when err is a *PartialError, nil otherwise.
+func Failed(err error) []string {
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		return partial.Regions()
+	}
+	return nil
+}
+
+// FanOut calls list concurrently for each region of the project, see List, and returns the
+// resources of all regions. When some regions fail, the resources of the others are returned
+// with a *PartialError. When all regions fail, the error of the first one is returned.
+// At most client.Limits.Parallelism regions are listed at once.
+func FanOut[T any](ctx context.Context, project string, list func(region string) ([]T, error)) ([]T, error) {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		items []T
+		errs  = map[string]error{}
+	)
+
+	// The calls of list hold the slots of the executor, they can't be held here too, see client.Call.
+	slots := make(chan struct{}, client.CurrentLimits().Parallelism)
+	regions := List(ctx, project)
+	for _, region := range regions {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(r string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result, err := list(r)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[r] = err
+				return
+			}
+			items = append(items, result...)
+		}(region)
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return items, nil
+	}
+	partial := &PartialError{Errors: errs, Total: len(regions)}
+	if len(errs) == len(regions) {
+		first := partial.Regions()[0]
+		return nil, fmt.Errorf("%s: %w", first, errs[first])
+	}
+	return items, partial
+}
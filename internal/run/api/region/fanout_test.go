@@ -0,0 +1,86 @@
+package region
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/stretchr/testify/assert"
+)
+
+var errDenied = errors.New("permission denied")
+
+func TestFanOut(t *testing.T) {
+	setup(t, &MockClient{})
+	SetAllowed([]string{"europe-west1", "us-central1"})
+
+	items, err := FanOut(context.Background(), "p", func(region string) ([]string, error) {
+		return []string{"svc-" + region}, nil
+	})
+	assert.NoError(t, err)
+	slices.Sort(items)
+	assert.Equal(t, []string{"svc-europe-west1", "svc-us-central1"}, items)
+}
+
+func TestFanOut_Partial(t *testing.T) {
+	setup(t, &MockClient{})
+	SetAllowed([]string{"asia-east1", "europe-west1", "us-central1"})
+
+	items, err := FanOut(context.Background(), "p", func(region string) ([]string, error) {
+		if region == "europe-west1" {
+			return []string{"svc-" + region}, nil
+		}
+		return nil, errDenied
+	})
+	assert.Equal(t, []string{"svc-europe-west1"}, items)
+
+	var partial *PartialError
+	assert.ErrorAs(t, err, &partial)
+	assert.Equal(t, []string{"asia-east1", "us-central1"}, partial.Regions())
+	assert.Equal(t, 3, partial.Total)
+	assert.ErrorIs(t, err, errDenied)
+	assert.EqualError(t, err, "2 of 3 regions could not be listed: asia-east1, us-central1")
+}
+
+func TestFanOut_AllFailed(t *testing.T) {
+	setup(t, &MockClient{})
+	SetAllowed([]string{"europe-west1", "us-central1"})
+
+	items, err := FanOut(context.Background(), "p", func(region string) ([]string, error) {
+		return nil, errDenied
+	})
+	assert.Nil(t, items)
+	assert.ErrorIs(t, err, errDenied)
+	assert.EqualError(t, err, "europe-west1: permission denied")
+
+	var partial *PartialError
+	assert.False(t, errors.As(err, &partial))
+}
+
+func TestFanOut_Parallelism(t *testing.T) {
+	setup(t, &MockClient{})
+	client.SetLimits(client.Limits{Parallelism: 2})
+	t.Cleanup(func() { client.SetLimits(client.DefaultLimits) })
+	SetAllowed([]string{"asia-east1", "europe-west1", "europe-west9", "us-central1", "us-east1"})
+
+	var running, peak atomic.Int32
+	items, err := FanOut(context.Background(), "p", func(region string) ([]string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return []string{"svc-" + region}, nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, items, 5)
+	assert.Equal(t, int32(2), peak.Load())
+}
@@ -1,16 +1,133 @@
 package region
 
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/cache"
+	"golang.org/x/sync/singleflight"
+)
+
 // Represents all regions.
 const ALL = "all"
 
-// List returns a list of supported Cloud Run regions.
-func List() []string {
+const (
+	// regionsTTL is the duration after which the regions of a project are fetched again.
+	regionsTTL = 24 * time.Hour
+	// fetchTimeout bounds the time spent fetching the regions, the built-in ones are used after it.
+	fetchTimeout = 10 * time.Second
+)
+
+var apiClient Client = &GCPClient{}
+
+// Variables for dependency injection
+var newCache = func() (*cache.Cache, error) { return cache.New("regions") }
+
+var (
+	mu sync.Mutex
+	// allowed are the regions set with SetAllowed, if any.
+	allowed []string
+	// fetched are the regions of the projects, fetched once per process.
+	fetched = map[string][]string{}
+	// fetching shares the in-flight fetch of the regions of a project between concurrent calls.
+	fetching singleflight.Group
+)
+
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	mu.Lock()
+	defer mu.Unlock()
+	apiClient = c
+	fetched = map[string][]string{}
+}
+
+// SetAllowed restricts the regions to the given ones, e.g. the regions of the configuration.
+// Without regions, List returns all the available regions.
+func SetAllowed(regions []string) {
+	mu.Lock()
+	defer mu.Unlock()
+	allowed = slices.Clone(regions)
+}
+
+// List returns the Cloud Run regions of a project: the allowed ones if set, see SetAllowed,
+// otherwise the ones where Cloud Run is available, cached on disk for a day. The built-in
+// regions are returned when they can't be fetched, or when ctx is done before they are.
+func List(ctx context.Context, project string) []string {
+	mu.Lock()
+	if len(allowed) > 0 {
+		defer mu.Unlock()
+		return slices.Clone(allowed)
+	}
+	regions, ok := fetched[project]
+	c, cached := apiClient, fetched
+	mu.Unlock()
+	if ok {
+		return slices.Clone(regions)
+	}
+
+	// Concurrent calls for a project wait for the first fetch instead of fetching again, without
+	// holding the lock: the other projects and SetAllowed aren't blocked by the network call. The
+	// fetch isn't cancelled with the call which started it, the others still wait for it: each call
+	// stops waiting when its own ctx is done.
+	ch := fetching.DoChan(project, func() (any, error) {
+		regions := fetch(context.WithoutCancel(ctx), c, project)
+		mu.Lock()
+		cached[project] = regions
+		mu.Unlock()
+		return regions, nil
+	})
+	select {
+	case result := <-ch:
+		return slices.Clone(result.Val.([]string))
+	case <-ctx.Done():
+		return Builtin()
+	}
+}
+
+// fetch returns the regions of a project from the disk cache, otherwise from the API within
+// fetchTimeout.
+func fetch(ctx context.Context, c Client, project string) []string {
+	if project == "" {
+		return Builtin()
+	}
+
+	disk, err := newCache()
+	if err != nil {
+		disk = nil
+	}
+	var regions []string
+	if disk != nil && disk.Get(project, regionsTTL, &regions) && len(regions) > 0 {
+		return regions
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	regions, err = client.Call(ctx, func(ctx context.Context) ([]string, error) {
+		return c.ListRegions(ctx, project)
+	})
+	if err != nil || len(regions) == 0 {
+		return Builtin()
+	}
+	if disk != nil {
+		_ = disk.Set(project, regions)
+	}
+	return regions
+}
+
+// Builtin returns the regions used when the available ones can't be fetched.
+func Builtin() []string {
 	return []string{
-		"asia-east1", "asia-northeast1", "asia-northeast2", "asia-northeast3",
-		"asia-south1", "asia-southeast1", "asia-southeast2", "australia-southeast1",
-		"europe-central2", "europe-north1", "europe-west1", "europe-west2",
-		"europe-west3", "europe-west4", "europe-west6", "northamerica-northeast1",
-		"southamerica-east1", "us-central1", "us-east1", "us-east4",
-		"us-west1", "us-west2", "us-west3", "us-west4",
+		"africa-south1", "asia-east1", "asia-east2", "asia-northeast1", "asia-northeast2",
+		"asia-northeast3", "asia-south1", "asia-south2", "asia-southeast1", "asia-southeast2",
+		"australia-southeast1", "australia-southeast2", "europe-central2", "europe-north1",
+		"europe-north2", "europe-southwest1", "europe-west1", "europe-west2", "europe-west3",
+		"europe-west4", "europe-west6", "europe-west8", "europe-west9", "europe-west10",
+		"europe-west12", "me-central1", "me-central2", "me-west1", "northamerica-northeast1",
+		"northamerica-northeast2", "northamerica-south1", "southamerica-east1", "southamerica-west1",
+		"us-central1", "us-east1", "us-east4", "us-east5", "us-south1", "us-west1", "us-west2",
+		"us-west3", "us-west4",
 	}
 }
@@ -1,14 +1,167 @@
 package region
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/cache"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	"google.golang.org/api/run/v1"
 )
 
-func TestList(t *testing.T) {
-	regions := List()
-	assert.NotEmpty(t, regions)
+// MockClient is a mock implementation of the Client interface.
+type MockClient struct {
+	calls int
+	err   error
+}
+
+func (m *MockClient) ListRegions(ctx context.Context, project string) ([]string, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []string{"europe-west1", "us-central1"}, nil
+}
+
+// mockLocationsClient is a mock implementation of the LocationsClientWrapper interface.
+type mockLocationsClient struct {
+	pages map[string]*run.ListLocationsResponse
+}
+
+func (m *mockLocationsClient) List(ctx context.Context, name string, pageToken string) (*run.ListLocationsResponse, error) {
+	return m.pages[pageToken], nil
+}
+
+// setup replaces the client and isolates the disk cache of the regions.
+func setup(t *testing.T, mock Client) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	SetClient(mock)
+	t.Cleanup(func() {
+		SetClient(&GCPClient{})
+		SetAllowed(nil)
+	})
+}
+
+func TestBuiltin(t *testing.T) {
+	regions := Builtin()
 	assert.Contains(t, regions, "us-central1")
 	assert.Contains(t, regions, "europe-west1")
+	assert.Contains(t, regions, "me-west1")
+}
+
+func TestList_Allowed(t *testing.T) {
+	mock := &MockClient{}
+	setup(t, mock)
+
+	SetAllowed([]string{"europe-west9"})
+	assert.Equal(t, []string{"europe-west9"}, List(context.Background(), "p"))
+	assert.Equal(t, 0, mock.calls)
+}
+
+func TestList_Fetched(t *testing.T) {
+	mock := &MockClient{}
+	setup(t, mock)
+
+	assert.Equal(t, []string{"europe-west1", "us-central1"}, List(context.Background(), "p"))
+	assert.Equal(t, []string{"europe-west1", "us-central1"}, List(context.Background(), "p"))
+	assert.Equal(t, 1, mock.calls)
+
+	// A new process reads the regions from the disk cache.
+	fetched = map[string][]string{}
+	assert.Equal(t, []string{"europe-west1", "us-central1"}, List(context.Background(), "p"))
+	assert.Equal(t, 1, mock.calls)
+}
+
+func TestList_Fallback(t *testing.T) {
+	mock := &MockClient{err: errors.New("permission denied")}
+	setup(t, mock)
+
+	assert.Equal(t, Builtin(), List(context.Background(), "p"))
+	assert.Equal(t, Builtin(), List(context.Background(), ""))
+	assert.Equal(t, 1, mock.calls)
+}
+
+// blockingClient is a client whose fetch of the regions of project "slow" blocks until released.
+type blockingClient struct {
+	MockClient
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingClient) ListRegions(ctx context.Context, project string) ([]string, error) {
+	if project != "slow" {
+		return b.MockClient.ListRegions(ctx, project)
+	}
+	close(b.started)
+	select {
+	case <-b.release:
+		return []string{"us-east1"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestList_Cancelled(t *testing.T) {
+	mock := &blockingClient{started: make(chan struct{}), release: make(chan struct{})}
+	setup(t, mock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan []string)
+	go func() { first <- List(ctx, "slow") }()
+	<-mock.started
+	second := make(chan []string)
+	go func() { second <- List(context.Background(), "slow") }()
+
+	// The fetch of a project doesn't block the other projects, nor SetAllowed.
+	assert.Equal(t, []string{"europe-west1", "us-central1"}, List(context.Background(), "p"))
+	SetAllowed(nil)
+
+	// A cancelled call stops waiting, the fetch goes on for the other calls and is kept.
+	cancel()
+	assert.Equal(t, Builtin(), <-first)
+	close(mock.release)
+	assert.Equal(t, []string{"us-east1"}, <-second)
+	mu.Lock()
+	regions := fetched["slow"]
+	mu.Unlock()
+	assert.Equal(t, []string{"us-east1"}, regions)
+}
+
+func TestList_NoCache(t *testing.T) {
+	mock := &MockClient{}
+	setup(t, mock)
+
+	origNewCache := newCache
+	t.Cleanup(func() { newCache = origNewCache })
+	newCache = func() (*cache.Cache, error) { return nil, errors.New("no cache directory") }
+
+	assert.Equal(t, []string{"europe-west1", "us-central1"}, List(context.Background(), "p"))
+}
+
+func TestGCPClient_ListRegions(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createClient
+	t.Cleanup(func() {
+		client.FindDefaultCredentials = origFindCreds
+		createClient = origCreateClient
+	})
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+	createClient = func(ctx context.Context, opts ...option.ClientOption) (LocationsClientWrapper, error) {
+		return &mockLocationsClient{pages: map[string]*run.ListLocationsResponse{
+			"":     {Locations: []*run.Location{{LocationId: "us-central1"}}, NextPageToken: "next"},
+			"next": {Locations: []*run.Location{{LocationId: "europe-west1"}}},
+		}}, nil
+	}
+
+	regions, err := (&GCPClient{}).ListRegions(context.Background(), "p")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"europe-west1", "us-central1"}, regions)
 }
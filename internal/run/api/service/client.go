@@ -7,6 +7,7 @@ import (
 	run "cloud.google.com/go/run/apiv2"
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	"github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
@@ -15,7 +16,9 @@ import (
 // Interfaces for mocking
 type ServicesClientWrapper interface {
 	ListServices(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ServiceIteratorWrapper
+	ListServicesPage(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ([]*runpb.Service, string, error)
 	GetService(ctx context.Context, req *runpb.GetServiceRequest, opts ...gax.CallOption) (*runpb.Service, error)
+	CreateService(ctx context.Context, req *runpb.CreateServiceRequest, opts ...gax.CallOption) (CreateServiceOperationWrapper, error)
 	UpdateService(ctx context.Context, req *runpb.UpdateServiceRequest, opts ...gax.CallOption) (UpdateServiceOperationWrapper, error)
 	Close() error
 }
@@ -24,7 +27,12 @@ type ServiceIteratorWrapper interface {
 	Next() (*runpb.Service, error)
 }
 
+type CreateServiceOperationWrapper interface {
+	Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error)
+}
+
 type UpdateServiceOperationWrapper interface {
+	operation.Poller[*runpb.Service]
 	Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error)
 }
 
@@ -37,6 +45,11 @@ var createServicesClient = func(ctx context.Context, opts ...option.ClientOption
 	return &GCPServicesClientWrapper{client: c}, nil
 }
 
+// servicesClient returns a services client from the pool, release must be called once it is no longer used.
+func servicesClient(ctx context.Context) (c ServicesClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "services"}, run.DefaultAuthScopes(), createServicesClient)
+}
+
 // Real implementations
 type GCPServicesClientWrapper struct {
 	client *run.ServicesClient
@@ -46,10 +59,25 @@ func (w *GCPServicesClientWrapper) ListServices(ctx context.Context, req *runpb.
 	return &GCPServiceIteratorWrapper{it: w.client.ListServices(ctx, req, opts...)}
 }
 
+// ListServicesPage returns the page of services of the page token and size of req, and the token of the next page.
+func (w *GCPServicesClientWrapper) ListServicesPage(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ([]*runpb.Service, string, error) {
+	var services []*runpb.Service
+	next, err := iterator.NewPager(w.client.ListServices(ctx, req, opts...), int(req.PageSize), req.PageToken).NextPage(&services)
+	return services, next, err
+}
+
 func (w *GCPServicesClientWrapper) GetService(ctx context.Context, req *runpb.GetServiceRequest, opts ...gax.CallOption) (*runpb.Service, error) {
 	return w.client.GetService(ctx, req, opts...)
 }
 
+func (w *GCPServicesClientWrapper) CreateService(ctx context.Context, req *runpb.CreateServiceRequest, opts ...gax.CallOption) (CreateServiceOperationWrapper, error) {
+	op, err := w.client.CreateService(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GCPCreateServiceOperationWrapper{op: op}, nil
+}
+
 func (w *GCPServicesClientWrapper) UpdateService(ctx context.Context, req *runpb.UpdateServiceRequest, opts ...gax.CallOption) (UpdateServiceOperationWrapper, error) {
 	op, err := w.client.UpdateService(ctx, req, opts...)
 	if err != nil {
@@ -70,6 +98,14 @@ func (w *GCPServiceIteratorWrapper) Next() (*runpb.Service, error) {
 	return w.it.Next()
 }
 
+type GCPCreateServiceOperationWrapper struct {
+	op *run.CreateServiceOperation
+}
+
+func (w *GCPCreateServiceOperationWrapper) Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error) {
+	return w.op.Wait(ctx, opts...)
+}
+
 type GCPUpdateServiceOperationWrapper struct {
 	op *run.UpdateServiceOperation
 }
@@ -78,11 +114,32 @@ func (w *GCPUpdateServiceOperationWrapper) Wait(ctx context.Context, opts ...gax
 	return w.op.Wait(ctx, opts...)
 }
 
+func (w *GCPUpdateServiceOperationWrapper) Name() string {
+	return w.op.Name()
+}
+
+func (w *GCPUpdateServiceOperationWrapper) Done() bool {
+	return w.op.Done()
+}
+
+func (w *GCPUpdateServiceOperationWrapper) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error) {
+	return w.op.Poll(ctx, opts...)
+}
+
+func (w *GCPUpdateServiceOperationWrapper) Metadata() (*runpb.Service, error) {
+	return w.op.Metadata()
+}
+
 // Client defines the interface for Cloud Run Service operations.
 type Client interface {
 	ListServices(ctx context.Context, project, region string) ([]*runpb.Service, error)
+	// ListServicesPage returns a page of services and the token of the next page, empty for the last one.
+	ListServicesPage(ctx context.Context, project, region string, pageSize int, pageToken string) ([]*runpb.Service, string, error)
 	GetService(ctx context.Context, name string) (*runpb.Service, error)
+	CreateService(ctx context.Context, parent, serviceID string, service *runpb.Service) (*runpb.Service, error)
 	UpdateService(ctx context.Context, service *runpb.Service) (*runpb.Service, error)
+	// StartUpdateService updates a service and returns the operation of its rollout without waiting for it.
+	StartUpdateService(ctx context.Context, service *runpb.Service) (operation.Poller[*runpb.Service], error)
 }
 
 // Ensure GCPClient implements Client
@@ -93,17 +150,12 @@ type GCPClient struct{}
 
 // ListServices lists services for a project and region.
 func (c *GCPClient) ListServices(ctx context.Context, project, region string) ([]*runpb.Service, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	cClient, err := createServicesClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := servicesClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	req := &runpb.ListServicesRequest{
@@ -126,37 +178,68 @@ func (c *GCPClient) ListServices(ctx context.Context, project, region string) ([
 	return services, nil
 }
 
-// GetService gets a single service.
-func (c *GCPClient) GetService(ctx context.Context, name string) (*runpb.Service, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
+// ListServicesPage lists a page of services for a project and region.
+func (c *GCPClient) ListServicesPage(ctx context.Context, project, region string, pageSize int, pageToken string) ([]*runpb.Service, string, error) {
+	cClient, release, err := servicesClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	req := &runpb.ListServicesRequest{
+		Parent:    fmt.Sprintf("projects/%s/locations/%s", project, region),
+		PageSize:  int32(pageSize),
+		PageToken: pageToken,
+	}
+
+	services, next, err := cClient.ListServicesPage(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
+		return nil, "", client.WrapError(err)
 	}
+	return services, next, nil
+}
 
-	cClient, err := createServicesClient(ctx, option.WithCredentials(creds))
+// GetService gets a single service.
+func (c *GCPClient) GetService(ctx context.Context, name string) (*runpb.Service, error) {
+	cClient, release, err := servicesClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	return cClient.GetService(ctx, &runpb.GetServiceRequest{Name: name})
 }
 
-// UpdateService updates a service.
-func (c *GCPClient) UpdateService(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
+// CreateService creates a service and waits for the operation to complete.
+func (c *GCPClient) CreateService(ctx context.Context, parent, serviceID string, service *runpb.Service) (*runpb.Service, error) {
+	cClient, release, err := servicesClient(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
+		return nil, err
 	}
+	defer func() {
+		_ = release()
+	}()
 
-	cClient, err := createServicesClient(ctx, option.WithCredentials(creds))
+	op, err := cClient.CreateService(ctx, &runpb.CreateServiceRequest{Parent: parent, ServiceId: serviceID, Service: service})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+
+	return op.Wait(ctx)
+}
+
+// UpdateService updates a service.
+func (c *GCPClient) UpdateService(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
+	cClient, release, err := servicesClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	op, err := cClient.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: service})
@@ -166,3 +249,20 @@ func (c *GCPClient) UpdateService(ctx context.Context, service *runpb.Service) (
 
 	return op.Wait(ctx)
 }
+
+// StartUpdateService updates a service and returns the operation of its rollout.
+func (c *GCPClient) StartUpdateService(ctx context.Context, service *runpb.Service) (operation.Poller[*runpb.Service], error) {
+	cClient, release, err := servicesClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := cClient.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: service})
+	if err != nil {
+		_ = release()
+		return nil, err
+	}
+
+	// The client is released once the operation is done, as it polls it.
+	return operation.Release[*runpb.Service](op, release), nil
+}
@@ -15,6 +15,8 @@ import (
 // Interfaces for mocking
 type RevisionsClientWrapper interface {
 	ListRevisions(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) RevisionIteratorWrapper
+	ListRevisionsPage(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) ([]*runpb.Revision, string, error)
+	GetRevision(ctx context.Context, req *runpb.GetRevisionRequest, opts ...gax.CallOption) (*runpb.Revision, error)
 	Close() error
 }
 
@@ -31,6 +33,11 @@ var createRevisionsClient = func(ctx context.Context, opts ...option.ClientOptio
 	return &GCPRevisionsClientWrapper{client: c}, nil
 }
 
+// revisionsClient returns a revisions client from the pool, release must be called once it is no longer used.
+func revisionsClient(ctx context.Context) (c RevisionsClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "revisions"}, run.DefaultAuthScopes(), createRevisionsClient)
+}
+
 // Real implementations
 type GCPRevisionsClientWrapper struct {
 	client *run.RevisionsClient
@@ -40,6 +47,17 @@ func (w *GCPRevisionsClientWrapper) ListRevisions(ctx context.Context, req *runp
 	return &GCPRevisionIteratorWrapper{it: w.client.ListRevisions(ctx, req, opts...)}
 }
 
+// ListRevisionsPage returns the page of revisions of the page token and size of req, and the token of the next page.
+func (w *GCPRevisionsClientWrapper) ListRevisionsPage(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) ([]*runpb.Revision, string, error) {
+	var revisions []*runpb.Revision
+	next, err := iterator.NewPager(w.client.ListRevisions(ctx, req, opts...), int(req.PageSize), req.PageToken).NextPage(&revisions)
+	return revisions, next, err
+}
+
+func (w *GCPRevisionsClientWrapper) GetRevision(ctx context.Context, req *runpb.GetRevisionRequest, opts ...gax.CallOption) (*runpb.Revision, error) {
+	return w.client.GetRevision(ctx, req, opts...)
+}
+
 func (w *GCPRevisionsClientWrapper) Close() error {
 	return w.client.Close()
 }
@@ -55,25 +73,30 @@ func (w *GCPRevisionIteratorWrapper) Next() (*runpb.Revision, error) {
 // Client defines the interface for Cloud Run Revision operations.
 type Client interface {
 	ListRevisions(ctx context.Context, project, region, service string) ([]*runpb.Revision, error)
+	// ListRevisionsPage returns a page of revisions and the token of the next page, empty for the last one.
+	ListRevisionsPage(ctx context.Context, project, region, service string, pageSize int, pageToken string) ([]*runpb.Revision, string, error)
+	GetRevision(ctx context.Context, name string) (*runpb.Revision, error)
 }
 
 var apiClient Client = &GCPClient{}
 
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
 // GCPClient is the Google Cloud Platform implementation of Client.
 type GCPClient struct{}
 
 // ListRevisions lists revisions for a service.
 func (c *GCPClient) ListRevisions(ctx context.Context, project, region, service string) ([]*runpb.Revision, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	cClient, err := createRevisionsClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := revisionsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = cClient.Close() }()
+	defer func() {
+		_ = release()
+	}()
 
 	req := &runpb.ListRevisionsRequest{
 		Parent: fmt.Sprintf("projects/%s/locations/%s/services/%s", project, region, service),
@@ -93,4 +116,44 @@ func (c *GCPClient) ListRevisions(ctx context.Context, project, region, service
 	}
 
 	return revisions, nil
-}
\ No newline at end of file
+}
+
+// ListRevisionsPage lists a page of revisions for a service.
+func (c *GCPClient) ListRevisionsPage(ctx context.Context, project, region, service string, pageSize int, pageToken string) ([]*runpb.Revision, string, error) {
+	cClient, release, err := revisionsClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	req := &runpb.ListRevisionsRequest{
+		Parent:    fmt.Sprintf("projects/%s/locations/%s/services/%s", project, region, service),
+		PageSize:  int32(pageSize),
+		PageToken: pageToken,
+	}
+
+	revisions, next, err := cClient.ListRevisionsPage(ctx, req)
+	if err != nil {
+		return nil, "", client.WrapError(err)
+	}
+	return revisions, next, nil
+}
+
+// GetRevision gets a single revision.
+func (c *GCPClient) GetRevision(ctx context.Context, name string) (*runpb.Revision, error) {
+	cClient, release, err := revisionsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = release()
+	}()
+
+	resp, err := cClient.GetRevision(ctx, &runpb.GetRevisionRequest{Name: name})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+	return resp, nil
+}
@@ -2,18 +2,20 @@ package revision
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"cloud.google.com/go/run/apiv2/runpb"
-	model_container "github.com/JulienBreux/run-cli/internal/run/model/common/container"
-	model_resources "github.com/JulienBreux/run-cli/internal/run/model/common/resources"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/common"
 	model "github.com/JulienBreux/run-cli/internal/run/model/service/revision"
 )
 
 // List returns a list of revisions for the given service.
-func List(project, region, service string) ([]model.Revision, error) {
-	ctx := context.Background()
-	pbRevisions, err := apiClient.ListRevisions(ctx, project, region, service)
+func List(ctx context.Context, project, region, service string) ([]model.Revision, error) {
+	pbRevisions, err := client.Call(ctx, func(ctx context.Context) ([]*runpb.Revision, error) {
+		return apiClient.ListRevisions(ctx, project, region, service)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +28,48 @@ func List(project, region, service string) ([]model.Revision, error) {
 	return revisions, nil
 }
 
+// ListPage returns a page of revisions for the given service, the latest first, and the token of the
+// next page, empty for the last one. The first page is returned for an empty token.
+func ListPage(ctx context.Context, project, region, service, pageToken string) ([]model.Revision, string, error) {
+	page, err := client.Call(ctx, func(ctx context.Context) (client.Page[*runpb.Revision], error) {
+		revisions, next, err := apiClient.ListRevisionsPage(ctx, project, region, service, client.PageSize, pageToken)
+		return client.Page[*runpb.Revision]{Items: revisions, Next: next}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var revisions []model.Revision
+	for _, resp := range page.Items {
+		revisions = append(revisions, mapRevision(resp, service))
+	}
+
+	return revisions, page.Next, nil
+}
+
+// Get returns a single revision of the given service.
+// The revision name can be either a short name or a fully qualified resource name.
+func Get(ctx context.Context, project, region, service, revisionName string) (*model.Revision, error) {
+	resp, err := GetRaw(ctx, project, region, service, revisionName)
+	if err != nil {
+		return nil, err
+	}
+
+	r := mapRevision(resp, service)
+	return &r, nil
+}
+
+// GetRaw returns a single revision as returned by the API.
+func GetRaw(ctx context.Context, project, region, service, revisionName string) (*runpb.Revision, error) {
+	name := revisionName
+	if !strings.HasPrefix(revisionName, "projects/") {
+		name = fmt.Sprintf("projects/%s/locations/%s/services/%s/revisions/%s", project, region, service, revisionName)
+	}
+	return client.Call(ctx, func(ctx context.Context) (*runpb.Revision, error) {
+		return apiClient.GetRevision(ctx, name)
+	})
+}
+
 func mapRevision(resp *runpb.Revision, service string) model.Revision {
 	nameParts := strings.Split(resp.Name, "/")
 	name := nameParts[len(nameParts)-1]
@@ -36,35 +80,6 @@ func mapRevision(resp *runpb.Revision, service string) model.Revision {
 		startupCpuBoost = resp.Containers[0].Resources.StartupCpuBoost
 	}
 
-	var containers []*model_container.Container
-	for _, c := range resp.Containers {
-		var ports []*model_container.Port
-		for _, p := range c.Ports {
-			ports = append(ports, &model_container.Port{
-				Name:          p.Name,
-				ContainerPort: p.ContainerPort,
-			})
-		}
-
-		var resources *model_resources.Resources
-		if c.Resources != nil {
-			resources = &model_resources.Resources{
-				Limits:          c.Resources.Limits,
-				CPUIdle:         c.Resources.CpuIdle,
-				StartupCPUBoost: c.Resources.StartupCpuBoost,
-			}
-		}
-
-		containers = append(containers, &model_container.Container{
-			Name:      c.Name,
-			Image:     c.Image,
-			Command:   c.Command,
-			Args:      c.Args,
-			Ports:     ports,
-			Resources: resources,
-		})
-	}
-
 	var accelerator string
 	if resp.NodeSelector != nil {
 		accelerator = resp.NodeSelector.Accelerator
@@ -75,8 +90,15 @@ func mapRevision(resp *runpb.Revision, service string) model.Revision {
 		CreateTime:                    resp.CreateTime.AsTime(),
 		UpdateTime:                    resp.UpdateTime.AsTime(),
 		Service:                       service,
-		Containers:                    containers,
+		Containers:                    common.Containers(resp.Containers),
+		Volumes:                       common.Volumes(resp.Volumes),
 		ExecutionEnvironment:          resp.ExecutionEnvironment.String(),
+		EncryptionKey:                 resp.EncryptionKey,
+		Reconciling:                   resp.Reconciling,
+		Conditions:                    common.Conditions(resp.Conditions),
+		ObservedGeneration:            resp.ObservedGeneration,
+		LogURI:                        resp.LogUri,
+		Etag:                          resp.Etag,
 		MaxInstanceRequestConcurrency: resp.MaxInstanceRequestConcurrency,
 		Timeout:                       resp.Timeout.AsDuration(),
 		CpuIdle:                       cpuIdle,
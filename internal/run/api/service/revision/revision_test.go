@@ -19,7 +19,9 @@ import (
 
 // MockClient is a mock implementation of Client.
 type MockClient struct {
-	ListRevisionsFunc func(ctx context.Context, project, region, service string) ([]*runpb.Revision, error)
+	ListRevisionsFunc     func(ctx context.Context, project, region, service string) ([]*runpb.Revision, error)
+	ListRevisionsPageFunc func(ctx context.Context, project, region, service string, pageSize int, pageToken string) ([]*runpb.Revision, string, error)
+	GetRevisionFunc       func(ctx context.Context, name string) (*runpb.Revision, error)
 }
 
 func (m *MockClient) ListRevisions(ctx context.Context, project, region, service string) ([]*runpb.Revision, error) {
@@ -29,6 +31,20 @@ func (m *MockClient) ListRevisions(ctx context.Context, project, region, service
 	return nil, nil
 }
 
+func (m *MockClient) ListRevisionsPage(ctx context.Context, project, region, service string, pageSize int, pageToken string) ([]*runpb.Revision, string, error) {
+	if m.ListRevisionsPageFunc != nil {
+		return m.ListRevisionsPageFunc(ctx, project, region, service, pageSize, pageToken)
+	}
+	return nil, "", nil
+}
+
+func (m *MockClient) GetRevision(ctx context.Context, name string) (*runpb.Revision, error) {
+	if m.GetRevisionFunc != nil {
+		return m.GetRevisionFunc(ctx, name)
+	}
+	return nil, nil
+}
+
 func TestList(t *testing.T) {
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
@@ -40,7 +56,7 @@ func TestList(t *testing.T) {
 		return []*runpb.Revision{{Name: "rev1"}}, nil
 	}
 
-	revisions, err := List("p", "r", "s")
+	revisions, err := List(context.Background(), "p", "r", "s")
 	assert.NoError(t, err)
 	assert.Len(t, revisions, 1)
 	assert.Equal(t, "rev1", revisions[0].Name)
@@ -57,11 +73,64 @@ func TestList_Error(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	revisions, err := List("p", "r", "s")
+	revisions, err := List(context.Background(), "p", "r", "s")
 	assert.Error(t, err)
 	assert.Nil(t, revisions)
 }
 
+func TestListPage(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotSize int
+	apiClient = &MockClient{
+		ListRevisionsPageFunc: func(ctx context.Context, project, region, service string, pageSize int, pageToken string) ([]*runpb.Revision, string, error) {
+			gotSize = pageSize
+			return client.Paginate([]*runpb.Revision{{Name: "projects/p/locations/r/services/s/revisions/s-00002-abc"}, {Name: "projects/p/locations/r/services/s/revisions/s-00001-xyz"}}, 1, pageToken)
+		},
+	}
+
+	revisions, next, err := ListPage(context.Background(), "p", "r", "s", "")
+	assert.NoError(t, err)
+	assert.Equal(t, client.PageSize, gotSize)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, "s-00002-abc", revisions[0].Name)
+	assert.NotEmpty(t, next)
+
+	revisions, next, err = ListPage(context.Background(), "p", "r", "s", next)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, "s-00001-xyz", revisions[0].Name)
+	assert.Empty(t, next)
+}
+
+func TestGet(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		GetRevisionFunc: func(ctx context.Context, name string) (*runpb.Revision, error) {
+			gotName = name
+			return &runpb.Revision{Name: name}, nil
+		},
+	}
+
+	rev, err := Get(context.Background(), "p", "r", "s", "s-00001-abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/services/s/revisions/s-00001-abc", gotName)
+	assert.Equal(t, "s-00001-abc", rev.Name)
+	assert.Equal(t, "s", rev.Service)
+
+	apiClient = &MockClient{
+		GetRevisionFunc: func(ctx context.Context, name string) (*runpb.Revision, error) {
+			return nil, assert.AnError
+		},
+	}
+	_, err = Get(context.Background(), "p", "r", "s", "s-00001-abc")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
 func TestMapRevision(t *testing.T) {
 	now := time.Now()
 	resp := &runpb.Revision{
@@ -115,6 +184,63 @@ func TestMapRevision(t *testing.T) {
 	assert.True(t, result.StartupCpuBoost)
 }
 
+func TestMapRevision_FullConfig(t *testing.T) {
+	resp := &runpb.Revision{
+		Name: "projects/p/locations/l/services/s/revisions/my-rev",
+		Containers: []*runpb.Container{
+			{
+				Image: "img:latest",
+				Env: []*runpb.EnvVar{
+					{Name: "TOKEN", Values: &runpb.EnvVar_ValueSource{ValueSource: &runpb.EnvVarSource{
+						SecretKeyRef: &runpb.SecretKeySelector{Secret: "token", Version: "1"},
+					}}},
+				},
+				VolumeMounts: []*runpb.VolumeMount{{Name: "sql", MountPath: "/cloudsql"}},
+				StartupProbe: &runpb.Probe{
+					ProbeType: &runpb.Probe_TcpSocket{TcpSocket: &runpb.TCPSocketAction{Port: 8080}},
+				},
+				WorkingDir: "/srv",
+			},
+		},
+		Volumes: []*runpb.Volume{
+			{Name: "sql", VolumeType: &runpb.Volume_CloudSqlInstance{CloudSqlInstance: &runpb.CloudSqlInstance{
+				Instances: []string{"p:l:db"},
+			}}},
+		},
+		EncryptionKey: "projects/p/locations/l/keyRings/k/cryptoKeys/c",
+		Reconciling:   true,
+		Conditions: []*runpb.Condition{
+			{Type: "Ready", State: runpb.Condition_CONDITION_PENDING, Reasons: &runpb.Condition_RevisionReason_{RevisionReason: runpb.Condition_MIN_INSTANCES_WARMING}},
+		},
+		ObservedGeneration: 3,
+		LogUri:             "https://console.cloud.google.com/logs",
+		Etag:               "etag-1",
+	}
+
+	result := mapRevision(resp, "my-service")
+
+	// Container
+	container := result.Containers[0]
+	assert.Equal(t, "token", container.Env[0].Source.SecretKeyRef.Secret)
+	assert.Equal(t, "1", container.Env[0].Source.SecretKeyRef.Key)
+	assert.Equal(t, "/cloudsql", container.VolumeMounts[0].MountPath)
+	assert.Equal(t, int32(8080), container.StartupProbe.TCPSocket.Port)
+	assert.Equal(t, "/srv", container.WorkingDirectory)
+
+	// Volumes
+	assert.Equal(t, []string{"p:l:db"}, result.Volumes[0].CloudSQLInstance.Instances)
+
+	// Status
+	assert.Equal(t, resp.EncryptionKey, result.EncryptionKey)
+	assert.True(t, result.Reconciling)
+	assert.Equal(t, "Ready", result.Conditions[0].Type)
+	assert.Equal(t, "CONDITION_PENDING", result.Conditions[0].State)
+	assert.Equal(t, "MIN_INSTANCES_WARMING", result.Conditions[0].Reason)
+	assert.Equal(t, int64(3), result.ObservedGeneration)
+	assert.Equal(t, resp.LogUri, result.LogURI)
+	assert.Equal(t, "etag-1", result.Etag)
+}
+
 func TestMapRevision_NilFields(t *testing.T) {
 	resp := &runpb.Revision{
 		Name: "projects/p/locations/l/services/s/revisions/my-rev",
@@ -131,8 +257,10 @@ func TestMapRevision_NilFields(t *testing.T) {
 // --- GCPClient Tests ---
 
 type MockRevisionsClientWrapper struct {
-	ListRevisionsFunc func(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) RevisionIteratorWrapper
-	CloseFunc         func() error
+	ListRevisionsFunc     func(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) RevisionIteratorWrapper
+	ListRevisionsPageFunc func(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) ([]*runpb.Revision, string, error)
+	GetRevisionFunc       func(ctx context.Context, req *runpb.GetRevisionRequest, opts ...gax.CallOption) (*runpb.Revision, error)
+	CloseFunc             func() error
 }
 
 func (m *MockRevisionsClientWrapper) ListRevisions(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) RevisionIteratorWrapper {
@@ -142,6 +270,20 @@ func (m *MockRevisionsClientWrapper) ListRevisions(ctx context.Context, req *run
 	return &MockRevisionIteratorWrapper{}
 }
 
+func (m *MockRevisionsClientWrapper) ListRevisionsPage(ctx context.Context, req *runpb.ListRevisionsRequest, opts ...gax.CallOption) ([]*runpb.Revision, string, error) {
+	if m.ListRevisionsPageFunc != nil {
+		return m.ListRevisionsPageFunc(ctx, req, opts...)
+	}
+	return nil, "", nil
+}
+
+func (m *MockRevisionsClientWrapper) GetRevision(ctx context.Context, req *runpb.GetRevisionRequest, opts ...gax.CallOption) (*runpb.Revision, error) {
+	if m.GetRevisionFunc != nil {
+		return m.GetRevisionFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
 func (m *MockRevisionsClientWrapper) Close() error {
 	if m.CloseFunc != nil {
 		return m.CloseFunc()
@@ -239,10 +381,52 @@ func TestGCPClient_ListRevisions(t *testing.T) {
 	})
 }
 
+func TestGCPClient_GetRevision(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createRevisionsClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createRevisionsClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		createRevisionsClient = func(ctx context.Context, opts ...option.ClientOption) (RevisionsClientWrapper, error) {
+			return &MockRevisionsClientWrapper{
+				GetRevisionFunc: func(ctx context.Context, req *runpb.GetRevisionRequest, opts ...gax.CallOption) (*runpb.Revision, error) {
+					return &runpb.Revision{Name: req.Name}, nil
+				},
+			}, nil
+		}
+
+		rev, err := (&GCPClient{}).GetRevision(context.Background(), "rev1")
+		assert.NoError(t, err)
+		assert.Equal(t, "rev1", rev.Name)
+	})
+
+	t.Run("Permission Error", func(t *testing.T) {
+		createRevisionsClient = func(ctx context.Context, opts ...option.ClientOption) (RevisionsClientWrapper, error) {
+			return &MockRevisionsClientWrapper{
+				GetRevisionFunc: func(ctx context.Context, req *runpb.GetRevisionRequest, opts ...gax.CallOption) (*runpb.Revision, error) {
+					return nil, errors.New("PermissionDenied")
+				},
+			}, nil
+		}
+
+		_, err := (&GCPClient{}).GetRevision(context.Background(), "rev1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication failed")
+	})
+}
+
 func TestWrappers_Delegation(t *testing.T) {
 	t.Run("GCPRevisionsClientWrapper", func(t *testing.T) {
 		w := &GCPRevisionsClientWrapper{client: nil}
 		assert.Panics(t, func() { _ = w.ListRevisions(context.Background(), nil) })
+		assert.Panics(t, func() { _, _ = w.GetRevision(context.Background(), nil) })
 		assert.Panics(t, func() { _ = w.Close() })
 	})
 
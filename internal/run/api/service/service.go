@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"sync"
 
 	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
 	model "github.com/JulienBreux/run-cli/internal/run/model/service"
 	model_networking "github.com/JulienBreux/run-cli/internal/run/model/service/networking"
@@ -17,15 +18,21 @@ import (
 
 var apiClient Client = &GCPClient{}
 
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
 // List returns a list of services for the given project and region.
 // If region is api_region.ALL, it lists services from all supported Cloud Run regions.
-func List(project, region string) ([]model.Service, error) {
+func List(ctx context.Context, project, region string) ([]model.Service, error) {
 	if region == api_region.ALL {
-		return listAllRegions(project)
+		return listAllRegions(ctx, project)
 	}
 
-	ctx := context.Background()
-	pbServices, err := apiClient.ListServices(ctx, project, region)
+	pbServices, err := client.Call(ctx, func(ctx context.Context) ([]*runpb.Service, error) {
+		return apiClient.ListServices(ctx, project, region)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +45,31 @@ func List(project, region string) ([]model.Service, error) {
 	return services, nil
 }
 
+// ListPage returns a page of services for the given project and region, and the token of the next page,
+// empty for the last one. The first page is returned for an empty token.
+// If region is api_region.ALL, all the services are returned at once, see List.
+func ListPage(ctx context.Context, project, region, pageToken string) ([]model.Service, string, error) {
+	if region == api_region.ALL {
+		services, err := listAllRegions(ctx, project)
+		return services, "", err
+	}
+
+	page, err := client.Call(ctx, func(ctx context.Context) (client.Page[*runpb.Service], error) {
+		services, next, err := apiClient.ListServicesPage(ctx, project, region, client.PageSize, pageToken)
+		return client.Page[*runpb.Service]{Items: services, Next: next}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var services []model.Service
+	for _, resp := range page.Items {
+		services = append(services, mapService(resp, project, region))
+	}
+
+	return services, page.Next, nil
+}
+
 func mapService(resp *runpb.Service, project, region string) model.Service {
 	// Determine Service Name (Last part of resource name)
 	nameParts := strings.Split(resp.Name, "/")
@@ -65,23 +97,7 @@ func mapService(resp *runpb.Service, project, region string) model.Service {
 		})
 	}
 
-	s := model_scaling.Scaling{
-		ScalingMode: "AUTOMATIC",
-	}
-	if resp.Scaling != nil {
-		switch resp.Scaling.ScalingMode {
-		case runpb.ServiceScaling_AUTOMATIC:
-			s.ScalingMode = "AUTOMATIC"
-			s.MinInstances = resp.Scaling.MinInstanceCount
-		case runpb.ServiceScaling_MANUAL:
-			s.ScalingMode = "MANUAL"
-			if resp.Scaling.ManualInstanceCount != nil {
-				s.ManualInstanceCount = *resp.Scaling.ManualInstanceCount
-			}
-		}
-		s.MinInstances = resp.Scaling.MinInstanceCount
-		s.MaxInstances = resp.Scaling.MaxInstanceCount
-	}
+	s := mapScaling(resp.Scaling)
 
 	latestReadyRevision := resp.LatestReadyRevision
 	if strings.Contains(latestReadyRevision, "/") {
@@ -138,31 +154,172 @@ func mapService(resp *runpb.Service, project, region string) model.Service {
 	}
 }
 
-// UpdateScaling updates the scaling settings for a service.
-func UpdateScaling(ctx context.Context, project, region, serviceName string, min, max, manual int32) (*model.Service, error) {
+// Get returns a single service.
+// The service name can be either a short name or a fully qualified resource name.
+func Get(ctx context.Context, project, region, serviceName string) (*model.Service, error) {
+	resp, err := GetRaw(ctx, project, region, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	s := mapService(resp, project, region)
+	return &s, nil
+}
+
+// GetRaw returns a single service as returned by the API.
+func GetRaw(ctx context.Context, project, region, serviceName string) (*runpb.Service, error) {
+	name := serviceName
+	if !strings.HasPrefix(serviceName, "projects/") {
+		name = fmt.Sprintf("projects/%s/locations/%s/services/%s", project, region, serviceName)
+	}
+
+	resp, err := client.Call(ctx, func(ctx context.Context) (*runpb.Service, error) {
+		return apiClient.GetService(ctx, name)
+	})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+	return resp, nil
+}
+
+// Create creates a service with the given name and returns it once ready.
+func Create(ctx context.Context, project, region, serviceName string, service *runpb.Service) (*runpb.Service, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	parent := fmt.Sprintf("projects/%s/locations/%s", project, region)
+	service.Name = ""
+	return apiClient.CreateService(ctx, parent, serviceName, service)
+}
+
+// Update replaces a service and returns it once ready.
+// The etag of the service, if any, is used to detect concurrent modifications.
+func Update(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	resp, err := apiClient.UpdateService(ctx, service)
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+	return resp, nil
+}
+
+// UpdateScaling updates the scaling settings for a service and returns the operation of its rollout.
+// base is the scaling the update was made from, e.g. the one shown to the user: the fields changed
+// concurrently since are kept, unless the update changes them too, which fails with a
+// *client.ConflictError. A nil base overwrites the scaling.
+func UpdateScaling(ctx context.Context, project, region, serviceName string, base *model_scaling.Scaling, min, max, manual int32) (*operation.Operation, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
 	fullServiceName := fmt.Sprintf("projects/%s/locations/%s/services/%s", project, region, serviceName)
-	
-	service, err := apiClient.GetService(ctx, fullServiceName)
+
+	update := model_scaling.Scaling{ScalingMode: "AUTOMATIC", MinInstances: min, MaxInstances: max}
+	if manual > 0 {
+		update = model_scaling.Scaling{ScalingMode: "MANUAL", ManualInstanceCount: manual}
+	}
+
+	read := func(ctx context.Context) (*runpb.Service, error) {
+		service, err := client.Call(ctx, func(ctx context.Context) (*runpb.Service, error) {
+			return apiClient.GetService(ctx, fullServiceName)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service: %w", err)
+		}
+		return service, nil
+	}
+	modify := func(service *runpb.Service) error {
+		scaling := update
+		if base != nil {
+			var conflicts []client.FieldConflict
+			scaling = mergeScaling(&conflicts, *base, mapScaling(service.Scaling), update)
+			if len(conflicts) > 0 {
+				return &client.ConflictError{Resource: "service " + serviceName, Fields: conflicts}
+			}
+		}
+		setScaling(service, scaling)
+		return nil
+	}
+	write := func(ctx context.Context, service *runpb.Service) (operation.Poller[*runpb.Service], error) {
+		op, err := apiClient.StartUpdateService(ctx, service)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update service: %w", err)
+		}
+		return op, nil
+	}
+
+	op, err := client.ReadModifyWrite(ctx, read, modify, write)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get service: %w", err)
+		return nil, err
 	}
+	return operation.New("Scale", fmt.Sprintf("service %s (%s)", serviceName, region), op, describeService), nil
+}
+
+// describeService describes the progress of the rollout of a service.
+func describeService(service *runpb.Service) string {
+	return operation.Conditions(service.GetTerminalCondition(), service.GetConditions())
+}
 
-	// Modify the scaling settings
+// mapScaling maps the scaling of a service, automatic when unset.
+func mapScaling(scaling *runpb.ServiceScaling) model_scaling.Scaling {
+	s := model_scaling.Scaling{
+		ScalingMode: "AUTOMATIC",
+	}
+	if scaling != nil {
+		switch scaling.ScalingMode {
+		case runpb.ServiceScaling_AUTOMATIC:
+			s.ScalingMode = "AUTOMATIC"
+			s.MinInstances = scaling.MinInstanceCount
+		case runpb.ServiceScaling_MANUAL:
+			s.ScalingMode = "MANUAL"
+			if scaling.ManualInstanceCount != nil {
+				s.ManualInstanceCount = *scaling.ManualInstanceCount
+			}
+		}
+		s.MinInstances = scaling.MinInstanceCount
+		s.MaxInstances = scaling.MaxInstanceCount
+	}
+	return s
+}
+
+// mergeScaling merges the scaling of an update, made from base, with the current one, see
+// client.MergeField. The counts unused by the scaling mode are ignored.
+func mergeScaling(conflicts *[]client.FieldConflict, base, current, update model_scaling.Scaling) model_scaling.Scaling {
+	base, current, update = usedScaling(base), usedScaling(current), usedScaling(update)
+	return model_scaling.Scaling{
+		ScalingMode:         client.MergeField(conflicts, "scaling mode", base.ScalingMode, current.ScalingMode, update.ScalingMode),
+		ManualInstanceCount: client.MergeField(conflicts, "instances", base.ManualInstanceCount, current.ManualInstanceCount, update.ManualInstanceCount),
+		MinInstances:        client.MergeField(conflicts, "min instances", base.MinInstances, current.MinInstances, update.MinInstances),
+		MaxInstances:        client.MergeField(conflicts, "max instances", base.MaxInstances, current.MaxInstances, update.MaxInstances),
+	}
+}
+
+// usedScaling returns the scaling without the counts unused by its mode.
+func usedScaling(s model_scaling.Scaling) model_scaling.Scaling {
+	if s.ScalingMode == "MANUAL" {
+		return model_scaling.Scaling{ScalingMode: s.ScalingMode, ManualInstanceCount: s.ManualInstanceCount}
+	}
+	return model_scaling.Scaling{ScalingMode: s.ScalingMode, MinInstances: s.MinInstances, MaxInstances: s.MaxInstances}
+}
+
+// setScaling sets the scaling of a service and clears its output-only fields.
+func setScaling(service *runpb.Service, scaling model_scaling.Scaling) {
 	if service.Scaling == nil {
 		service.Scaling = &runpb.ServiceScaling{}
 	}
 
-	if manual > 0 {
+	if scaling.ScalingMode == "MANUAL" {
 		service.Scaling.ScalingMode = runpb.ServiceScaling_MANUAL
 		service.Scaling.MinInstanceCount = 0
 		service.Scaling.MaxInstanceCount = 0
 
-		manualInstanceCount := manual
+		manualInstanceCount := scaling.ManualInstanceCount
 		service.Scaling.ManualInstanceCount = &manualInstanceCount
 	} else {
 		service.Scaling.ScalingMode = runpb.ServiceScaling_AUTOMATIC
-		service.Scaling.MinInstanceCount = min
-		service.Scaling.MaxInstanceCount = max
+		service.Scaling.MinInstanceCount = scaling.MinInstances
+		service.Scaling.MaxInstanceCount = scaling.MaxInstances
 		service.Scaling.ManualInstanceCount = nil
 	}
 
@@ -180,38 +337,11 @@ func UpdateScaling(ctx context.Context, project, region, serviceName string, min
 	service.TerminalCondition = nil
 	service.Conditions = nil
 	// Keep Etag for concurrency control
-
-	// Update the service
-	resp, err := apiClient.UpdateService(ctx, service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update service: %w", err)
-	}
-
-	s := mapService(resp, project, region)
-	return &s, nil
 }
 
-func listAllRegions(project string) ([]model.Service, error) {
-	var (
-		mu       sync.Mutex
-		services []model.Service
-		wg       sync.WaitGroup
-	)
-
-	for _, region := range api_region.List() {
-		wg.Add(1)
-		go func(r string) {
-			defer wg.Done()
-			// Call List recursively for each region
-			// We ignore errors here to allow partial success (e.g. if one region is down or disabled)
-			if s, err := List(project, r); err == nil {
-				mu.Lock()
-				services = append(services, s...)
-				mu.Unlock()
-			}
-		}(region)
-	}
-
-	wg.Wait()
-	return services, nil
+// listAllRegions lists the services of all regions, see api_region.FanOut.
+func listAllRegions(ctx context.Context, project string) ([]model.Service, error) {
+	return api_region.FanOut(ctx, project, func(region string) ([]model.Service, error) {
+		return List(ctx, project, region)
+	})
 }
@@ -8,7 +8,9 @@ import (
 
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	model_scaling "github.com/JulienBreux/run-cli/internal/run/model/service/scaling"
 	"github.com/googleapis/gax-go/v2"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2/google"
@@ -113,9 +115,13 @@ func TestMapService_Complex(t *testing.T) {
 
 // MockClient is a mock implementation of the Client interface.
 type MockClient struct {
-	ListServicesFunc  func(ctx context.Context, project, region string) ([]*runpb.Service, error)
-	GetServiceFunc    func(ctx context.Context, name string) (*runpb.Service, error)
-	UpdateServiceFunc func(ctx context.Context, service *runpb.Service) (*runpb.Service, error)
+	ListServicesFunc     func(ctx context.Context, project, region string) ([]*runpb.Service, error)
+	ListServicesPageFunc func(ctx context.Context, project, region string, pageSize int, pageToken string) ([]*runpb.Service, string, error)
+	GetServiceFunc       func(ctx context.Context, name string) (*runpb.Service, error)
+	CreateServiceFunc    func(ctx context.Context, parent, serviceID string, service *runpb.Service) (*runpb.Service, error)
+	UpdateServiceFunc    func(ctx context.Context, service *runpb.Service) (*runpb.Service, error)
+	// StartUpdateServiceFunc defaults to UpdateServiceFunc, whose operation is done at once.
+	StartUpdateServiceFunc func(ctx context.Context, service *runpb.Service) (operation.Poller[*runpb.Service], error)
 }
 
 func (m *MockClient) ListServices(ctx context.Context, project, region string) ([]*runpb.Service, error) {
@@ -125,6 +131,13 @@ func (m *MockClient) ListServices(ctx context.Context, project, region string) (
 	return nil, nil
 }
 
+func (m *MockClient) ListServicesPage(ctx context.Context, project, region string, pageSize int, pageToken string) ([]*runpb.Service, string, error) {
+	if m.ListServicesPageFunc != nil {
+		return m.ListServicesPageFunc(ctx, project, region, pageSize, pageToken)
+	}
+	return nil, "", nil
+}
+
 func (m *MockClient) GetService(ctx context.Context, name string) (*runpb.Service, error) {
 	if m.GetServiceFunc != nil {
 		return m.GetServiceFunc(ctx, name)
@@ -132,6 +145,13 @@ func (m *MockClient) GetService(ctx context.Context, name string) (*runpb.Servic
 	return nil, nil
 }
 
+func (m *MockClient) CreateService(ctx context.Context, parent, serviceID string, service *runpb.Service) (*runpb.Service, error) {
+	if m.CreateServiceFunc != nil {
+		return m.CreateServiceFunc(ctx, parent, serviceID, service)
+	}
+	return nil, nil
+}
+
 func (m *MockClient) UpdateService(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
 	if m.UpdateServiceFunc != nil {
 		return m.UpdateServiceFunc(ctx, service)
@@ -139,6 +159,17 @@ func (m *MockClient) UpdateService(ctx context.Context, service *runpb.Service)
 	return nil, nil
 }
 
+func (m *MockClient) StartUpdateService(ctx context.Context, service *runpb.Service) (operation.Poller[*runpb.Service], error) {
+	if m.StartUpdateServiceFunc != nil {
+		return m.StartUpdateServiceFunc(ctx, service)
+	}
+	svc, err := m.UpdateService(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	return operation.Completed(service.Name, svc), nil
+}
+
 func TestList(t *testing.T) {
 	// Save original client and restore after test
 	originalClient := apiClient
@@ -154,7 +185,7 @@ func TestList(t *testing.T) {
 		}, nil
 	}
 
-	services, err := List("p", "r")
+	services, err := List(context.Background(), "p", "r")
 
 	assert.NoError(t, err)
 	assert.Len(t, services, 2)
@@ -188,12 +219,27 @@ func TestUpdateScaling(t *testing.T) {
 		return service, nil
 	}
 
-	result, err := UpdateScaling(context.Background(), "p", "r", "s1", 2, 5, 0)
+	op, err := UpdateScaling(context.Background(), "p", "r", "s1", nil, 2, 5, 0)
 
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, "s1", result.Name)
-	assert.Equal(t, int32(2), result.Scaling.MinInstances)
+	if !assert.NotNil(t, op) {
+		return
+	}
+	assert.Equal(t, "Scale", op.Kind)
+	assert.Equal(t, "service s1 (r)", op.Target)
+	assert.NoError(t, op.Wait(context.Background()))
+}
+
+func TestDescribeService(t *testing.T) {
+	assert.Equal(t, "1/2 conditions ready", describeService(&runpb.Service{
+		Conditions: []*runpb.Condition{
+			{Type: "ConfigurationsReady", State: runpb.Condition_CONDITION_SUCCEEDED},
+			{Type: "RoutesReady", State: runpb.Condition_CONDITION_PENDING},
+		},
+	}))
+	assert.Equal(t, "ready", describeService(&runpb.Service{
+		TerminalCondition: &runpb.Condition{Type: "Ready", State: runpb.Condition_CONDITION_SUCCEEDED},
+	}))
 }
 
 func TestList_Error(t *testing.T) {
@@ -207,11 +253,143 @@ func TestList_Error(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	services, err := List("p", "r")
+	services, err := List(context.Background(), "p", "r")
 	assert.Error(t, err)
 	assert.Nil(t, services)
 }
 
+func TestList_Cancelled(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	mock := &MockClient{}
+	apiClient = mock
+
+	called := false
+	mock.ListServicesFunc = func(ctx context.Context, project, region string) ([]*runpb.Service, error) {
+		called = true
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	services, err := List(ctx, "p", "r")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, services)
+	assert.False(t, called)
+}
+
+func TestListPage(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	mock := &MockClient{}
+	apiClient = mock
+
+	var gotSize int
+	mock.ListServicesPageFunc = func(ctx context.Context, project, region string, pageSize int, pageToken string) ([]*runpb.Service, string, error) {
+		gotSize = pageSize
+		return client.Paginate([]*runpb.Service{
+			{Name: "projects/p/locations/r/services/s1"},
+			{Name: "projects/p/locations/r/services/s2"},
+		}, 1, pageToken)
+	}
+
+	services, next, err := ListPage(context.Background(), "p", "r", "")
+	assert.NoError(t, err)
+	assert.Equal(t, client.PageSize, gotSize)
+	assert.Len(t, services, 1)
+	assert.Equal(t, "s1", services[0].Name)
+	assert.NotEmpty(t, next)
+
+	services, next, err = ListPage(context.Background(), "p", "r", next)
+	assert.NoError(t, err)
+	assert.Len(t, services, 1)
+	assert.Equal(t, "s2", services[0].Name)
+	assert.Empty(t, next)
+}
+
+func TestListPage_AllRegions(t *testing.T) {
+	api_region.SetAllowed([]string{"europe-west1", "us-central1"})
+	t.Cleanup(func() { api_region.SetAllowed(nil) })
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	mock := &MockClient{}
+	apiClient = mock
+
+	mock.ListServicesFunc = func(ctx context.Context, project, region string) ([]*runpb.Service, error) {
+		return []*runpb.Service{{Name: "projects/p/locations/" + region + "/services/s1"}}, nil
+	}
+
+	// All the regions are listed at once.
+	services, next, err := ListPage(context.Background(), "p", api_region.ALL, "")
+	assert.NoError(t, err)
+	assert.Len(t, services, 2)
+	assert.Empty(t, next)
+}
+
+func TestUpdateScaling_Conflict(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	mock := &MockClient{}
+	apiClient = mock
+
+	// Someone else raised the max instances since the scaling was shown.
+	mock.GetServiceFunc = func(ctx context.Context, name string) (*runpb.Service, error) {
+		return &runpb.Service{
+			Name: name,
+			Etag: "2",
+			Scaling: &runpb.ServiceScaling{
+				ScalingMode:      runpb.ServiceScaling_AUTOMATIC,
+				MinInstanceCount: 1,
+				MaxInstanceCount: 10,
+			},
+		}, nil
+	}
+	var updated *runpb.Service
+	mock.UpdateServiceFunc = func(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
+		updated = service
+		return service, nil
+	}
+	base := &model_scaling.Scaling{ScalingMode: "AUTOMATIC", MinInstances: 1, MaxInstances: 5}
+
+	// Their change is kept when the update changes other fields.
+	_, err := UpdateScaling(context.Background(), "p", "r", "s1", base, 2, 5, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), updated.Scaling.MinInstanceCount)
+	assert.Equal(t, int32(10), updated.Scaling.MaxInstanceCount)
+	assert.Equal(t, "2", updated.Etag)
+
+	// The update fails when it changes the same fields.
+	updated = nil
+	_, err = UpdateScaling(context.Background(), "p", "r", "s1", base, 1, 8, 0)
+	var conflict *client.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, []client.FieldConflict{{Field: "max instances", Base: "5", Theirs: "10", Mine: "8"}}, conflict.Fields)
+	assert.Equal(t, "max instances: was 5, now 10, yours 8\n", conflict.Diff())
+	assert.Nil(t, updated)
+
+	// Switching to manual scaling conflicts with their change of the max instances.
+	_, err = UpdateScaling(context.Background(), "p", "r", "s1", base, 0, 0, 3)
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "max instances", conflict.Fields[0].Field)
+}
+
+func TestMergeScaling(t *testing.T) {
+	var conflicts []client.FieldConflict
+	base := model_scaling.Scaling{ScalingMode: "MANUAL", ManualInstanceCount: 2, MinInstances: 1}
+	current := model_scaling.Scaling{ScalingMode: "MANUAL", ManualInstanceCount: 2, MinInstances: 4}
+	update := model_scaling.Scaling{ScalingMode: "MANUAL", ManualInstanceCount: 3}
+
+	// The counts unused by the manual scaling are ignored.
+	merged := mergeScaling(&conflicts, base, current, update)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, model_scaling.Scaling{ScalingMode: "MANUAL", ManualInstanceCount: 3}, merged)
+}
+
 func TestUpdateScaling_Error(t *testing.T) {
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
@@ -223,7 +401,7 @@ func TestUpdateScaling_Error(t *testing.T) {
 	mock.GetServiceFunc = func(ctx context.Context, name string) (*runpb.Service, error) {
 		return nil, assert.AnError
 	}
-	_, err := UpdateScaling(context.Background(), "p", "r", "s", 1, 2, 0)
+	_, err := UpdateScaling(context.Background(), "p", "r", "s", nil, 1, 2, 0)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get service")
 
@@ -234,12 +412,47 @@ func TestUpdateScaling_Error(t *testing.T) {
 	mock.UpdateServiceFunc = func(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
 		return nil, assert.AnError
 	}
-	_, err = UpdateScaling(context.Background(), "p", "r", "s", 1, 2, 0)
+	_, err = UpdateScaling(context.Background(), "p", "r", "s", nil, 1, 2, 0)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to update service")
 }
 
+func TestGet(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		GetServiceFunc: func(ctx context.Context, name string) (*runpb.Service, error) {
+			gotName = name
+			return &runpb.Service{Name: name}, nil
+		},
+	}
+
+	res, err := Get(context.Background(), "p", "r", "s1")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/services/s1", gotName)
+	assert.Equal(t, "s1", res.Name)
+	assert.Equal(t, "r", res.Region)
+
+	_, err = GetRaw(context.Background(), "p", "r", "projects/p/locations/r/services/s1")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/services/s1", gotName)
+
+	apiClient = &MockClient{
+		GetServiceFunc: func(ctx context.Context, name string) (*runpb.Service, error) {
+			return nil, errors.New("PermissionDenied")
+		},
+	}
+	_, err = Get(context.Background(), "p", "r", "s1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "authentication failed")
+}
+
 func TestList_AllRegions(t *testing.T) {
+	api_region.SetAllowed([]string{"europe-west1", "us-central1"})
+	t.Cleanup(func() { api_region.SetAllowed(nil) })
+
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
 
@@ -255,7 +468,7 @@ func TestList_AllRegions(t *testing.T) {
 		return []*runpb.Service{}, nil
 	}
 
-	services, err := List("p", api_region.ALL)
+	services, err := List(context.Background(), "p", api_region.ALL)
 	assert.NoError(t, err)
 	
 	// We expect at least 1 service
@@ -272,10 +485,12 @@ func TestList_AllRegions(t *testing.T) {
 // --- Mocks for GCPClient testing ---
 
 type MockServicesClientWrapper struct {
-	ListServicesFunc  func(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ServiceIteratorWrapper
-	GetServiceFunc    func(ctx context.Context, req *runpb.GetServiceRequest, opts ...gax.CallOption) (*runpb.Service, error)
-	UpdateServiceFunc func(ctx context.Context, req *runpb.UpdateServiceRequest, opts ...gax.CallOption) (UpdateServiceOperationWrapper, error)
-	CloseFunc         func() error
+	ListServicesFunc     func(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ServiceIteratorWrapper
+	ListServicesPageFunc func(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ([]*runpb.Service, string, error)
+	GetServiceFunc       func(ctx context.Context, req *runpb.GetServiceRequest, opts ...gax.CallOption) (*runpb.Service, error)
+	CreateServiceFunc    func(ctx context.Context, req *runpb.CreateServiceRequest, opts ...gax.CallOption) (CreateServiceOperationWrapper, error)
+	UpdateServiceFunc    func(ctx context.Context, req *runpb.UpdateServiceRequest, opts ...gax.CallOption) (UpdateServiceOperationWrapper, error)
+	CloseFunc            func() error
 }
 
 func (m *MockServicesClientWrapper) ListServices(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ServiceIteratorWrapper {
@@ -285,6 +500,13 @@ func (m *MockServicesClientWrapper) ListServices(ctx context.Context, req *runpb
 	return &MockServiceIteratorWrapper{}
 }
 
+func (m *MockServicesClientWrapper) ListServicesPage(ctx context.Context, req *runpb.ListServicesRequest, opts ...gax.CallOption) ([]*runpb.Service, string, error) {
+	if m.ListServicesPageFunc != nil {
+		return m.ListServicesPageFunc(ctx, req, opts...)
+	}
+	return nil, "", nil
+}
+
 func (m *MockServicesClientWrapper) GetService(ctx context.Context, req *runpb.GetServiceRequest, opts ...gax.CallOption) (*runpb.Service, error) {
 	if m.GetServiceFunc != nil {
 		return m.GetServiceFunc(ctx, req, opts...)
@@ -292,6 +514,13 @@ func (m *MockServicesClientWrapper) GetService(ctx context.Context, req *runpb.G
 	return nil, nil
 }
 
+func (m *MockServicesClientWrapper) CreateService(ctx context.Context, req *runpb.CreateServiceRequest, opts ...gax.CallOption) (CreateServiceOperationWrapper, error) {
+	if m.CreateServiceFunc != nil {
+		return m.CreateServiceFunc(ctx, req, opts...)
+	}
+	return nil, nil
+}
+
 func (m *MockServicesClientWrapper) UpdateService(ctx context.Context, req *runpb.UpdateServiceRequest, opts ...gax.CallOption) (UpdateServiceOperationWrapper, error) {
 	if m.UpdateServiceFunc != nil {
 		return m.UpdateServiceFunc(ctx, req, opts...)
@@ -324,6 +553,7 @@ func (m *MockServiceIteratorWrapper) Next() (*runpb.Service, error) {
 	return item, nil
 }
 
+// MockUpdateServiceOperationWrapper is an operation done at once, with the result of WaitFunc.
 type MockUpdateServiceOperationWrapper struct {
 	WaitFunc func(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error)
 }
@@ -335,6 +565,29 @@ func (m *MockUpdateServiceOperationWrapper) Wait(ctx context.Context, opts ...ga
 	return nil, nil
 }
 
+func (m *MockUpdateServiceOperationWrapper) Name() string { return "operation-1" }
+
+func (m *MockUpdateServiceOperationWrapper) Done() bool { return true }
+
+func (m *MockUpdateServiceOperationWrapper) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error) {
+	return m.Wait(ctx, opts...)
+}
+
+func (m *MockUpdateServiceOperationWrapper) Metadata() (*runpb.Service, error) {
+	return nil, nil
+}
+
+type MockCreateServiceOperationWrapper struct {
+	WaitFunc func(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error)
+}
+
+func (m *MockCreateServiceOperationWrapper) Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error) {
+	if m.WaitFunc != nil {
+		return m.WaitFunc(ctx, opts...)
+	}
+	return nil, nil
+}
+
 func TestGCPClient_ListServices(t *testing.T) {
 	origFindCreds := client.FindDefaultCredentials
 	origCreateClient := createServicesClient
@@ -486,6 +739,101 @@ func TestGCPClient_UpdateService(t *testing.T) {
 	})
 }
 
+func TestGCPClient_CreateService(t *testing.T) {
+	origFindCreds := client.FindDefaultCredentials
+	origCreateClient := createServicesClient
+	defer func() {
+		client.FindDefaultCredentials = origFindCreds
+		createServicesClient = origCreateClient
+	}()
+
+	client.FindDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+		return &google.Credentials{}, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		var gotReq *runpb.CreateServiceRequest
+		createServicesClient = func(ctx context.Context, opts ...option.ClientOption) (ServicesClientWrapper, error) {
+			return &MockServicesClientWrapper{
+				CreateServiceFunc: func(ctx context.Context, req *runpb.CreateServiceRequest, opts ...gax.CallOption) (CreateServiceOperationWrapper, error) {
+					gotReq = req
+					return &MockCreateServiceOperationWrapper{
+						WaitFunc: func(ctx context.Context, opts ...gax.CallOption) (*runpb.Service, error) {
+							return &runpb.Service{Name: req.Parent + "/services/" + req.ServiceId}, nil
+						},
+					}, nil
+				},
+			}, nil
+		}
+
+		s, err := (&GCPClient{}).CreateService(context.Background(), "projects/p/locations/r", "s1", &runpb.Service{})
+		assert.NoError(t, err)
+		assert.Equal(t, "projects/p/locations/r/services/s1", s.Name)
+		assert.Equal(t, "s1", gotReq.ServiceId)
+	})
+
+	t.Run("Create Start Error", func(t *testing.T) {
+		createServicesClient = func(ctx context.Context, opts ...option.ClientOption) (ServicesClientWrapper, error) {
+			return &MockServicesClientWrapper{
+				CreateServiceFunc: func(ctx context.Context, req *runpb.CreateServiceRequest, opts ...gax.CallOption) (CreateServiceOperationWrapper, error) {
+					return nil, errors.New("PermissionDenied")
+				},
+			}, nil
+		}
+
+		_, err := (&GCPClient{}).CreateService(context.Background(), "projects/p/locations/r", "s1", &runpb.Service{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication failed")
+	})
+}
+
+func TestCreateAndUpdate(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotParent, gotID string
+	apiClient = &MockClient{
+		CreateServiceFunc: func(ctx context.Context, parent, serviceID string, service *runpb.Service) (*runpb.Service, error) {
+			gotParent, gotID = parent, serviceID
+			assert.Empty(t, service.Name)
+			return service, nil
+		},
+		UpdateServiceFunc: func(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
+			if service.Etag != "1" {
+				return nil, errors.New("etag mismatch")
+			}
+			return service, nil
+		},
+	}
+
+	_, err := Create(context.Background(), "p", "r", "s1", &runpb.Service{Name: "ignored"})
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r", gotParent)
+	assert.Equal(t, "s1", gotID)
+
+	_, err = Update(context.Background(), &runpb.Service{Name: "s1", Etag: "1"})
+	assert.NoError(t, err)
+
+	_, err = Update(context.Background(), &runpb.Service{Name: "s1", Etag: "0"})
+	assert.EqualError(t, err, "etag mismatch")
+}
+
+func TestReadOnly(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = &MockClient{}
+
+	client.SetReadOnly(true)
+	defer client.SetReadOnly(false)
+
+	_, err := Create(context.Background(), "p", "r", "s1", &runpb.Service{})
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+	_, err = Update(context.Background(), &runpb.Service{Name: "s1"})
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+	_, err = UpdateScaling(context.Background(), "p", "r", "s1", nil, 0, 1, 0)
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+}
+
 func TestWrappers_Delegation(t *testing.T) {
 	// Expect panics because nil clients are used
 	
@@ -493,6 +841,7 @@ func TestWrappers_Delegation(t *testing.T) {
 		w := &GCPServicesClientWrapper{client: nil}
 		assert.Panics(t, func() { _ = w.ListServices(context.Background(), nil) })
 		assert.Panics(t, func() { _, _ = w.GetService(context.Background(), nil) })
+		assert.Panics(t, func() { _, _ = w.CreateService(context.Background(), nil) })
 		assert.Panics(t, func() { _, _ = w.UpdateService(context.Background(), nil) })
 		assert.Panics(t, func() { _ = w.Close() })
 	})
@@ -502,6 +851,11 @@ func TestWrappers_Delegation(t *testing.T) {
 		assert.Panics(t, func() { _, _ = it.Next() })
 	})
 	
+	t.Run("GCPCreateServiceOperationWrapper", func(t *testing.T) {
+		op := &GCPCreateServiceOperationWrapper{op: nil}
+		assert.Panics(t, func() { _, _ = op.Wait(context.Background()) })
+	})
+
 	t.Run("GCPUpdateServiceOperationWrapper", func(t *testing.T) {
 		op := &GCPUpdateServiceOperationWrapper{op: nil}
 		assert.Panics(t, func() { _, _ = op.Wait(context.Background()) })
@@ -7,6 +7,7 @@ import (
 	run "cloud.google.com/go/run/apiv2"
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	"github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
@@ -25,6 +26,7 @@ type WorkerPoolIteratorWrapper interface {
 }
 
 type UpdateWorkerPoolOperationWrapper interface {
+	operation.Poller[*runpb.WorkerPool]
 	Wait(ctx context.Context, opts ...gax.CallOption) (*runpb.WorkerPool, error)
 }
 
@@ -37,6 +39,11 @@ var createWorkerPoolsClient = func(ctx context.Context, opts ...option.ClientOpt
 	return &GCPWorkerPoolsClientWrapper{client: c}, nil
 }
 
+// workerPoolsClient returns a worker pools client from the pool, release must be called once it is no longer used.
+func workerPoolsClient(ctx context.Context) (c WorkerPoolsClientWrapper, release func() error, err error) {
+	return client.Get(ctx, client.Key{API: client.APIRun, Client: "workerpools"}, run.DefaultAuthScopes(), createWorkerPoolsClient)
+}
+
 // Real implementations
 type GCPWorkerPoolsClientWrapper struct {
 	client *run.WorkerPoolsClient
@@ -78,11 +85,29 @@ func (w *GCPUpdateWorkerPoolOperationWrapper) Wait(ctx context.Context, opts ...
 	return w.op.Wait(ctx, opts...)
 }
 
+func (w *GCPUpdateWorkerPoolOperationWrapper) Name() string {
+	return w.op.Name()
+}
+
+func (w *GCPUpdateWorkerPoolOperationWrapper) Done() bool {
+	return w.op.Done()
+}
+
+func (w *GCPUpdateWorkerPoolOperationWrapper) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.WorkerPool, error) {
+	return w.op.Poll(ctx, opts...)
+}
+
+func (w *GCPUpdateWorkerPoolOperationWrapper) Metadata() (*runpb.WorkerPool, error) {
+	return w.op.Metadata()
+}
+
 // Client defines the interface for Cloud Run WorkerPool operations.
 type Client interface {
 	ListWorkerPools(ctx context.Context, project, region string) ([]*runpb.WorkerPool, error)
 	GetWorkerPool(ctx context.Context, name string) (*runpb.WorkerPool, error)
 	UpdateWorkerPool(ctx context.Context, workerPool *runpb.WorkerPool) (*runpb.WorkerPool, error)
+	// StartUpdateWorkerPool updates a worker pool and returns the operation of its rollout without waiting for it.
+	StartUpdateWorkerPool(ctx context.Context, workerPool *runpb.WorkerPool) (operation.Poller[*runpb.WorkerPool], error)
 }
 
 var _ Client = (*GCPClient)(nil)
@@ -92,17 +117,12 @@ type GCPClient struct{}
 
 // ListWorkerPools lists worker pools for a project and region.
 func (c *GCPClient) ListWorkerPools(ctx context.Context, project, region string) ([]*runpb.WorkerPool, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	cClient, err := createWorkerPoolsClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := workerPoolsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	req := &runpb.ListWorkerPoolsRequest{
@@ -127,17 +147,12 @@ func (c *GCPClient) ListWorkerPools(ctx context.Context, project, region string)
 
 // GetWorkerPool gets a worker pool.
 func (c *GCPClient) GetWorkerPool(ctx context.Context, name string) (*runpb.WorkerPool, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	cClient, err := createWorkerPoolsClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := workerPoolsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	return cClient.GetWorkerPool(ctx, &runpb.GetWorkerPoolRequest{Name: name})
@@ -145,17 +160,12 @@ func (c *GCPClient) GetWorkerPool(ctx context.Context, name string) (*runpb.Work
 
 // UpdateWorkerPool updates a worker pool.
 func (c *GCPClient) UpdateWorkerPool(ctx context.Context, workerPool *runpb.WorkerPool) (*runpb.WorkerPool, error) {
-	creds, err := client.FindDefaultCredentials(ctx, run.DefaultAuthScopes()...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default credentials: %w", err)
-	}
-
-	cClient, err := createWorkerPoolsClient(ctx, option.WithCredentials(creds))
+	cClient, release, err := workerPoolsClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		_ = cClient.Close()
+		_ = release()
 	}()
 
 	op, err := cClient.UpdateWorkerPool(ctx, &runpb.UpdateWorkerPoolRequest{WorkerPool: workerPool})
@@ -165,3 +175,20 @@ func (c *GCPClient) UpdateWorkerPool(ctx context.Context, workerPool *runpb.Work
 
 	return op.Wait(ctx)
 }
+
+// StartUpdateWorkerPool updates a worker pool and returns the operation of its rollout.
+func (c *GCPClient) StartUpdateWorkerPool(ctx context.Context, workerPool *runpb.WorkerPool) (operation.Poller[*runpb.WorkerPool], error) {
+	cClient, release, err := workerPoolsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := cClient.UpdateWorkerPool(ctx, &runpb.UpdateWorkerPoolRequest{WorkerPool: workerPool})
+	if err != nil {
+		_ = release()
+		return nil, err
+	}
+
+	// The client is released once the operation is done, as it polls it.
+	return operation.Release[*runpb.WorkerPool](op, release), nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"sync"
 
 	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/common"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
 	model "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
 	model_scaling "github.com/JulienBreux/run-cli/internal/run/model/workerpool/scaling"
@@ -14,15 +16,21 @@ import (
 
 var apiClient Client = &GCPClient{}
 
+// SetClient replaces the client of the API calls, e.g. with the in-memory one of the demo mode.
+func SetClient(c Client) {
+	apiClient = c
+}
+
 // List returns a list of worker pools for the given project and region.
 // If region is api_region.ALL, it lists worker pools from all supported Cloud Run regions.
-func List(project, region string) ([]model.WorkerPool, error) {
+func List(ctx context.Context, project, region string) ([]model.WorkerPool, error) {
 	if region == api_region.ALL {
-		return listAllRegions(project)
+		return listAllRegions(ctx, project)
 	}
 
-	ctx := context.Background()
-	pbWorkerPools, err := apiClient.ListWorkerPools(ctx, project, region)
+	pbWorkerPools, err := client.Call(ctx, func(ctx context.Context) ([]*runpb.WorkerPool, error) {
+		return apiClient.ListWorkerPools(ctx, project, region)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -41,81 +49,175 @@ func mapWorkerPool(resp *runpb.WorkerPool, project, region string) model.WorkerP
 	nameParts := strings.Split(resp.Name, "/")
 	name := nameParts[len(nameParts)-1]
 
-	s := model_scaling.Scaling{}
-	if resp.Scaling != nil {
-		if resp.Scaling.ManualInstanceCount != nil {
-			s.ManualInstanceCount = *resp.Scaling.ManualInstanceCount
-		}
-	}
+	s := mapScaling(resp.Scaling)
+
+	networkConfig, vpcConfig := mapVPCAccess(resp.GetTemplate().GetVpcAccess())
 
 	// Map fields
 	return model.WorkerPool{
-		DisplayName:  name,
-		Name:         resp.Name,
-		State:        "...", ///resp.State.String(),
-		UpdateTime:   resp.UpdateTime.AsTime(),
-		LastModifier: resp.LastModifier,
-		Region:       region,
-		Project:      project,
-		Scaling:      &s,
-		Labels:       resp.Labels,
+		DisplayName:          name,
+		Name:                 resp.Name,
+		Uid:                  resp.Uid,
+		CreateTime:           common.Time(resp.CreateTime),
+		UpdateTime:           common.Time(resp.UpdateTime),
+		DeleteTime:           common.Time(resp.DeleteTime),
+		State:                workerPoolState(resp),
+		Annotations:          resp.Annotations,
+		Etag:                 resp.Etag,
+		LastModifier:         resp.LastModifier,
+		Region:               region,
+		Project:              project,
+		NetworkConfig:        networkConfig,
+		PrivatePoolVpcConfig: vpcConfig,
+		Scaling:              &s,
+		Labels:               resp.Labels,
 	}
 }
 
-// UpdateScaling updates the scaling settings for a worker pool.
-func UpdateScaling(ctx context.Context, project, region, workerPoolName string, instanceCount int32) (*model.WorkerPool, error) {
-	fullPoolName := fmt.Sprintf("projects/%s/locations/%s/workerPools/%s", project, region, workerPoolName)
-	workerPool, err := apiClient.GetWorkerPool(ctx, fullPoolName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get worker pool: %w", err)
+// workerPoolState derives the state of a worker pool, which the API doesn't return, from its deletion
+// time and its terminal condition.
+func workerPoolState(resp *runpb.WorkerPool) string {
+	switch {
+	case resp.DeleteTime != nil:
+		return "DELETE_REQUESTED"
+	case resp.Reconciling:
+		return "PENDING"
 	}
+	switch resp.GetTerminalCondition().GetState() {
+	case runpb.Condition_CONDITION_SUCCEEDED:
+		return "ACTIVE"
+	case runpb.Condition_CONDITION_FAILED:
+		return "FAILED"
+	}
+	return "PENDING"
+}
 
-	// Update Scaling
-	if workerPool.Scaling == nil {
-		workerPool.Scaling = &runpb.WorkerPoolScaling{}
+// mapVPCAccess maps the VPC access of the instances of a worker pool, through its first network
+// interface, nil when there is none.
+func mapVPCAccess(access *runpb.VpcAccess) (*model.NetworkConfig, *model.PrivatePoolVpcConfig) {
+	if len(access.GetNetworkInterfaces()) == 0 {
+		return nil, nil
 	}
-	workerPool.Scaling.ManualInstanceCount = &instanceCount
 
-	// Clean up output-only fields
-	workerPool.Uid = ""
-	workerPool.CreateTime = nil
-	workerPool.UpdateTime = nil
-	workerPool.DeleteTime = nil
-	// workerPool.State is not accessible/exported
-	// Keep Etag for concurrency control
+	egress := access.Egress.String()
+	networkInterface := access.NetworkInterfaces[0]
+	networkConfig := &model.NetworkConfig{
+		PeeredNetwork: networkInterface.Network,
+		EgressOption:  egress,
+	}
+	vpcConfig := &model.PrivatePoolVpcConfig{
+		EgressOption: egress,
+		Subnetwork:   networkInterface.Subnetwork,
+	}
+	return networkConfig, vpcConfig
+}
 
-	// Update
-	resp, err := apiClient.UpdateWorkerPool(ctx, workerPool)
+// Get returns a single worker pool.
+// The worker pool name can be either a short name or a fully qualified resource name.
+func Get(ctx context.Context, project, region, workerPoolName string) (*model.WorkerPool, error) {
+	resp, err := GetRaw(ctx, project, region, workerPoolName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update worker pool: %w", err)
+		return nil, err
 	}
 
 	wp := mapWorkerPool(resp, project, region)
 	return &wp, nil
 }
 
-func listAllRegions(project string) ([]model.WorkerPool, error) {
-	var (
-		mu          sync.Mutex
-		workerPools []model.WorkerPool
-		wg          sync.WaitGroup
-	)
-
-	for _, region := range api_region.List() {
-		wg.Add(1)
-		go func(r string) {
-			defer wg.Done()
-			// Call List recursively for each region
-			// We ignore errors here to allow partial success (e.g. if one region is down or disabled)
-			if wp, err := List(project, r); err == nil {
-				mu.Lock()
-				workerPools = append(workerPools, wp...)
-				mu.Unlock()
+// GetRaw returns a single worker pool as returned by the API.
+func GetRaw(ctx context.Context, project, region, workerPoolName string) (*runpb.WorkerPool, error) {
+	name := workerPoolName
+	if !strings.HasPrefix(workerPoolName, "projects/") {
+		name = fmt.Sprintf("projects/%s/locations/%s/workerPools/%s", project, region, workerPoolName)
+	}
+
+	resp, err := client.Call(ctx, func(ctx context.Context) (*runpb.WorkerPool, error) {
+		return apiClient.GetWorkerPool(ctx, name)
+	})
+	if err != nil {
+		return nil, client.WrapError(err)
+	}
+	return resp, nil
+}
+
+// UpdateScaling updates the scaling settings for a worker pool.
+// base is the scaling the update was made from, e.g. the one shown to the user: when the instance
+// count was changed concurrently since, the update fails with a *client.ConflictError. A nil base
+// overwrites the scaling.
+func UpdateScaling(ctx context.Context, project, region, workerPoolName string, base *model_scaling.Scaling, instanceCount int32) (*operation.Operation, error) {
+	if err := client.CheckWritable(); err != nil {
+		return nil, err
+	}
+	fullPoolName := fmt.Sprintf("projects/%s/locations/%s/workerPools/%s", project, region, workerPoolName)
+
+	read := func(ctx context.Context) (*runpb.WorkerPool, error) {
+		workerPool, err := client.Call(ctx, func(ctx context.Context) (*runpb.WorkerPool, error) {
+			return apiClient.GetWorkerPool(ctx, fullPoolName)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get worker pool: %w", err)
+		}
+		return workerPool, nil
+	}
+	modify := func(workerPool *runpb.WorkerPool) error {
+		count := instanceCount
+		if base != nil {
+			var conflicts []client.FieldConflict
+			current := mapScaling(workerPool.Scaling)
+			count = client.MergeField(&conflicts, "instances", base.ManualInstanceCount, current.ManualInstanceCount, instanceCount)
+			if len(conflicts) > 0 {
+				return &client.ConflictError{Resource: "worker pool " + workerPoolName, Fields: conflicts}
 			}
-		}(region)
+		}
+
+		// Update Scaling
+		if workerPool.Scaling == nil {
+			workerPool.Scaling = &runpb.WorkerPoolScaling{}
+		}
+		workerPool.Scaling.ManualInstanceCount = &count
+
+		// Clean up output-only fields
+		workerPool.Uid = ""
+		workerPool.CreateTime = nil
+		workerPool.UpdateTime = nil
+		workerPool.DeleteTime = nil
+		// workerPool.State is not accessible/exported
+		// Keep Etag for concurrency control
+		return nil
+	}
+	write := func(ctx context.Context, workerPool *runpb.WorkerPool) (operation.Poller[*runpb.WorkerPool], error) {
+		op, err := apiClient.StartUpdateWorkerPool(ctx, workerPool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update worker pool: %w", err)
+		}
+		return op, nil
 	}
 
-	wg.Wait()
-	return workerPools, nil
+	op, err := client.ReadModifyWrite(ctx, read, modify, write)
+	if err != nil {
+		return nil, err
+	}
+	return operation.New("Scale", fmt.Sprintf("worker pool %s (%s)", workerPoolName, region), op, describeWorkerPool), nil
+}
+
+// describeWorkerPool describes the progress of the rollout of a worker pool.
+func describeWorkerPool(workerPool *runpb.WorkerPool) string {
+	return operation.Conditions(workerPool.GetTerminalCondition(), workerPool.GetConditions())
+}
+
+// mapScaling maps the scaling of a worker pool.
+func mapScaling(scaling *runpb.WorkerPoolScaling) model_scaling.Scaling {
+	s := model_scaling.Scaling{}
+	if scaling != nil && scaling.ManualInstanceCount != nil {
+		s.ManualInstanceCount = *scaling.ManualInstanceCount
+	}
+	return s
+}
+
+// listAllRegions lists the worker pools of all regions, see api_region.FanOut.
+func listAllRegions(ctx context.Context, project string) ([]model.WorkerPool, error) {
+	return api_region.FanOut(ctx, project, func(region string) ([]model.WorkerPool, error) {
+		return List(ctx, project, region)
+	})
 }
 
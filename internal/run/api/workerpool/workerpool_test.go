@@ -3,17 +3,23 @@ package workerpool
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	model "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
+	model_scaling "github.com/JulienBreux/run-cli/internal/run/model/workerpool/scaling"
 	"github.com/googleapis/gax-go/v2"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -22,6 +28,8 @@ type MockClient struct {
 	ListWorkerPoolsFunc  func(ctx context.Context, project, region string) ([]*runpb.WorkerPool, error)
 	GetWorkerPoolFunc    func(ctx context.Context, name string) (*runpb.WorkerPool, error)
 	UpdateWorkerPoolFunc func(ctx context.Context, workerPool *runpb.WorkerPool) (*runpb.WorkerPool, error)
+	// StartUpdateWorkerPoolFunc defaults to UpdateWorkerPoolFunc, whose operation is done at once.
+	StartUpdateWorkerPoolFunc func(ctx context.Context, workerPool *runpb.WorkerPool) (operation.Poller[*runpb.WorkerPool], error)
 }
 
 func (m *MockClient) ListWorkerPools(ctx context.Context, project, region string) ([]*runpb.WorkerPool, error) {
@@ -45,6 +53,17 @@ func (m *MockClient) UpdateWorkerPool(ctx context.Context, workerPool *runpb.Wor
 	return nil, nil
 }
 
+func (m *MockClient) StartUpdateWorkerPool(ctx context.Context, workerPool *runpb.WorkerPool) (operation.Poller[*runpb.WorkerPool], error) {
+	if m.StartUpdateWorkerPoolFunc != nil {
+		return m.StartUpdateWorkerPoolFunc(ctx, workerPool)
+	}
+	updated, err := m.UpdateWorkerPool(ctx, workerPool)
+	if err != nil {
+		return nil, err
+	}
+	return operation.Completed(workerPool.Name, updated), nil
+}
+
 func TestMapWorkerPool(t *testing.T) {
 	now := time.Now()
 	instanceCount := int32(2)
@@ -77,6 +96,62 @@ func TestMapWorkerPool(t *testing.T) {
 	assert.Equal(t, "prod", result.Labels["env"])
 }
 
+func TestMapWorkerPool_FullConfig(t *testing.T) {
+	now := time.Now().UTC()
+	resp := &runpb.WorkerPool{
+		Name:        "projects/my-project/locations/us-central1/workerPools/my-pool",
+		Uid:         "uid-1",
+		CreateTime:  timestamppb.New(now),
+		Annotations: map[string]string{"note": "consumers"},
+		Etag:        "etag-1",
+		Template: &runpb.WorkerPoolRevisionTemplate{
+			VpcAccess: &runpb.VpcAccess{
+				Egress: runpb.VpcAccess_PRIVATE_RANGES_ONLY,
+				NetworkInterfaces: []*runpb.VpcAccess_NetworkInterface{
+					{Network: "my-network", Subnetwork: "my-subnet"},
+				},
+			},
+		},
+		TerminalCondition: &runpb.Condition{State: runpb.Condition_CONDITION_SUCCEEDED},
+	}
+
+	result := mapWorkerPool(resp, "my-project", "us-central1")
+
+	assert.Equal(t, "uid-1", result.Uid)
+	assert.Equal(t, now, result.CreateTime)
+	assert.True(t, result.DeleteTime.IsZero())
+	assert.Equal(t, "ACTIVE", result.State)
+	assert.Equal(t, resp.Annotations, result.Annotations)
+	assert.Equal(t, "etag-1", result.Etag)
+	assert.Equal(t, &model.NetworkConfig{PeeredNetwork: "my-network", EgressOption: "PRIVATE_RANGES_ONLY"}, result.NetworkConfig)
+	assert.Equal(t, &model.PrivatePoolVpcConfig{EgressOption: "PRIVATE_RANGES_ONLY", Subnetwork: "my-subnet"}, result.PrivatePoolVpcConfig)
+
+	// Without a VPC
+	result = mapWorkerPool(&runpb.WorkerPool{Name: resp.Name}, "my-project", "us-central1")
+	assert.Nil(t, result.NetworkConfig)
+	assert.Nil(t, result.PrivatePoolVpcConfig)
+}
+
+func TestWorkerPoolState(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *runpb.WorkerPool
+		want string
+	}{
+		{"deleted", &runpb.WorkerPool{DeleteTime: timestamppb.Now()}, "DELETE_REQUESTED"},
+		{"reconciling", &runpb.WorkerPool{Reconciling: true, TerminalCondition: &runpb.Condition{State: runpb.Condition_CONDITION_SUCCEEDED}}, "PENDING"},
+		{"ready", &runpb.WorkerPool{TerminalCondition: &runpb.Condition{State: runpb.Condition_CONDITION_SUCCEEDED}}, "ACTIVE"},
+		{"failed", &runpb.WorkerPool{TerminalCondition: &runpb.Condition{State: runpb.Condition_CONDITION_FAILED}}, "FAILED"},
+		{"unknown", &runpb.WorkerPool{}, "PENDING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, workerPoolState(tt.resp))
+		})
+	}
+}
+
 func TestList(t *testing.T) {
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
@@ -88,7 +163,7 @@ func TestList(t *testing.T) {
 		return []*runpb.WorkerPool{{Name: "pool1"}}, nil
 	}
 
-	pools, err := List("p", "r")
+	pools, err := List(context.Background(), "p", "r")
 	assert.NoError(t, err)
 	assert.Len(t, pools, 1)
 }
@@ -104,12 +179,47 @@ func TestList_Error(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	pools, err := List("p", "r")
+	pools, err := List(context.Background(), "p", "r")
 	assert.Error(t, err)
 	assert.Nil(t, pools)
 }
 
+func TestGet(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var gotName string
+	apiClient = &MockClient{
+		GetWorkerPoolFunc: func(ctx context.Context, name string) (*runpb.WorkerPool, error) {
+			gotName = name
+			return &runpb.WorkerPool{Name: name}, nil
+		},
+	}
+
+	res, err := Get(context.Background(), "p", "r", "wp1")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/workerPools/wp1", gotName)
+	assert.Equal(t, "wp1", res.DisplayName)
+	assert.Equal(t, "r", res.Region)
+
+	_, err = GetRaw(context.Background(), "p", "r", "projects/p/locations/r/workerPools/wp1")
+	assert.NoError(t, err)
+	assert.Equal(t, "projects/p/locations/r/workerPools/wp1", gotName)
+
+	apiClient = &MockClient{
+		GetWorkerPoolFunc: func(ctx context.Context, name string) (*runpb.WorkerPool, error) {
+			return nil, errors.New("PermissionDenied")
+		},
+	}
+	_, err = Get(context.Background(), "p", "r", "wp1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "authentication failed")
+}
+
 func TestList_AllRegions(t *testing.T) {
+	api_region.SetAllowed([]string{"europe-west1", "us-central1"})
+	t.Cleanup(func() { api_region.SetAllowed(nil) })
+
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
 
@@ -120,9 +230,9 @@ func TestList_AllRegions(t *testing.T) {
 		return []*runpb.WorkerPool{{Name: "projects/" + project + "/locations/" + region + "/workerPools/pool-" + region}}, nil
 	}
 
-	pools, err := List("p", api_region.ALL)
+	pools, err := List(context.Background(), "p", api_region.ALL)
 	assert.NoError(t, err)
-	// We expect at least one pool per region. api_region.List() has > 0 regions.
+	// We expect at least one pool per region. api_region.List returns the allowed regions.
 	assert.NotEmpty(t, pools)
 }
 
@@ -141,10 +251,13 @@ func TestUpdateScaling(t *testing.T) {
 		return workerPool, nil
 	}
 
-	result, err := UpdateScaling(context.Background(), "p", "r", "pool1", 5)
+	op, err := UpdateScaling(context.Background(), "p", "r", "pool1", nil, 5)
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, int32(5), result.Scaling.ManualInstanceCount)
+	if !assert.NotNil(t, op) {
+		return
+	}
+	assert.Equal(t, "worker pool pool1 (r)", op.Target)
+	assert.NoError(t, op.Wait(context.Background()))
 }
 
 func TestUpdateScaling_GetError(t *testing.T) {
@@ -158,12 +271,20 @@ func TestUpdateScaling_GetError(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	result, err := UpdateScaling(context.Background(), "p", "r", "pool1", 5)
+	result, err := UpdateScaling(context.Background(), "p", "r", "pool1", nil, 5)
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to get worker pool")
 }
 
+func TestUpdateScaling_ReadOnly(t *testing.T) {
+	client.SetReadOnly(true)
+	defer client.SetReadOnly(false)
+
+	_, err := UpdateScaling(context.Background(), "p", "r", "pool1", nil, 5)
+	assert.ErrorIs(t, err, client.ErrReadOnly)
+}
+
 func TestUpdateScaling_UpdateError(t *testing.T) {
 	originalClient := apiClient
 	defer func() { apiClient = originalClient }()
@@ -178,12 +299,60 @@ func TestUpdateScaling_UpdateError(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	result, err := UpdateScaling(context.Background(), "p", "r", "pool1", 5)
+	result, err := UpdateScaling(context.Background(), "p", "r", "pool1", nil, 5)
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to update worker pool")
 }
 
+func TestUpdateScaling_Conflict(t *testing.T) {
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	mock := &MockClient{}
+	apiClient = mock
+
+	// The worker pool is scaled concurrently between the reads and the writes.
+	count := int32(1)
+	mock.GetWorkerPoolFunc = func(ctx context.Context, name string) (*runpb.WorkerPool, error) {
+		c := count
+		return &runpb.WorkerPool{Name: name, Etag: fmt.Sprint(c), Scaling: &runpb.WorkerPoolScaling{ManualInstanceCount: &c}}, nil
+	}
+	updates, written := 0, int32(0)
+	mock.UpdateWorkerPoolFunc = func(ctx context.Context, workerPool *runpb.WorkerPool) (*runpb.WorkerPool, error) {
+		updates++
+		if workerPool.Etag != fmt.Sprint(count) {
+			return nil, status.Error(codes.Aborted, "etag mismatch")
+		}
+		if updates == 1 {
+			count = 2
+			return nil, status.Error(codes.Aborted, "etag mismatch")
+		}
+		written = *workerPool.Scaling.ManualInstanceCount
+		return workerPool, nil
+	}
+
+	// The update is applied again when the instance count is unchanged since its base.
+	_, err := UpdateScaling(context.Background(), "p", "r", "pool1", nil, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), written)
+	assert.Equal(t, 2, updates)
+
+	// It fails with a conflict when the instance count was changed since its base.
+	updates = 0
+	_, err = UpdateScaling(context.Background(), "p", "r", "pool1", &model_scaling.Scaling{ManualInstanceCount: 1}, 5)
+	var conflict *client.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, []client.FieldConflict{{Field: "instances", Base: "1", Theirs: "2", Mine: "5"}}, conflict.Fields)
+	assert.Equal(t, 0, updates)
+
+	// It is applied when only the update changes it.
+	written = 0
+	_, err = UpdateScaling(context.Background(), "p", "r", "pool1", &model_scaling.Scaling{ManualInstanceCount: 2}, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), written)
+}
+
 // --- Mocks for GCPClient testing ---
 
 type MockWorkerPoolsClientWrapper struct {
@@ -239,6 +408,7 @@ func (m *MockWorkerPoolIteratorWrapper) Next() (*runpb.WorkerPool, error) {
 	return item, nil
 }
 
+// MockUpdateWorkerPoolOperationWrapper is an operation done at once, with the result of WaitFunc.
 type MockUpdateWorkerPoolOperationWrapper struct {
 	WaitFunc func(ctx context.Context, opts ...gax.CallOption) (*runpb.WorkerPool, error)
 }
@@ -250,6 +420,18 @@ func (m *MockUpdateWorkerPoolOperationWrapper) Wait(ctx context.Context, opts ..
 	return nil, nil
 }
 
+func (m *MockUpdateWorkerPoolOperationWrapper) Name() string { return "operation-1" }
+
+func (m *MockUpdateWorkerPoolOperationWrapper) Done() bool { return true }
+
+func (m *MockUpdateWorkerPoolOperationWrapper) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.WorkerPool, error) {
+	return m.Wait(ctx, opts...)
+}
+
+func (m *MockUpdateWorkerPoolOperationWrapper) Metadata() (*runpb.WorkerPool, error) {
+	return nil, nil
+}
+
 func TestGCPClient_ListWorkerPools(t *testing.T) {
 	origFindCreds := client.FindDefaultCredentials
 	origCreateClient := createWorkerPoolsClient
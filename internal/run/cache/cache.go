@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Variables for dependency injection
+var (
+	userCacheDir = os.UserCacheDir
+	now          = time.Now
+)
+
+// Cache stores values as JSON files in the user cache directory.
+type Cache struct {
+	dir string
+}
+
+// New returns the cache of the given name, e.g. $XDG_CACHE_HOME/run/completion.
+func New(name string) (*Cache, error) {
+	base, err := userCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cache directory: %w", err)
+	}
+	return &Cache{dir: filepath.Join(base, "run", name)}, nil
+}
+
+// Get decodes the value of key into v, unless it is missing or older than ttl.
+func (c *Cache) Get(key string, ttl time.Duration, v any) bool {
+	_, ok := c.Lookup(key, ttl, v)
+	return ok
+}
+
+// Lookup decodes the value of key into v, unless it is missing or older than ttl,
+// and returns when it was stored, e.g. to show how stale it is.
+func (c *Cache) Lookup(key string, ttl time.Duration, v any) (time.Time, bool) {
+	path := c.path(key)
+
+	info, err := os.Stat(path)
+	if err != nil || now().Sub(info.ModTime()) > ttl {
+		return time.Time{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, v) != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
+
+// Set stores v as the value of key.
+func (c *Cache) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache value: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write then rename, so that concurrent readers never see a partial file.
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, url.PathEscape(key)+".json")
+}
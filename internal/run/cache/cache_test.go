@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCache(t *testing.T) *Cache {
+	dir := t.TempDir()
+	origDir := userCacheDir
+	t.Cleanup(func() { userCacheDir = origDir })
+	userCacheDir = func() (string, error) { return dir, nil }
+
+	c, err := New("test")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "run", "test"), c.dir)
+	return c
+}
+
+func TestCache(t *testing.T) {
+	c := newTestCache(t)
+
+	var v []string
+	assert.False(t, c.Get("services/p/r", time.Minute, &v))
+
+	assert.NoError(t, c.Set("services/p/r", []string{"s1", "s2"}))
+	assert.True(t, c.Get("services/p/r", time.Minute, &v))
+	assert.Equal(t, []string{"s1", "s2"}, v)
+
+	// Keys are stored as flat files
+	_, err := os.Stat(filepath.Join(c.dir, "services%2Fp%2Fr.json"))
+	assert.NoError(t, err)
+}
+
+func TestCache_Expired(t *testing.T) {
+	c := newTestCache(t)
+	assert.NoError(t, c.Set("k", "v"))
+
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	var v string
+	assert.False(t, c.Get("k", time.Minute, &v))
+	assert.True(t, c.Get("k", time.Hour, &v))
+	assert.Equal(t, "v", v)
+}
+
+func TestCache_Lookup(t *testing.T) {
+	c := newTestCache(t)
+
+	var v string
+	_, ok := c.Lookup("k", time.Minute, &v)
+	assert.False(t, ok)
+
+	assert.NoError(t, c.Set("k", "v"))
+	stored := time.Now().Add(-30 * time.Second).Truncate(time.Second)
+	assert.NoError(t, os.Chtimes(c.path("k"), stored, stored))
+
+	at, ok := c.Lookup("k", time.Minute, &v)
+	assert.True(t, ok)
+	assert.Equal(t, "v", v)
+	assert.True(t, stored.Equal(at))
+
+	_, ok = c.Lookup("k", 10*time.Second, &v)
+	assert.False(t, ok)
+}
+
+func TestCache_Errors(t *testing.T) {
+	c := newTestCache(t)
+
+	assert.Error(t, c.Set("k", make(chan int)))
+
+	// Corrupted values are ignored
+	assert.NoError(t, c.Set("k", "v"))
+	assert.NoError(t, os.WriteFile(c.path("k"), []byte("{"), 0644))
+	var v string
+	assert.False(t, c.Get("k", time.Minute, &v))
+
+	origDir := userCacheDir
+	defer func() { userCacheDir = origDir }()
+	userCacheDir = func() (string, error) { return "", assert.AnError }
+	_, err := New("test")
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,250 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	"github.com/JulienBreux/run-cli/internal/run/manifest"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Variables for dependency injection
+var (
+	getServiceFunc    = api_service.GetRaw
+	createServiceFunc = api_service.Create
+	updateServiceFunc = api_service.Update
+	getJobFunc        = api_job.GetRaw
+	createJobFunc     = api_job.Create
+	updateJobFunc     = api_job.Update
+)
+
+// resource adapts the API of a kind of resource to apply manifests.
+type resource struct {
+	// outputOnly lists the fields set by the API which are ignored from manifests.
+	outputOnly []string
+	newMessage func() proto.Message
+	get        func(ctx context.Context, project, region, name string) (proto.Message, error)
+	create     func(ctx context.Context, project, region, name string, m proto.Message) error
+	update     func(ctx context.Context, m proto.Message) error
+}
+
+var resources = map[string]resource{
+	manifest.KindService: {
+		outputOnly: []string{
+			"name", "uid", "generation", "createTime", "updateTime", "deleteTime", "expireTime",
+			"creator", "lastModifier", "observedGeneration", "terminalCondition", "conditions",
+			"latestReadyRevision", "latestCreatedRevision", "trafficStatuses", "uri", "urls",
+			"reconciling", "etag", "satisfiesPzs",
+		},
+		newMessage: func() proto.Message { return &runpb.Service{} },
+		get: func(ctx context.Context, project, region, name string) (proto.Message, error) {
+			s, err := getServiceFunc(ctx, project, region, name)
+			if err != nil {
+				return nil, err
+			}
+			return s, nil
+		},
+		create: func(ctx context.Context, project, region, name string, m proto.Message) error {
+			_, err := createServiceFunc(ctx, project, region, name, m.(*runpb.Service))
+			return err
+		},
+		update: func(ctx context.Context, m proto.Message) error {
+			_, err := updateServiceFunc(ctx, m.(*runpb.Service))
+			return err
+		},
+	},
+	manifest.KindJob: {
+		outputOnly: []string{
+			"name", "uid", "generation", "createTime", "updateTime", "deleteTime", "expireTime",
+			"creator", "lastModifier", "observedGeneration", "terminalCondition", "conditions",
+			"executionCount", "latestCreatedExecution", "reconciling", "etag", "satisfiesPzs",
+		},
+		newMessage: func() proto.Message { return &runpb.Job{} },
+		get: func(ctx context.Context, project, region, name string) (proto.Message, error) {
+			j, err := getJobFunc(ctx, project, region, name)
+			if err != nil {
+				return nil, err
+			}
+			return j, nil
+		},
+		create: func(ctx context.Context, project, region, name string, m proto.Message) error {
+			_, err := createJobFunc(ctx, project, region, name, m.(*runpb.Job))
+			return err
+		},
+		update: func(ctx context.Context, m proto.Message) error {
+			_, err := updateJobFunc(ctx, m.(*runpb.Job))
+			return err
+		},
+	},
+}
+
+// NewCmdApply returns a command to apply manifests.
+func NewCmdApply(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	var (
+		scope     cmdutil.Scope
+		filenames []string
+		dryRun    bool
+	)
+
+	cmd = &cobra.Command{
+		Use:   "apply -f FILENAME",
+		Short: "Create or update Cloud Run services and jobs from manifests",
+		Long: `Create or update Cloud Run services and jobs from YAML or JSON manifests.
+
+A manifest has a kind (Service or Job), a metadata with the name and optionally
+the project and region of the resource, and a spec using the fields of the
+Cloud Run Admin API, as printed by 'run describe --raw'. Fields missing from
+the spec keep their live value.
+
+The changes are printed before being applied. Updates use the etag of the live
+resource, so they fail if it was modified in the meantime.`,
+		Example: `  run describe service my-service --raw > spec.yaml
+  run apply -f service.yaml --dry-run
+  cat job.yaml | run apply -f -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(filenames) == 0 {
+				return fmt.Errorf("at least one manifest is required, use --filename")
+			}
+
+			var manifests []manifest.Manifest
+			for _, filename := range filenames {
+				m, err := read(in, filename)
+				if err != nil {
+					return err
+				}
+				manifests = append(manifests, m...)
+			}
+
+			for _, m := range manifests {
+				if err := apply(cmd.Context(), out, scope, m, dryRun); err != nil {
+					return fmt.Errorf("%s: %w", m, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmd.Flags().StringArrayVarP(&filenames, "filename", "f", nil, "Manifest file to apply, '-' to read from the standard input.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the changes, without applying them.")
+
+	return
+}
+
+// read parses the manifests of a file or of in when filename is '-'.
+func read(in io.Reader, filename string) ([]manifest.Manifest, error) {
+	if filename == "-" {
+		return manifest.Parse(in)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	manifests, err := manifest.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return manifests, nil
+}
+
+// apply prints the plan of a manifest and applies it unless dryRun is set.
+func apply(ctx context.Context, out io.Writer, scope cmdutil.Scope, m manifest.Manifest, dryRun bool) error {
+	if m.Metadata.Project != "" {
+		scope.Project = m.Metadata.Project
+	}
+	if m.Metadata.Region != "" {
+		scope.Region = m.Metadata.Region
+	}
+	current, err := cmdutil.ResolveScope(scope)
+	if err != nil {
+		return err
+	}
+	if current.Region == api_region.ALL {
+		return fmt.Errorf("a region is required, set metadata.region or use --region")
+	}
+
+	r := resources[m.Kind]
+
+	// Validate and normalize the spec.
+	desired := r.newMessage()
+	if err := protojson.Unmarshal(m.Spec, desired); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+	desiredDoc, err := manifest.ToMap(desired)
+	if err != nil {
+		return err
+	}
+	manifest.Strip(desiredDoc, r.outputOnly...)
+
+	live, err := r.get(ctx, current.Project, current.Region, m.Metadata.Name)
+	if status.Code(err) == codes.NotFound {
+		printPlan(out, m, current.Region, "created", manifest.Diff(map[string]any{}, desiredDoc))
+		if dryRun {
+			return nil
+		}
+		msg := r.newMessage()
+		if err := manifest.FromMap(desiredDoc, msg); err != nil {
+			return err
+		}
+		if err := r.create(ctx, current.Project, current.Region, m.Metadata.Name, msg); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "%s created.\n", m)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	liveDoc, err := manifest.ToMap(live)
+	if err != nil {
+		return err
+	}
+	name, etag := liveDoc["name"], liveDoc["etag"]
+	manifest.Strip(liveDoc, r.outputOnly...)
+
+	merged := manifest.Merge(liveDoc, desiredDoc)
+	changes := manifest.Diff(liveDoc, merged)
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(out, "%s in %s is up to date.\n", m, current.Region)
+		return nil
+	}
+
+	printPlan(out, m, current.Region, "updated", changes)
+	if dryRun {
+		return nil
+	}
+
+	merged["name"], merged["etag"] = name, etag
+	msg := r.newMessage()
+	if err := manifest.FromMap(merged, msg); err != nil {
+		return err
+	}
+	if err := r.update(ctx, msg); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "%s updated.\n", m)
+	return nil
+}
+
+func printPlan(out io.Writer, m manifest.Manifest, region, action string, changes []manifest.Change) {
+	_, _ = fmt.Fprintf(out, "%s in %s will be %s:\n", m, region, action)
+	for _, c := range changes {
+		_, _ = fmt.Fprintf(out, "  %s\n", c)
+	}
+}
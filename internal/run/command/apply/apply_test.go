@@ -0,0 +1,194 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func isolateConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(tmpDir, "gcloud"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gcloud"), 0755))
+}
+
+func execute(t *testing.T, in string, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := NewCmdApply(strings.NewReader(in), out, &bytes.Buffer{})
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+const serviceManifest = `kind: Service
+metadata:
+  name: s1
+  region: europe-west1
+spec:
+  labels:
+    team: b
+  template:
+    containers:
+    - image: app:v2
+`
+
+func mockService(t *testing.T, live *runpb.Service, getErr error) (created, updated **runpb.Service) {
+	origGet, origCreate, origUpdate := getServiceFunc, createServiceFunc, updateServiceFunc
+	t.Cleanup(func() { getServiceFunc, createServiceFunc, updateServiceFunc = origGet, origCreate, origUpdate })
+
+	created, updated = new(*runpb.Service), new(*runpb.Service)
+	getServiceFunc = func(ctx context.Context, project, region, name string) (*runpb.Service, error) {
+		assert.Equal(t, "p", project)
+		assert.Equal(t, "europe-west1", region)
+		assert.Equal(t, "s1", name)
+		return live, getErr
+	}
+	createServiceFunc = func(ctx context.Context, project, region, name string, s *runpb.Service) (*runpb.Service, error) {
+		*created = s
+		return s, nil
+	}
+	updateServiceFunc = func(ctx context.Context, s *runpb.Service) (*runpb.Service, error) {
+		*updated = s
+		return s, nil
+	}
+	return
+}
+
+func liveService() *runpb.Service {
+	return &runpb.Service{
+		Name:   "projects/p/locations/europe-west1/services/s1",
+		Etag:   "etag-1",
+		Uri:    "https://s1.run.app",
+		Labels: map[string]string{"team": "a", "env": "prod"},
+		Template: &runpb.RevisionTemplate{
+			Containers: []*runpb.Container{{Image: "app:v1", Ports: []*runpb.ContainerPort{{ContainerPort: 8080}}}},
+		},
+	}
+}
+
+func TestNewCmdApply(t *testing.T) {
+	cmd := NewCmdApply(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "apply -f FILENAME", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("filename"))
+	assert.NotNil(t, cmd.Flags().Lookup("dry-run"))
+}
+
+func TestApply_Update(t *testing.T) {
+	isolateConfig(t)
+
+	t.Run("Dry run", func(t *testing.T) {
+		_, updated := mockService(t, liveService(), nil)
+
+		out, err := execute(t, serviceManifest, "-f", "-", "-p", "p", "--dry-run")
+		assert.NoError(t, err)
+		assert.Equal(t, `service/s1 in europe-west1 will be updated:
+  ~ labels.team: "a" => "b"
+  ~ template.containers[0].image: "app:v1" => "app:v2"
+`, out)
+		assert.Nil(t, *updated)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		_, updated := mockService(t, liveService(), nil)
+
+		out, err := execute(t, serviceManifest, "-f", "-", "-p", "p")
+		assert.NoError(t, err)
+		assert.Contains(t, out, "service/s1 updated.\n")
+		if assert.NotNil(t, *updated) {
+			s := *updated
+			assert.Equal(t, "projects/p/locations/europe-west1/services/s1", s.Name)
+			assert.Equal(t, "etag-1", s.Etag)
+			assert.Equal(t, map[string]string{"team": "b", "env": "prod"}, s.Labels)
+			assert.Equal(t, "app:v2", s.Template.Containers[0].Image)
+			assert.Equal(t, int32(8080), s.Template.Containers[0].Ports[0].ContainerPort)
+			assert.Empty(t, s.Uri)
+		}
+	})
+
+	t.Run("Up to date", func(t *testing.T) {
+		live := liveService()
+		live.Labels["team"] = "b"
+		live.Template.Containers[0].Image = "app:v2"
+		_, updated := mockService(t, live, nil)
+
+		out, err := execute(t, serviceManifest, "-f", "-", "-p", "p")
+		assert.NoError(t, err)
+		assert.Equal(t, "service/s1 in europe-west1 is up to date.\n", out)
+		assert.Nil(t, *updated)
+	})
+}
+
+func TestApply_Create(t *testing.T) {
+	isolateConfig(t)
+
+	created, _ := mockService(t, nil, status.Error(codes.NotFound, "not found"))
+
+	file := filepath.Join(t.TempDir(), "service.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(serviceManifest), 0644))
+
+	out, err := execute(t, "", "-f", file, "-p", "p")
+	assert.NoError(t, err)
+	assert.Equal(t, `service/s1 in europe-west1 will be created:
+  + labels: {"team":"b"}
+  + template: {"containers":[{"image":"app:v2"}]}
+service/s1 created.
+`, out)
+	if assert.NotNil(t, *created) {
+		assert.Equal(t, "app:v2", (*created).Template.Containers[0].Image)
+	}
+}
+
+func TestApply_Job(t *testing.T) {
+	isolateConfig(t)
+
+	origGet, origUpdate := getJobFunc, updateJobFunc
+	defer func() { getJobFunc, updateJobFunc = origGet, origUpdate }()
+
+	getJobFunc = func(ctx context.Context, project, region, name string) (*runpb.Job, error) {
+		return &runpb.Job{Name: "projects/p/locations/us-central1/jobs/j1", Template: &runpb.ExecutionTemplate{TaskCount: 1}}, nil
+	}
+	var updated *runpb.Job
+	updateJobFunc = func(ctx context.Context, j *runpb.Job) (*runpb.Job, error) {
+		updated = j
+		return j, nil
+	}
+
+	out, err := execute(t, "kind: job\nmetadata: {name: j1}\nspec: {template: {taskCount: 3}}\n", "-f", "-", "-p", "p", "-r", "us-central1")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "~ template.taskCount: 1 => 3")
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, int32(3), updated.Template.TaskCount)
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := execute(t, "")
+	assert.EqualError(t, err, "at least one manifest is required, use --filename")
+
+	_, err = execute(t, "", "-f", filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = execute(t, "kind: Service\nmetadata: {name: s1}\nspec: {}\n", "-f", "-", "-p", "p", "-r", "all")
+	assert.EqualError(t, err, "service/s1: a region is required, set metadata.region or use --region")
+
+	_, err = execute(t, "kind: Service\nmetadata: {name: s1}\nspec: {unknown: 1}\n", "-f", "-", "-p", "p", "-r", "us-central1")
+	assert.ErrorContains(t, err, "service/s1: invalid spec:")
+
+	mockService(t, nil, assert.AnError)
+	_, err = execute(t, serviceManifest, "-f", "-", "-p", "p")
+	assert.ErrorIs(t, err, assert.AnError)
+}
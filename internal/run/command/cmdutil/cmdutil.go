@@ -0,0 +1,155 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/auth"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/JulienBreux/run-cli/pkg/format"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// OutputTable represents the human readable table output.
+	OutputTable = "table"
+	// OutputJSON represents the JSON output.
+	OutputJSON = "json"
+	// OutputYAML represents the YAML output.
+	OutputYAML = "yaml"
+	// OutputText represents the human readable text output.
+	OutputText = "text"
+	// OutputCSV represents the comma separated values output.
+	OutputCSV = "csv"
+	// OutputGoTemplate represents the Go template output, e.g. go-template={{.name}}.
+	OutputGoTemplate = "go-template"
+	// OutputJSONPath represents the JSONPath output, e.g. jsonpath={.name}.
+	OutputJSONPath = "jsonpath"
+)
+
+// ListOutputs are the outputs supported by list commands.
+var ListOutputs = []string{OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputGoTemplate, OutputJSONPath}
+
+// Variables for dependency injection
+var (
+	loadConfig = config.Load
+	getInfo    = auth.GetInfo
+)
+
+// Scope represents the project and region targeted by a command.
+type Scope struct {
+	Project string
+	Region  string
+}
+
+// AddScopeFlags registers the --project and --region flags.
+func AddScopeFlags(cmd *cobra.Command, s *Scope) {
+	cmd.Flags().StringVarP(&s.Project, "project", "p", "", "Google Cloud project ID (defaults to the configured project).")
+	cmd.Flags().StringVarP(&s.Region, "region", "r", "", "Cloud Run region or 'all' (defaults to the configured region).")
+	_ = cmd.RegisterFlagCompletionFunc("project", CompleteProjects)
+	_ = cmd.RegisterFlagCompletionFunc("region", CompleteRegions)
+}
+
+// AddOutputFlag registers the --output flag restricted to the given formats.
+func AddOutputFlag(cmd *cobra.Command, output *string, def string, formats ...string) {
+	cmd.Flags().StringVarP(output, "output", "o", def, fmt.Sprintf("One of %s.", quoteList(formats)))
+}
+
+// ValidateOutput returns an error if the output is not one of the given formats
+// or if its template is invalid.
+func ValidateOutput(output string, formats ...string) error {
+	for _, f := range formats {
+		if output == f && !isTemplate(f) {
+			return nil
+		}
+		if isTemplate(f) && strings.HasPrefix(output, f+"=") {
+			_, err := format.NewPrinter(output)
+			return err
+		}
+	}
+	return fmt.Errorf("unsupported output format %q, expected one of %s", output, quoteList(formats))
+}
+
+// ResolveScope resolves the project and region using the same precedence as the TUI:
+// flags, then the CLI configuration or its selected context, then the gcloud configuration.
+func ResolveScope(s Scope) (info.Info, error) {
+	current := info.Info{Region: api_region.ALL}
+
+	if gcloudInfo, err := getInfo(); err == nil {
+		current.User = gcloudInfo.User
+		current.Project = gcloudInfo.Project
+		current.Region = gcloudInfo.Region
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return current, err
+	}
+	current.Context = cfg.CurrentContext
+	if cfg.Project != "" {
+		current.Project = cfg.Project
+	}
+	if cfg.Region != "" {
+		current.Region = cfg.Region
+	}
+
+	if s.Project != "" {
+		current.Project = s.Project
+	}
+	if s.Region != "" {
+		current.Region = s.Region
+	}
+
+	if current.Project == "" {
+		return current, fmt.Errorf("no project selected, use --project or select one in the TUI")
+	}
+
+	return current, nil
+}
+
+// Print prints v in the requested output, headers and rows being used by the table and CSV outputs.
+func Print(w io.Writer, output string, v any, headers []string, rows [][]string) error {
+	p, err := format.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if err := p.Print(w, v, &format.Table{Headers: headers, Rows: rows}, nil); err != nil {
+		return err
+	}
+	if output == OutputJSON {
+		_, _ = fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// PrintTable prints rows as aligned columns.
+func PrintTable(w io.Writer, headers []string, rows [][]string) {
+	_ = format.ToTable(w, format.Table{Headers: headers, Rows: rows})
+}
+
+// ShortName returns the last part of a resource name.
+func ShortName(name string) string {
+	parts := strings.Split(name, "/")
+	return parts[len(parts)-1]
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		if isTemplate(v) {
+			v += "=..."
+		}
+		quoted[i] = "'" + v + "'"
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
+
+func isTemplate(output string) bool {
+	return output == OutputGoTemplate || output == OutputJSONPath
+}
@@ -0,0 +1,116 @@
+package cmdutil
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockScopeSources(t *testing.T, gcloudInfo info.Info, gcloudErr error, cfg *config.Config, cfgErr error) {
+	origLoadConfig := loadConfig
+	origGetInfo := getInfo
+	t.Cleanup(func() {
+		loadConfig = origLoadConfig
+		getInfo = origGetInfo
+	})
+
+	getInfo = func() (info.Info, error) { return gcloudInfo, gcloudErr }
+	loadConfig = func() (*config.Config, error) { return cfg, cfgErr }
+}
+
+func TestResolveScope(t *testing.T) {
+	t.Run("Flags win", func(t *testing.T) {
+		mockScopeSources(t, info.Info{Project: "gcloud-p", Region: "us-east1"}, nil, &config.Config{Project: "cfg-p", Region: "europe-west1"}, nil)
+
+		current, err := ResolveScope(Scope{Project: "flag-p", Region: "asia-east1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "flag-p", current.Project)
+		assert.Equal(t, "asia-east1", current.Region)
+	})
+
+	t.Run("Config wins over gcloud", func(t *testing.T) {
+		mockScopeSources(t, info.Info{User: "me", Project: "gcloud-p", Region: "us-east1"}, nil, &config.Config{Project: "cfg-p"}, nil)
+
+		current, err := ResolveScope(Scope{})
+		assert.NoError(t, err)
+		assert.Equal(t, "cfg-p", current.Project)
+		assert.Equal(t, "us-east1", current.Region)
+		assert.Equal(t, "me", current.User)
+	})
+
+	t.Run("Defaults to all regions", func(t *testing.T) {
+		mockScopeSources(t, info.Info{}, errors.New("no gcloud"), &config.Config{Project: "cfg-p"}, nil)
+
+		current, err := ResolveScope(Scope{})
+		assert.NoError(t, err)
+		assert.Equal(t, api_region.ALL, current.Region)
+	})
+
+	t.Run("Missing project", func(t *testing.T) {
+		mockScopeSources(t, info.Info{}, errors.New("no gcloud"), &config.Config{}, nil)
+
+		_, err := ResolveScope(Scope{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no project selected")
+	})
+
+	t.Run("Config error", func(t *testing.T) {
+		mockScopeSources(t, info.Info{}, nil, nil, errors.New("corrupted"))
+
+		_, err := ResolveScope(Scope{Project: "p"})
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateOutput(t *testing.T) {
+	assert.NoError(t, ValidateOutput("json", OutputTable, OutputJSON))
+
+	err := ValidateOutput("xml", OutputTable, OutputJSON, OutputYAML)
+	assert.Error(t, err)
+	assert.Equal(t, `unsupported output format "xml", expected one of 'table', 'json' or 'yaml'`, err.Error())
+
+	assert.NoError(t, ValidateOutput("jsonpath={.name}", ListOutputs...))
+	assert.NoError(t, ValidateOutput("go-template={{.name}}", ListOutputs...))
+	assert.ErrorContains(t, ValidateOutput("go-template={{.name", ListOutputs...), "invalid go-template")
+	assert.ErrorContains(t, ValidateOutput("jsonpath", ListOutputs...), `'go-template=...' or 'jsonpath=...'`)
+}
+
+func TestPrint(t *testing.T) {
+	v := []struct {
+		Name string `json:"name" yaml:"name"`
+	}{{Name: "s1"}}
+	headers := []string{"NAME", "REGION"}
+	rows := [][]string{{"s1", "us-central1"}}
+
+	w := &bytes.Buffer{}
+	Print(w, OutputTable, v, headers, rows)
+	assert.Equal(t, "NAME   REGION\ns1     us-central1\n", w.String())
+
+	w.Reset()
+	Print(w, OutputJSON, v, headers, rows)
+	assert.Equal(t, "[{\"name\":\"s1\"}]\n", w.String())
+
+	w.Reset()
+	Print(w, OutputYAML, v, headers, rows)
+	assert.Equal(t, "- name: s1\n", w.String())
+
+	w.Reset()
+	assert.NoError(t, Print(w, OutputCSV, v, headers, rows))
+	assert.Equal(t, "NAME,REGION\ns1,us-central1\n", w.String())
+
+	w.Reset()
+	assert.NoError(t, Print(w, "jsonpath={[*].name}", v, headers, rows))
+	assert.Equal(t, "s1", w.String())
+
+	assert.Error(t, Print(w, "go-template={{", v, headers, rows))
+}
+
+func TestShortName(t *testing.T) {
+	assert.Equal(t, "my-job", ShortName("projects/p/locations/r/jobs/my-job"))
+	assert.Equal(t, "my-job", ShortName("my-job"))
+}
@@ -0,0 +1,211 @@
+package cmdutil
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	api_domainmapping "github.com/JulienBreux/run-cli/internal/run/api/domainmapping"
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_execution "github.com/JulienBreux/run-cli/internal/run/api/job/execution"
+	api_project "github.com/JulienBreux/run-cli/internal/run/api/project"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	api_revision "github.com/JulienBreux/run-cli/internal/run/api/service/revision"
+	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
+	"github.com/JulienBreux/run-cli/internal/run/cache"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of resources which names can be completed.
+const (
+	KindService       = "service"
+	KindRevision      = "revision"
+	KindJob           = "job"
+	KindExecution     = "execution"
+	KindWorkerPool    = "workerpool"
+	KindDomainMapping = "domainmapping"
+)
+
+// Durations after which the completion cache is refreshed.
+const (
+	namesTTL    = 2 * time.Minute
+	projectsTTL = time.Hour
+)
+
+// Variables for dependency injection
+var (
+	newCompletionCache = func() (*cache.Cache, error) { return cache.New("completion") }
+
+	listProjectsFunc       = api_project.List
+	listServicesFunc       = api_service.List
+	listRevisionsFunc      = api_revision.List
+	listJobsFunc           = api_job.List
+	listExecutionsFunc     = api_execution.List
+	listWorkerPoolsFunc    = api_workerpool.List
+	listDomainMappingsFunc = api_domainmapping.List
+)
+
+// CompleteProjects completes the projects of the current user.
+func CompleteProjects(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	names, err := cached("projects", projectsTTL, func() ([]string, error) {
+		projects, err := listProjectsFunc(commandContext(cmd))
+		names := make([]string, 0, len(projects))
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		return names, err
+	})
+	return complete(names, err, toComplete)
+}
+
+// CompleteRegions completes the Cloud Run regions.
+func CompleteRegions(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	project, _ := cmd.Flags().GetString("project")
+	current, _ := ResolveScope(Scope{Project: project})
+	return complete(append(api_region.List(cmd.Context(), current.Project), api_region.ALL), nil, toComplete)
+}
+
+// CompleteNames returns a function completing the names of a kind of resource
+// in the project and region of the --project and --region flags.
+// Revisions and executions also use the --service and --job flags.
+func CompleteNames(kind string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		project, _ := cmd.Flags().GetString("project")
+		region, _ := cmd.Flags().GetString("region")
+		current, err := ResolveScope(Scope{Project: project, Region: region})
+		if err != nil {
+			return complete(nil, err, toComplete)
+		}
+
+		parent := ""
+		switch kind {
+		case KindRevision:
+			parent, _ = cmd.Flags().GetString("service")
+		case KindExecution:
+			parent, _ = cmd.Flags().GetString("job")
+		}
+
+		key := strings.Join([]string{kind, current.Project, current.Region, parent}, "/")
+		names, err := cached(key, namesTTL, func() ([]string, error) {
+			return listNames(commandContext(cmd), kind, current.Project, current.Region, parent)
+		})
+		return complete(names, err, toComplete)
+	}
+}
+
+// CompleteKindAndName completes the kinds of the first argument, then the names of the second one
+// for KIND NAME commands. kindOf returns the kind of names of a first argument, e.g. resolving aliases.
+func CompleteKindAndName(kinds []string, kindOf func(arg string) string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return complete(kinds, nil, toComplete)
+		case 1:
+			if kind := kindOf(args[0]); kind != "" {
+				return CompleteNames(kind)(cmd, args, toComplete)
+			}
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// listNames returns the short names of the resources of a kind.
+func listNames(ctx context.Context, kind, project, region, parent string) ([]string, error) {
+	var names []string
+	add := func(name string) { names = append(names, ShortName(name)) }
+
+	switch kind {
+	case KindService:
+		services, err := listServicesFunc(ctx, project, region)
+		for _, s := range services {
+			add(s.Name)
+		}
+		return names, err
+	case KindRevision:
+		if parent == "" || region == api_region.ALL {
+			return nil, nil
+		}
+		revisions, err := listRevisionsFunc(ctx, project, region, parent)
+		for _, r := range revisions {
+			add(r.Name)
+		}
+		return names, err
+	case KindJob:
+		jobs, err := listJobsFunc(ctx, project, region)
+		for _, j := range jobs {
+			add(j.Name)
+		}
+		return names, err
+	case KindExecution:
+		if parent == "" || region == api_region.ALL {
+			return nil, nil
+		}
+		executions, err := listExecutionsFunc(ctx, project, region, parent)
+		for _, e := range executions {
+			add(e.Name)
+		}
+		return names, err
+	case KindWorkerPool:
+		workerPools, err := listWorkerPoolsFunc(ctx, project, region)
+		for _, w := range workerPools {
+			add(w.Name)
+		}
+		return names, err
+	case KindDomainMapping:
+		domainMappings, err := listDomainMappingsFunc(ctx, project, region)
+		for _, d := range domainMappings {
+			add(d.Name)
+		}
+		return names, err
+	}
+	return nil, nil
+}
+
+// commandContext returns the context of the command, cancelled when the completion is interrupted.
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// cached returns the names stored under key, listing and storing them when missing or expired.
+// The cache is best effort, completion still works when it is not writable.
+func cached(key string, ttl time.Duration, list func() ([]string, error)) ([]string, error) {
+	c, err := newCompletionCache()
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return list()
+	}
+
+	var names []string
+	if c.Get(key, ttl, &names) {
+		return names, nil
+	}
+
+	names, err = list()
+	if err != nil {
+		// The names of the regions which could be listed are completed, but not cached.
+		return names, IgnorePartial(err)
+	}
+	if err := c.Set(key, names); err != nil {
+		cobra.CompDebugln(err.Error(), false)
+	}
+	return names, nil
+}
+
+func complete(names []string, err error, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []cobra.Completion
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
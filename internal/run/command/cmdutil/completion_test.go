@@ -0,0 +1,168 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/cache"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	model_project "github.com/JulienBreux/run-cli/internal/run/model/common/project"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func isolateCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+}
+
+func newScopedCmd() *cobra.Command {
+	var scope Scope
+	cmd := &cobra.Command{Use: "test"}
+	AddScopeFlags(cmd, &scope)
+	cmd.Flags().String("job", "", "")
+	return cmd
+}
+
+func TestCompleteRegions(t *testing.T) {
+	mockScopeSources(t, info.Info{Project: "p"}, nil, &config.Config{}, nil)
+	api_region.SetAllowed([]string{"europe-west1", "europe-west4", "us-central1"})
+	t.Cleanup(func() { api_region.SetAllowed(nil) })
+
+	completions, directive := CompleteRegions(newScopedCmd(), nil, "europe-west")
+	assert.Contains(t, completions, "europe-west1")
+	assert.NotContains(t, completions, "us-central1")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, _ = CompleteRegions(newScopedCmd(), nil, "a")
+	assert.Contains(t, completions, "all")
+}
+
+func TestCompleteProjects(t *testing.T) {
+	isolateCache(t)
+
+	origList := listProjectsFunc
+	defer func() { listProjectsFunc = origList }()
+
+	calls := 0
+	listProjectsFunc = func(ctx context.Context) ([]model_project.Project, error) {
+		calls++
+		return []model_project.Project{{Name: "prod-1"}, {Name: "prod-2"}, {Name: "staging"}}, nil
+	}
+
+	completions, _ := CompleteProjects(newScopedCmd(), nil, "prod")
+	assert.Equal(t, []string{"prod-1", "prod-2"}, completions)
+
+	// Projects are read from the cache
+	completions, _ = CompleteProjects(newScopedCmd(), nil, "")
+	assert.Equal(t, []string{"prod-1", "prod-2", "staging"}, completions)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCompleteNames(t *testing.T) {
+	isolateCache(t)
+	mockScopeSources(t, info.Info{}, errors.New("no gcloud"), &config.Config{Project: "cfg-p", Region: "us-central1"}, nil)
+
+	origServices, origExecutions := listServicesFunc, listExecutionsFunc
+	defer func() { listServicesFunc, listExecutionsFunc = origServices, origExecutions }()
+
+	var gotProject, gotRegion string
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		gotProject, gotRegion = project, region
+		return []model_service.Service{{Name: "api"}, {Name: "projects/p/locations/r/services/web"}}, nil
+	}
+
+	t.Run("Scope from flags", func(t *testing.T) {
+		cmd := newScopedCmd()
+		assert.NoError(t, cmd.Flags().Set("project", "p"))
+		assert.NoError(t, cmd.Flags().Set("region", "europe-west1"))
+
+		completions, directive := CompleteNames(KindService)(cmd, nil, "")
+		assert.Equal(t, []string{"api", "web"}, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		assert.Equal(t, "p", gotProject)
+		assert.Equal(t, "europe-west1", gotRegion)
+	})
+
+	t.Run("Scope from config", func(t *testing.T) {
+		completions, _ := CompleteNames(KindService)(newScopedCmd(), nil, "w")
+		assert.Equal(t, []string{"web"}, completions)
+		assert.Equal(t, "cfg-p", gotProject)
+		assert.Equal(t, "us-central1", gotRegion)
+	})
+
+	t.Run("Parent", func(t *testing.T) {
+		var gotJob string
+		listExecutionsFunc = func(ctx context.Context, project, region, job string) ([]model_execution.Execution, error) {
+			gotJob = job
+			return []model_execution.Execution{{Name: "j1-abcde"}}, nil
+		}
+
+		completions, _ := CompleteNames(KindExecution)(newScopedCmd(), nil, "")
+		assert.Empty(t, completions)
+
+		cmd := newScopedCmd()
+		assert.NoError(t, cmd.Flags().Set("job", "j1"))
+		completions, _ = CompleteNames(KindExecution)(cmd, nil, "")
+		assert.Equal(t, []string{"j1-abcde"}, completions)
+		assert.Equal(t, "j1", gotJob)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+			return nil, assert.AnError
+		}
+		cmd := newScopedCmd()
+		assert.NoError(t, cmd.Flags().Set("region", "asia-east1"))
+
+		completions, directive := CompleteNames(KindService)(cmd, nil, "")
+		assert.Empty(t, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+}
+
+func TestCached_WithoutCache(t *testing.T) {
+	origCache := newCompletionCache
+	defer func() { newCompletionCache = origCache }()
+	newCompletionCache = func() (*cache.Cache, error) { return nil, assert.AnError }
+
+	names, err := cached("k", namesTTL, func() ([]string, error) { return []string{"a"}, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, names)
+}
+
+func TestCompleteKindAndName(t *testing.T) {
+	isolateCache(t)
+
+	origServices := listServicesFunc
+	defer func() { listServicesFunc = origServices }()
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		return []model_service.Service{{Name: "api"}}, nil
+	}
+
+	complete := CompleteKindAndName([]string{KindService, KindJob}, func(arg string) string {
+		if arg == "svc" {
+			return KindService
+		}
+		return ""
+	})
+	cmd := newScopedCmd()
+	assert.NoError(t, cmd.Flags().Set("project", "p"))
+
+	completions, _ := complete(cmd, nil, "s")
+	assert.Equal(t, []string{"service"}, completions)
+
+	completions, _ = complete(cmd, []string{"svc"}, "")
+	assert.Equal(t, []string{"api"}, completions)
+
+	completions, _ = complete(cmd, []string{"unknown"}, "")
+	assert.Empty(t, completions)
+
+	completions, _ = complete(cmd, []string{"svc", "api"}, "")
+	assert.Empty(t, completions)
+}
@@ -0,0 +1,61 @@
+package cmdutil
+
+import (
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/spf13/cobra"
+)
+
+// contextName is the context selected with the --context flag, if any.
+var contextName string
+
+// AddContextFlag registers the persistent --context flag.
+func AddContextFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&contextName, "context", "", "Name of the context to use (defaults to the current context).")
+	_ = cmd.RegisterFlagCompletionFunc("context", CompleteContexts)
+}
+
+// LoadConfig loads the CLI configuration, switched to the context of the --context flag if any,
+// with the endpoints of the --endpoint flag.
+func LoadConfig() (*config.Config, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if contextName != "" {
+		if err := cfg.UseContext(contextName); err != nil {
+			return nil, err
+		}
+		for _, key := range []string{config.KeyCurrentContext, config.KeyProject, config.KeyRegion} {
+			cfg.SetOrigin(key, "flag:--context")
+		}
+	}
+	for api, endpoint := range endpointOverrides {
+		cfg.SetEndpoint(api, endpoint)
+		cfg.SetOrigin(config.EndpointKey(api), "flag:--endpoint")
+	}
+	return cfg, nil
+}
+
+// ApplyContext applies the account settings of the current context to all API calls.
+func ApplyContext(cfg *config.Config) {
+	ctx := cfg.Current()
+	if ctx == nil {
+		ctx = &config.Context{}
+	}
+	client.Impersonate(ctx.ImpersonateServiceAccount)
+	client.SetReadOnly(ctx.ReadOnly)
+}
+
+// CompleteContexts completes the names of the contexts.
+func CompleteContexts(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return complete(nil, err, toComplete)
+	}
+	names := make([]string, 0, len(cfg.Contexts))
+	for _, ctx := range cfg.Contexts {
+		names = append(names, ctx.Name)
+	}
+	return complete(names, nil, toComplete)
+}
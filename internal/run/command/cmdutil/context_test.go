@@ -0,0 +1,73 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContextConfig() *config.Config {
+	return &config.Config{
+		Project:        "dev",
+		CurrentContext: "dev",
+		Contexts: []config.Context{
+			{Name: "dev", Project: "dev"},
+			{Name: "prod-eu", Project: "prod", Region: "europe-west1", ReadOnly: true},
+		},
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	defer func() { contextName = "" }()
+
+	mockScopeSources(t, info.Info{}, nil, newContextConfig(), nil)
+	cfg, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", cfg.CurrentContext)
+
+	// The --context flag switches the context of the run
+	contextName = "prod-eu"
+	mockScopeSources(t, info.Info{}, nil, newContextConfig(), nil)
+	current, err := ResolveScope(Scope{})
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", current.Project)
+	assert.Equal(t, "europe-west1", current.Region)
+	assert.Equal(t, "prod-eu", current.Context)
+
+	cfg, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "flag:--context", cfg.Origin(config.KeyProject))
+
+	contextName = "unknown"
+	_, err = LoadConfig()
+	assert.EqualError(t, err, `context "unknown" not found`)
+
+	mockScopeSources(t, info.Info{}, nil, nil, assert.AnError)
+	_, err = LoadConfig()
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestApplyContext(t *testing.T) {
+	defer client.SetReadOnly(false)
+
+	cfg := newContextConfig()
+	assert.NoError(t, cfg.UseContext("prod-eu"))
+	ApplyContext(cfg)
+	assert.ErrorIs(t, client.CheckWritable(), client.ErrReadOnly)
+
+	cfg.CurrentContext = ""
+	ApplyContext(cfg)
+	assert.NoError(t, client.CheckWritable())
+}
+
+func TestCompleteContexts(t *testing.T) {
+	mockScopeSources(t, info.Info{}, nil, newContextConfig(), nil)
+
+	completions, directive := CompleteContexts(&cobra.Command{}, nil, "pr")
+	assert.Equal(t, []string{"prod-eu"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
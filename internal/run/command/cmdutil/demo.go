@@ -0,0 +1,25 @@
+package cmdutil
+
+import (
+	"github.com/JulienBreux/run-cli/internal/run/demo"
+	"github.com/spf13/cobra"
+)
+
+// demoMode is true with the --demo flag.
+var demoMode bool
+
+// AddDemoFlag registers the persistent --demo flag.
+func AddDemoFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(&demoMode, "demo", false, "Use generated demo resources instead of Google Cloud, no account needed.")
+}
+
+// ApplyDemo makes all API calls use the demo resources with the --demo flag, with the
+// demo project and region and a configuration which is never saved.
+func ApplyDemo() {
+	if !demoMode {
+		return
+	}
+	demo.Enable()
+	loadConfig = demo.Config
+	getInfo = demo.Info
+}
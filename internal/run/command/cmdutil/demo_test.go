@@ -0,0 +1,27 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/demo"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDemo(t *testing.T) {
+	defer func() { demoMode = false }()
+	mockScopeSources(t, info.Info{Project: "gcloud-p"}, nil, &config.Config{Project: "cfg-p"}, nil)
+
+	// Without the --demo flag, nothing changes
+	ApplyDemo()
+	current, err := ResolveScope(Scope{})
+	assert.NoError(t, err)
+	assert.Equal(t, "cfg-p", current.Project)
+
+	demoMode = true
+	ApplyDemo()
+	current, err = ResolveScope(Scope{})
+	assert.NoError(t, err)
+	assert.Equal(t, info.Info{User: demo.User, Project: demo.Project, Region: demo.Region}, current)
+}
@@ -0,0 +1,31 @@
+package cmdutil
+
+import (
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/spf13/cobra"
+)
+
+// endpointOverrides are the endpoints set with the --endpoint flag, if any.
+var endpointOverrides map[string]string
+
+// AddEndpointFlag registers the persistent --endpoint flag.
+func AddEndpointFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringToStringVar(&endpointOverrides, "endpoint", nil, "Endpoint of an API, e.g. run=http://localhost:8080 for the fake server (run, cloudresourcemanager or logging).")
+	_ = cmd.RegisterFlagCompletionFunc("endpoint", CompleteEndpoints)
+}
+
+// ApplyEndpoints makes all API calls use the endpoints of the configuration.
+func ApplyEndpoints(cfg *config.Config) error {
+	return client.SetEndpoints(cfg.Endpoints)
+}
+
+// CompleteEndpoints completes the names of the APIs whose endpoint can be overridden.
+func CompleteEndpoints(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	names := make([]string, 0, len(client.APIs))
+	for _, api := range client.APIs {
+		names = append(names, api+"=")
+	}
+	completions, directive := complete(names, nil, toComplete)
+	return completions, directive | cobra.ShellCompDirectiveNoSpace
+}
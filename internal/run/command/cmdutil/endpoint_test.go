@@ -0,0 +1,33 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_Endpoints(t *testing.T) {
+	defer func() { endpointOverrides = nil }()
+
+	mockScopeSources(t, info.Info{}, nil, &config.Config{Endpoints: map[string]string{"run": "europe-west1-run.googleapis.com", "logging": "logging.example.com"}}, nil)
+
+	// The --endpoint flag overrides the configured endpoints
+	endpointOverrides = map[string]string{"run": "http://localhost:8080"}
+	cfg, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"run": "http://localhost:8080", "logging": "logging.example.com"}, cfg.Endpoints)
+	assert.Equal(t, "flag:--endpoint", cfg.Origin(config.EndpointKey("run")))
+
+	assert.NoError(t, ApplyEndpoints(cfg))
+	assert.NoError(t, ApplyEndpoints(&config.Config{}))
+	assert.ErrorContains(t, ApplyEndpoints(&config.Config{Endpoints: map[string]string{"storage": "localhost"}}), `unknown API "storage"`)
+}
+
+func TestCompleteEndpoints(t *testing.T) {
+	completions, directive := CompleteEndpoints(&cobra.Command{}, nil, "r")
+	assert.Equal(t, []string{"run="}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace, directive)
+}
@@ -0,0 +1,59 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+)
+
+const (
+	// ExitCodeError is the exit code of a generic failure.
+	ExitCodeError = 1
+	// ExitCodeFailed is the exit code used when a remote operation completed but failed.
+	ExitCodeFailed = 2
+	// ExitCodeTimeout is the exit code used when a remote operation did not complete in time.
+	ExitCodeTimeout = 3
+	// ExitCodePartial is the exit code used when a listing misses the resources of some regions.
+	ExitCodePartial = 4
+)
+
+// ExitError represents an error with a dedicated process exit code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error returns human readable error
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap unwraps the original error
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// IgnorePartial returns nil when err only reports regions which could not be listed, the
+// resources of the other regions being usable, see WarnPartial. It returns err otherwise.
+func IgnorePartial(err error) error {
+	var partial *api_region.PartialError
+	if errors.As(err, &partial) {
+		return nil
+	}
+	return err
+}
+
+// WarnPartial prints a warning for each region which could not be listed, if any, and returns
+// an error exiting with ExitCodePartial. It returns nil for any other error.
+func WarnPartial(w io.Writer, err error) error {
+	var partial *api_region.PartialError
+	if !errors.As(err, &partial) {
+		return nil
+	}
+	for _, region := range partial.Regions() {
+		_, _ = fmt.Fprintf(w, "Warning: failed to list region %s: %s\n", region, partial.Errors[region])
+	}
+	return &ExitError{Code: ExitCodePartial, Err: partial}
+}
@@ -0,0 +1,17 @@
+package cmdutil
+
+import (
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+)
+
+// ApplyLimits bounds all API calls with the limits of the configuration, the default ones if unset.
+func ApplyLimits(cfg *config.Config) {
+	limits := client.Limits{}
+	if cfg.Limits != nil {
+		limits.Parallelism = cfg.Limits.Parallelism
+		limits.Retries = cfg.Limits.Retries
+		limits.Rate = cfg.Limits.RateLimit
+	}
+	client.SetLimits(limits)
+}
@@ -0,0 +1,11 @@
+package cmdutil
+
+import (
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+)
+
+// ApplyRegions restricts the regions listed when using all regions to the ones of the configuration.
+func ApplyRegions(cfg *config.Config) {
+	api_region.SetAllowed(cfg.Regions)
+}
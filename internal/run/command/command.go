@@ -3,8 +3,19 @@ package command
 import (
 	"io"
 
+	"github.com/JulienBreux/run-cli/internal/run/command/apply"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	"github.com/JulienBreux/run-cli/internal/run/command/completion"
+	cmd_config "github.com/JulienBreux/run-cli/internal/run/command/config"
+	"github.com/JulienBreux/run-cli/internal/run/command/describe"
+	"github.com/JulienBreux/run-cli/internal/run/command/doctor"
+	"github.com/JulienBreux/run-cli/internal/run/command/domainmapping"
+	"github.com/JulienBreux/run-cli/internal/run/command/fakeserver"
+	"github.com/JulienBreux/run-cli/internal/run/command/job"
+	"github.com/JulienBreux/run-cli/internal/run/command/log"
+	"github.com/JulienBreux/run-cli/internal/run/command/service"
 	"github.com/JulienBreux/run-cli/internal/run/command/version"
-	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/command/workerpool"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +25,26 @@ func New(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:   "run",
 		Short: "Run is a CLI to play with Google Cloud Run interactively.",
+		// Errors are printed by the caller, see PrintError.
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmdutil.ApplyDemo()
+			cfg, err := cmdutil.LoadConfig()
+			if err != nil {
+				// Commands which don't need the configuration still work when it is invalid.
+				if cmd.Flags().Changed("context") || cmd.Flags().Changed("endpoint") {
+					return err
+				}
+				return nil
+			}
+			cmdutil.ApplyContext(cfg)
+			cmdutil.ApplyRegions(cfg)
+			cmdutil.ApplyLimits(cfg)
+			return cmdutil.ApplyEndpoints(cfg)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load()
+			cfg, err := cmdutil.LoadConfig()
 			if err != nil {
 				return err
 			}
@@ -23,7 +52,25 @@ func New(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
 		},
 	}
 
+	cmdutil.AddContextFlag(cmd)
+	cmdutil.AddEndpointFlag(cmd)
+	cmdutil.AddDemoFlag(cmd)
+
+	// Replaced by the completion command, which documents the name completion.
+	cmd.CompletionOptions.DisableDefaultCmd = true
+
 	cmd.AddCommand(version.NewCmdVersion(in, out, err))
+	cmd.AddCommand(service.NewCmdService(in, out, err))
+	cmd.AddCommand(job.NewCmdJob(in, out, err))
+	cmd.AddCommand(workerpool.NewCmdWorkerPool(in, out, err))
+	cmd.AddCommand(domainmapping.NewCmdDomainMapping(in, out, err))
+	cmd.AddCommand(log.NewCmdLog(in, out, err))
+	cmd.AddCommand(describe.NewCmdDescribe(in, out, err))
+	cmd.AddCommand(apply.NewCmdApply(in, out, err))
+	cmd.AddCommand(completion.NewCmdCompletion(in, out, err))
+	cmd.AddCommand(cmd_config.NewCmdConfig(in, out, err))
+	cmd.AddCommand(doctor.NewCmdDoctor(in, out, err))
+	cmd.AddCommand(fakeserver.NewCmdFakeServer(in, out, err))
 
 	return
 }
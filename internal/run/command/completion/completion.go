@@ -0,0 +1,48 @@
+package completion
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// Shells supported by the completion command.
+var shells = []string{"bash", "zsh", "fish"}
+
+// NewCmdCompletion returns a command to generate shell completion scripts.
+func NewCmdCompletion(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "completion SHELL",
+		Short: "Generate the completion script for a shell",
+		Long: `Generate the completion script for bash, zsh or fish.
+
+Besides commands and flags, the script completes projects, regions and the
+names of resources. Names are listed with the Cloud Run API and cached for a
+couple of minutes in the user cache directory.`,
+		Example: `  # Bash, requires the bash-completion package
+  source <(run completion bash)
+
+  # Zsh
+  run completion zsh > "${fpath[1]}/_run"
+
+  # Fish
+  run completion fish > ~/.config/fish/completions/run.fish`,
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: shells,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			}
+			return fmt.Errorf("unsupported shell %q", args[0])
+		},
+	}
+
+	return
+}
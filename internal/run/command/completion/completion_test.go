@@ -0,0 +1,51 @@
+package completion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func execute(t *testing.T, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	root := &cobra.Command{Use: "run"}
+	root.AddCommand(NewCmdCompletion(&bytes.Buffer{}, out, &bytes.Buffer{}))
+	root.SetArgs(append([]string{"completion"}, args...))
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestNewCmdCompletion(t *testing.T) {
+	cmd := NewCmdCompletion(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "completion SHELL", cmd.Use)
+	assert.Equal(t, []string{"bash", "zsh", "fish"}, cmd.ValidArgs)
+}
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		shell    string
+		expected string
+	}{
+		{"bash", "__run_get_completion_results"},
+		{"zsh", "#compdef run"},
+		{"fish", "complete -c run"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			out, err := execute(t, tt.shell)
+			assert.NoError(t, err)
+			assert.Contains(t, out, tt.expected)
+		})
+	}
+
+	_, err := execute(t, "powershell")
+	assert.Error(t, err)
+
+	_, err = execute(t)
+	assert.Error(t, err)
+}
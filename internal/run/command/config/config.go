@@ -0,0 +1,320 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	run_config "github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/spf13/cobra"
+)
+
+var contextHeaders = []string{"CURRENT", "NAME", "PROJECT", "REGION", "IMPERSONATE", "READ-ONLY"}
+
+var viewHeaders = []string{"ORIGIN", "KEY", "VALUE"}
+
+// Variables for dependency injection
+var (
+	loadConfig          = run_config.Load
+	loadEffectiveConfig = cmdutil.LoadConfig
+)
+
+// NewCmdConfig returns a command to manage the CLI configuration.
+func NewCmdConfig(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "config",
+		Short: "Manage the Run CLI configuration",
+		Long: `Manage the Run CLI configuration.
+
+A context is a named environment with a project, a region, an optional service
+account to impersonate and an optional read-only flag preventing any change.
+Using a context selects its project and region, in the CLI and in the TUI.
+
+The configuration is merged from the following sources, the first one winning:
+flags, the RUN_PROJECT and RUN_REGION variables, the file of RUN_CONFIG_FILE,
+the first .run.yaml file found walking up from the working directory, the user
+configuration file ($XDG_CONFIG_HOME/run/config.yaml or ~/.run.yaml) and
+/etc/run.yaml. Changes are written to RUN_CONFIG_FILE, or to the user file.`,
+	}
+
+	cmd.AddCommand(newCmdView(out))
+	cmd.AddCommand(newCmdGetContexts(out))
+	cmd.AddCommand(newCmdCurrentContext(out))
+	cmd.AddCommand(newCmdUseContext(out))
+	cmd.AddCommand(newCmdSetContext(out))
+	cmd.AddCommand(newCmdDeleteContext(out))
+
+	return
+}
+
+// newCmdView returns a command to print the merged configuration.
+func newCmdView(out io.Writer) *cobra.Command {
+	var output string
+	var showOrigin bool
+
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Print the merged configuration",
+		Example: `  run config view
+  run config view --show-origin`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.OutputYAML, cmdutil.OutputJSON); err != nil {
+				return err
+			}
+			cfg, err := loadEffectiveConfig()
+			if err != nil {
+				return err
+			}
+			if !showOrigin {
+				return cmdutil.Print(out, output, cfg, nil, nil)
+			}
+
+			var rows [][]string
+			add := func(key, value string) {
+				if value != "" {
+					rows = append(rows, []string{valueOrNone(cfg.Origin(key)), key, value})
+				}
+			}
+			add(run_config.KeyProject, cfg.Project)
+			add(run_config.KeyRegion, cfg.Region)
+			add(run_config.KeyCurrentContext, cfg.CurrentContext)
+			add(run_config.KeyRegions, strings.Join(cfg.Regions, ","))
+			if limits := cfg.Limits; limits != nil {
+				add(run_config.KeyParallelism, formatNonZero(float64(limits.Parallelism)))
+				add(run_config.KeyRetries, formatNonZero(float64(limits.Retries)))
+				add(run_config.KeyRateLimit, formatNonZero(limits.RateLimit))
+			}
+			for _, ctx := range cfg.Contexts {
+				add(run_config.ContextKey(ctx.Name), contextSummary(ctx))
+			}
+			for _, api := range slices.Sorted(maps.Keys(cfg.Endpoints)) {
+				add(run_config.EndpointKey(api), cfg.Endpoints[api])
+			}
+			cmdutil.PrintTable(out, viewHeaders, rows)
+			return nil
+		},
+	}
+
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputYAML, cmdutil.OutputYAML, cmdutil.OutputJSON)
+	cmd.Flags().BoolVar(&showOrigin, "show-origin", false, "Print where each value comes from, a file, a variable or a flag.")
+
+	return cmd
+}
+
+// newCmdGetContexts returns a command to list contexts.
+func newCmdGetContexts(out io.Writer) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the contexts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.ListOutputs...); err != nil {
+				return err
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			contexts := cfg.Contexts
+			if contexts == nil {
+				contexts = []run_config.Context{}
+			}
+			rows := make([][]string, 0, len(contexts))
+			for _, ctx := range contexts {
+				current := ""
+				if ctx.Name == cfg.CurrentContext {
+					current = "*"
+				}
+				rows = append(rows, []string{
+					current,
+					ctx.Name,
+					ctx.Project,
+					valueOrNone(ctx.Region),
+					valueOrNone(ctx.ImpersonateServiceAccount),
+					strconv.FormatBool(ctx.ReadOnly),
+				})
+			}
+			return cmdutil.Print(out, output, contexts, contextHeaders, rows)
+		},
+	}
+
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputTable, cmdutil.ListOutputs...)
+
+	return cmd
+}
+
+// newCmdCurrentContext returns a command to print the current context.
+func newCmdCurrentContext(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "current-context",
+		Short: "Print the name of the current context",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.CurrentContext == "" {
+				return fmt.Errorf("no current context, use 'run config use-context NAME'")
+			}
+			_, _ = fmt.Fprintln(out, cfg.CurrentContext)
+			return nil
+		},
+	}
+}
+
+// newCmdUseContext returns a command to switch to a context.
+func newCmdUseContext(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:               "use-context NAME",
+		Short:             "Switch to a context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContext,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := cfg.UseContext(args[0]); err != nil {
+				return err
+			}
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "Switched to context %q.\n", args[0])
+			return nil
+		},
+	}
+}
+
+// newCmdSetContext returns a command to create or update a context.
+func newCmdSetContext(out io.Writer) *cobra.Command {
+	var ctx run_config.Context
+
+	cmd := &cobra.Command{
+		Use:   "set-context NAME",
+		Short: "Create or update a context",
+		Example: `  run config set-context prod-eu --project my-prod --region europe-west1 --read-only
+  run config set-context staging-us --project my-staging --region us-central1 \
+    --impersonate-service-account deployer@my-staging.iam.gserviceaccount.com`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContext,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			ctx.Name = args[0]
+			existing, err := cfg.Context(ctx.Name)
+			if err == nil {
+				// Only the given flags change an existing context.
+				flags := cmd.Flags()
+				if !flags.Changed("project") {
+					ctx.Project = existing.Project
+				}
+				if !flags.Changed("region") {
+					ctx.Region = existing.Region
+				}
+				if !flags.Changed("impersonate-service-account") {
+					ctx.ImpersonateServiceAccount = existing.ImpersonateServiceAccount
+				}
+				if !flags.Changed("read-only") {
+					ctx.ReadOnly = existing.ReadOnly
+				}
+			}
+			if ctx.Project == "" {
+				return fmt.Errorf("the project of the context is required, use --project")
+			}
+
+			cfg.SetContext(ctx)
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			if existing != nil {
+				_, _ = fmt.Fprintf(out, "Context %q updated.\n", ctx.Name)
+			} else {
+				_, _ = fmt.Fprintf(out, "Context %q created.\n", ctx.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&ctx.Project, "project", "p", "", "Google Cloud project ID of the context.")
+	cmd.Flags().StringVarP(&ctx.Region, "region", "r", "", "Cloud Run region or 'all' of the context.")
+	cmd.Flags().StringVar(&ctx.ImpersonateServiceAccount, "impersonate-service-account", "", "Email of a service account to impersonate, empty to use your credentials.")
+	cmd.Flags().BoolVar(&ctx.ReadOnly, "read-only", false, "Prevent any change to the resources.")
+	_ = cmd.RegisterFlagCompletionFunc("project", cmdutil.CompleteProjects)
+	_ = cmd.RegisterFlagCompletionFunc("region", cmdutil.CompleteRegions)
+
+	return cmd
+}
+
+// newCmdDeleteContext returns a command to delete a context.
+func newCmdDeleteContext(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete-context NAME",
+		Short:             "Delete a context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContext,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := cfg.DeleteContext(args[0]); err != nil {
+				return err
+			}
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "Context %q deleted.\n", args[0])
+			return nil
+		},
+	}
+}
+
+func completeContext(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cmdutil.CompleteContexts(cmd, args, toComplete)
+}
+
+// contextSummary returns the settings of a context on one line.
+func contextSummary(ctx run_config.Context) string {
+	summary := "project=" + ctx.Project
+	if ctx.Region != "" {
+		summary += " region=" + ctx.Region
+	}
+	if ctx.ImpersonateServiceAccount != "" {
+		summary += " impersonate=" + ctx.ImpersonateServiceAccount
+	}
+	if ctx.ReadOnly {
+		summary += " read-only"
+	}
+	return summary
+}
+
+// formatNonZero formats a number, empty when zero.
+func formatNonZero(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func valueOrNone(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	run_config "github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func isolateConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(run_config.EnvConfigFile, "")
+	t.Setenv(run_config.EnvProject, "")
+	t.Setenv(run_config.EnvRegion, "")
+}
+
+func execute(t *testing.T, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := NewCmdConfig(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestNewCmdConfig(t *testing.T) {
+	cmd := NewCmdConfig(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "config", cmd.Use)
+	var names []string
+	for _, c := range cmd.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.ElementsMatch(t, []string{"view", "get-contexts", "current-context", "use-context", "set-context", "delete-context"}, names)
+}
+
+func TestContexts(t *testing.T) {
+	isolateConfig(t)
+
+	out, err := execute(t, "set-context", "prod-eu", "--project", "prod", "--region", "europe-west1", "--read-only")
+	assert.NoError(t, err)
+	assert.Equal(t, "Context \"prod-eu\" created.\n", out)
+
+	_, err = execute(t, "set-context", "staging-us", "-p", "staging", "-r", "us-central1", "--impersonate-service-account", "sa@staging.iam.gserviceaccount.com")
+	assert.NoError(t, err)
+
+	_, err = execute(t, "current-context")
+	assert.EqualError(t, err, "no current context, use 'run config use-context NAME'")
+
+	out, err = execute(t, "use-context", "prod-eu")
+	assert.NoError(t, err)
+	assert.Equal(t, "Switched to context \"prod-eu\".\n", out)
+
+	out, err = execute(t, "current-context")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod-eu\n", out)
+
+	cfg, err := run_config.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", cfg.Project)
+	assert.Equal(t, "europe-west1", cfg.Region)
+
+	out, err = execute(t, "get-contexts")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "CURRENT   NAME")
+	assert.Regexp(t, `\*\s+prod-eu\s+prod\s+europe-west1\s+-\s+true`, out)
+	assert.Regexp(t, `staging-us\s+staging\s+us-central1\s+sa@staging.iam.gserviceaccount.com\s+false`, out)
+
+	// Only the given flags change an existing context
+	out, err = execute(t, "set-context", "prod-eu", "--region", "europe-west4")
+	assert.NoError(t, err)
+	assert.Equal(t, "Context \"prod-eu\" updated.\n", out)
+
+	cfg, err = run_config.Load()
+	assert.NoError(t, err)
+	ctx, err := cfg.Context("prod-eu")
+	assert.NoError(t, err)
+	assert.Equal(t, run_config.Context{Name: "prod-eu", Project: "prod", Region: "europe-west4", ReadOnly: true}, *ctx)
+	assert.Equal(t, "europe-west4", cfg.Region)
+
+	out, err = execute(t, "delete-context", "prod-eu")
+	assert.NoError(t, err)
+	assert.Equal(t, "Context \"prod-eu\" deleted.\n", out)
+
+	out, err = execute(t, "get-contexts", "-o", "jsonpath={[*].name}")
+	assert.NoError(t, err)
+	assert.Equal(t, "staging-us", out)
+
+	_, err = execute(t, "current-context")
+	assert.Error(t, err)
+}
+
+func TestContexts_Errors(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := execute(t, "set-context", "prod-eu")
+	assert.EqualError(t, err, "the project of the context is required, use --project")
+
+	_, err = execute(t, "use-context", "unknown")
+	assert.EqualError(t, err, `context "unknown" not found`)
+
+	_, err = execute(t, "delete-context", "unknown")
+	assert.EqualError(t, err, `context "unknown" not found`)
+
+	_, err = execute(t, "get-contexts", "-o", "xml")
+	assert.Error(t, err)
+
+	origLoad := loadConfig
+	defer func() { loadConfig = origLoad }()
+	loadConfig = func() (*run_config.Config, error) { return nil, assert.AnError }
+
+	for _, args := range [][]string{{"get-contexts"}, {"current-context"}, {"use-context", "a"}, {"set-context", "a"}, {"delete-context", "a"}} {
+		_, err = execute(t, args...)
+		assert.ErrorIs(t, err, assert.AnError, args[0])
+	}
+}
+
+func TestGetContexts_Empty(t *testing.T) {
+	isolateConfig(t)
+
+	out, err := execute(t, "get-contexts", "-o", "json")
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", out)
+}
+
+func TestView(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := execute(t, "set-context", "prod-eu", "--project", "prod", "--region", "europe-west1", "--read-only")
+	assert.NoError(t, err)
+	_, err = execute(t, "use-context", "prod-eu")
+	assert.NoError(t, err)
+
+	out, err := execute(t, "view")
+	assert.NoError(t, err)
+	assert.Equal(t, `project: prod
+region: europe-west1
+currentContext: prod-eu
+contexts:
+  - name: prod-eu
+    project: prod
+    region: europe-west1
+    readOnly: true
+`, out)
+
+	out, err = execute(t, "view", "-o", "json")
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"currentContext":"prod-eu"`)
+
+	t.Setenv(run_config.EnvRegion, "us-east1")
+	path, err := run_config.GetConfigPath()
+	assert.NoError(t, err)
+
+	out, err = execute(t, "view", "--show-origin")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "ORIGIN")
+	assert.Regexp(t, `file:`+path+`\s+project\s+prod\n`, out)
+	assert.Regexp(t, `env:RUN_REGION\s+region\s+us-east1\n`, out)
+	assert.Regexp(t, `file:`+path+`\s+contexts.prod-eu\s+project=prod region=europe-west1 read-only\n`, out)
+
+	_, err = execute(t, "view", "-o", "table")
+	assert.Error(t, err)
+
+	origLoad := loadEffectiveConfig
+	defer func() { loadEffectiveConfig = origLoad }()
+	loadEffectiveConfig = func() (*run_config.Config, error) { return nil, assert.AnError }
+	_, err = execute(t, "view")
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,273 @@
+package describe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	api_domainmapping "github.com/JulienBreux/run-cli/internal/run/api/domainmapping"
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_execution "github.com/JulienBreux/run-cli/internal/run/api/job/execution"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	api_revision "github.com/JulienBreux/run-cli/internal/run/api/service/revision"
+	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	"github.com/JulienBreux/run-cli/pkg/format"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// Variables for dependency injection
+var (
+	getServiceFunc          = api_service.Get
+	getServiceRawFunc       = api_service.GetRaw
+	getRevisionFunc         = api_revision.Get
+	getRevisionRawFunc      = api_revision.GetRaw
+	getJobFunc              = api_job.Get
+	getJobRawFunc           = api_job.GetRaw
+	getExecutionFunc        = api_execution.Get
+	getExecutionRawFunc     = api_execution.GetRaw
+	getWorkerPoolFunc       = api_workerpool.Get
+	getWorkerPoolRawFunc    = api_workerpool.GetRaw
+	getDomainMappingFunc    = api_domainmapping.Get
+	getDomainMappingRawFunc = api_domainmapping.GetRaw
+)
+
+// getter returns a resource by name, parent is the service of a revision or the job of an execution.
+type getter func(ctx context.Context, project, region, parent, name string) (any, error)
+
+// kind represents a describable kind of resource.
+type kind struct {
+	name    string
+	aliases []string
+	// parent is the flag naming the parent resource, if any.
+	parent string
+	get    getter
+	getRaw getter
+}
+
+// outputs are the outputs supported by the describe command.
+var outputs = []string{cmdutil.OutputYAML, cmdutil.OutputJSON, cmdutil.OutputGoTemplate, cmdutil.OutputJSONPath}
+
+var kinds = []kind{
+	{
+		name:    "service",
+		aliases: []string{"services", "svc"},
+		get: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getServiceFunc(ctx, project, region, name))
+		},
+		getRaw: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getServiceRawFunc(ctx, project, region, name))
+		},
+	},
+	{
+		name:    "revision",
+		aliases: []string{"revisions", "rev"},
+		parent:  "service",
+		get: func(ctx context.Context, project, region, service, name string) (any, error) {
+			return nilIfError(getRevisionFunc(ctx, project, region, service, name))
+		},
+		getRaw: func(ctx context.Context, project, region, service, name string) (any, error) {
+			return nilIfError(getRevisionRawFunc(ctx, project, region, service, name))
+		},
+	},
+	{
+		name:    "job",
+		aliases: []string{"jobs"},
+		get: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getJobFunc(ctx, project, region, name))
+		},
+		getRaw: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getJobRawFunc(ctx, project, region, name))
+		},
+	},
+	{
+		name:    "execution",
+		aliases: []string{"executions", "exec"},
+		parent:  "job",
+		get: func(ctx context.Context, project, region, job, name string) (any, error) {
+			return nilIfError(getExecutionFunc(ctx, project, region, job, name))
+		},
+		getRaw: func(ctx context.Context, project, region, job, name string) (any, error) {
+			return nilIfError(getExecutionRawFunc(ctx, project, region, job, name))
+		},
+	},
+	{
+		name:    "workerpool",
+		aliases: []string{"workerpools", "wp"},
+		get: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getWorkerPoolFunc(ctx, project, region, name))
+		},
+		getRaw: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getWorkerPoolRawFunc(ctx, project, region, name))
+		},
+	},
+	{
+		name:    "domainmapping",
+		aliases: []string{"domainmappings", "dm"},
+		get: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getDomainMappingFunc(ctx, project, region, name))
+		},
+		getRaw: func(ctx context.Context, project, region, _, name string) (any, error) {
+			return nilIfError(getDomainMappingRawFunc(ctx, project, region, name))
+		},
+	},
+}
+
+// NewCmdDescribe returns a command to describe a resource.
+func NewCmdDescribe(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	var (
+		scope     cmdutil.Scope
+		output    string
+		raw       bool
+		service   string
+		job       string
+		kindNames []string
+	)
+
+	for _, k := range kinds {
+		kindNames = append(kindNames, k.name)
+	}
+
+	cmd = &cobra.Command{
+		Use:   "describe KIND NAME",
+		Short: "Describe a Cloud Run resource",
+		Long: fmt.Sprintf(`Describe a Cloud Run resource, printing the same document as the describe view of the TUI.
+
+KIND is one of %s.
+With --raw, the resource is printed as returned by the Cloud Run API.`, strings.Join(kindNames, ", ")),
+		Example: `  run describe service my-service --region europe-west1
+  run describe revision my-service-00002-abc --service my-service -o json
+  run describe execution my-job-x7k2p --job my-job --raw`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: cmdutil.CompleteKindAndName(kindNames, func(arg string) string {
+			k, _ := lookupKind(arg)
+			return k.name
+		}),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, outputs...); err != nil {
+				return err
+			}
+
+			k, err := lookupKind(args[0])
+			if err != nil {
+				return err
+			}
+
+			parent := ""
+			switch k.parent {
+			case "service":
+				parent = service
+			case "job":
+				parent = job
+			}
+			if k.parent != "" && parent == "" && !strings.HasPrefix(args[1], "projects/") {
+				return fmt.Errorf("the %s of the %s is required, use --%s", k.parent, k.name, k.parent)
+			}
+
+			current, err := cmdutil.ResolveScope(scope)
+			if err != nil {
+				return err
+			}
+			if current.Region == api_region.ALL {
+				return fmt.Errorf("a region is required to describe a %s, use --region", k.name)
+			}
+
+			get := k.get
+			if raw {
+				get = k.getRaw
+			}
+			resource, err := get(cmd.Context(), current.Project, current.Region, parent, args[1])
+			if err != nil {
+				return err
+			}
+
+			b, err := marshal(resource, output)
+			if err != nil {
+				return err
+			}
+			_, err = out.Write(b)
+			return err
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputYAML, outputs...)
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the resource as returned by the Cloud Run API.")
+	cmd.Flags().StringVar(&service, "service", "", "Service of the revision.")
+	cmd.Flags().StringVar(&job, "job", "", "Job of the execution.")
+	_ = cmd.RegisterFlagCompletionFunc("service", cmdutil.CompleteNames(cmdutil.KindService))
+	_ = cmd.RegisterFlagCompletionFunc("job", cmdutil.CompleteNames(cmdutil.KindJob))
+
+	return
+}
+
+// lookupKind returns the kind matching a name or an alias.
+func lookupKind(name string) (kind, error) {
+	var names []string
+	for _, k := range kinds {
+		if k.name == name {
+			return k, nil
+		}
+		for _, a := range k.aliases {
+			if a == name {
+				return k, nil
+			}
+		}
+		names = append(names, k.name)
+	}
+	return kind{}, fmt.Errorf("unsupported kind %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// marshal returns the YAML or JSON document of a resource, or the document applied to a template.
+// API protos are marshaled with protojson to keep their field names.
+func marshal(resource any, output string) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if m, ok := resource.(proto.Message); ok {
+		b, err = protojson.Marshal(m)
+	} else {
+		b, err = json.Marshal(resource)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal resource: %w", err)
+	}
+
+	if output == cmdutil.OutputYAML {
+		return yaml.JSONToYAML(b)
+	}
+	if output != cmdutil.OutputJSON {
+		p, err := format.NewPrinter(output)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := p.Print(&buf, json.RawMessage(b), nil, nil); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, b, "", "  "); err != nil {
+		return nil, err
+	}
+	indented.WriteString("\n")
+	return indented.Bytes(), nil
+}
+
+// nilIfError drops typed nil values so that callers can check the error alone.
+func nilIfError[T any](v *T, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,172 @@
+package describe
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/run/v1"
+)
+
+func isolateConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(tmpDir, "gcloud"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gcloud"), 0755))
+}
+
+func execute(t *testing.T, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := NewCmdDescribe(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestNewCmdDescribe(t *testing.T) {
+	cmd := NewCmdDescribe(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "describe KIND NAME", cmd.Use)
+
+	kinds, _ := cmd.ValidArgsFunction(cmd, nil, "")
+	assert.Equal(t, []string{"service", "revision", "job", "execution", "workerpool", "domainmapping"}, kinds)
+}
+
+func TestDescribe_Service(t *testing.T) {
+	isolateConfig(t)
+
+	origGet, origGetRaw := getServiceFunc, getServiceRawFunc
+	defer func() { getServiceFunc, getServiceRawFunc = origGet, origGetRaw }()
+
+	var gotProject, gotRegion, gotName string
+	getServiceFunc = func(ctx context.Context, project, region, name string) (*model_service.Service, error) {
+		gotProject, gotRegion, gotName = project, region, name
+		return &model_service.Service{Name: name, URI: "https://s1.run.app"}, nil
+	}
+	getServiceRawFunc = func(ctx context.Context, project, region, name string) (*runpb.Service, error) {
+		return &runpb.Service{Name: "projects/p/locations/r/services/" + name, LatestReadyRevision: "s1-00001"}, nil
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		out, err := execute(t, "svc", "s1", "-p", "p", "-r", "us-central1")
+		assert.NoError(t, err)
+		assert.Equal(t, "p", gotProject)
+		assert.Equal(t, "us-central1", gotRegion)
+		assert.Equal(t, "s1", gotName)
+		assert.Contains(t, out, "name: s1\n")
+		assert.Contains(t, out, "uri: https://s1.run.app\n")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := execute(t, "service", "s1", "-p", "p", "-r", "us-central1", "-o", "json")
+		assert.NoError(t, err)
+		assert.Contains(t, out, "{\n  \"name\": \"s1\",\n")
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		out, err := execute(t, "service", "s1", "-p", "p", "-r", "us-central1", "--raw")
+		assert.NoError(t, err)
+		assert.Equal(t, "latestReadyRevision: s1-00001\nname: projects/p/locations/r/services/s1\n", out)
+	})
+
+	t.Run("Raw JSON", func(t *testing.T) {
+		out, err := execute(t, "service", "s1", "-p", "p", "-r", "us-central1", "--raw", "-o", "json")
+		assert.NoError(t, err)
+		assert.Contains(t, out, `"latestReadyRevision": "s1-00001"`)
+	})
+
+	t.Run("JSONPath", func(t *testing.T) {
+		out, err := execute(t, "service", "s1", "-p", "p", "-r", "us-central1", "-o", "jsonpath={.uri}")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://s1.run.app", out)
+	})
+
+	t.Run("Raw Go template", func(t *testing.T) {
+		out, err := execute(t, "service", "s1", "-p", "p", "-r", "us-central1", "--raw", "-o", "go-template={{.latestReadyRevision}}")
+		assert.NoError(t, err)
+		assert.Equal(t, "s1-00001", out)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		getServiceFunc = func(ctx context.Context, project, region, name string) (*model_service.Service, error) {
+			return nil, assert.AnError
+		}
+		_, err := execute(t, "service", "s1", "-p", "p", "-r", "us-central1")
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestDescribe_Execution(t *testing.T) {
+	isolateConfig(t)
+
+	origGet := getExecutionFunc
+	defer func() { getExecutionFunc = origGet }()
+
+	var gotJob string
+	getExecutionFunc = func(ctx context.Context, project, region, job, name string) (*model_execution.Execution, error) {
+		gotJob = job
+		return &model_execution.Execution{Name: name, TaskCount: 2}, nil
+	}
+
+	out, err := execute(t, "execution", "e1", "--job", "j1", "-p", "p", "-r", "us-central1")
+	assert.NoError(t, err)
+	assert.Equal(t, "j1", gotJob)
+	assert.Contains(t, out, "taskCount: 2")
+
+	_, err = execute(t, "execution", "e1", "-p", "p", "-r", "us-central1")
+	assert.EqualError(t, err, "the job of the execution is required, use --job")
+}
+
+func TestDescribe_DomainMappingRaw(t *testing.T) {
+	isolateConfig(t)
+
+	origGetRaw := getDomainMappingRawFunc
+	defer func() { getDomainMappingRawFunc = origGetRaw }()
+
+	getDomainMappingRawFunc = func(ctx context.Context, project, region, name string) (*run.DomainMapping, error) {
+		return &run.DomainMapping{Kind: "DomainMapping", Metadata: &run.ObjectMeta{Name: name}}, nil
+	}
+
+	out, err := execute(t, "dm", "example.com", "-p", "p", "-r", "us-central1", "--raw")
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: DomainMapping\nmetadata:\n  name: example.com\n", out)
+}
+
+func TestDescribe_Errors(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := execute(t, "project", "p1", "-p", "p", "-r", "us-central1")
+	assert.ErrorContains(t, err, `unsupported kind "project"`)
+
+	_, err = execute(t, "service", "s1", "-p", "p", "-r", "all")
+	assert.EqualError(t, err, "a region is required to describe a service, use --region")
+
+	_, err = execute(t, "service", "s1", "-p", "p", "-r", "us-central1", "-o", "table")
+	assert.Error(t, err)
+}
+
+func complete(t *testing.T, args ...string) string {
+	out := &bytes.Buffer{}
+	cmd := NewCmdDescribe(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+	cmd.SetOut(out)
+	cmd.SetArgs(append([]string{"__complete"}, args...))
+	assert.NoError(t, cmd.Execute())
+	return out.String()
+}
+
+func TestDescribe_Completion(t *testing.T) {
+	isolateConfig(t)
+
+	assert.Equal(t, "execution\n:4\n", complete(t, "exec"))
+
+	// Executions are only completed once the job is known
+	assert.Equal(t, ":4\n", complete(t, "execution", "-p", "p", "-r", "us-central1", ""))
+}
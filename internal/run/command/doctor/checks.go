@@ -0,0 +1,310 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	api_project "github.com/JulienBreux/run-cli/internal/run/api/project"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	"github.com/JulienBreux/run-cli/internal/run/auth"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Status represents the result of a check.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// Check represents the result of a diagnostic, with a fix when it did not pass.
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+// Report represents the results of all diagnostics.
+type Report struct {
+	Checks []Check `json:"checks"`
+	// Failed is the number of failed checks.
+	Failed int `json:"failed"`
+}
+
+// defaultRegion is the region in which the Run API is checked when all regions are selected.
+const defaultRegion = "us-central1"
+
+// logsWindow is how far back logs are read to check the Logging API.
+const logsWindow = time.Hour
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// Variables for dependency injection
+var (
+	findDefaultCredentials = func(ctx context.Context, scopes ...string) (*credentials, error) {
+		creds, err := client.FindDefaultCredentials(ctx, scopes...)
+		if err != nil {
+			return nil, err
+		}
+		return &credentials{JSON: creds.JSON, token: func() error {
+			_, err := creds.TokenSource.Token()
+			return err
+		}}, nil
+	}
+	getInfo          = auth.GetInfo
+	loadConfig       = cmdutil.LoadConfig
+	getProjectFunc   = api_project.Get
+	listServicesFunc = api_service.List
+	tailLogsFunc     = api_log.Tail
+	now              = time.Now
+)
+
+// credentials represents application default credentials.
+type credentials struct {
+	// JSON is the content of the credentials file, empty for the metadata server.
+	JSON []byte
+	// token fetches a token, checking that the credentials are still valid.
+	token func() error
+}
+
+// diagnose runs all checks in order. The API checks are skipped without credentials or project.
+func diagnose(ctx context.Context, scope cmdutil.Scope) Report {
+	var r Report
+	add := func(c Check) {
+		if c.Status == StatusFail {
+			r.Failed++
+		}
+		r.Checks = append(r.Checks, c)
+	}
+
+	creds := checkCredentials(ctx)
+	add(creds)
+	gcloud, gcloudInfo := checkGcloud()
+	add(gcloud)
+	project, projectID, region := checkProject(scope, gcloudInfo.Project, gcloudInfo.Region)
+	add(project)
+
+	apis := []struct {
+		name  string
+		check func(ctx context.Context, project, region string) Check
+	}{
+		{"Resource Manager API", checkResourceManager},
+		{"Run API", checkRun},
+		{"Logging API", checkLogging},
+	}
+	for _, api := range apis {
+		switch {
+		case creds.Status == StatusFail:
+			add(Check{Name: api.name, Status: StatusSkip, Message: "Skipped, no valid credentials."})
+		case projectID == "":
+			add(Check{Name: api.name, Status: StatusSkip, Message: "Skipped, no project selected."})
+		default:
+			c := api.check(ctx, projectID, region)
+			c.Name = api.name
+			add(c)
+		}
+	}
+	return r
+}
+
+// checkCredentials checks that application default credentials are found and valid.
+func checkCredentials(ctx context.Context) Check {
+	c := Check{Name: "Credentials"}
+
+	creds, err := findDefaultCredentials(ctx, cloudPlatformScope)
+	if err != nil {
+		c.Status = StatusFail
+		c.Message = fmt.Sprintf("No application default credentials: %s", firstLine(err))
+		c.Fix = "Run 'gcloud auth application-default login', or set GOOGLE_APPLICATION_CREDENTIALS to a service account key file."
+		return c
+	}
+
+	kind := "metadata server"
+	var file struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(creds.JSON, &file) == nil && file.Type != "" {
+		kind = file.Type
+	}
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		kind += " from " + path
+	}
+
+	if err := creds.token(); err != nil {
+		c.Status = StatusFail
+		c.Message = fmt.Sprintf("The %s credentials are invalid: %s", kind, firstLine(err))
+		c.Fix = "Run 'gcloud auth application-default login' again, the credentials may be expired or revoked."
+		return c
+	}
+
+	c.Status = StatusPass
+	c.Message = fmt.Sprintf("Found %s credentials.", kind)
+	return c
+}
+
+// checkGcloud checks the active gcloud configuration, which is optional.
+func checkGcloud() (Check, info.Info) {
+	c := Check{Name: "gcloud configuration"}
+
+	i, err := getInfo()
+	if err != nil {
+		c.Status = StatusWarn
+		c.Message = fmt.Sprintf("No gcloud configuration: %s", firstLine(err))
+		c.Fix = "Run 'gcloud init', or select a project with --project or 'run config set-context'."
+		return c, info.Info{}
+	}
+
+	if i.User == "" {
+		c.Status = StatusWarn
+		c.Message = "The gcloud configuration has no account."
+		c.Fix = "Run 'gcloud auth login'."
+		return c, i
+	}
+
+	c.Status = StatusPass
+	c.Message = fmt.Sprintf("Account %s, project %s, region %s.", i.User, valueOrNone(i.Project), valueOrNone(i.Region))
+	return c, i
+}
+
+// checkProject checks that a project is selected, returning it with the region to check the APIs in.
+// It uses the same precedence as cmdutil.ResolveScope.
+func checkProject(scope cmdutil.Scope, gcloudProject, gcloudRegion string) (Check, string, string) {
+	c := Check{Name: "Project"}
+	project, origin := gcloudProject, "gcloud configuration"
+	region := gcloudRegion
+
+	cfg, err := loadConfig()
+	if err != nil {
+		c.Status = StatusFail
+		c.Message = fmt.Sprintf("Invalid configuration: %s", firstLine(err))
+		c.Fix = "Fix or remove the files listed by 'run config view --show-origin'."
+		return c, "", ""
+	}
+	if cfg.Project != "" {
+		project, origin = cfg.Project, cfg.Origin(config.KeyProject)
+	}
+	if cfg.Region != "" {
+		region = cfg.Region
+	}
+	if scope.Project != "" {
+		project, origin = scope.Project, "flag:--project"
+	}
+	if scope.Region != "" {
+		region = scope.Region
+	}
+
+	if project == "" {
+		c.Status = StatusFail
+		c.Message = "No project selected."
+		c.Fix = "Use --project, set RUN_PROJECT, run 'run config use-context NAME' or 'gcloud config set project PROJECT'."
+		return c, "", ""
+	}
+	if region == "" || region == api_region.ALL {
+		region = defaultRegion
+	}
+
+	c.Status = StatusPass
+	c.Message = fmt.Sprintf("Project %s", project)
+	if origin != "" {
+		c.Message += fmt.Sprintf(", from %s", origin)
+	}
+	c.Message += "."
+	return c, project, region
+}
+
+func checkResourceManager(ctx context.Context, project, region string) Check {
+	p, err := getProjectFunc(ctx, project)
+	if err != nil {
+		return apiFailure(err, project, "cloudresourcemanager.googleapis.com", "roles/browser")
+	}
+	return Check{Status: StatusPass, Message: fmt.Sprintf("Project %s (%d) is accessible.", p.Name, p.Number)}
+}
+
+func checkRun(ctx context.Context, project, region string) Check {
+	services, err := listServicesFunc(ctx, project, region)
+	var partial *api_region.PartialError
+	if errors.As(err, &partial) {
+		return Check{Status: StatusWarn, Message: fmt.Sprintf("Listed %d services, but %s.", len(services), partial)}
+	}
+	if err != nil {
+		return apiFailure(err, project, "run.googleapis.com", "roles/run.viewer")
+	}
+	return Check{Status: StatusPass, Message: fmt.Sprintf("Listed %d services in %s.", len(services), region)}
+}
+
+func checkLogging(ctx context.Context, project, region string) Check {
+	filter := fmt.Sprintf(`resource.type="cloud_run_revision" AND timestamp>="%s"`, now().Add(-logsWindow).UTC().Format(time.RFC3339))
+	entries := 0
+	err := tailLogsFunc(ctx, project, filter, 1, false, func(*logging.Entry) { entries++ })
+	if err != nil {
+		return apiFailure(err, project, "logging.googleapis.com", "roles/logging.viewer")
+	}
+	return Check{Status: StatusPass, Message: "Logs are readable."}
+}
+
+// apiFailure returns the failed check of an API call, with a fix matching its status code.
+func apiFailure(err error, project, service, role string) Check {
+	c := Check{Status: StatusFail, Message: firstLine(err)}
+
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		c.Message = "Unauthenticated: " + c.Message
+		c.Fix = "Run 'gcloud auth application-default login'."
+	case codes.PermissionDenied:
+		if serviceDisabled(err) {
+			c.Message = "The API is disabled: " + c.Message
+			c.Fix = fmt.Sprintf("Run 'gcloud services enable %s --project %s'.", service, project)
+			break
+		}
+		c.Message = "Permission denied: " + c.Message
+		c.Fix = fmt.Sprintf("Ask for the %s role, e.g. 'gcloud projects add-iam-policy-binding %s --member user:EMAIL --role %s'.", role, project, role)
+	case codes.NotFound:
+		c.Message = "Not found: " + c.Message
+		c.Fix = "Check the project ID, 'gcloud projects list' lists your projects."
+	case codes.Unavailable, codes.DeadlineExceeded:
+		c.Message = "Unreachable: " + c.Message
+		c.Fix = "Check your network connection and proxy settings."
+	}
+	return c
+}
+
+// serviceDisabled returns true if the error is due to a disabled API.
+func serviceDisabled(err error) bool {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Reason == "SERVICE_DISABLED" {
+			return true
+		}
+	}
+	return false
+}
+
+// firstLine returns the first line of an error, API errors can be long.
+func firstLine(err error) string {
+	line, _, _ := strings.Cut(err.Error(), "\n")
+	return line
+}
+
+func valueOrNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
@@ -0,0 +1,83 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// labels are the labels of the statuses in the text report.
+var labels = map[Status]string{
+	StatusPass: "[PASS]",
+	StatusWarn: "[WARN]",
+	StatusFail: "[FAIL]",
+	StatusSkip: "[SKIP]",
+}
+
+// NewCmdDoctor returns a command to diagnose the setup.
+func NewCmdDoctor(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	var scope cmdutil.Scope
+	var output string
+
+	cmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose credentials, configuration and API access",
+		Long: `Diagnose credentials, configuration and API access.
+
+Checks the application default credentials, the gcloud configuration, the
+selected project, and that the Resource Manager, Run and Logging APIs are
+reachable with the needed permissions. Each failed check comes with a fix.
+
+The command exits with code 1 when a check fails.`,
+		Example: `  run doctor
+  run doctor --project my-project -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.OutputText, cmdutil.OutputJSON); err != nil {
+				return err
+			}
+
+			report := diagnose(cmd.Context(), scope)
+			if output == cmdutil.OutputJSON {
+				if err := cmdutil.Print(out, output, report, nil, nil); err != nil {
+					return err
+				}
+			} else {
+				printText(out, report)
+			}
+
+			if report.Failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", report.Failed, len(report.Checks))
+			}
+			return nil
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputText, cmdutil.OutputText, cmdutil.OutputJSON)
+
+	return
+}
+
+// printText prints the report with one line per check, followed by its fix.
+func printText(w io.Writer, r Report) {
+	width := 0
+	for _, c := range r.Checks {
+		width = max(width, len(c.Name))
+	}
+
+	counts := map[Status]int{}
+	for _, c := range r.Checks {
+		counts[c.Status]++
+		_, _ = fmt.Fprintf(w, "%s %-*s  %s\n", labels[c.Status], width, c.Name, c.Message)
+		if c.Fix != "" && c.Status != StatusPass {
+			_, _ = fmt.Fprintf(w, "       %s  Fix: %s\n", strings.Repeat(" ", width), c.Fix)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%d passed, %d warnings, %d failed, %d skipped.\n",
+		counts[StatusPass], counts[StatusWarn], counts[StatusFail], counts[StatusSkip])
+}
@@ -0,0 +1,232 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	model_project "github.com/JulienBreux/run-cli/internal/run/model/common/project"
+	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockHealthy mocks a working setup, returning the config to tweak.
+func mockHealthy(t *testing.T) *config.Config {
+	origCreds, origInfo, origLoad := findDefaultCredentials, getInfo, loadConfig
+	origProject, origServices, origLogs, origNow := getProjectFunc, listServicesFunc, tailLogsFunc, now
+	t.Cleanup(func() {
+		findDefaultCredentials, getInfo, loadConfig = origCreds, origInfo, origLoad
+		getProjectFunc, listServicesFunc, tailLogsFunc, now = origProject, origServices, origLogs, origNow
+	})
+
+	cfg := &config.Config{}
+	findDefaultCredentials = func(ctx context.Context, scopes ...string) (*credentials, error) {
+		return &credentials{JSON: []byte(`{"type":"authorized_user"}`), token: func() error { return nil }}, nil
+	}
+	getInfo = func() (info.Info, error) {
+		return info.Info{User: "me@example.com", Project: "gcloud-p", Region: "all"}, nil
+	}
+	loadConfig = func() (*config.Config, error) { return cfg, nil }
+	getProjectFunc = func(ctx context.Context, project string) (model_project.Project, error) {
+		return model_project.Project{Name: project, Number: 42}, nil
+	}
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		return []model_service.Service{{Name: "s1"}}, nil
+	}
+	tailLogsFunc = func(ctx context.Context, projectID, filter string, limit int, follow bool, fn func(*logging.Entry)) error {
+		return nil
+	}
+	now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+	return cfg
+}
+
+func execute(t *testing.T, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := NewCmdDoctor(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestNewCmdDoctor(t *testing.T) {
+	cmd := NewCmdDoctor(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "doctor", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("project"))
+	assert.NotNil(t, cmd.Flags().Lookup("output"))
+}
+
+func TestDoctor(t *testing.T) {
+	mockHealthy(t)
+
+	var gotRegion, gotFilter string
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		gotRegion = region
+		return nil, nil
+	}
+	tailLogsFunc = func(ctx context.Context, projectID, filter string, limit int, follow bool, fn func(*logging.Entry)) error {
+		gotFilter = filter
+		assert.Equal(t, 1, limit)
+		assert.False(t, follow)
+		return nil
+	}
+
+	out, err := execute(t)
+	assert.NoError(t, err)
+	assert.Equal(t, `[PASS] Credentials           Found authorized_user credentials.
+[PASS] gcloud configuration  Account me@example.com, project gcloud-p, region all.
+[PASS] Project               Project gcloud-p, from gcloud configuration.
+[PASS] Resource Manager API  Project gcloud-p (42) is accessible.
+[PASS] Run API               Listed 0 services in us-central1.
+[PASS] Logging API           Logs are readable.
+
+6 passed, 0 warnings, 0 failed, 0 skipped.
+`, out)
+	assert.Equal(t, defaultRegion, gotRegion)
+	assert.Equal(t, `resource.type="cloud_run_revision" AND timestamp>="2026-01-01T11:00:00Z"`, gotFilter)
+}
+
+func TestDoctor_JSON(t *testing.T) {
+	cfg := mockHealthy(t)
+	cfg.Project = "cfg-p"
+	cfg.SetOrigin(config.KeyProject, "env:RUN_PROJECT")
+	getInfo = func() (info.Info, error) { return info.Info{}, errors.New("open config_default: no such file") }
+
+	out, err := execute(t, "-o", "json", "--region", "europe-west1")
+	assert.NoError(t, err)
+
+	var report Report
+	assert.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Len(t, report.Checks, 6)
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, Check{
+		Name:    "gcloud configuration",
+		Status:  StatusWarn,
+		Message: "No gcloud configuration: open config_default: no such file",
+		Fix:     "Run 'gcloud init', or select a project with --project or 'run config set-context'.",
+	}, report.Checks[1])
+	assert.Equal(t, "Project cfg-p, from env:RUN_PROJECT.", report.Checks[2].Message)
+	assert.Equal(t, "Listed 1 services in europe-west1.", report.Checks[4].Message)
+}
+
+func TestDoctor_Failures(t *testing.T) {
+	t.Run("No credentials", func(t *testing.T) {
+		mockHealthy(t)
+		findDefaultCredentials = func(ctx context.Context, scopes ...string) (*credentials, error) {
+			return nil, errors.New("google: could not find default credentials")
+		}
+
+		out, err := execute(t)
+		assert.EqualError(t, err, "1 of 6 checks failed")
+		assert.Contains(t, out, "[FAIL] Credentials           No application default credentials: google: could not find default credentials\n")
+		assert.Contains(t, out, "Fix: Run 'gcloud auth application-default login'")
+		assert.Contains(t, out, "[SKIP] Run API               Skipped, no valid credentials.\n")
+		assert.Contains(t, out, "2 passed, 0 warnings, 1 failed, 3 skipped.")
+	})
+
+	t.Run("Expired credentials", func(t *testing.T) {
+		mockHealthy(t)
+		findDefaultCredentials = func(ctx context.Context, scopes ...string) (*credentials, error) {
+			return &credentials{token: func() error { return errors.New("oauth2: \"invalid_grant\"") }}, nil
+		}
+
+		out, err := execute(t)
+		assert.Error(t, err)
+		assert.Contains(t, out, "The metadata server credentials are invalid: oauth2: \"invalid_grant\"")
+	})
+
+	t.Run("No project", func(t *testing.T) {
+		mockHealthy(t)
+		getInfo = func() (info.Info, error) { return info.Info{User: "me@example.com"}, nil }
+
+		out, err := execute(t)
+		assert.EqualError(t, err, "1 of 6 checks failed")
+		assert.Contains(t, out, "[FAIL] Project               No project selected.")
+		assert.Contains(t, out, "[SKIP] Logging API           Skipped, no project selected.")
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		mockHealthy(t)
+		loadConfig = func() (*config.Config, error) { return nil, errors.New("cannot unmarshal config") }
+
+		out, err := execute(t)
+		assert.Error(t, err)
+		assert.Contains(t, out, "Invalid configuration: cannot unmarshal config")
+	})
+
+	t.Run("API errors", func(t *testing.T) {
+		mockHealthy(t)
+		disabled, _ := status.New(codes.PermissionDenied, "Cloud Run Admin API has not been used in project p before or it is disabled.").
+			WithDetails(&errdetails.ErrorInfo{Reason: "SERVICE_DISABLED"})
+		getProjectFunc = func(ctx context.Context, project string) (model_project.Project, error) {
+			return model_project.Project{}, fmt.Errorf("authentication failed: %w", status.Error(codes.PermissionDenied, "denied"))
+		}
+		listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+			return nil, disabled.Err()
+		}
+		tailLogsFunc = func(ctx context.Context, projectID, filter string, limit int, follow bool, fn func(*logging.Entry)) error {
+			return status.Error(codes.Unauthenticated, "token expired\nmore details")
+		}
+
+		out, err := execute(t, "-p", "p")
+		assert.EqualError(t, err, "3 of 6 checks failed")
+		assert.Contains(t, out, "Permission denied: authentication failed: rpc error: code = PermissionDenied desc = denied")
+		assert.Contains(t, out, "Fix: Ask for the roles/browser role, e.g. 'gcloud projects add-iam-policy-binding p --member user:EMAIL --role roles/browser'.")
+		assert.Contains(t, out, "Fix: Run 'gcloud services enable run.googleapis.com --project p'.")
+		assert.Contains(t, out, "Unauthenticated: rpc error: code = Unauthenticated desc = token expired\n")
+		assert.NotContains(t, out, "more details")
+	})
+
+	t.Run("Invalid output", func(t *testing.T) {
+		_, err := execute(t, "-o", "yaml")
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckRun_PartialRegions(t *testing.T) {
+	origServices := listServicesFunc
+	t.Cleanup(func() { listServicesFunc = origServices })
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		return []model_service.Service{{Name: "s1"}}, &api_region.PartialError{
+			Errors: map[string]error{"europe-west1": status.Error(codes.Unavailable, "x")},
+			Total:  2,
+		}
+	}
+
+	c := checkRun(context.Background(), "p", "all")
+	assert.Equal(t, StatusWarn, c.Status)
+	assert.Equal(t, "Listed 1 services, but 1 of 2 regions could not be listed: europe-west1.", c.Message)
+}
+
+func TestAPIFailure(t *testing.T) {
+	tests := []struct {
+		code    codes.Code
+		message string
+		fix     string
+	}{
+		{codes.NotFound, "Not found: rpc error: code = NotFound desc = x", "Check the project ID, 'gcloud projects list' lists your projects."},
+		{codes.Unavailable, "Unreachable: rpc error: code = Unavailable desc = x", "Check your network connection and proxy settings."},
+		{codes.Internal, "rpc error: code = Internal desc = x", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			c := apiFailure(status.Error(tt.code, "x"), "p", "run.googleapis.com", "roles/run.viewer")
+			assert.Equal(t, StatusFail, c.Status)
+			assert.Equal(t, tt.message, c.Message)
+			assert.Equal(t, tt.fix, c.Fix)
+		})
+	}
+}
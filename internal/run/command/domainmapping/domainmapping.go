@@ -0,0 +1,82 @@
+package domainmapping
+
+import (
+	"io"
+
+	api_domainmapping "github.com/JulienBreux/run-cli/internal/run/api/domainmapping"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	model_domainmapping "github.com/JulienBreux/run-cli/internal/run/model/domainmapping"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+var listHeaders = []string{"DOMAIN", "MAPPED TO", "REGION", "ADDED BY", "CREATED"}
+
+var listDomainMappingsFunc = api_domainmapping.List
+
+// NewCmdDomainMapping returns a command to manage domain mappings.
+func NewCmdDomainMapping(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "domainmappings",
+		Aliases: []string{"domainmapping", "dm"},
+		Short:   "Manage Cloud Run domain mappings",
+		Long:    "Manage Cloud Run domain mappings",
+	}
+
+	cmd.AddCommand(newCmdList(out, err))
+
+	return
+}
+
+// newCmdList returns a command to list domain mappings.
+func newCmdList(out, errOut io.Writer) *cobra.Command {
+	var (
+		scope  cmdutil.Scope
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Cloud Run domain mappings",
+		Long:  "List Cloud Run domain mappings of a project in a region or in all regions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.ListOutputs...); err != nil {
+				return err
+			}
+			current, err := cmdutil.ResolveScope(scope)
+			if err != nil {
+				return err
+			}
+
+			// The resources of the regions which could be listed are still printed.
+			domainMappings, listErr := listDomainMappingsFunc(cmd.Context(), current.Project, current.Region)
+			if err := cmdutil.IgnorePartial(listErr); err != nil {
+				return err
+			}
+			if domainMappings == nil {
+				domainMappings = []model_domainmapping.DomainMapping{}
+			}
+
+			rows := make([][]string, 0, len(domainMappings))
+			for _, dm := range domainMappings {
+				rows = append(rows, []string{
+					dm.Name,
+					dm.RouteName,
+					dm.Region,
+					dm.Creator,
+					humanize.Time(dm.CreateTime),
+				})
+			}
+			if err := cmdutil.Print(out, output, domainMappings, listHeaders, rows); err != nil {
+				return err
+			}
+			return cmdutil.WarnPartial(errOut, listErr)
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputTable, cmdutil.ListOutputs...)
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+package domainmapping
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	model_domainmapping "github.com/JulienBreux/run-cli/internal/run/model/domainmapping"
+	"github.com/stretchr/testify/assert"
+)
+
+func isolateConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(tmpDir, "gcloud"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gcloud"), 0755))
+}
+
+func TestNewCmdDomainMapping(t *testing.T) {
+	cmd := NewCmdDomainMapping(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "domainmappings", cmd.Use)
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestList(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listDomainMappingsFunc
+	defer func() { listDomainMappingsFunc = origList }()
+
+	listDomainMappingsFunc = func(ctx context.Context, project, region string) ([]model_domainmapping.DomainMapping, error) {
+		return []model_domainmapping.DomainMapping{
+			{Name: "example.com", RouteName: "s1", Region: "europe-west1"},
+		}, nil
+	}
+
+	out := &bytes.Buffer{}
+	cmd := NewCmdDomainMapping(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs([]string{"list", "-p", "p", "-o", "json"})
+
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), `"name":"example.com"`)
+	assert.Contains(t, out.String(), `"routeName":"s1"`)
+}
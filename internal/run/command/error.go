@@ -1,8 +1,11 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"io"
+
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
 )
 
 // PrintError prints error properly to the writer.
@@ -10,3 +13,15 @@ func PrintError(w io.Writer, err error) error {
 	_, printErr := fmt.Fprintf(w, "%s\n", err)
 	return printErr
 }
+
+// ExitCode returns the process exit code matching the error.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *cmdutil.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return cmdutil.ExitCodeError
+}
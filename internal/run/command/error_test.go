@@ -3,9 +3,11 @@ package command_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/JulienBreux/run-cli/internal/run/command"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,3 +20,11 @@ func TestPrintError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "something went wrong\n", w.String())
 }
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, command.ExitCode(nil))
+	assert.Equal(t, cmdutil.ExitCodeError, command.ExitCode(errors.New("boom")))
+
+	err := fmt.Errorf("wrapped: %w", &cmdutil.ExitError{Code: cmdutil.ExitCodeTimeout, Err: errors.New("timeout")})
+	assert.Equal(t, cmdutil.ExitCodeTimeout, command.ExitCode(err))
+}
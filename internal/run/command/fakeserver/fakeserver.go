@@ -0,0 +1,67 @@
+package fakeserver
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/JulienBreux/run-cli/internal/run/fake"
+	"github.com/spf13/cobra"
+)
+
+// Variables for dependency injection
+var listen = net.Listen
+
+// NewCmdFakeServer returns a command to serve a fake Cloud Run API.
+func NewCmdFakeServer(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	var (
+		address string
+		fixture string
+	)
+
+	cmd = &cobra.Command{
+		Use:   "fake-server",
+		Short: "Serve an in-memory Cloud Run API for offline development",
+		Long: `Serve an in-memory Cloud Run API for offline development.
+
+The server implements the Services, Revisions, Jobs, Executions and WorkerPools
+APIs without TLS nor authentication, seeded with the resources of a YAML fixture.
+Changes are kept in memory until the server is interrupted. Point the CLI at it
+with the --endpoint flag or the endpoints of the configuration.`,
+		Example: `  run fake-server --fixture docs/fake/fixture.yaml
+  run --endpoint run=http://localhost:8080 services list --project demo`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var f *fake.Fixture
+			if fixture != "" {
+				file, err := os.Open(fixture)
+				if err != nil {
+					return fmt.Errorf("failed to open fixture: %w", err)
+				}
+				defer func() { _ = file.Close() }()
+				if f, err = fake.LoadFixture(file); err != nil {
+					return err
+				}
+			}
+
+			lis, err := listen("tcp", address)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", address, err)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			_, _ = fmt.Fprintf(out, "Serving the fake Cloud Run API on %s, use --endpoint run=http://%s\n", lis.Addr(), lis.Addr())
+			return fake.New(f).Serve(ctx, lis)
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "localhost:8080", "Address to listen on.")
+	cmd.Flags().StringVarP(&fixture, "fixture", "f", "", "YAML or JSON file of the resources to serve.")
+	_ = cmd.MarkFlagFilename("fixture", "yaml", "yml", "json")
+
+	return
+}
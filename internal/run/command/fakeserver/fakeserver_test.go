@@ -0,0 +1,60 @@
+package fakeserver
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func execute(ctx context.Context, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := NewCmdFakeServer(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.ExecuteContext(ctx)
+	return out.String(), err
+}
+
+func TestNewCmdFakeServer(t *testing.T) {
+	cmd := NewCmdFakeServer(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Equal(t, "fake-server", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("address"))
+	assert.NotNil(t, cmd.Flags().Lookup("fixture"))
+}
+
+func TestFakeServer_Serve(t *testing.T) {
+	orig := listen
+	t.Cleanup(func() { listen = orig })
+	var addr string
+	listen = func(network, address string) (net.Listener, error) {
+		assert.Equal(t, "localhost:9090", address)
+		lis, err := net.Listen(network, "localhost:0")
+		if err == nil {
+			addr = lis.Addr().String()
+		}
+		return lis, err
+	}
+
+	// The server stops when the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	out, err := execute(ctx, "--address", "localhost:9090", "--fixture", "../../../../docs/fake/fixture.yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "use --endpoint run=http://"+addr)
+}
+
+func TestFakeServer_InvalidFixture(t *testing.T) {
+	_, err := execute(context.Background(), "--fixture", filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to open fixture")
+
+	path := filepath.Join(t.TempDir(), "fixture.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("services:\n  - {}\n"), 0o600))
+	_, err = execute(context.Background(), "--fixture", path)
+	assert.EqualError(t, err, "services[0]: missing name")
+}
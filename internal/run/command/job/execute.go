@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_execution "github.com/JulienBreux/run-cli/internal/run/api/job/execution"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	"github.com/spf13/cobra"
+)
+
+const (
+	conditionSucceeded = "CONDITION_SUCCEEDED"
+	conditionFailed    = "CONDITION_FAILED"
+)
+
+// Variables for dependency injection
+var (
+	startJobFunc     = api_job.Start
+	getExecutionFunc = api_execution.Get
+	pollInterval     = 5 * time.Second
+)
+
+// newCmdExecute returns a command to execute a job.
+func newCmdExecute(out io.Writer) *cobra.Command {
+	var (
+		scope   cmdutil.Scope
+		wait    bool
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:     "execute NAME",
+		Aliases: []string{"exec"},
+		Short:   "Execute a Cloud Run job",
+		Long: `Execute a Cloud Run job.
+
+With --wait, the command follows the execution until it completes and exits with
+code 2 if the execution failed or 3 if the timeout is reached.`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return cmdutil.CompleteNames(cmdutil.KindJob)(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, err := cmdutil.ResolveScope(scope)
+			if err != nil {
+				return err
+			}
+			if current.Region == api_region.ALL {
+				return fmt.Errorf("a region is required to execute a job, use --region")
+			}
+
+			ctx := cmd.Context()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			jobName := args[0]
+			executionName, err := startJobFunc(ctx, current.Project, current.Region, jobName)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "Execution %s started.\n", cmdutil.ShortName(executionName))
+
+			if !wait {
+				return nil
+			}
+
+			return waitExecution(ctx, out, current.Project, current.Region, jobName, executionName, timeout)
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "Wait for the execution to complete and print its progress.")
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Hour, "Maximum time to wait for the execution, 0 to wait forever.")
+
+	return cmd
+}
+
+// waitExecution polls the execution until it completes, fails or the context expires.
+func waitExecution(ctx context.Context, out io.Writer, project, region, jobName, executionName string, timeout time.Duration) error {
+	timeoutErr := &cmdutil.ExitError{
+		Code: cmdutil.ExitCodeTimeout,
+		Err:  fmt.Errorf("timed out after %s waiting for execution %s", timeout, cmdutil.ShortName(executionName)),
+	}
+
+	lastProgress := ""
+	for {
+		exec, err := getExecutionFunc(ctx, project, region, jobName, executionName)
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return timeoutErr
+			}
+			return err
+		}
+
+		if p := progress(exec); p != lastProgress {
+			_, _ = fmt.Fprintln(out, p)
+			lastProgress = p
+		}
+
+		if done, succeeded := result(exec); done {
+			if succeeded {
+				_, _ = fmt.Fprintf(out, "Execution %s succeeded.\n", cmdutil.ShortName(executionName))
+				return nil
+			}
+			message := "see logs for details"
+			if exec.TerminalCondition.Message != "" {
+				message = exec.TerminalCondition.Message
+			}
+			return &cmdutil.ExitError{
+				Code: cmdutil.ExitCodeFailed,
+				Err:  fmt.Errorf("execution %s failed: %s", cmdutil.ShortName(executionName), message),
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return timeoutErr
+			}
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// progress returns the task progress of an execution.
+func progress(exec *model_execution.Execution) string {
+	return fmt.Sprintf("Tasks: %d/%d succeeded, %d failed, %d running, %d cancelled, %d retried",
+		exec.SucceededCount, exec.TaskCount, exec.FailedCount, exec.RunningCount, exec.CancelledCount, exec.RetriedCount)
+}
+
+// result returns whether the execution is done and whether it succeeded.
+func result(exec *model_execution.Execution) (done, succeeded bool) {
+	if exec.TerminalCondition == nil {
+		return false, false
+	}
+	switch exec.TerminalCondition.State {
+	case conditionSucceeded:
+		return true, true
+	case conditionFailed:
+		return true, false
+	}
+	return false, false
+}
@@ -0,0 +1,124 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockExecution(t *testing.T, states ...*model_execution.Execution) *int {
+	origStart := startJobFunc
+	origGet := getExecutionFunc
+	origInterval := pollInterval
+	t.Cleanup(func() {
+		startJobFunc = origStart
+		getExecutionFunc = origGet
+		pollInterval = origInterval
+	})
+
+	pollInterval = time.Millisecond
+	startJobFunc = func(ctx context.Context, project, region, jobName string) (string, error) {
+		return "projects/" + project + "/locations/" + region + "/jobs/" + jobName + "/executions/exec-1", nil
+	}
+
+	calls := 0
+	getExecutionFunc = func(ctx context.Context, project, region, jobName, executionName string) (*model_execution.Execution, error) {
+		assert.Equal(t, "projects/p/locations/r/jobs/j1/executions/exec-1", executionName)
+		state := states[min(calls, len(states)-1)]
+		calls++
+		return state, nil
+	}
+	return &calls
+}
+
+func executeCmd(out *bytes.Buffer, args ...string) error {
+	cmd := NewCmdJob(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs(append([]string{"execute"}, args...))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func TestExecute_NoWait(t *testing.T) {
+	isolateConfig(t)
+	calls := mockExecution(t, &model_execution.Execution{})
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, executeCmd(out, "j1", "-p", "p", "-r", "r"))
+	assert.Equal(t, "Execution exec-1 started.\n", out.String())
+	assert.Equal(t, 0, *calls)
+}
+
+func TestExecute_WaitSucceeded(t *testing.T) {
+	isolateConfig(t)
+	mockExecution(t,
+		&model_execution.Execution{TaskCount: 2, RunningCount: 2},
+		&model_execution.Execution{TaskCount: 2, RunningCount: 2},
+		&model_execution.Execution{TaskCount: 2, SucceededCount: 1, RunningCount: 1},
+		&model_execution.Execution{TaskCount: 2, SucceededCount: 2, TerminalCondition: &condition.Condition{State: conditionSucceeded}},
+	)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, executeCmd(out, "j1", "-p", "p", "-r", "r", "--wait"))
+	assert.Equal(t, `Execution exec-1 started.
+Tasks: 0/2 succeeded, 0 failed, 2 running, 0 cancelled, 0 retried
+Tasks: 1/2 succeeded, 0 failed, 1 running, 0 cancelled, 0 retried
+Tasks: 2/2 succeeded, 0 failed, 0 running, 0 cancelled, 0 retried
+Execution exec-1 succeeded.
+`, out.String())
+}
+
+func TestExecute_WaitFailed(t *testing.T) {
+	isolateConfig(t)
+	mockExecution(t, &model_execution.Execution{
+		TaskCount:         1,
+		FailedCount:       1,
+		TerminalCondition: &condition.Condition{State: conditionFailed, Message: "Task failed"},
+	})
+
+	err := executeCmd(&bytes.Buffer{}, "j1", "-p", "p", "-r", "r", "--wait")
+
+	var exitErr *cmdutil.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, cmdutil.ExitCodeFailed, exitErr.Code)
+	assert.Equal(t, "execution exec-1 failed: Task failed", err.Error())
+}
+
+func TestExecute_WaitTimeout(t *testing.T) {
+	isolateConfig(t)
+	mockExecution(t, &model_execution.Execution{TaskCount: 1, RunningCount: 1})
+
+	err := executeCmd(&bytes.Buffer{}, "j1", "-p", "p", "-r", "r", "--wait", "--timeout", "20ms")
+
+	var exitErr *cmdutil.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, cmdutil.ExitCodeTimeout, exitErr.Code)
+	assert.Contains(t, err.Error(), "timed out after 20ms")
+}
+
+func TestExecute_AllRegions(t *testing.T) {
+	isolateConfig(t)
+	mockExecution(t, &model_execution.Execution{})
+
+	err := executeCmd(&bytes.Buffer{}, "j1", "-p", "p", "-r", "all")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "a region is required")
+}
+
+func TestExecute_StartError(t *testing.T) {
+	isolateConfig(t)
+	mockExecution(t, &model_execution.Execution{})
+	startJobFunc = func(ctx context.Context, project, region, jobName string) (string, error) {
+		return "", assert.AnError
+	}
+
+	err := executeCmd(&bytes.Buffer{}, "j1", "-p", "p", "-r", "r", "--wait")
+	assert.ErrorIs(t, err, assert.AnError)
+}
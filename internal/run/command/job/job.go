@@ -0,0 +1,91 @@
+package job
+
+import (
+	"io"
+
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	model_job "github.com/JulienBreux/run-cli/internal/run/model/job"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+var listHeaders = []string{"NAME", "STATUS OF LAST EXECUTION", "LAST EXECUTED", "REGION", "CREATED BY"}
+
+var listJobsFunc = api_job.List
+
+// NewCmdJob returns a command to manage jobs.
+func NewCmdJob(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "jobs",
+		Aliases: []string{"job"},
+		Short:   "Manage Cloud Run jobs",
+		Long:    "Manage Cloud Run jobs",
+	}
+
+	cmd.AddCommand(newCmdList(out, err))
+	cmd.AddCommand(newCmdExecute(out))
+
+	return
+}
+
+// newCmdList returns a command to list jobs.
+func newCmdList(out, errOut io.Writer) *cobra.Command {
+	var (
+		scope  cmdutil.Scope
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Cloud Run jobs",
+		Long:  "List Cloud Run jobs of a project in a region or in all regions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.ListOutputs...); err != nil {
+				return err
+			}
+			current, err := cmdutil.ResolveScope(scope)
+			if err != nil {
+				return err
+			}
+
+			// The resources of the regions which could be listed are still printed.
+			jobs, listErr := listJobsFunc(cmd.Context(), current.Project, current.Region)
+			if err := cmdutil.IgnorePartial(listErr); err != nil {
+				return err
+			}
+			if jobs == nil {
+				jobs = []model_job.Job{}
+			}
+
+			rows := make([][]string, 0, len(jobs))
+			for _, j := range jobs {
+				status := "-"
+				if j.TerminalCondition != nil {
+					status = j.TerminalCondition.State
+				}
+				lastExecuted := "-"
+				if j.LatestCreatedExecution != nil {
+					lastExecuted = humanize.Time(j.LatestCreatedExecution.CreateTime)
+				}
+				rows = append(rows, []string{
+					cmdutil.ShortName(j.Name),
+					status,
+					lastExecuted,
+					j.Region,
+					j.Creator,
+				})
+			}
+			if err := cmdutil.Print(out, output, jobs, listHeaders, rows); err != nil {
+				return err
+			}
+			return cmdutil.WarnPartial(errOut, listErr)
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputTable, cmdutil.ListOutputs...)
+
+	return cmd
+}
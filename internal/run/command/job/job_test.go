@@ -0,0 +1,78 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
+	model_job "github.com/JulienBreux/run-cli/internal/run/model/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func isolateConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(tmpDir, "gcloud"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gcloud"), 0755))
+}
+
+func TestNewCmdJob(t *testing.T) {
+	cmd := NewCmdJob(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "jobs", cmd.Use)
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestList(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listJobsFunc
+	defer func() { listJobsFunc = origList }()
+
+	listJobsFunc = func(ctx context.Context, project, region string) ([]model_job.Job, error) {
+		return []model_job.Job{
+			{
+				Name:                   "projects/p/locations/us-central1/jobs/j1",
+				Region:                 "us-central1",
+				Creator:                "me@example.com",
+				TerminalCondition:      &condition.Condition{State: "CONDITION_SUCCEEDED"},
+				LatestCreatedExecution: &model_job.ExecutionReference{CreateTime: time.Now()},
+			},
+		}, nil
+	}
+
+	out := &bytes.Buffer{}
+	cmd := NewCmdJob(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs([]string{"list", "-p", "p"})
+
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "STATUS OF LAST EXECUTION")
+	assert.Contains(t, out.String(), "j1")
+	assert.Contains(t, out.String(), "CONDITION_SUCCEEDED")
+
+	out.Reset()
+	cmd.SetArgs([]string{"list", "-p", "p", "-o", "yaml"})
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "name: projects/p/locations/us-central1/jobs/j1")
+}
+
+func TestList_Error(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listJobsFunc
+	defer func() { listJobsFunc = origList }()
+	listJobsFunc = func(ctx context.Context, project, region string) ([]model_job.Job, error) {
+		return nil, assert.AnError
+	}
+
+	cmd := NewCmdJob(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+	cmd.SetArgs([]string{"list", "-p", "p"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	assert.ErrorIs(t, cmd.Execute(), assert.AnError)
+}
@@ -0,0 +1,171 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging"
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Variables for dependency injection
+var (
+	tailFunc = api_log.Tail
+	now      = time.Now
+)
+
+// entry is the JSON representation of a log entry.
+type entry struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Severity  string            `json:"severity"`
+	LogName   string            `json:"logName,omitempty"`
+	InsertID  string            `json:"insertId,omitempty"`
+	Trace     string            `json:"trace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Payload   any               `json:"payload"`
+}
+
+// NewCmdLog returns a command to read logs.
+func NewCmdLog(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	var (
+		scope    cmdutil.Scope
+		follow   bool
+		since    time.Duration
+		limit    int
+		severity string
+		output   string
+	)
+
+	cmd = &cobra.Command{
+		Use:   "logs KIND NAME",
+		Short: "Print the logs of a Cloud Run resource",
+		Long: `Print the logs of a Cloud Run service, job or worker pool.
+
+KIND is one of service, job or workerpool.`,
+		Example: `  run logs service my-service --region europe-west1 --since 1h
+  run logs job my-job --severity error -o json | jq .payload
+  run logs workerpool my-pool --follow`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: cmdutil.CompleteKindAndName(api_log.Kinds(), func(arg string) string {
+			return arg
+		}),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.OutputText, cmdutil.OutputJSON); err != nil {
+				return err
+			}
+
+			current, err := cmdutil.ResolveScope(scope)
+			if err != nil {
+				return err
+			}
+
+			filter, err := api_log.Filter(args[0], args[1], current.Region)
+			if err != nil {
+				return err
+			}
+			filters := []string{filter}
+			if since > 0 {
+				filters = append(filters, api_log.SinceFilter(now().Add(-since)))
+			}
+			if severity != "" {
+				f, err := api_log.SeverityFilter(severity)
+				if err != nil {
+					return err
+				}
+				filters = append(filters, f)
+			}
+
+			ctx := cmd.Context()
+			if follow {
+				var stop func()
+				ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+				defer stop()
+			}
+
+			enc := json.NewEncoder(out)
+			return tailFunc(ctx, current.Project, strings.Join(filters, " "), limit, follow, func(e *logging.Entry) {
+				if output == cmdutil.OutputJSON {
+					_ = enc.Encode(toEntry(e))
+					return
+				}
+				_, _ = fmt.Fprintln(out, formatText(e))
+			})
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new logs until interrupted.")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only print logs newer than a relative duration like 5m or 1h.")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of existing logs to print, 0 for no limit.")
+	cmd.Flags().StringVar(&severity, "severity", "", "Minimum severity of the logs, e.g. info, warning or error.")
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputText, cmdutil.OutputText, cmdutil.OutputJSON)
+
+	return
+}
+
+// formatText formats a log entry as a single line of text.
+func formatText(e *logging.Entry) string {
+	return fmt.Sprintf("%s %-8s %s", e.Timestamp.Format(time.RFC3339Nano), severityName(e.Severity), payloadText(e.Payload))
+}
+
+// toEntry converts a log entry to its JSON representation.
+func toEntry(e *logging.Entry) entry {
+	return entry{
+		Timestamp: e.Timestamp,
+		Severity:  severityName(e.Severity),
+		LogName:   e.LogName,
+		InsertID:  e.InsertID,
+		Trace:     e.Trace,
+		Labels:    e.Labels,
+		Payload:   payloadValue(e.Payload),
+	}
+}
+
+func severityName(s logging.Severity) string {
+	return strings.ToUpper(s.String())
+}
+
+// payloadValue returns a JSON friendly value of a log payload.
+func payloadValue(p any) any {
+	switch v := p.(type) {
+	case *structpb.Struct:
+		return v.AsMap()
+	case proto.Message:
+		if b, err := protojson.Marshal(v); err == nil {
+			return json.RawMessage(b)
+		}
+	}
+	return p
+}
+
+// payloadText returns the text of a log payload, preferring the message of structured logs.
+func payloadText(p any) string {
+	switch v := p.(type) {
+	case string:
+		return v
+	case *structpb.Struct:
+		if m, ok := v.GetFields()["message"]; ok {
+			if s, ok := m.GetKind().(*structpb.Value_StringValue); ok {
+				return s.StringValue
+			}
+		}
+		if b, err := json.Marshal(v.AsMap()); err == nil {
+			return string(b)
+		}
+	case proto.Message:
+		if b, err := protojson.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprintf("%v", p)
+}
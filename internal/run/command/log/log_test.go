@@ -0,0 +1,138 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func isolateConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(tmpDir, "gcloud"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gcloud"), 0755))
+}
+
+type tailCall struct {
+	project string
+	filter  string
+	limit   int
+	follow  bool
+}
+
+func mockTail(t *testing.T, entries []*logging.Entry) *tailCall {
+	origTail := tailFunc
+	origNow := now
+	t.Cleanup(func() {
+		tailFunc = origTail
+		now = origNow
+	})
+
+	now = func() time.Time { return time.Date(2023, 10, 27, 11, 0, 0, 0, time.UTC) }
+
+	call := &tailCall{}
+	tailFunc = func(ctx context.Context, projectID, filter string, limit int, follow bool, fn func(*logging.Entry)) error {
+		*call = tailCall{project: projectID, filter: filter, limit: limit, follow: follow}
+		for _, e := range entries {
+			fn(e)
+		}
+		return nil
+	}
+	return call
+}
+
+func TestNewCmdLog(t *testing.T) {
+	cmd := NewCmdLog(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "logs KIND NAME", cmd.Use)
+
+	kinds, _ := cmd.ValidArgsFunction(cmd, nil, "")
+	assert.Equal(t, []string{"service", "job", "workerpool"}, kinds)
+}
+
+func TestLogs(t *testing.T) {
+	isolateConfig(t)
+
+	ts := time.Date(2023, 10, 27, 10, 0, 0, 0, time.UTC)
+	jsonPayload, _ := structpb.NewStruct(map[string]any{"message": "structured", "status": 500})
+	call := mockTail(t, []*logging.Entry{
+		{Timestamp: ts, Severity: logging.Info, Payload: "hello"},
+		{Timestamp: ts, Severity: logging.Error, Payload: jsonPayload, InsertID: "id2"},
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := NewCmdLog(&bytes.Buffer{}, out, &bytes.Buffer{})
+		cmd.SetArgs([]string{"service", "s1", "-p", "p", "-r", "us-central1", "--limit", "10"})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, "p", call.project)
+		assert.Equal(t, `resource.type="cloud_run_revision" resource.labels.service_name="s1" resource.labels.location="us-central1"`, call.filter)
+		assert.Equal(t, 10, call.limit)
+		assert.False(t, call.follow)
+		assert.Equal(t, "2023-10-27T10:00:00Z INFO     hello\n2023-10-27T10:00:00Z ERROR    structured\n", out.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := NewCmdLog(&bytes.Buffer{}, out, &bytes.Buffer{})
+		cmd.SetArgs([]string{"job", "j1", "-p", "p", "-r", "all", "--since", "1h", "--severity", "warning", "-o", "json"})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, `resource.type="cloud_run_job" resource.labels.job_name="j1" timestamp>="2023-10-27T10:00:00Z" severity>=WARNING`, call.filter)
+		assert.Equal(t, `{"timestamp":"2023-10-27T10:00:00Z","severity":"INFO","payload":"hello"}
+{"timestamp":"2023-10-27T10:00:00Z","severity":"ERROR","insertId":"id2","payload":{"message":"structured","status":500}}
+`, out.String())
+	})
+
+	t.Run("Follow", func(t *testing.T) {
+		cmd := NewCmdLog(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+		cmd.SetArgs([]string{"workerpool", "wp1", "-p", "p", "-r", "all", "-f"})
+
+		assert.NoError(t, cmd.Execute())
+		assert.True(t, call.follow)
+		assert.Equal(t, `resource.type="cloud_run_worker_pool" resource.labels.worker_pool_name="wp1"`, call.filter)
+	})
+
+	t.Run("Invalid kind", func(t *testing.T) {
+		cmd := NewCmdLog(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+		cmd.SetArgs([]string{"domainmapping", "d1", "-p", "p"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		assert.ErrorContains(t, cmd.Execute(), "unsupported log kind")
+	})
+
+	t.Run("Invalid severity", func(t *testing.T) {
+		cmd := NewCmdLog(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+		cmd.SetArgs([]string{"service", "s1", "-p", "p", "--severity", "loud"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		assert.ErrorContains(t, cmd.Execute(), "unsupported severity")
+	})
+
+	t.Run("Invalid output", func(t *testing.T) {
+		cmd := NewCmdLog(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+		cmd.SetArgs([]string{"service", "s1", "-p", "p", "-o", "yaml"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		assert.Error(t, cmd.Execute())
+	})
+}
+
+func TestPayloadText(t *testing.T) {
+	noMessage, _ := structpb.NewStruct(map[string]any{"status": 200})
+
+	assert.Equal(t, "plain", payloadText("plain"))
+	assert.Equal(t, `{"status":200}`, payloadText(noMessage))
+	assert.Equal(t, "42", payloadText(42))
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+	"io"
+
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+var listHeaders = []string{"SERVICE", "REGION", "SCALING", "URL", "LAST DEPLOYED BY", "LAST DEPLOYED AT"}
+
+var listServicesFunc = api_service.List
+
+// NewCmdService returns a command to manage services.
+func NewCmdService(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "services",
+		Aliases: []string{"service", "svc"},
+		Short:   "Manage Cloud Run services",
+		Long:    "Manage Cloud Run services",
+	}
+
+	cmd.AddCommand(newCmdList(out, err))
+
+	return
+}
+
+// newCmdList returns a command to list services.
+func newCmdList(out, errOut io.Writer) *cobra.Command {
+	var (
+		scope  cmdutil.Scope
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Cloud Run services",
+		Long:  "List Cloud Run services of a project in a region or in all regions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.ListOutputs...); err != nil {
+				return err
+			}
+			current, err := cmdutil.ResolveScope(scope)
+			if err != nil {
+				return err
+			}
+
+			// The resources of the regions which could be listed are still printed.
+			services, listErr := listServicesFunc(cmd.Context(), current.Project, current.Region)
+			if err := cmdutil.IgnorePartial(listErr); err != nil {
+				return err
+			}
+			if services == nil {
+				services = []model_service.Service{}
+			}
+
+			rows := make([][]string, 0, len(services))
+			for _, s := range services {
+				rows = append(rows, []string{
+					s.Name,
+					s.Region,
+					scaling(s),
+					s.URI,
+					s.LastModifier,
+					humanize.Time(s.UpdateTime),
+				})
+			}
+			if err := cmdutil.Print(out, output, services, listHeaders, rows); err != nil {
+				return err
+			}
+			return cmdutil.WarnPartial(errOut, listErr)
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputTable, cmdutil.ListOutputs...)
+
+	return cmd
+}
+
+// scaling returns a human readable scaling summary.
+func scaling(s model_service.Service) string {
+	if s.Scaling == nil {
+		return "n/a"
+	}
+	switch s.Scaling.ScalingMode {
+	case "AUTOMATIC":
+		str := fmt.Sprintf("Auto: min %d", s.Scaling.MinInstances)
+		if s.Scaling.MaxInstances != 0 {
+			str += fmt.Sprintf(", max %d", s.Scaling.MaxInstances)
+		}
+		return str
+	case "MANUAL":
+		return fmt.Sprintf("Manual: %d", s.Scaling.ManualInstanceCount)
+	}
+	return "n/a"
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
+	model_scaling "github.com/JulienBreux/run-cli/internal/run/model/service/scaling"
+	"github.com/stretchr/testify/assert"
+)
+
+func isolateConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(tmpDir, "gcloud"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gcloud"), 0755))
+}
+
+func TestNewCmdService(t *testing.T) {
+	cmd := NewCmdService(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "services", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "svc")
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestList(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listServicesFunc
+	defer func() { listServicesFunc = origList }()
+
+	var gotProject, gotRegion string
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		gotProject, gotRegion = project, region
+		return []model_service.Service{
+			{
+				Name:    "s1",
+				Region:  "us-central1",
+				URI:     "https://s1.run.app",
+				Scaling: &model_scaling.Scaling{ScalingMode: "AUTOMATIC", MinInstances: 1, MaxInstances: 3},
+			},
+		}, nil
+	}
+
+	t.Run("Table", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := NewCmdService(&bytes.Buffer{}, out, &bytes.Buffer{})
+		cmd.SetArgs([]string{"list", "--project", "p", "--region", "all"})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, "p", gotProject)
+		assert.Equal(t, "all", gotRegion)
+		assert.Contains(t, out.String(), "SERVICE")
+		assert.Contains(t, out.String(), "https://s1.run.app")
+		assert.Contains(t, out.String(), "Auto: min 1, max 3")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		cmd := NewCmdService(&bytes.Buffer{}, out, &bytes.Buffer{})
+		cmd.SetArgs([]string{"list", "-p", "p", "-r", "us-central1", "-o", "json"})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), `"name":"s1"`)
+	})
+
+	t.Run("Invalid output", func(t *testing.T) {
+		cmd := NewCmdService(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+		cmd.SetArgs([]string{"list", "-p", "p", "-o", "xml"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		assert.Error(t, cmd.Execute())
+	})
+}
+
+func TestList_Empty(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listServicesFunc
+	defer func() { listServicesFunc = origList }()
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		return nil, nil
+	}
+
+	out := &bytes.Buffer{}
+	cmd := NewCmdService(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs([]string{"list", "-p", "p", "-o", "json"})
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestList_PartialRegions(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listServicesFunc
+	defer func() { listServicesFunc = origList }()
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		return []model_service.Service{{Name: "s1", Region: "us-central1"}}, &api_region.PartialError{
+			Errors: map[string]error{"europe-west1": errors.New("permission denied")},
+			Total:  2,
+		}
+	}
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := NewCmdService(&bytes.Buffer{}, out, errOut)
+	cmd.SetArgs([]string{"list", "-p", "p", "-r", "all"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	err := cmd.Execute()
+	var exitErr *cmdutil.ExitError
+	assert.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, cmdutil.ExitCodePartial, exitErr.Code)
+	assert.Contains(t, out.String(), "s1")
+	assert.Equal(t, "Warning: failed to list region europe-west1: permission denied\n", errOut.String())
+}
+
+func TestList_Error(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listServicesFunc
+	defer func() { listServicesFunc = origList }()
+	listServicesFunc = func(ctx context.Context, project, region string) ([]model_service.Service, error) {
+		return nil, errors.New("permission denied")
+	}
+
+	out := &bytes.Buffer{}
+	cmd := NewCmdService(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs([]string{"list", "-p", "p", "-r", "all"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	assert.EqualError(t, cmd.Execute(), "permission denied")
+	assert.Empty(t, out.String())
+}
+
+func TestScaling(t *testing.T) {
+	assert.Equal(t, "n/a", scaling(model_service.Service{}))
+	assert.Equal(t, "Auto: min 0", scaling(model_service.Service{Scaling: &model_scaling.Scaling{ScalingMode: "AUTOMATIC"}}))
+	assert.Equal(t, "Manual: 2", scaling(model_service.Service{Scaling: &model_scaling.Scaling{ScalingMode: "MANUAL", ManualInstanceCount: 2}}))
+}
@@ -15,17 +15,17 @@ func NewCmdVersion(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
 		Use:   "version",
 		Short: "Print the Run CLI version",
 		Long:  "Print the Run CLI version",
-		Run:   run(out),
+		RunE:  run(out),
 	}
 
-	cmd.Flags().StringVarP(&output, "output", "o", "", "One of '', 'yaml' or 'json'.")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "One of '', 'yaml', 'json', 'table', 'csv', 'go-template=...' or 'jsonpath=...'.")
 
 	return
 }
 
 // run returns the command.
-func run(out io.Writer) func(cmd *cobra.Command, args []string) {
-	return func(cmd *cobra.Command, args []string) {
-		version.Print(out, output)
+func run(out io.Writer) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return version.Print(out, output)
 	}
 }
@@ -24,7 +24,7 @@ func TestNewCmdVersion(t *testing.T) {
 	outputFlag := cmd.Flags().Lookup("output")
 	assert.NotNil(t, outputFlag)
 	assert.Equal(t, "o", outputFlag.Shorthand)
-	assert.Equal(t, "One of '', 'yaml' or 'json'.", outputFlag.Usage)
+	assert.Equal(t, "One of '', 'yaml', 'json', 'table', 'csv', 'go-template=...' or 'jsonpath=...'.", outputFlag.Usage)
 
 	// Test execution
 	cmd.SetOut(out)
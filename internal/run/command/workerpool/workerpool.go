@@ -0,0 +1,97 @@
+package workerpool
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
+	"github.com/JulienBreux/run-cli/internal/run/command/cmdutil"
+	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+var listHeaders = []string{"NAME", "REGION", "LAST UPDATED", "SCALING", "MODIFIED BY", "LABELS"}
+
+var listWorkerPoolsFunc = api_workerpool.List
+
+// NewCmdWorkerPool returns a command to manage worker pools.
+func NewCmdWorkerPool(in io.Reader, out, err io.Writer) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "workerpools",
+		Aliases: []string{"workerpool", "wp"},
+		Short:   "Manage Cloud Run worker pools",
+		Long:    "Manage Cloud Run worker pools",
+	}
+
+	cmd.AddCommand(newCmdList(out, err))
+
+	return
+}
+
+// newCmdList returns a command to list worker pools.
+func newCmdList(out, errOut io.Writer) *cobra.Command {
+	var (
+		scope  cmdutil.Scope
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Cloud Run worker pools",
+		Long:  "List Cloud Run worker pools of a project in a region or in all regions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateOutput(output, cmdutil.ListOutputs...); err != nil {
+				return err
+			}
+			current, err := cmdutil.ResolveScope(scope)
+			if err != nil {
+				return err
+			}
+
+			// The resources of the regions which could be listed are still printed.
+			workerPools, listErr := listWorkerPoolsFunc(cmd.Context(), current.Project, current.Region)
+			if err := cmdutil.IgnorePartial(listErr); err != nil {
+				return err
+			}
+			if workerPools == nil {
+				workerPools = []model_workerpool.WorkerPool{}
+			}
+
+			rows := make([][]string, 0, len(workerPools))
+			for _, w := range workerPools {
+				var labels []string
+				for k, v := range w.Labels {
+					labels = append(labels, fmt.Sprintf("%s: %s", k, v))
+				}
+				sort.Strings(labels)
+
+				scaling := "n/a"
+				if w.Scaling != nil {
+					scaling = fmt.Sprintf("Manual: %d", w.Scaling.ManualInstanceCount)
+				}
+
+				rows = append(rows, []string{
+					w.DisplayName,
+					w.Region,
+					humanize.Time(w.UpdateTime),
+					scaling,
+					w.LastModifier,
+					strings.Join(labels, ", "),
+				})
+			}
+			if err := cmdutil.Print(out, output, workerPools, listHeaders, rows); err != nil {
+				return err
+			}
+			return cmdutil.WarnPartial(errOut, listErr)
+		},
+	}
+
+	cmdutil.AddScopeFlags(cmd, &scope)
+	cmdutil.AddOutputFlag(cmd, &output, cmdutil.OutputTable, cmdutil.ListOutputs...)
+
+	return cmd
+}
@@ -0,0 +1,54 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
+	model_scaling "github.com/JulienBreux/run-cli/internal/run/model/workerpool/scaling"
+	"github.com/stretchr/testify/assert"
+)
+
+func isolateConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(tmpDir, "gcloud"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gcloud"), 0755))
+}
+
+func TestNewCmdWorkerPool(t *testing.T) {
+	cmd := NewCmdWorkerPool(&bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{})
+
+	assert.Equal(t, "workerpools", cmd.Use)
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestList(t *testing.T) {
+	isolateConfig(t)
+
+	origList := listWorkerPoolsFunc
+	defer func() { listWorkerPoolsFunc = origList }()
+
+	listWorkerPoolsFunc = func(ctx context.Context, project, region string) ([]model_workerpool.WorkerPool, error) {
+		return []model_workerpool.WorkerPool{
+			{
+				DisplayName: "wp1",
+				Region:      "us-central1",
+				Scaling:     &model_scaling.Scaling{ManualInstanceCount: 2},
+				Labels:      map[string]string{"team": "a", "env": "prod"},
+			},
+		}, nil
+	}
+
+	out := &bytes.Buffer{}
+	cmd := NewCmdWorkerPool(&bytes.Buffer{}, out, &bytes.Buffer{})
+	cmd.SetArgs([]string{"list", "-p", "p"})
+
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "wp1")
+	assert.Contains(t, out.String(), "Manual: 2")
+	assert.Contains(t, out.String(), "env: prod, team: a")
+}
@@ -9,55 +9,206 @@ import (
 )
 
 const (
+	// DefaultFile is the name of the repository configuration files, and of the legacy user one.
 	DefaultFile = ".run.yaml"
 )
 
 // Config represents the CLI configuration.
 type Config struct {
-	Project string `yaml:"project,omitempty"`
-	Region  string `yaml:"region,omitempty"`
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	Region  string `yaml:"region,omitempty" json:"region,omitempty"`
+
+	CurrentContext string    `yaml:"currentContext,omitempty" json:"currentContext,omitempty"`
+	Contexts       []Context `yaml:"contexts,omitempty" json:"contexts,omitempty"`
+
+	// Endpoints override the endpoints of the APIs, keyed by API name, e.g. run: http://localhost:8080.
+	Endpoints map[string]string `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+
+	// Regions restrict the regions listed when using all regions, empty to discover them.
+	Regions []string `yaml:"regions,omitempty" json:"regions,omitempty"`
+
+	// Limits bound the API calls, nil to use the default limits.
+	Limits *Limits `yaml:"limits,omitempty" json:"limits,omitempty"`
+
+	// origins are the sources of the values, see Origin.
+	origins map[string]string
+	// loaded are the values as of Load or Save, to only save the changed ones.
+	loaded snapshot
+	// inMemory prevents Save from writing the configuration, see InMemory.
+	inMemory bool
 }
 
-// GetConfigPath returns the path to the configuration file.
-func GetConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+// InMemory returns a configuration of the given project and region which is
+// never written by Save, e.g. for the demo mode.
+func InMemory(project, region string) *Config {
+	c := &Config{Project: project, Region: region, inMemory: true}
+	c.loaded = c.snapshot()
+	return c
+}
+
+// Limits bound the API calls. The zero values keep the default limits.
+type Limits struct {
+	// Parallelism is the maximum number of concurrent API calls.
+	Parallelism int `yaml:"parallelism,omitempty" json:"parallelism,omitempty"`
+	// Retries is the number of retries of the calls failing with a transient error, negative for none.
+	Retries int `yaml:"retries,omitempty" json:"retries,omitempty"`
+	// RateLimit is the maximum number of API calls per second.
+	RateLimit float64 `yaml:"rateLimit,omitempty" json:"rateLimit,omitempty"`
+}
+
+// Context represents a named environment: a project, a region and account settings.
+type Context struct {
+	Name    string `yaml:"name" json:"name"`
+	Project string `yaml:"project" json:"project"`
+	Region  string `yaml:"region,omitempty" json:"region,omitempty"`
+	// ImpersonateServiceAccount is the email of the service account used to call the APIs.
+	ImpersonateServiceAccount string `yaml:"impersonateServiceAccount,omitempty" json:"impersonateServiceAccount,omitempty"`
+	// ReadOnly prevents any change to the resources.
+	ReadOnly bool `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+}
+
+// Context returns the context of the given name.
+func (c *Config) Context(name string) (*Context, error) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("context %q not found", name)
+}
+
+// Current returns the current context, nil if none is used.
+func (c *Config) Current() *Context {
+	if c.CurrentContext == "" {
+		return nil
+	}
+	ctx, err := c.Context(c.CurrentContext)
 	if err != nil {
-		return "", fmt.Errorf("failed to get user home directory: %w", err)
+		return nil
 	}
-	return filepath.Join(homeDir, DefaultFile), nil
+	return ctx
 }
 
-// Load reads the configuration from the config file.
-func Load() (*Config, error) {
-	configPath, err := GetConfigPath()
+// UseContext makes the context of the given name the current one, using its project and region.
+func (c *Config) UseContext(name string) error {
+	ctx, err := c.Context(name)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	c.CurrentContext = ctx.Name
+	c.Project = ctx.Project
+	c.Region = ctx.Region
+	return nil
+}
+
+// SetContext adds a context or replaces the one of the same name.
+// The project and region are updated when it is the current context.
+func (c *Config) SetContext(ctx Context) {
+	c.putContext(ctx)
+	if c.CurrentContext == ctx.Name {
+		c.Project = ctx.Project
+		c.Region = ctx.Region
+	}
+}
+
+// putContext adds a context or replaces the one of the same name.
+func (c *Config) putContext(ctx Context) {
+	if existing, err := c.Context(ctx.Name); err == nil {
+		*existing = ctx
+	} else {
+		c.Contexts = append(c.Contexts, ctx)
+	}
+}
+
+// DeleteContext deletes the context of the given name.
+func (c *Config) DeleteContext(name string) error {
+	for i, ctx := range c.Contexts {
+		if ctx.Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			if c.CurrentContext == name {
+				c.CurrentContext = ""
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("context %q not found", name)
+}
+
+// SetProject selects a project outside of any context.
+func (c *Config) SetProject(project string) {
+	c.Project = project
+	c.leaveContext()
+}
+
+// SetRegion selects a region outside of any context.
+func (c *Config) SetRegion(region string) {
+	c.Region = region
+	c.leaveContext()
+}
+
+// leaveContext leaves the current context when the project or region no longer match it.
+func (c *Config) leaveContext() {
+	if ctx := c.Current(); ctx != nil && (ctx.Project != c.Project || ctx.Region != c.Region) {
+		c.CurrentContext = ""
 	}
+}
 
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return &Config{}, nil
+// GetConfigPath returns the path to the configuration file written by Save:
+// $RUN_CONFIG_FILE if set, otherwise the user configuration file.
+func GetConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
 	}
+	return userConfigPath()
+}
 
-	data, err := os.ReadFile(configPath)
+// Load reads and merges the configuration layers, see Sources.
+func Load() (*Config, error) {
+	sources, err := Sources()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, err
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("cannot unmarshal config: %w", err)
+	c := &Config{}
+	l := newLayering(c)
+	for _, path := range sources {
+		layer, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if layer != nil {
+			l.merge(*layer, "file:"+path)
+		}
 	}
+	l.mergeEnv()
+	l.resolveContext()
 
-	return &config, nil
+	c.loaded = c.snapshot()
+	return c, nil
 }
 
-// Save writes the configuration to the config file.
+// Save writes the values changed since Load to the configuration file,
+// leaving the values of the other layers out of it.
 func (c *Config) Save() error {
+	if c.inMemory {
+		c.loaded = c.snapshot()
+		return nil
+	}
+
 	configPath, err := GetConfigPath()
 	if err != nil {
 		return err
 	}
 
+	target, err := readFile(configPath)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		target = &Config{}
+	}
+	c.applyChanges(target)
+
 	configDir := filepath.Dir(configPath)
 	if _, err := os.Stat(configDir); os.IsNotExist(err) {
 		if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -65,7 +216,7 @@ func (c *Config) Save() error {
 		}
 	}
 
-	data, err := yaml.Marshal(c)
+	data, err := yaml.Marshal(target)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config data: %w", err)
 	}
@@ -74,5 +225,25 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
+	c.loaded = c.snapshot()
 	return nil
 }
+
+// readFile reads a configuration file, nil if it does not exist.
+func readFile(path string) (*Config, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal config %s: %w", path, err)
+	}
+
+	return &config, nil
+}
@@ -101,7 +101,8 @@ func TestGetConfigPath(t *testing.T) {
 		}
 	})
 
-	expectedPath := filepath.Join(tmpDir, ".run.yaml")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	expectedPath := filepath.Join(tmpDir, ".config", "run", "config.yaml")
 	actualPath, err := config.GetConfigPath()
 	if err != nil {
 		t.Fatalf("failed to get config path: %v", err)
@@ -109,6 +110,33 @@ func TestGetConfigPath(t *testing.T) {
 	if actualPath != expectedPath {
 		t.Errorf("expected config path '%s', but got: '%s'", expectedPath, actualPath)
 	}
+
+	// The legacy config file is used when it exists
+	legacyPath := filepath.Join(tmpDir, ".run.yaml")
+	if err := os.WriteFile(legacyPath, []byte("region: us-central1\n"), 0644); err != nil {
+		t.Fatalf("failed to write legacy config file: %v", err)
+	}
+	actualPath, err = config.GetConfigPath()
+	if err != nil {
+		t.Fatalf("failed to get config path: %v", err)
+	}
+	if actualPath != legacyPath {
+		t.Errorf("expected config path '%s', but got: '%s'", legacyPath, actualPath)
+	}
+
+	// The XDG config directory and RUN_CONFIG_FILE
+	if err := os.Remove(legacyPath); err != nil {
+		t.Fatalf("failed to remove legacy config file: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "xdg"))
+	expectedPath = filepath.Join(tmpDir, "xdg", "run", "config.yaml")
+	if actualPath, _ = config.GetConfigPath(); actualPath != expectedPath {
+		t.Errorf("expected config path '%s', but got: '%s'", expectedPath, actualPath)
+	}
+	t.Setenv("RUN_CONFIG_FILE", "/etc/run.yaml")
+	if actualPath, _ = config.GetConfigPath(); actualPath != "/etc/run.yaml" {
+		t.Errorf("expected config path '/etc/run.yaml', but got: '%s'", actualPath)
+	}
 }
 
 func TestCorruptedConfig(t *testing.T) {
@@ -138,6 +166,9 @@ func TestCorruptedConfig(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to get config path: %v", err)
 	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatalf("failed to create config directory: %v", err)
+	}
 	invalidYAML := []byte("region: us-central1\n  invalid-indent:")
 	if err := os.WriteFile(configPath, invalidYAML, 0644); err != nil {
 		t.Fatalf("failed to write corrupted config file: %v", err)
@@ -297,3 +328,339 @@ func TestSave_MkdirError(t *testing.T) {
 		t.Fatal("expected error when creating config directory in read-only parent, but got nil")
 	}
 }
+
+func TestContexts(t *testing.T) {
+	cfg := &config.Config{Project: "p", Region: "us-central1"}
+
+	if cfg.Current() != nil {
+		t.Fatal("expected no current context")
+	}
+	if err := cfg.UseContext("prod"); err == nil {
+		t.Error("expected error when using an unknown context, but got nil")
+	}
+
+	cfg.SetContext(config.Context{Name: "prod", Project: "prod-p", Region: "europe-west1", ReadOnly: true})
+	cfg.SetContext(config.Context{Name: "dev", Project: "dev-p"})
+	if len(cfg.Contexts) != 2 {
+		t.Fatalf("expected 2 contexts, but got: %d", len(cfg.Contexts))
+	}
+
+	// Using a context selects its project and region
+	if err := cfg.UseContext("prod"); err != nil {
+		t.Fatalf("failed to use context: %v", err)
+	}
+	if cfg.Project != "prod-p" || cfg.Region != "europe-west1" {
+		t.Errorf("expected prod-p/europe-west1, but got: %s/%s", cfg.Project, cfg.Region)
+	}
+	if ctx := cfg.Current(); ctx == nil || !ctx.ReadOnly {
+		t.Errorf("expected the read-only prod context, but got: %v", ctx)
+	}
+
+	// Updating the current context updates the project and region
+	cfg.SetContext(config.Context{Name: "prod", Project: "prod-p", Region: "europe-west4"})
+	if len(cfg.Contexts) != 2 {
+		t.Errorf("expected the context to be replaced, but got %d contexts", len(cfg.Contexts))
+	}
+	if cfg.Region != "europe-west4" {
+		t.Errorf("expected region 'europe-west4', but got: %s", cfg.Region)
+	}
+
+	// Selecting the same region keeps the context, another one leaves it
+	cfg.SetRegion("europe-west4")
+	if cfg.CurrentContext != "prod" {
+		t.Errorf("expected current context 'prod', but got: %q", cfg.CurrentContext)
+	}
+	cfg.SetProject("other")
+	if cfg.CurrentContext != "" || cfg.Project != "other" {
+		t.Errorf("expected no current context and project 'other', but got: %q and %s", cfg.CurrentContext, cfg.Project)
+	}
+
+	// Deleting the current context
+	if err := cfg.UseContext("dev"); err != nil {
+		t.Fatalf("failed to use context: %v", err)
+	}
+	if err := cfg.DeleteContext("dev"); err != nil {
+		t.Fatalf("failed to delete context: %v", err)
+	}
+	if cfg.CurrentContext != "" || len(cfg.Contexts) != 1 {
+		t.Errorf("expected no current context and 1 context, but got: %q and %d", cfg.CurrentContext, len(cfg.Contexts))
+	}
+	if err := cfg.DeleteContext("dev"); err == nil {
+		t.Error("expected error when deleting an unknown context, but got nil")
+	}
+}
+
+func TestContexts_LoadSave(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := &config.Config{}
+	cfg.SetContext(config.Context{Name: "prod", Project: "prod-p", ImpersonateServiceAccount: "sa@prod-p.iam.gserviceaccount.com", ReadOnly: true})
+	if err := cfg.UseContext("prod"); err != nil {
+		t.Fatalf("failed to use context: %v", err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	loadedCfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	ctx := loadedCfg.Current()
+	if ctx == nil {
+		t.Fatal("expected a current context, but got nil")
+	}
+	if *ctx != cfg.Contexts[0] {
+		t.Errorf("expected context %v, but got: %v", cfg.Contexts[0], *ctx)
+	}
+}
+
+// isolateLayers points all configuration layers to the returned temporary directory.
+func isolateLayers(t *testing.T) string {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmpDir, "home"))
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("RUN_CONFIG_FILE", "")
+	t.Setenv("RUN_PROJECT", "")
+	t.Setenv("RUN_REGION", "")
+
+	origSystemFile := config.SystemFile
+	config.SystemFile = filepath.Join(tmpDir, "etc", "run.yaml")
+	t.Cleanup(func() { config.SystemFile = origSystemFile })
+
+	t.Chdir(tmpDir)
+	return tmpDir
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+}
+
+func TestLoad_Layers(t *testing.T) {
+	tmpDir := isolateLayers(t)
+	systemPath := filepath.Join(tmpDir, "etc", "run.yaml")
+	userPath := filepath.Join(tmpDir, "home", ".config", "run", "config.yaml")
+	repoPath := filepath.Join(tmpDir, "repo", ".run.yaml")
+
+	writeConfig(t, systemPath, "project: system-p\nregion: us-central1\n")
+	writeConfig(t, userPath, "project: user-p\ncontexts:\n- name: prod\n  project: prod-p\n")
+	writeConfig(t, repoPath, "region: europe-west1\n")
+
+	// The repository file is found from a sub directory
+	subDir := filepath.Join(tmpDir, "repo", "cmd", "app")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("failed to create sub directory: %v", err)
+	}
+	t.Chdir(subDir)
+
+	sources, err := config.Sources()
+	if err != nil {
+		t.Fatalf("failed to get sources: %v", err)
+	}
+	if strings.Join(sources, ",") != strings.Join([]string{systemPath, userPath, repoPath}, ",") {
+		t.Errorf("unexpected sources: %v", sources)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Project != "user-p" || cfg.Region != "europe-west1" {
+		t.Errorf("expected user-p/europe-west1, but got: %s/%s", cfg.Project, cfg.Region)
+	}
+	if origin := cfg.Origin(config.KeyProject); origin != "file:"+userPath {
+		t.Errorf("unexpected project origin: %s", origin)
+	}
+	if origin := cfg.Origin(config.KeyRegion); origin != "file:"+repoPath {
+		t.Errorf("unexpected region origin: %s", origin)
+	}
+	if origin := cfg.Origin(config.ContextKey("prod")); origin != "file:"+userPath {
+		t.Errorf("unexpected context origin: %s", origin)
+	}
+
+	// RUN_CONFIG_FILE, then the variables have precedence over all files
+	envPath := filepath.Join(tmpDir, "env.yaml")
+	writeConfig(t, envPath, "project: env-file-p\nregion: asia-east1\n")
+	t.Setenv("RUN_CONFIG_FILE", envPath)
+	t.Setenv("RUN_REGION", "us-east1")
+
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Project != "env-file-p" || cfg.Region != "us-east1" {
+		t.Errorf("expected env-file-p/us-east1, but got: %s/%s", cfg.Project, cfg.Region)
+	}
+	if origin := cfg.Origin(config.KeyRegion); origin != "env:RUN_REGION" {
+		t.Errorf("unexpected region origin: %s", origin)
+	}
+}
+
+func TestLoad_Endpoints(t *testing.T) {
+	tmpDir := isolateLayers(t)
+	userPath := filepath.Join(tmpDir, "home", ".config", "run", "config.yaml")
+	repoPath := filepath.Join(tmpDir, ".run.yaml")
+	writeConfig(t, userPath, "endpoints:\n  run: europe-west1-run.googleapis.com\n  logging: logging.example.com\n")
+	writeConfig(t, repoPath, "endpoints:\n  run: http://localhost:8080\n")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Endpoints["run"] != "http://localhost:8080" || cfg.Endpoints["logging"] != "logging.example.com" {
+		t.Errorf("unexpected endpoints: %v", cfg.Endpoints)
+	}
+	if origin := cfg.Origin(config.EndpointKey("run")); origin != "file:"+repoPath {
+		t.Errorf("unexpected run endpoint origin: %s", origin)
+	}
+
+	// The endpoints of the other layers are not saved
+	cfg.SetProject("p")
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	data, err := os.ReadFile(userPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if strings.Contains(string(data), "localhost") || !strings.Contains(string(data), "logging.example.com") {
+		t.Errorf("unexpected saved config: %s", data)
+	}
+}
+
+func TestLoad_Regions(t *testing.T) {
+	tmpDir := isolateLayers(t)
+	userPath := filepath.Join(tmpDir, "home", ".config", "run", "config.yaml")
+	repoPath := filepath.Join(tmpDir, ".run.yaml")
+	writeConfig(t, userPath, "regions:\n- us-central1\n")
+	writeConfig(t, repoPath, "regions:\n- europe-west1\n- europe-west4\n")
+
+	// The regions of a layer replace the ones of the layers below
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if strings.Join(cfg.Regions, ",") != "europe-west1,europe-west4" {
+		t.Errorf("unexpected regions: %v", cfg.Regions)
+	}
+	if origin := cfg.Origin(config.KeyRegions); origin != "file:"+repoPath {
+		t.Errorf("unexpected regions origin: %s", origin)
+	}
+}
+
+func TestLoad_Limits(t *testing.T) {
+	tmpDir := isolateLayers(t)
+	userPath := filepath.Join(tmpDir, "home", ".config", "run", "config.yaml")
+	repoPath := filepath.Join(tmpDir, ".run.yaml")
+	writeConfig(t, userPath, "limits:\n  parallelism: 4\n  rateLimit: 5\n")
+	writeConfig(t, repoPath, "limits:\n  parallelism: 2\n  retries: -1\n")
+
+	// Each limit is merged on its own
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if *cfg.Limits != (config.Limits{Parallelism: 2, Retries: -1, RateLimit: 5}) {
+		t.Errorf("unexpected limits: %+v", *cfg.Limits)
+	}
+	if origin := cfg.Origin(config.KeyRateLimit); origin != "file:"+userPath {
+		t.Errorf("unexpected rate limit origin: %s", origin)
+	}
+	if origin := cfg.Origin(config.KeyParallelism); origin != "file:"+repoPath {
+		t.Errorf("unexpected parallelism origin: %s", origin)
+	}
+}
+
+func TestLoad_LayersContext(t *testing.T) {
+	tmpDir := isolateLayers(t)
+	userPath := filepath.Join(tmpDir, "home", ".config", "run", "config.yaml")
+	writeConfig(t, userPath, "currentContext: prod\ncontexts:\n- name: prod\n  project: prod-p\n  region: europe-west1\n")
+
+	// The current context sets the project and region
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.CurrentContext != "prod" || cfg.Project != "prod-p" || cfg.Region != "europe-west1" {
+		t.Errorf("expected prod context, but got: %q %s/%s", cfg.CurrentContext, cfg.Project, cfg.Region)
+	}
+
+	// A repository pinning another project keeps the context
+	writeConfig(t, filepath.Join(tmpDir, ".run.yaml"), "project: repo-p\n")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.CurrentContext != "prod" || cfg.Project != "repo-p" || cfg.Region != "europe-west1" {
+		t.Errorf("expected prod context with repo-p project, but got: %q %s/%s", cfg.CurrentContext, cfg.Project, cfg.Region)
+	}
+}
+
+func TestSave_Layers(t *testing.T) {
+	tmpDir := isolateLayers(t)
+	userPath := filepath.Join(tmpDir, "home", ".config", "run", "config.yaml")
+	repoPath := filepath.Join(tmpDir, ".run.yaml")
+	writeConfig(t, userPath, "project: user-p\ncontexts:\n- name: old\n  project: old-p\n")
+	writeConfig(t, repoPath, "region: europe-west1\n")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	cfg.Project = "new-p"
+	cfg.SetContext(config.Context{Name: "new", Project: "new-p"})
+	if err := cfg.DeleteContext("old"); err != nil {
+		t.Fatalf("failed to delete context: %v", err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	// Only the changes are saved, in the user configuration
+	data, err := os.ReadFile(userPath)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	expected := "project: new-p\ncontexts:\n- name: new\n  project: new-p\n"
+	if string(data) != expected {
+		t.Errorf("expected user config %q, but got: %q", expected, string(data))
+	}
+
+	// RUN_CONFIG_FILE is saved instead
+	envPath := filepath.Join(tmpDir, "env.yaml")
+	t.Setenv("RUN_CONFIG_FILE", envPath)
+	cfg.Region = "us-east1"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	data, err = os.ReadFile(envPath)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	if string(data) != "region: us-east1\n" {
+		t.Errorf("expected env config %q, but got: %q", "region: us-east1\n", string(data))
+	}
+}
+
+func TestInMemory(t *testing.T) {
+	tmpDir := isolateLayers(t)
+	envPath := filepath.Join(tmpDir, "env.yaml")
+	t.Setenv("RUN_CONFIG_FILE", envPath)
+
+	cfg := config.InMemory("demo", "europe-west1")
+	if cfg.Project != "demo" || cfg.Region != "europe-west1" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	cfg.SetRegion("us-east1")
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	if _, err := os.Stat(envPath); !os.IsNotExist(err) {
+		t.Errorf("expected no config file, but got: %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Environment variables overriding the configuration.
+const (
+	EnvConfigFile = "RUN_CONFIG_FILE"
+	EnvProject    = "RUN_PROJECT"
+	EnvRegion     = "RUN_REGION"
+)
+
+// Keys of the configuration values, see Origin.
+const (
+	KeyProject        = "project"
+	KeyRegion         = "region"
+	KeyCurrentContext = "currentContext"
+	KeyRegions        = "regions"
+	KeyParallelism    = "limits.parallelism"
+	KeyRetries        = "limits.retries"
+	KeyRateLimit      = "limits.rateLimit"
+)
+
+// userFile is the path of the user configuration file in the XDG config directory.
+const userFile = "run/config.yaml"
+
+// SystemFile is the path of the system wide configuration file.
+var SystemFile = "/etc/run.yaml"
+
+// ContextKey returns the key of a context, see Origin.
+func ContextKey(name string) string {
+	return "contexts." + name
+}
+
+// EndpointKey returns the key of the endpoint override of an API, see Origin.
+func EndpointKey(api string) string {
+	return "endpoints." + api
+}
+
+// SetEndpoint overrides the endpoint of an API.
+func (c *Config) SetEndpoint(api, endpoint string) {
+	if c.Endpoints == nil {
+		c.Endpoints = map[string]string{}
+	}
+	c.Endpoints[api] = endpoint
+}
+
+// Sources returns the paths of the configuration files, from the lowest to the highest precedence:
+// SystemFile, the user configuration file, the first .run.yaml found walking up from the working
+// directory, then $RUN_CONFIG_FILE. The files may not exist.
+//
+// The RUN_PROJECT and RUN_REGION variables have precedence over all files.
+func Sources() ([]string, error) {
+	userPath, err := userConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	candidates := []string{SystemFile, userPath, repoConfigPath(), os.Getenv(EnvConfigFile)}
+
+	// A file listed twice keeps its highest precedence.
+	var sources []string
+	for i, path := range candidates {
+		if path != "" && !slices.Contains(candidates[i+1:], path) {
+			sources = append(sources, path)
+		}
+	}
+	return sources, nil
+}
+
+// Origin returns where the value of a key comes from, e.g. file:/etc/run.yaml or env:RUN_PROJECT.
+// It is empty for the values which are not loaded.
+func (c *Config) Origin(key string) string {
+	return c.origins[key]
+}
+
+// SetOrigin records where the value of a key comes from.
+func (c *Config) SetOrigin(key, origin string) {
+	if c.origins == nil {
+		c.origins = map[string]string{}
+	}
+	c.origins[key] = origin
+}
+
+// userConfigPath returns $XDG_CONFIG_HOME/run/config.yaml, $XDG_CONFIG_HOME defaulting to ~/.config.
+// The legacy ~/.run.yaml is returned instead when it exists and the former does not.
+func userConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	path := filepath.Join(configDir, userFile)
+
+	legacyPath := filepath.Join(homeDir, DefaultFile)
+	if !exists(path) && exists(legacyPath) {
+		return legacyPath, nil
+	}
+	return path, nil
+}
+
+// repoConfigPath returns the first .run.yaml found walking up from the working directory,
+// empty if none. The legacy user configuration file is skipped.
+func repoConfigPath() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	homeDir, _ := os.UserHomeDir()
+	legacyPath := filepath.Join(homeDir, DefaultFile)
+
+	for {
+		path := filepath.Join(dir, DefaultFile)
+		if path != legacyPath && exists(path) {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// layering merges configuration layers, from the lowest to the highest precedence.
+type layering struct {
+	c      *Config
+	level  int
+	levels map[string]int
+}
+
+func newLayering(c *Config) *layering {
+	return &layering{c: c, levels: map[string]int{}}
+}
+
+func (l *layering) set(key, origin string) {
+	l.c.SetOrigin(key, origin)
+	l.levels[key] = l.level
+}
+
+// merge merges the values set in a layer.
+func (l *layering) merge(layer Config, origin string) {
+	l.level++
+	if layer.Project != "" {
+		l.c.Project = layer.Project
+		l.set(KeyProject, origin)
+	}
+	if layer.Region != "" {
+		l.c.Region = layer.Region
+		l.set(KeyRegion, origin)
+	}
+	if layer.CurrentContext != "" {
+		l.c.CurrentContext = layer.CurrentContext
+		l.set(KeyCurrentContext, origin)
+	}
+	for _, ctx := range layer.Contexts {
+		l.c.putContext(ctx)
+		l.set(ContextKey(ctx.Name), origin)
+	}
+	if len(layer.Regions) > 0 {
+		l.c.Regions = layer.Regions
+		l.set(KeyRegions, origin)
+	}
+	if layer.Limits != nil {
+		l.mergeLimits(*layer.Limits, origin)
+	}
+	for api, endpoint := range layer.Endpoints {
+		l.c.SetEndpoint(api, endpoint)
+		l.set(EndpointKey(api), origin)
+	}
+}
+
+// mergeLimits merges the limits set in a layer.
+func (l *layering) mergeLimits(limits Limits, origin string) {
+	if l.c.Limits == nil {
+		l.c.Limits = &Limits{}
+	}
+	if limits.Parallelism != 0 {
+		l.c.Limits.Parallelism = limits.Parallelism
+		l.set(KeyParallelism, origin)
+	}
+	if limits.Retries != 0 {
+		l.c.Limits.Retries = limits.Retries
+		l.set(KeyRetries, origin)
+	}
+	if limits.RateLimit != 0 {
+		l.c.Limits.RateLimit = limits.RateLimit
+		l.set(KeyRateLimit, origin)
+	}
+}
+
+// mergeEnv merges the values of the environment variables.
+func (l *layering) mergeEnv() {
+	l.level++
+	if project := os.Getenv(EnvProject); project != "" {
+		l.c.Project = project
+		l.set(KeyProject, "env:"+EnvProject)
+	}
+	if region := os.Getenv(EnvRegion); region != "" {
+		l.c.Region = region
+		l.set(KeyRegion, "env:"+EnvRegion)
+	}
+}
+
+// resolveContext uses the project and region of the current context, unless a layer of higher
+// precedence overrides them. The context is kept in that case, so that its account settings,
+// e.g. read-only, still apply.
+func (l *layering) resolveContext() {
+	ctx := l.c.Current()
+	if ctx == nil {
+		return
+	}
+
+	level, origin := l.levels[KeyCurrentContext], l.c.Origin(KeyCurrentContext)
+	if l.levels[KeyProject] <= level {
+		l.c.Project = ctx.Project
+		l.c.SetOrigin(KeyProject, origin)
+	}
+	if l.levels[KeyRegion] <= level {
+		l.c.Region = ctx.Region
+		l.c.SetOrigin(KeyRegion, origin)
+	}
+}
+
+// snapshot represents the values of a configuration at a point in time.
+type snapshot struct {
+	project        string
+	region         string
+	currentContext string
+	contexts       []Context
+}
+
+func (c *Config) snapshot() snapshot {
+	return snapshot{
+		project:        c.Project,
+		region:         c.Region,
+		currentContext: c.CurrentContext,
+		contexts:       slices.Clone(c.Contexts),
+	}
+}
+
+// applyChanges applies the values changed since the last snapshot to target.
+func (c *Config) applyChanges(target *Config) {
+	for _, ctx := range c.loaded.contexts {
+		if _, err := c.Context(ctx.Name); err != nil {
+			_ = target.DeleteContext(ctx.Name)
+		}
+	}
+	for _, ctx := range c.Contexts {
+		if !slices.Contains(c.loaded.contexts, ctx) {
+			target.putContext(ctx)
+		}
+	}
+
+	if c.Project != c.loaded.project {
+		target.Project = c.Project
+	}
+	if c.Region != c.loaded.region {
+		target.Region = c.Region
+	}
+	if c.CurrentContext != c.loaded.currentContext {
+		target.CurrentContext = c.CurrentContext
+	}
+}
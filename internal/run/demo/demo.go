@@ -0,0 +1,68 @@
+// Package demo implements every api Client in memory, with generated services, revisions,
+// jobs, executions, tasks, worker pools, domain mappings and logs, to demo the CLI, onboard people
+// and try the keybindings without any Google Cloud account.
+package demo
+
+import (
+	"time"
+
+	api_domainmapping "github.com/JulienBreux/run-cli/internal/run/api/domainmapping"
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_execution "github.com/JulienBreux/run-cli/internal/run/api/job/execution"
+	api_task "github.com/JulienBreux/run-cli/internal/run/api/job/execution/task"
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	api_project "github.com/JulienBreux/run-cli/internal/run/api/project"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	api_revision "github.com/JulienBreux/run-cli/internal/run/api/service/revision"
+	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+)
+
+const (
+	// Project is the project selected in the demo mode.
+	Project = "demo-project"
+	// Region is the region selected in the demo mode.
+	Region = "europe-west1"
+	// User is the account shown in the demo mode.
+	User = "demo@example.com"
+)
+
+// Regions are the regions of the demo resources, listed when using all regions.
+var Regions = []string{"asia-northeast1", "europe-west1", "us-central1"}
+
+var enabled bool
+
+// Enable replaces the clients of all API calls with in-memory ones, seeded with
+// resources generated as of now. Changes are kept until the process exits.
+func Enable() {
+	s := newStore(time.Now)
+	api_project.SetClient(&projects{store: s})
+	api_service.SetClient(&services{store: s})
+	api_revision.SetClient(&revisions{store: s})
+	api_job.SetClient(&jobs{store: s})
+	api_execution.SetClient(&executions{store: s})
+	api_task.SetClient(&tasks{store: s})
+	api_workerpool.SetClient(&workerPools{store: s})
+	api_domainmapping.SetClient(&domainMappings{store: s})
+	api_log.SetClientFactory(s.newLogs)
+	enabled = true
+}
+
+// Enabled returns whether the demo mode is enabled, see Enable.
+func Enabled() bool {
+	return enabled
+}
+
+// Info returns the user, project and region of the demo mode, in place of the gcloud ones.
+func Info() (info.Info, error) {
+	return info.Info{User: User, Project: Project, Region: Region}, nil
+}
+
+// Config returns the configuration of the demo mode, which is never saved so that
+// selecting a project or a region leaves the configuration of the user untouched.
+func Config() (*config.Config, error) {
+	cfg := config.InMemory(Project, Region)
+	cfg.Regions = Regions
+	return cfg, nil
+}
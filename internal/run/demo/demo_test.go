@@ -0,0 +1,324 @@
+package demo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/run/apiv2/runpb"
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	fake_store "github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// clock is a settable time, for the executions and the logs.
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+func newTestStore() (*store, *clock) {
+	c := &clock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	return newStore(c.now), c
+}
+
+func TestEnable(t *testing.T) {
+	t.Cleanup(func() { enabled = false })
+	assert.False(t, Enabled())
+
+	Enable()
+	assert.True(t, Enabled())
+	services, err := api_service.List(context.Background(), Project, "europe-west1")
+	assert.NoError(t, err)
+	assert.Len(t, services, 3)
+
+	cfg, err := Config()
+	assert.NoError(t, err)
+	assert.Equal(t, Project, cfg.Project)
+	assert.Equal(t, Region, cfg.Region)
+}
+
+func TestServices(t *testing.T) {
+	s, _ := newTestStore()
+	c := &services{store: s}
+	ctx := context.Background()
+
+	list, err := c.ListServices(ctx, Project, "us-central1")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	list, err = c.ListServices(ctx, "other", "us-central1")
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	// The last revision of a failing service doesn't serve the traffic
+	svc, err := c.GetService(ctx, location(Project, "us-central1")+"/services/recommendations")
+	assert.NoError(t, err)
+	assert.Equal(t, runpb.Condition_CONDITION_FAILED, svc.TerminalCondition.State)
+	assert.NotEqual(t, svc.LatestCreatedRevision, svc.LatestReadyRevision)
+	assert.Equal(t, fake_store.ShortName(svc.LatestReadyRevision), svc.TrafficStatuses[0].Revision)
+
+	// An update deploys a new revision
+	svc, err = c.GetService(ctx, location(Project, "europe-west1")+"/services/api")
+	assert.NoError(t, err)
+	generation := svc.Generation
+	svc.Template.Containers[0].Image = "api:2"
+	updated, err := c.UpdateService(ctx, svc)
+	assert.NoError(t, err)
+	assert.Equal(t, generation+1, updated.Generation)
+	assert.NotEqual(t, svc.LatestReadyRevision, updated.LatestReadyRevision)
+
+	revisions, err := (&revisions{store: s}).ListRevisions(ctx, Project, "europe-west1", "api")
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 13)
+	assert.Equal(t, updated.LatestReadyRevision, revisions[0].Name)
+	assert.Equal(t, "api:2", revisions[0].Containers[0].Image)
+
+	// The etag of the previous version is stale
+	_, err = c.UpdateService(ctx, svc)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = c.CreateService(ctx, location(Project, "europe-west1"), "api", &runpb.Service{})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	created, err := c.CreateService(ctx, location(Project, "europe-west1"), "worker", &runpb.Service{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), created.Generation)
+}
+
+func TestJobs_Executions(t *testing.T) {
+	s, clock := newTestStore()
+	j, e := &jobs{store: s}, &executions{store: s}
+	ctx := context.Background()
+	name := location(Project, "europe-west1") + "/jobs/db-migrate"
+
+	// The second execution of the fixture failed
+	list, err := e.ListExecutions(ctx, Project, "europe-west1", "db-migrate")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, int32(1), list[0].FailedCount)
+	assert.Equal(t, runpb.Condition_CONDITION_FAILED, list[0].Conditions[2].State)
+
+	started, err := j.StartJob(ctx, name)
+	assert.NoError(t, err)
+	assert.Nil(t, started.CompletionTime)
+	assert.Equal(t, int32(1), started.RunningCount)
+	assert.Equal(t, runpb.Condition_CONDITION_RECONCILING, started.Conditions[2].State)
+
+	job, err := j.GetJob(ctx, name)
+	assert.NoError(t, err)
+	assert.Equal(t, fake_store.ShortName(started.Name), job.LatestCreatedExecution.Name)
+	assert.Equal(t, runpb.ExecutionReference_EXECUTION_RUNNING, job.LatestCreatedExecution.CompletionStatus)
+
+	// The task completes after its duration
+	clock.t = clock.t.Add(15 * time.Second)
+	done, err := e.GetExecution(ctx, started.Name)
+	assert.NoError(t, err)
+	assert.NotNil(t, done.CompletionTime)
+	assert.Equal(t, int32(1), done.SucceededCount)
+	assert.Equal(t, runpb.Condition_CONDITION_SUCCEEDED, done.Conditions[2].State)
+
+	_, err = j.StartJob(ctx, location(Project, "europe-west1")+"/jobs/missing")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestExecution_At(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := &execution{
+		Execution:    &runpb.Execution{Name: "e", TaskCount: 5, Parallelism: 2, StartTime: timestamppb.New(start)},
+		taskDuration: 10 * time.Second,
+		failed:       1,
+	}
+
+	tests := []struct {
+		elapsed                    time.Duration
+		succeeded, failed, running int32
+		completed                  bool
+	}{
+		{0, 0, 0, 2, false},
+		{15 * time.Second, 2, 0, 2, false},
+		{25 * time.Second, 4, 0, 1, false},
+		{30 * time.Second, 4, 1, 0, true},
+		{time.Hour, 4, 1, 0, true},
+	}
+	for _, tt := range tests {
+		x := e.at(start.Add(tt.elapsed))
+		assert.Equal(t, tt.succeeded, x.SucceededCount, tt.elapsed)
+		assert.Equal(t, tt.failed, x.FailedCount, tt.elapsed)
+		assert.Equal(t, tt.running, x.RunningCount, tt.elapsed)
+		assert.Equal(t, tt.completed, x.CompletionTime != nil, tt.elapsed)
+	}
+	assert.Equal(t, start.Add(30*time.Second), e.completion())
+}
+
+func TestExecution_Tasks(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := &execution{
+		Execution: &runpb.Execution{
+			Name:        "projects/p/locations/r/jobs/j/executions/j-abcde",
+			TaskCount:   5,
+			Parallelism: 2,
+			StartTime:   timestamppb.New(start),
+			Template:    &runpb.TaskTemplate{Retries: &runpb.TaskTemplate_MaxRetries{MaxRetries: 3}},
+		},
+		taskDuration: 10 * time.Second,
+		failed:       1,
+	}
+
+	// The third batch is pending while the second one runs
+	tasks := e.tasks(start.Add(15 * time.Second))
+	assert.Len(t, tasks, 5)
+	assert.Equal(t, "projects/p/locations/r/jobs/j/executions/j-abcde/tasks/j-abcde-task2", tasks[2].Name)
+	assert.Equal(t, runpb.Condition_CONDITION_SUCCEEDED, tasks[0].Conditions[0].State)
+	assert.Equal(t, runpb.Condition_CONDITION_RECONCILING, tasks[2].Conditions[0].State)
+	assert.Equal(t, runpb.Condition_CONDITION_PENDING, tasks[4].Conditions[0].State)
+	assert.Nil(t, tasks[4].StartTime)
+
+	// The last task fails after its retries
+	tasks = e.tasks(start.Add(time.Minute))
+	assert.Equal(t, int32(0), tasks[3].LastAttemptResult.ExitCode)
+	assert.Equal(t, int32(4), tasks[4].Index)
+	assert.Equal(t, int32(3), tasks[4].Retried)
+	assert.Equal(t, int32(1), tasks[4].LastAttemptResult.ExitCode)
+	assert.Equal(t, runpb.Condition_CONDITION_FAILED, tasks[4].Conditions[0].State)
+	assert.Equal(t, start.Add(30*time.Second), tasks[4].CompletionTime.AsTime())
+}
+
+func TestExecutions_CancelAndDelete(t *testing.T) {
+	s, clock := newTestStore()
+	j, e, c := &jobs{store: s}, &executions{store: s}, &tasks{store: s}
+	ctx := context.Background()
+	name := location(Project, "us-central1") + "/jobs/thumbnail-backfill"
+
+	// The first batch of 10 tasks is done when the execution is cancelled
+	started, err := j.StartJob(ctx, name)
+	assert.NoError(t, err)
+	clock.t = clock.t.Add(45 * time.Second)
+	op, err := e.CancelExecution(ctx, started.Name)
+	assert.NoError(t, err)
+	assert.True(t, op.Done())
+	cancelled, err := op.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(10), cancelled.SucceededCount)
+	assert.Equal(t, int32(40), cancelled.CancelledCount)
+	assert.Equal(t, int32(0), cancelled.RunningCount)
+	assert.Equal(t, clock.t, cancelled.CompletionTime.AsTime())
+	assert.Equal(t, runpb.Condition_CANCELLED, cancelled.Conditions[2].GetExecutionReason())
+
+	// The tasks make no progress once cancelled
+	clock.t = clock.t.Add(time.Hour)
+	x, err := e.GetExecution(ctx, started.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(40), x.CancelledCount)
+	tasks, err := c.ListTasks(ctx, started.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, runpb.Condition_CONDITION_SUCCEEDED, tasks[9].Conditions[0].State)
+	assert.Equal(t, runpb.Condition_CANCELLED, tasks[10].Conditions[0].GetExecutionReason())
+	assert.NotNil(t, tasks[10].StartTime)
+	assert.Nil(t, tasks[20].StartTime)
+	job, err := j.GetJob(ctx, name)
+	assert.NoError(t, err)
+	assert.Equal(t, runpb.ExecutionReference_EXECUTION_CANCELLED, job.LatestCreatedExecution.CompletionStatus)
+
+	// A completed execution can't be cancelled, but can be deleted
+	_, err = e.CancelExecution(ctx, started.Name)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	op, err = e.DeleteExecution(ctx, started.Name)
+	assert.NoError(t, err)
+	deleted, _ := op.Poll(ctx)
+	assert.NotNil(t, deleted.DeleteTime)
+	_, err = e.GetExecution(ctx, started.Name)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = e.DeleteExecution(ctx, started.Name)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = e.CancelExecution(ctx, started.Name)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestJobs_StartRunJob_Overrides(t *testing.T) {
+	s, _ := newTestStore()
+	j, e := &jobs{store: s}, &executions{store: s}
+	ctx := context.Background()
+	name := location(Project, "europe-west1") + "/jobs/db-migrate"
+
+	op, err := j.StartRunJob(ctx, name, &runpb.RunJobRequest_Overrides{
+		TaskCount: 3,
+		Timeout:   durationpb.New(time.Minute),
+		ContainerOverrides: []*runpb.RunJobRequest_Overrides_ContainerOverride{{
+			Args: []string{"--dry-run"},
+			Env:  []*runpb.EnvVar{{Name: "TARGET", Values: &runpb.EnvVar_Value{Value: "staging"}}},
+		}},
+	})
+	assert.NoError(t, err)
+	x, err := e.GetExecution(ctx, op.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), x.TaskCount)
+	assert.Equal(t, time.Minute, x.Template.Timeout.AsDuration())
+	assert.Equal(t, []string{"--dry-run"}, x.Template.Containers[0].Args)
+	assert.Equal(t, "staging", x.Template.Containers[0].Env[0].GetValue())
+
+	// The job itself is unchanged
+	job, err := j.GetJob(ctx, name)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), job.Template.TaskCount)
+	assert.Empty(t, job.Template.Template.Containers[0].Args)
+}
+
+func TestTasks(t *testing.T) {
+	s, _ := newTestStore()
+	c := &tasks{store: s}
+	ctx := context.Background()
+
+	list, err := (&executions{store: s}).ListExecutions(ctx, Project, "europe-west1", "db-migrate")
+	assert.NoError(t, err)
+	tasks, err := c.ListTasks(ctx, list[0].Name)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, int(list[0].TaskCount))
+
+	task, err := c.GetTask(ctx, tasks[0].Name)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), task.Index)
+
+	_, err = c.GetTask(ctx, list[0].Name+"/tasks/unknown")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, _, err = c.ListTasksPage(ctx, "unknown", 10, "")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestLogs(t *testing.T) {
+	s, clock := newTestStore()
+	c, err := s.newLogs(context.Background(), Project)
+	assert.NoError(t, err)
+
+	// The backlog comes newest first
+	backlog := entries(t, c.Entries(context.Background()))
+	assert.Greater(t, len(backlog), 500)
+	assert.True(t, backlog[0].Timestamp.After(backlog[1].Timestamp))
+	assert.False(t, backlog[0].Timestamp.After(clock.t))
+
+	// The next calls only return the new entries, oldest first
+	clock.t = clock.t.Add(5 * time.Second)
+	next := entries(t, c.Entries(context.Background()))
+	assert.NotEmpty(t, next)
+	assert.True(t, next[0].Timestamp.After(backlog[0].Timestamp))
+	assert.Empty(t, entries(t, c.Entries(context.Background())))
+	assert.NoError(t, c.Close())
+}
+
+func entries(t *testing.T, it api_log.EntryIterator) []*logging.Entry {
+	var all []*logging.Entry
+	for {
+		e, err := it.Next()
+		if err != nil {
+			return all
+		}
+		all = append(all, e)
+	}
+}
@@ -0,0 +1,48 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	api_domainmapping "github.com/JulienBreux/run-cli/internal/run/api/domainmapping"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/run/v1"
+)
+
+// domainMappings implements the domain mappings client. The domain mappings can't be changed.
+type domainMappings struct {
+	*store
+}
+
+var _ api_domainmapping.Client = (*domainMappings)(nil)
+
+func (d *domainMappings) ListDomainMappings(ctx context.Context, project, region string) ([]*run.DomainMapping, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	var domainMappings []*run.DomainMapping
+	for name, dm := range d.domainMappings {
+		if strings.HasPrefix(name, location(project, region)+"/domainmappings/") {
+			domainMappings = append(domainMappings, dm)
+		}
+	}
+	slices.SortFunc(domainMappings, func(a, b *run.DomainMapping) int {
+		return strings.Compare(a.Metadata.Name, b.Metadata.Name)
+	})
+	return domainMappings, nil
+}
+
+func (d *domainMappings) GetDomainMapping(ctx context.Context, name string) (*run.DomainMapping, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	dm, ok := d.domainMappings[name]
+	if !ok {
+		return nil, fmt.Errorf("failed to get domain mapping: %w", &googleapi.Error{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("domain mapping %q not found", name),
+		})
+	}
+	return dm, nil
+}
@@ -0,0 +1,241 @@
+package demo
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	fake_store "github.com/JulienBreux/run-cli/internal/run/fake/store"
+	model_project "github.com/JulienBreux/run-cli/internal/run/model/common/project"
+	"google.golang.org/api/run/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// serviceFixture describes a demo service.
+type serviceFixture struct {
+	name      string
+	region    string
+	image     string
+	revisions int
+	// updated is how long ago the last revision was deployed.
+	updated time.Duration
+	// failing makes the last revision fail to start, the previous one keeps serving.
+	failing  bool
+	maxScale int32
+}
+
+// jobFixture describes a demo job and its executions.
+type jobFixture struct {
+	name        string
+	region      string
+	image       string
+	tasks       int32
+	parallelism int32
+	// taskDuration is how long each task runs.
+	taskDuration time.Duration
+	executions   []executionFixture
+}
+
+// executionFixture describes an execution of a demo job.
+type executionFixture struct {
+	// started is how long ago the execution started, it is still running when
+	// started is shorter than the duration of its tasks.
+	started time.Duration
+	// failed is the number of tasks which fail.
+	failed int32
+}
+
+// workerPoolFixture describes a demo worker pool.
+type workerPoolFixture struct {
+	name      string
+	region    string
+	image     string
+	instances int32
+	updated   time.Duration
+}
+
+// domainMappingFixture describes a demo domain mapping.
+type domainMappingFixture struct {
+	domain  string
+	region  string
+	service string
+	// pending leaves the certificate provisioning in progress.
+	pending bool
+}
+
+var (
+	fixtureProjects = []model_project.Project{
+		{Name: Project, Number: 123456789012},
+		{Name: "demo-staging", Number: 210987654321},
+		{Name: "demo-sandbox", Number: 135792468024},
+	}
+
+	fixtureServices = []serviceFixture{
+		{name: "frontend", region: "europe-west1", image: "web/frontend:2.4.1", revisions: 7, updated: 2 * time.Hour, maxScale: 20},
+		{name: "api", region: "europe-west1", image: "web/api:1.18.0", revisions: 12, updated: 25 * time.Minute, maxScale: 50},
+		{name: "auth", region: "europe-west1", image: "platform/auth:3.0.2", revisions: 3, updated: 72 * time.Hour, maxScale: 10},
+		{name: "image-resizer", region: "us-central1", image: "media/image-resizer:0.9.4", revisions: 2, updated: 6 * 24 * time.Hour, maxScale: 100},
+		{name: "recommendations", region: "us-central1", image: "ml/recommendations:1.3.0", revisions: 4, updated: 8 * time.Minute, failing: true, maxScale: 5},
+		{name: "notifications", region: "asia-northeast1", image: "platform/notifications:2.1.0", revisions: 5, updated: 30 * time.Hour, maxScale: 10},
+	}
+
+	fixtureJobs = []jobFixture{
+		{
+			name: "nightly-export", region: "europe-west1", image: "data/export:1.4.0",
+			tasks: 12, parallelism: 3, taskDuration: 40 * time.Second,
+			executions: []executionFixture{{started: 26 * time.Hour}, {started: 2 * time.Hour}, {started: time.Minute}},
+		},
+		{
+			name: "db-migrate", region: "europe-west1", image: "platform/migrate:3.0.2",
+			tasks: 1, parallelism: 1, taskDuration: 15 * time.Second,
+			executions: []executionFixture{{started: 72 * time.Hour}, {started: 3 * time.Hour, failed: 1}},
+		},
+		{
+			name: "thumbnail-backfill", region: "us-central1", image: "media/backfill:0.2.0",
+			tasks: 50, parallelism: 10, taskDuration: 30 * time.Second,
+			executions: []executionFixture{{started: 5 * 24 * time.Hour, failed: 2}, {started: 90 * time.Second}},
+		},
+		{
+			name: "report-generator", region: "asia-northeast1", image: "data/reports:2.0.0",
+			tasks: 4, parallelism: 4, taskDuration: 20 * time.Second,
+		},
+	}
+
+	fixtureWorkerPools = []workerPoolFixture{
+		{name: "queue-consumer", region: "europe-west1", image: "platform/consumer:1.7.3", instances: 3, updated: 5 * time.Hour},
+		{name: "stream-processor", region: "us-central1", image: "data/stream:0.12.1", instances: 1, updated: 4 * 24 * time.Hour},
+	}
+
+	fixtureDomainMappings = []domainMappingFixture{
+		{domain: "www.example.com", region: "europe-west1", service: "frontend"},
+		{domain: "api.example.com", region: "europe-west1", service: "api"},
+		{domain: "beta.example.com", region: "us-central1", service: "recommendations", pending: true},
+	}
+)
+
+func (s *store) seedService(f serviceFixture, start time.Time) {
+	svc := &runpb.Service{
+		Name:    parent(f.region) + "/services/" + f.name,
+		Ingress: runpb.IngressTraffic_INGRESS_TRAFFIC_ALL,
+		Scaling: &runpb.ServiceScaling{ScalingMode: runpb.ServiceScaling_AUTOMATIC, MaxInstanceCount: f.maxScale},
+		Template: &runpb.RevisionTemplate{
+			ServiceAccount:                "runtime@" + Project + ".iam.gserviceaccount.com",
+			MaxInstanceRequestConcurrency: 80,
+			Timeout:                       durationpb.New(5 * time.Minute),
+			Scaling:                       &runpb.RevisionScaling{MaxInstanceCount: f.maxScale},
+			Containers: []*runpb.Container{{
+				Image: image(f.region, f.image),
+				Ports: []*runpb.ContainerPort{{Name: "http1", ContainerPort: 8080}},
+				Resources: &runpb.ResourceRequirements{
+					Limits:  map[string]string{"cpu": "1000m", "memory": "512Mi"},
+					CpuIdle: true,
+				},
+			}},
+		},
+	}
+
+	// Older revisions were deployed one day apart.
+	var live *runpb.Service
+	for i := 1; i <= f.revisions; i++ {
+		deployed := proto.Clone(svc).(*runpb.Service)
+		s.Deploy(deployed, live, start.Add(-f.updated-time.Duration(f.revisions-i)*24*time.Hour), f.failing && i == f.revisions)
+		live = deployed
+	}
+}
+
+func (s *store) seedJob(f jobFixture, start time.Time) {
+	job := &runpb.Job{
+		Name:         parent(f.region) + "/jobs/" + f.name,
+		Uid:          fake_store.UID(f.name),
+		Generation:   1,
+		Creator:      User,
+		LastModifier: User,
+		CreateTime:   timestamppb.New(start.Add(-30 * 24 * time.Hour)),
+		UpdateTime:   timestamppb.New(start.Add(-7 * 24 * time.Hour)),
+		Template: &runpb.ExecutionTemplate{
+			TaskCount:   f.tasks,
+			Parallelism: f.parallelism,
+			Template: &runpb.TaskTemplate{
+				Containers: []*runpb.Container{{
+					Image:     image(f.region, f.image),
+					Resources: &runpb.ResourceRequirements{Limits: map[string]string{"cpu": "1000m", "memory": "512Mi"}},
+				}},
+				Timeout:        durationpb.New(10 * time.Minute),
+				ServiceAccount: "jobs@" + Project + ".iam.gserviceaccount.com",
+				Retries:        &runpb.TaskTemplate_MaxRetries{MaxRetries: 3},
+			},
+		},
+		ObservedGeneration: 1,
+		TerminalCondition:  fake_store.Succeeded("Ready", start.Add(-7*24*time.Hour)),
+		Etag:               fake_store.Etag(f.name, 1),
+	}
+	s.Jobs[job.Name] = job
+	s.taskDurations[job.Name] = f.taskDuration
+
+	for _, e := range f.executions {
+		s.startExecution(job, start.Add(-e.started), f.taskDuration, e.failed)
+	}
+}
+
+func (s *store) seedWorkerPool(f workerPoolFixture, start time.Time) {
+	name := parent(f.region) + "/workerPools/" + f.name
+	s.WorkerPools[name] = &runpb.WorkerPool{
+		Name:         name,
+		Uid:          fake_store.UID(f.name),
+		Generation:   1,
+		Creator:      User,
+		LastModifier: User,
+		CreateTime:   timestamppb.New(start.Add(-20 * 24 * time.Hour)),
+		UpdateTime:   timestamppb.New(start.Add(-f.updated)),
+		Scaling:      &runpb.WorkerPoolScaling{ManualInstanceCount: &f.instances},
+		Template: &runpb.WorkerPoolRevisionTemplate{
+			Containers: []*runpb.Container{{Image: image(f.region, f.image)}},
+		},
+		ObservedGeneration: 1,
+		TerminalCondition:  fake_store.Succeeded("Ready", start.Add(-f.updated)),
+		Etag:               fake_store.Etag(f.name, 1),
+	}
+}
+
+func (s *store) seedDomainMapping(f domainMappingFixture, start time.Time) {
+	certificate := &run.GoogleCloudRunV1Condition{Type: "CertificateProvisioned", Status: "True"}
+	ready := &run.GoogleCloudRunV1Condition{Type: "Ready", Status: "True"}
+	if f.pending {
+		certificate = &run.GoogleCloudRunV1Condition{
+			Type: "CertificateProvisioned", Status: "Unknown", Reason: "CertificatePending",
+			Message: "Certificate issuance pending. The challenge data was not visible through the public internet.",
+		}
+		ready = &run.GoogleCloudRunV1Condition{Type: "Ready", Status: "Unknown", Reason: "CertificatePending"}
+	}
+
+	name := parent(f.region) + "/domainmappings/" + f.domain
+	s.domainMappings[name] = &run.DomainMapping{
+		ApiVersion: "domains.cloudrun.com/v1",
+		Kind:       "DomainMapping",
+		Metadata: &run.ObjectMeta{
+			Name:              f.domain,
+			Namespace:         Project,
+			CreationTimestamp: start.Add(-10 * 24 * time.Hour).UTC().Format(time.RFC3339),
+			Annotations:       map[string]string{"serving.knative.dev/creator": User},
+		},
+		Spec: &run.DomainMappingSpec{RouteName: f.service, CertificateMode: "AUTOMATIC"},
+		Status: &run.DomainMappingStatus{
+			Conditions: []*run.GoogleCloudRunV1Condition{ready, certificate},
+			ResourceRecords: []*run.ResourceRecord{
+				{Type: "CNAME", Name: f.domain[:len(f.domain)-len(".example.com")], Rrdata: "ghs.googlehosted.com."},
+			},
+		},
+	}
+}
+
+// parent returns the name of the location of the demo project in a region.
+func parent(region string) string {
+	return location(Project, region)
+}
+
+// image returns the Artifact Registry image of the demo project in a region.
+func image(region, name string) string {
+	return fmt.Sprintf("%s-docker.pkg.dev/%s/%s", region, Project, name)
+}
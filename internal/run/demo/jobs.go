@@ -0,0 +1,543 @@
+package demo
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_execution "github.com/JulienBreux/run-cli/internal/run/api/job/execution"
+	api_task "github.com/JulienBreux/run-cli/internal/run/api/job/execution/task"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
+	fake_store "github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"github.com/googleapis/gax-go/v2"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultTaskDuration is how long the tasks of the jobs created in the demo mode run.
+const defaultTaskDuration = 5 * time.Second
+
+// jobs implements the jobs client. Executions make progress as time goes by.
+type jobs struct {
+	*store
+}
+
+var _ api_job.Client = (*jobs)(nil)
+
+func (j *jobs) ListJobs(ctx context.Context, project, region string) ([]*runpb.Job, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	jobs := fake_store.List(j.Jobs, location(project, region))
+	for _, job := range jobs {
+		j.refreshJob(job)
+	}
+	return jobs, nil
+}
+
+func (j *jobs) GetJob(ctx context.Context, name string) (*runpb.Job, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	job, err := fake_store.Get(j.Jobs, "job", name)
+	if err != nil {
+		return nil, err
+	}
+	j.refreshJob(job)
+	return job, nil
+}
+
+func (j *jobs) CreateJob(ctx context.Context, parent, jobID string, job *runpb.Job) (*runpb.Job, error) {
+	created := proto.Clone(job).(*runpb.Job)
+	created.Name = parent + "/jobs/" + jobID
+
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	if _, ok := j.Jobs[created.Name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "job %q already exists", created.Name)
+	}
+	j.PutJob(created, nil)
+	return proto.Clone(created).(*runpb.Job), nil
+}
+
+func (j *jobs) UpdateJob(ctx context.Context, job *runpb.Job) (*runpb.Job, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	live, ok := j.Jobs[job.GetName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "job %q not found", job.GetName())
+	}
+	if err := fake_store.CheckEtag(job.GetEtag(), live.GetEtag()); err != nil {
+		return nil, err
+	}
+
+	updated := proto.Clone(job).(*runpb.Job)
+	j.PutJob(updated, live)
+	return proto.Clone(updated).(*runpb.Job), nil
+}
+
+// RunJob starts an execution and waits for its completion.
+func (j *jobs) RunJob(ctx context.Context, name string) (*runpb.Execution, error) {
+	e, err := j.start(name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		j.Mu.Lock()
+		x := e.at(j.Now())
+		completion := e.completion()
+		j.Mu.Unlock()
+		if x.CompletionTime != nil {
+			return x, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Until(completion)):
+		}
+	}
+}
+
+// StartJob starts an execution and returns it without waiting for its completion.
+func (j *jobs) StartJob(ctx context.Context, name string) (*runpb.Execution, error) {
+	e, err := j.start(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	return e.at(j.Now()), nil
+}
+
+// StartRunJob starts an execution, with the overrides if any, and returns its operation, done once
+// the execution completes.
+func (j *jobs) StartRunJob(ctx context.Context, name string, overrides *runpb.RunJobRequest_Overrides) (operation.Poller[*runpb.Execution], error) {
+	e, err := j.start(name, overrides)
+	if err != nil {
+		return nil, err
+	}
+	return &runJobOperation{jobs: j, execution: e}, nil
+}
+
+// runJobOperation is the operation of an execution, polled from the clock of the store.
+type runJobOperation struct {
+	jobs      *jobs
+	execution *execution
+	done      bool
+}
+
+func (o *runJobOperation) Name() string { return o.execution.Name }
+
+func (o *runJobOperation) Done() bool { return o.done }
+
+func (o *runJobOperation) Poll(ctx context.Context, opts ...gax.CallOption) (*runpb.Execution, error) {
+	x, _ := o.Metadata()
+	o.done = x.CompletionTime != nil
+	if o.done && x.FailedCount > 0 {
+		return x, status.Errorf(codes.Unknown, "execution %s failed: %d tasks failed", fake_store.ShortName(x.Name), x.FailedCount)
+	}
+	return x, nil
+}
+
+func (o *runJobOperation) Metadata() (*runpb.Execution, error) {
+	o.jobs.Mu.Lock()
+	defer o.jobs.Mu.Unlock()
+	return o.execution.at(o.jobs.Now()), nil
+}
+
+// start starts an execution of a job, whose template is changed by the overrides if any.
+func (j *jobs) start(name string, overrides *runpb.RunJobRequest_Overrides) (*execution, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	job, ok := j.Jobs[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "job %q not found", name)
+	}
+	taskDuration, ok := j.taskDurations[name]
+	if !ok {
+		taskDuration = defaultTaskDuration
+	}
+	if overrides != nil {
+		job = override(job, overrides)
+	}
+	return j.startExecution(job, j.Now(), taskDuration, 0), nil
+}
+
+// override returns a copy of a job whose template is changed by the overrides.
+func override(job *runpb.Job, overrides *runpb.RunJobRequest_Overrides) *runpb.Job {
+	job = proto.Clone(job).(*runpb.Job)
+	if job.Template == nil {
+		job.Template = &runpb.ExecutionTemplate{}
+	}
+	if job.Template.Template == nil {
+		job.Template.Template = &runpb.TaskTemplate{}
+	}
+	if overrides.TaskCount > 0 {
+		job.Template.TaskCount = overrides.TaskCount
+	}
+	if overrides.Timeout != nil {
+		job.Template.Template.Timeout = overrides.Timeout
+	}
+	for _, o := range overrides.ContainerOverrides {
+		for _, c := range job.Template.Template.Containers {
+			if c.Name != o.Name {
+				continue
+			}
+			if len(o.Args) > 0 || o.ClearArgs {
+				c.Args = o.Args
+			}
+			c.Env = append(slices.DeleteFunc(c.Env, func(env *runpb.EnvVar) bool {
+				return slices.ContainsFunc(o.Env, func(v *runpb.EnvVar) bool { return v.Name == env.Name })
+			}), o.Env...)
+		}
+	}
+	return job
+}
+
+// refreshJob updates the reference to the latest execution of a job copy, whose
+// status changes as time goes by.
+func (s *store) refreshJob(job *runpb.Job) {
+	if job.LatestCreatedExecution == nil {
+		return
+	}
+	e, ok := s.executions[job.Name+"/executions/"+job.LatestCreatedExecution.Name]
+	if !ok {
+		return
+	}
+	x := e.at(s.Now())
+	job.LatestCreatedExecution.CompletionTime = x.CompletionTime
+	switch {
+	case x.CompletionTime == nil:
+		job.LatestCreatedExecution.CompletionStatus = runpb.ExecutionReference_EXECUTION_RUNNING
+	case x.CancelledCount > 0:
+		job.LatestCreatedExecution.CompletionStatus = runpb.ExecutionReference_EXECUTION_CANCELLED
+	case x.FailedCount > 0:
+		job.LatestCreatedExecution.CompletionStatus = runpb.ExecutionReference_EXECUTION_FAILED
+	default:
+		job.LatestCreatedExecution.CompletionStatus = runpb.ExecutionReference_EXECUTION_SUCCEEDED
+	}
+}
+
+// startExecution stores a new execution of the job, started at the given time,
+// whose last failed tasks fail.
+func (s *store) startExecution(job *runpb.Job, start time.Time, taskDuration time.Duration, failed int32) *execution {
+	tmpl := job.GetTemplate()
+	if tmpl == nil {
+		tmpl = &runpb.ExecutionTemplate{}
+	}
+	tmpl = proto.Clone(tmpl).(*runpb.ExecutionTemplate)
+
+	id := fake_store.ShortName(job.Name) + "-" + fake_store.Suffix(5, job.Name, start.UnixNano())
+	name := job.Name + "/executions/" + id
+	at := timestamppb.New(start)
+	e := &execution{
+		Execution: &runpb.Execution{
+			Name:               name,
+			Uid:                fake_store.UID(name),
+			Generation:         1,
+			Labels:             tmpl.Labels,
+			Annotations:        tmpl.Annotations,
+			CreateTime:         at,
+			StartTime:          at,
+			UpdateTime:         at,
+			Job:                fake_store.ShortName(job.Name),
+			Parallelism:        tmpl.Parallelism,
+			TaskCount:          max(tmpl.TaskCount, 1),
+			Template:           tmpl.Template,
+			ObservedGeneration: 1,
+			LogUri:             fmt.Sprintf("https://console.cloud.google.com/logs/viewer?project=%s&resource=cloud_run_job/job_name/%s", Project, fake_store.ShortName(job.Name)),
+			Etag:               fake_store.Etag(name, 1),
+		},
+		taskDuration: taskDuration,
+		failed:       min(failed, max(tmpl.TaskCount, 1)),
+	}
+	s.executions[name] = e
+
+	live := s.Jobs[job.Name]
+	live.ExecutionCount++
+	live.LatestCreatedExecution = &runpb.ExecutionReference{Name: id, CreateTime: at}
+	return e
+}
+
+// execution is an execution whose tasks make progress as time goes by: they run
+// by batches of parallelism tasks, each batch during taskDuration.
+type execution struct {
+	// Execution is the execution as of its start.
+	*runpb.Execution
+	taskDuration time.Duration
+	// failed is the number of tasks which fail, the last ones.
+	failed int32
+	// cancelled is when the execution was cancelled, zero if it wasn't.
+	cancelled time.Time
+}
+
+// parallelism returns the number of tasks running at the same time.
+func (e *execution) parallelism() int32 {
+	if e.Parallelism <= 0 || e.Parallelism > e.TaskCount {
+		return e.TaskCount
+	}
+	return e.Parallelism
+}
+
+// completion returns the time at which all the tasks are completed, or cancelled.
+func (e *execution) completion() time.Time {
+	if !e.cancelled.IsZero() {
+		return e.cancelled
+	}
+	batches := (e.TaskCount + e.parallelism() - 1) / e.parallelism()
+	return e.StartTime.AsTime().Add(time.Duration(batches) * e.taskDuration)
+}
+
+// at returns the execution as of the given time.
+func (e *execution) at(now time.Time) *runpb.Execution {
+	x := proto.Clone(e.Execution).(*runpb.Execution)
+	start := x.StartTime.AsTime()
+	// The tasks make no progress once the execution is cancelled.
+	cancelled := e.isCancelled(now)
+	progress := now
+	if cancelled {
+		progress = e.cancelled
+	}
+	batches := max(0, min(int64(progress.Sub(start)/e.taskDuration), int64(x.TaskCount)))
+	done := min(x.TaskCount, int32(batches)*e.parallelism())
+
+	x.FailedCount = max(0, done-(x.TaskCount-e.failed))
+	x.SucceededCount = done - x.FailedCount
+	x.RunningCount = min(e.parallelism(), x.TaskCount-done)
+	x.RetriedCount = x.FailedCount * maxRetries(x)
+	if cancelled {
+		x.CancelledCount, x.RunningCount = x.TaskCount-done, 0
+	}
+
+	completed := &runpb.Condition{
+		Type:               "Completed",
+		State:              runpb.Condition_CONDITION_RECONCILING,
+		Message:            fmt.Sprintf("%d of %d tasks completed.", done, x.TaskCount),
+		LastTransitionTime: x.StartTime,
+	}
+	x.UpdateTime = timestamppb.New(now)
+	if done == x.TaskCount {
+		end := timestamppb.New(e.completion())
+		x.CompletionTime, x.UpdateTime = end, end
+		completed = &runpb.Condition{
+			Type:               "Completed",
+			State:              runpb.Condition_CONDITION_SUCCEEDED,
+			Message:            "Execution completed successfully.",
+			LastTransitionTime: end,
+		}
+		if x.FailedCount > 0 {
+			completed.State = runpb.Condition_CONDITION_FAILED
+			completed.Message = fmt.Sprintf("Task %s-task%d failed with message: The container exited with an error.", fake_store.ShortName(x.Name), x.TaskCount-1)
+		}
+	} else if cancelled {
+		end := timestamppb.New(e.cancelled)
+		x.CompletionTime, x.UpdateTime = end, end
+		completed = &runpb.Condition{
+			Type:               "Completed",
+			State:              runpb.Condition_CONDITION_FAILED,
+			Message:            "Execution was cancelled.",
+			LastTransitionTime: end,
+			Reasons:            &runpb.Condition_ExecutionReason_{ExecutionReason: runpb.Condition_CANCELLED},
+		}
+	}
+	x.Conditions = []*runpb.Condition{fake_store.Succeeded("ResourcesAvailable", start), fake_store.Succeeded("Started", start), completed}
+	return x
+}
+
+// isCancelled returns whether the execution is cancelled as of the given time.
+func (e *execution) isCancelled(now time.Time) bool {
+	return !e.cancelled.IsZero() && !now.Before(e.cancelled)
+}
+
+// maxRetries returns the number of retries of the failed tasks of an execution.
+func maxRetries(x *runpb.Execution) int32 {
+	return x.GetTemplate().GetMaxRetries()
+}
+
+// executions implements the executions client.
+type executions struct {
+	*store
+}
+
+var _ api_execution.Client = (*executions)(nil)
+
+// ListExecutions lists the executions of a job, the latest first as with the API.
+func (e *executions) ListExecutions(ctx context.Context, project, region, jobName string) ([]*runpb.Execution, error) {
+	parent := jobName
+	if !strings.HasPrefix(jobName, "projects/") {
+		parent = location(project, region) + "/jobs/" + jobName
+	}
+
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	now := e.Now()
+	var executions []*runpb.Execution
+	for name, exec := range e.executions {
+		if strings.HasPrefix(name, parent+"/executions/") {
+			executions = append(executions, exec.at(now))
+		}
+	}
+	slices.SortFunc(executions, func(a, b *runpb.Execution) int {
+		return cmp.Compare(b.CreateTime.AsTime().UnixNano(), a.CreateTime.AsTime().UnixNano())
+	})
+	return executions, nil
+}
+
+func (e *executions) ListExecutionsPage(ctx context.Context, project, region, jobName string, pageSize int, pageToken string) ([]*runpb.Execution, string, error) {
+	executions, _ := e.ListExecutions(ctx, project, region, jobName)
+	return client.Paginate(executions, pageSize, pageToken)
+}
+
+func (e *executions) GetExecution(ctx context.Context, name string) (*runpb.Execution, error) {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	exec, ok := e.executions[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "execution %q not found", name)
+	}
+	return exec.at(e.Now()), nil
+}
+
+// CancelExecution cancels a running execution, whose operation is done at once.
+func (e *executions) CancelExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	exec, ok := e.executions[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "execution %q not found", name)
+	}
+	now := e.Now()
+	if !now.Before(exec.completion()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "execution %q is already completed", name)
+	}
+	exec.cancelled = now
+	return operation.Completed(name, exec.at(now)), nil
+}
+
+// DeleteExecution deletes an execution, cancelling it if it is running, whose operation is done at once.
+func (e *executions) DeleteExecution(ctx context.Context, name string) (operation.Poller[*runpb.Execution], error) {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	exec, ok := e.executions[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "execution %q not found", name)
+	}
+	now := e.Now()
+	if now.Before(exec.completion()) {
+		exec.cancelled = now
+	}
+	delete(e.executions, name)
+	x := exec.at(now)
+	x.DeleteTime = timestamppb.New(now)
+	return operation.Completed(name, x), nil
+}
+
+// tasks returns the tasks of the execution as of the given time: the task of index i runs with the
+// batch i / parallelism, and the last failed tasks fail after their retries.
+func (e *execution) tasks(now time.Time) []*runpb.Task {
+	var tasks []*runpb.Task
+	for i := range e.TaskCount {
+		name := fmt.Sprintf("%s/tasks/%s-task%d", e.Name, fake_store.ShortName(e.Name), i)
+		start := e.StartTime.AsTime().Add(time.Duration(i/e.parallelism()) * e.taskDuration)
+		t := &runpb.Task{
+			Name:          name,
+			Uid:           fake_store.UID(name),
+			CreateTime:    e.CreateTime,
+			ScheduledTime: e.StartTime,
+			Job:           e.Job,
+			Execution:     fake_store.ShortName(e.Name),
+			Index:         i,
+			MaxRetries:    maxRetries(e.Execution),
+			LogUri:        e.LogUri,
+		}
+		completed := &runpb.Condition{Type: "Completed", State: runpb.Condition_CONDITION_PENDING}
+		end := start.Add(e.taskDuration)
+		if e.isCancelled(now) && e.cancelled.Before(end) {
+			// The task is cancelled while running, or before it started.
+			if e.cancelled.After(start) {
+				t.StartTime = timestamppb.New(start)
+			}
+			t.CompletionTime = timestamppb.New(e.cancelled)
+			completed = &runpb.Condition{
+				Type:               "Completed",
+				State:              runpb.Condition_CONDITION_FAILED,
+				Message:            "Task was cancelled.",
+				LastTransitionTime: t.CompletionTime,
+				Reasons:            &runpb.Condition_ExecutionReason_{ExecutionReason: runpb.Condition_CANCELLED},
+			}
+			t.Conditions = []*runpb.Condition{completed}
+			tasks = append(tasks, t)
+			continue
+		}
+		if !now.Before(start) {
+			t.StartTime = timestamppb.New(start)
+			completed.State = runpb.Condition_CONDITION_RECONCILING
+		}
+		if !now.Before(end) {
+			t.CompletionTime = timestamppb.New(end)
+			t.LastAttemptResult = &runpb.TaskAttemptResult{}
+			completed = fake_store.Succeeded("Completed", end)
+			if i >= e.TaskCount-e.failed {
+				t.Retried = t.MaxRetries
+				t.LastAttemptResult = &runpb.TaskAttemptResult{
+					ExitCode: 1,
+					Status:   &rpcstatus.Status{Code: int32(codes.Unknown), Message: "The container exited with an error."},
+				}
+				completed.State = runpb.Condition_CONDITION_FAILED
+				completed.Message = "Task failed: The container exited with an error."
+			}
+		}
+		t.Conditions = []*runpb.Condition{completed}
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+// tasks implements the tasks client.
+type tasks struct {
+	*store
+}
+
+var _ api_task.Client = (*tasks)(nil)
+
+// ListTasks lists the tasks of an execution, by index as with the API.
+func (t *tasks) ListTasks(ctx context.Context, execution string) ([]*runpb.Task, error) {
+	t.Mu.Lock()
+	defer t.Mu.Unlock()
+	exec, ok := t.executions[execution]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "execution %q not found", execution)
+	}
+	return exec.tasks(t.Now()), nil
+}
+
+func (t *tasks) ListTasksPage(ctx context.Context, execution string, pageSize int, pageToken string) ([]*runpb.Task, string, error) {
+	tasks, err := t.ListTasks(ctx, execution)
+	if err != nil {
+		return nil, "", err
+	}
+	return client.Paginate(tasks, pageSize, pageToken)
+}
+
+func (t *tasks) GetTask(ctx context.Context, name string) (*runpb.Task, error) {
+	execution, _, _ := strings.Cut(name, "/tasks/")
+	tasks, err := t.ListTasks(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.Name == name {
+			return task, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "task %q not found", name)
+}
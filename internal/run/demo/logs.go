@@ -0,0 +1,110 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/logging"
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	fake_store "github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// logInterval is the average time between two log entries.
+	logInterval = time.Second
+	// logBacklog is how far back the first call returns log entries.
+	logBacklog = 10 * time.Minute
+)
+
+// logMessages are the payloads of the log entries, picked at random by severity.
+var logMessages = []struct {
+	severity logging.Severity
+	message  string
+}{
+	{logging.Info, "GET /api/v1/orders 200 12ms"},
+	{logging.Info, "GET /api/v1/orders/8412 200 7ms"},
+	{logging.Info, "POST /api/v1/checkout 201 84ms"},
+	{logging.Info, "GET /healthz 200 1ms"},
+	{logging.Info, "GET /api/v1/products?page=2 200 23ms"},
+	{logging.Debug, "cache hit for key product:1337"},
+	{logging.Debug, "cache miss for key user:42, loading from database"},
+	{logging.Info, "processed 128 messages from subscription orders-sub"},
+	{logging.Notice, "new instance started in 412ms"},
+	{logging.Warning, "slow query on orders took 1.24s"},
+	{logging.Warning, "GET /api/v1/search 429 3ms: rate limit exceeded"},
+	{logging.Error, "POST /api/v1/payments 502 30004ms: upstream request failed: context deadline exceeded"},
+}
+
+// logs implements the logging client with synthetic entries, about one per logInterval,
+// whatever the filter. The first call returns the entries of the last logBacklog, the
+// next ones the entries written since the previous call.
+type logs struct {
+	now func() time.Time
+	// last is the time of the last slot returned, zero before the first call.
+	last time.Time
+}
+
+// newLogs returns a logging client, see api_log.ClientFactory.
+func (s *store) newLogs(ctx context.Context, projectID string) (api_log.Client, error) {
+	return &logs{now: s.Now}, nil
+}
+
+func (l *logs) Entries(ctx context.Context, opts ...interface{}) api_log.EntryIterator {
+	now := l.now()
+	backlog := l.last.IsZero()
+	from := l.last.Add(logInterval)
+	if backlog {
+		from = now.Add(-logBacklog).Truncate(logInterval)
+	}
+
+	var entries []*logging.Entry
+	for slot := from; !slot.After(now); slot = slot.Add(logInterval) {
+		if e := logEntry(slot); !e.Timestamp.After(now) {
+			entries = append(entries, e)
+			l.last = slot
+		}
+	}
+	// The backlog is requested newest first.
+	if backlog {
+		slices.Reverse(entries)
+	}
+	return &entryIterator{entries: entries}
+}
+
+func (l *logs) Close() error {
+	return nil
+}
+
+// logEntry returns the entry of a slot, the same for every call.
+func logEntry(slot time.Time) *logging.Entry {
+	h := fake_store.Hash(slot.UnixNano())
+	m := logMessages[h%uint64(len(logMessages))]
+	// Errors are rarer than the other entries.
+	if m.severity == logging.Error && h%3 != 0 {
+		m = logMessages[0]
+	}
+	return &logging.Entry{
+		Timestamp: slot.Add(time.Duration(h>>32%uint64(logInterval/time.Millisecond)) * time.Millisecond),
+		Severity:  m.severity,
+		Payload:   m.message,
+		LogName:   fmt.Sprintf("projects/%s/logs/run.googleapis.com%%2Fstdout", Project),
+		InsertID:  fmt.Sprintf("%016x", h),
+	}
+}
+
+// entryIterator iterates over the generated entries.
+type entryIterator struct {
+	entries []*logging.Entry
+}
+
+func (it *entryIterator) Next() (*logging.Entry, error) {
+	if len(it.entries) == 0 {
+		return nil, iterator.Done
+	}
+	e := it.entries[0]
+	it.entries = it.entries[1:]
+	return e, nil
+}
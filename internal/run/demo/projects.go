@@ -0,0 +1,31 @@
+package demo
+
+import (
+	"context"
+	"slices"
+
+	api_project "github.com/JulienBreux/run-cli/internal/run/api/project"
+	model "github.com/JulienBreux/run-cli/internal/run/model/common/project"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// projects implements the projects client. Only the first project has resources.
+type projects struct {
+	*store
+}
+
+var _ api_project.Client = (*projects)(nil)
+
+func (p *projects) ListProjects(ctx context.Context) ([]model.Project, error) {
+	return slices.Clone(p.projects), nil
+}
+
+func (p *projects) GetProject(ctx context.Context, projectID string) (model.Project, error) {
+	for _, project := range p.projects {
+		if project.Name == projectID {
+			return project, nil
+		}
+	}
+	return model.Project{}, status.Errorf(codes.NotFound, "project %q not found", projectID)
+}
@@ -0,0 +1,105 @@
+package demo
+
+import (
+	"context"
+	"slices"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	api_revision "github.com/JulienBreux/run-cli/internal/run/api/service/revision"
+	fake_store "github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// services implements the services client. Each create or update deploys a new revision.
+type services struct {
+	*store
+}
+
+var _ api_service.Client = (*services)(nil)
+
+func (s *services) ListServices(ctx context.Context, project, region string) ([]*runpb.Service, error) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	return fake_store.List(s.Services, location(project, region)), nil
+}
+
+func (s *services) ListServicesPage(ctx context.Context, project, region string, pageSize int, pageToken string) ([]*runpb.Service, string, error) {
+	services, _ := s.ListServices(ctx, project, region)
+	return client.Paginate(services, pageSize, pageToken)
+}
+
+func (s *services) GetService(ctx context.Context, name string) (*runpb.Service, error) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	return fake_store.Get(s.Services, "service", name)
+}
+
+func (s *services) CreateService(ctx context.Context, parent, serviceID string, service *runpb.Service) (*runpb.Service, error) {
+	svc := proto.Clone(service).(*runpb.Service)
+	svc.Name = parent + "/services/" + serviceID
+
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	if _, ok := s.Services[svc.Name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "service %q already exists", svc.Name)
+	}
+	s.Deploy(svc, nil, s.Now(), false)
+	return proto.Clone(svc).(*runpb.Service), nil
+}
+
+func (s *services) UpdateService(ctx context.Context, service *runpb.Service) (*runpb.Service, error) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	live, ok := s.Services[service.GetName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "service %q not found", service.GetName())
+	}
+	if err := fake_store.CheckEtag(service.GetEtag(), live.GetEtag()); err != nil {
+		return nil, err
+	}
+
+	svc := proto.Clone(service).(*runpb.Service)
+	s.Deploy(svc, live, s.Now(), false)
+	return proto.Clone(svc).(*runpb.Service), nil
+}
+
+// StartUpdateService updates a service, whose rollout is done at once.
+func (s *services) StartUpdateService(ctx context.Context, service *runpb.Service) (operation.Poller[*runpb.Service], error) {
+	svc, err := s.UpdateService(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	return operation.Completed(svc.Name, svc), nil
+}
+
+// revisions implements the revisions client.
+type revisions struct {
+	*store
+}
+
+var _ api_revision.Client = (*revisions)(nil)
+
+func (r *revisions) ListRevisions(ctx context.Context, project, region, service string) ([]*runpb.Revision, error) {
+	r.Mu.Lock()
+	defer r.Mu.Unlock()
+	// The latest revisions come first, as with the API.
+	revisions := fake_store.List(r.Revisions, location(project, region)+"/services/"+service)
+	slices.Reverse(revisions)
+	return revisions, nil
+}
+
+func (r *revisions) ListRevisionsPage(ctx context.Context, project, region, service string, pageSize int, pageToken string) ([]*runpb.Revision, string, error) {
+	revisions, _ := r.ListRevisions(ctx, project, region, service)
+	return client.Paginate(revisions, pageSize, pageToken)
+}
+
+func (r *revisions) GetRevision(ctx context.Context, name string) (*runpb.Revision, error) {
+	r.Mu.Lock()
+	defer r.Mu.Unlock()
+	return fake_store.Get(r.Revisions, "revision", name)
+}
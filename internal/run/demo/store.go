@@ -0,0 +1,51 @@
+package demo
+
+import (
+	"time"
+
+	fake_store "github.com/JulienBreux/run-cli/internal/run/fake/store"
+	model_project "github.com/JulienBreux/run-cli/internal/run/model/common/project"
+	"google.golang.org/api/run/v1"
+)
+
+// store holds the demo resources, keyed by full resource name. The executions make progress
+// as time goes by, unlike the ones of the shared store.
+type store struct {
+	*fake_store.Store
+	projects   []model_project.Project
+	executions map[string]*execution
+	// taskDurations are how long the tasks of the jobs run, keyed by job name.
+	taskDurations  map[string]time.Duration
+	domainMappings map[string]*run.DomainMapping
+}
+
+// newStore returns a store seeded with the fixtures, relative to the current time.
+func newStore(now func() time.Time) *store {
+	s := &store{
+		Store:          fake_store.New(now, User),
+		projects:       fixtureProjects,
+		executions:     map[string]*execution{},
+		taskDurations:  map[string]time.Duration{},
+		domainMappings: map[string]*run.DomainMapping{},
+	}
+	start := now()
+
+	for _, f := range fixtureServices {
+		s.seedService(f, start)
+	}
+	for _, f := range fixtureJobs {
+		s.seedJob(f, start)
+	}
+	for _, f := range fixtureWorkerPools {
+		s.seedWorkerPool(f, start)
+	}
+	for _, f := range fixtureDomainMappings {
+		s.seedDomainMapping(f, start)
+	}
+	return s
+}
+
+// location returns the name of a location.
+func location(project, region string) string {
+	return "projects/" + project + "/locations/" + region
+}
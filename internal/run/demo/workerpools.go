@@ -0,0 +1,57 @@
+package demo
+
+import (
+	"context"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/operation"
+	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
+	fake_store "github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// workerPools implements the worker pools client.
+type workerPools struct {
+	*store
+}
+
+var _ api_workerpool.Client = (*workerPools)(nil)
+
+func (w *workerPools) ListWorkerPools(ctx context.Context, project, region string) ([]*runpb.WorkerPool, error) {
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	return fake_store.List(w.WorkerPools, location(project, region)), nil
+}
+
+func (w *workerPools) GetWorkerPool(ctx context.Context, name string) (*runpb.WorkerPool, error) {
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	return fake_store.Get(w.WorkerPools, "worker pool", name)
+}
+
+func (w *workerPools) UpdateWorkerPool(ctx context.Context, workerPool *runpb.WorkerPool) (*runpb.WorkerPool, error) {
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	live, ok := w.WorkerPools[workerPool.GetName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "worker pool %q not found", workerPool.GetName())
+	}
+	if err := fake_store.CheckEtag(workerPool.GetEtag(), live.GetEtag()); err != nil {
+		return nil, err
+	}
+
+	updated := proto.Clone(workerPool).(*runpb.WorkerPool)
+	w.PutWorkerPool(updated, live)
+	return proto.Clone(updated).(*runpb.WorkerPool), nil
+}
+
+// StartUpdateWorkerPool updates a worker pool, whose rollout is done at once.
+func (w *workerPools) StartUpdateWorkerPool(ctx context.Context, workerPool *runpb.WorkerPool) (operation.Poller[*runpb.WorkerPool], error) {
+	updated, err := w.UpdateWorkerPool(ctx, workerPool)
+	if err != nil {
+		return nil, err
+	}
+	return operation.Completed(updated.Name, updated), nil
+}
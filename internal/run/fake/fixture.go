@@ -0,0 +1,85 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture represents the resources served by the fake server when it starts.
+//
+// The resources use the JSON field names of the Cloud Run Admin API v2, so the
+// output of `run describe --raw` can be pasted in a fixture. Their name must be
+// the full resource name, e.g. projects/demo/locations/europe-west1/services/api.
+type Fixture struct {
+	Services    []*runpb.Service
+	Revisions   []*runpb.Revision
+	Jobs        []*runpb.Job
+	Executions  []*runpb.Execution
+	WorkerPools []*runpb.WorkerPool
+}
+
+// document is the YAML layout of a fixture.
+type document struct {
+	Services    []any `yaml:"services"`
+	Revisions   []any `yaml:"revisions"`
+	Jobs        []any `yaml:"jobs"`
+	Executions  []any `yaml:"executions"`
+	WorkerPools []any `yaml:"workerPools"`
+}
+
+// LoadFixture parses a YAML or JSON fixture.
+func LoadFixture(r io.Reader) (*Fixture, error) {
+	var doc document
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+
+	f := &Fixture{}
+	var err error
+	if f.Services, err = decodeAll[runpb.Service]("services", doc.Services); err != nil {
+		return nil, err
+	}
+	if f.Revisions, err = decodeAll[runpb.Revision]("revisions", doc.Revisions); err != nil {
+		return nil, err
+	}
+	if f.Jobs, err = decodeAll[runpb.Job]("jobs", doc.Jobs); err != nil {
+		return nil, err
+	}
+	if f.Executions, err = decodeAll[runpb.Execution]("executions", doc.Executions); err != nil {
+		return nil, err
+	}
+	if f.WorkerPools, err = decodeAll[runpb.WorkerPool]("workerPools", doc.WorkerPools); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// decodeAll decodes the resources of a fixture section.
+func decodeAll[T any, M interface {
+	*T
+	proto.Message
+	GetName() string
+}](section string, items []any) ([]M, error) {
+	resources := make([]M, 0, len(items))
+	for i, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", section, i, err)
+		}
+		m := M(new(T))
+		if err := protojson.Unmarshal(b, m); err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", section, i, err)
+		}
+		if m.GetName() == "" {
+			return nil, fmt.Errorf("%s[%d]: missing name", section, i)
+		}
+		resources = append(resources, m)
+	}
+	return resources, nil
+}
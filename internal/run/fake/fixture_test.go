@@ -0,0 +1,57 @@
+package fake
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFixture(t *testing.T) {
+	f, err := LoadFixture(strings.NewReader(`
+services:
+  - name: projects/p/locations/r/services/s
+    template:
+      containers:
+        - image: nginx
+      maxInstanceRequestConcurrency: 80
+jobs:
+  - name: projects/p/locations/r/jobs/j
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, f.Services, 1) {
+		return
+	}
+	assert.Equal(t, "nginx", f.Services[0].Template.Containers[0].Image)
+	assert.Equal(t, int32(80), f.Services[0].Template.MaxInstanceRequestConcurrency)
+	if !assert.Len(t, f.Jobs, 1) {
+		return
+	}
+	assert.Empty(t, f.WorkerPools)
+
+	f, err = LoadFixture(strings.NewReader(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, f.Services)
+}
+
+func TestLoadFixture_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		wantErr string
+	}{
+		{"YAML", "services: [", "invalid fixture"},
+		{"Unknown field", "jobs:\n  - name: projects/p/locations/r/jobs/j\n    unknown: 1\n", "jobs[0]"},
+		{"Missing name", "workerPools:\n  - labels: {a: b}\n", "workerPools[0]: missing name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFixture(strings.NewReader(tt.fixture))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// jobs implements the Jobs API. Executions complete as soon as they are run.
+type jobs struct {
+	runpb.UnimplementedJobsServer
+	*Server
+}
+
+func (j *jobs) ListJobs(ctx context.Context, req *runpb.ListJobsRequest) (*runpb.ListJobsResponse, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	return &runpb.ListJobsResponse{Jobs: store.List(j.Jobs, req.GetParent())}, nil
+}
+
+func (j *jobs) GetJob(ctx context.Context, req *runpb.GetJobRequest) (*runpb.Job, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	return store.Get(j.Jobs, "job", req.GetName())
+}
+
+func (j *jobs) CreateJob(ctx context.Context, req *runpb.CreateJobRequest) (*longrunningpb.Operation, error) {
+	if req.GetJobId() == "" || req.GetJob() == nil {
+		return nil, status.Error(codes.InvalidArgument, "job and job_id are required")
+	}
+	job := req.GetJob()
+	job.Name = req.GetParent() + "/jobs/" + req.GetJobId()
+
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	if _, ok := j.Jobs[job.Name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "job %q already exists", job.Name)
+	}
+	if !req.GetValidateOnly() {
+		j.PutJob(job, nil)
+	}
+	return j.done(job)
+}
+
+func (j *jobs) UpdateJob(ctx context.Context, req *runpb.UpdateJobRequest) (*longrunningpb.Operation, error) {
+	job := req.GetJob()
+	if job == nil {
+		return nil, status.Error(codes.InvalidArgument, "job is required")
+	}
+
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	live, ok := j.Jobs[job.GetName()]
+	if !ok && !req.GetAllowMissing() {
+		return nil, status.Errorf(codes.NotFound, "job %q not found", job.GetName())
+	}
+	if ok {
+		if err := store.CheckEtag(job.GetEtag(), live.GetEtag()); err != nil {
+			return nil, err
+		}
+	}
+	if !req.GetValidateOnly() {
+		j.PutJob(job, live)
+	}
+	return j.done(job)
+}
+
+func (j *jobs) DeleteJob(ctx context.Context, req *runpb.DeleteJobRequest) (*longrunningpb.Operation, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	job, err := store.Get(j.Jobs, "job", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CheckEtag(req.GetEtag(), job.GetEtag()); err != nil {
+		return nil, err
+	}
+	if !req.GetValidateOnly() {
+		delete(j.Jobs, job.Name)
+		for name := range j.executions {
+			if store.InParent(name, job.Name) {
+				delete(j.executions, name)
+			}
+		}
+	}
+	return j.done(job)
+}
+
+func (j *jobs) RunJob(ctx context.Context, req *runpb.RunJobRequest) (*longrunningpb.Operation, error) {
+	j.Mu.Lock()
+	defer j.Mu.Unlock()
+	live, err := store.Get(j.Jobs, "job", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CheckEtag(req.GetEtag(), live.GetEtag()); err != nil {
+		return nil, err
+	}
+
+	tmpl := live.GetTemplate()
+	if tmpl == nil {
+		tmpl = &runpb.ExecutionTemplate{}
+	}
+	tmpl = proto.Clone(tmpl).(*runpb.ExecutionTemplate)
+	taskCount := max(tmpl.TaskCount, 1)
+	if req.GetOverrides().GetTaskCount() > 0 {
+		taskCount = req.GetOverrides().GetTaskCount()
+	}
+	if req.GetOverrides().GetTimeout() != nil && tmpl.Template != nil {
+		tmpl.Template.Timeout = req.GetOverrides().GetTimeout()
+	}
+
+	now := timestamppb.New(j.Now())
+	name := fmt.Sprintf("%s/executions/%s-%s", live.Name, store.ShortName(live.Name), store.Suffix(5, live.Name, live.Uid, live.ExecutionCount))
+	exec := &runpb.Execution{
+		Name:               name,
+		Uid:                store.UID(name, now.AsTime().UnixNano()),
+		Generation:         1,
+		Labels:             tmpl.Labels,
+		Annotations:        tmpl.Annotations,
+		CreateTime:         now,
+		StartTime:          now,
+		CompletionTime:     now,
+		UpdateTime:         now,
+		Job:                store.ShortName(live.Name),
+		Parallelism:        tmpl.Parallelism,
+		TaskCount:          taskCount,
+		Template:           tmpl.Template,
+		Conditions:         []*runpb.Condition{store.Succeeded("Completed", now.AsTime()), store.Succeeded("ResourcesAvailable", now.AsTime()), store.Succeeded("Started", now.AsTime())},
+		ObservedGeneration: 1,
+		SucceededCount:     taskCount,
+		Etag:               store.Etag(name, 1),
+	}
+	j.executions[exec.Name] = exec
+
+	job := proto.Clone(live).(*runpb.Job)
+	job.ExecutionCount++
+	job.LatestCreatedExecution = &runpb.ExecutionReference{
+		Name:             store.ShortName(exec.Name),
+		CreateTime:       exec.CreateTime,
+		CompletionTime:   exec.CompletionTime,
+		CompletionStatus: runpb.ExecutionReference_EXECUTION_SUCCEEDED,
+	}
+	j.Jobs[job.Name] = job
+
+	return j.done(exec)
+}
+
+// executions implements the Executions API.
+type executions struct {
+	runpb.UnimplementedExecutionsServer
+	*Server
+}
+
+func (e *executions) ListExecutions(ctx context.Context, req *runpb.ListExecutionsRequest) (*runpb.ListExecutionsResponse, error) {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	executions, next, err := client.Paginate(store.List(e.executions, req.GetParent()), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &runpb.ListExecutionsResponse{Executions: executions, NextPageToken: next}, nil
+}
+
+func (e *executions) GetExecution(ctx context.Context, req *runpb.GetExecutionRequest) (*runpb.Execution, error) {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	return store.Get(e.executions, "execution", req.GetName())
+}
+
+func (e *executions) DeleteExecution(ctx context.Context, req *runpb.DeleteExecutionRequest) (*longrunningpb.Operation, error) {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	exec, err := store.Get(e.executions, "execution", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CheckEtag(req.GetEtag(), exec.GetEtag()); err != nil {
+		return nil, err
+	}
+	if !req.GetValidateOnly() {
+		delete(e.executions, exec.Name)
+	}
+	return e.done(exec)
+}
+
+func (e *executions) CancelExecution(ctx context.Context, req *runpb.CancelExecutionRequest) (*longrunningpb.Operation, error) {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	live, err := store.Get(e.executions, "execution", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CheckEtag(req.GetEtag(), live.GetEtag()); err != nil {
+		return nil, err
+	}
+	if live.CompletionTime != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "execution %q is already completed", live.Name)
+	}
+
+	exec := proto.Clone(live).(*runpb.Execution)
+	now := timestamppb.New(e.Now())
+	exec.CompletionTime = now
+	exec.UpdateTime = now
+	exec.CancelledCount = exec.TaskCount - exec.SucceededCount - exec.FailedCount
+	exec.RunningCount = 0
+	exec.Etag = store.Etag(exec.Name, now.AsTime().UnixNano())
+	exec.Conditions = []*runpb.Condition{{
+		Type:               "Completed",
+		State:              runpb.Condition_CONDITION_FAILED,
+		Message:            "The execution was cancelled.",
+		LastTransitionTime: now,
+	}}
+	if !req.GetValidateOnly() {
+		e.executions[exec.Name] = exec
+	}
+	return e.done(exec)
+}
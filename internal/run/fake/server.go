@@ -0,0 +1,120 @@
+// Package fake implements an in-memory Cloud Run Admin API v2, to demo the CLI,
+// write integration tests and reproduce bugs without touching real projects.
+package fake
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Server is an in-memory implementation of the Services, Revisions, Jobs, Executions
+// and WorkerPools APIs. The operations it returns are already done.
+type Server struct {
+	*store.Store
+	executions map[string]*runpb.Execution
+	operations map[string]*longrunningpb.Operation
+}
+
+// New returns a server seeded with the resources of the fixture, which may be nil.
+func New(f *Fixture) *Server {
+	s := &Server{
+		Store:      store.New(time.Now, ""),
+		executions: map[string]*runpb.Execution{},
+		operations: map[string]*longrunningpb.Operation{},
+	}
+	if f == nil {
+		return s
+	}
+	for _, r := range f.Services {
+		s.Services[r.Name] = r
+	}
+	for _, r := range f.Revisions {
+		s.Revisions[r.Name] = r
+	}
+	for _, r := range f.Jobs {
+		s.Jobs[r.Name] = r
+	}
+	for _, r := range f.Executions {
+		s.executions[r.Name] = r
+	}
+	for _, r := range f.WorkerPools {
+		s.WorkerPools[r.Name] = r
+	}
+	return s
+}
+
+// Register registers the APIs, and the Operations API used to wait for their operations.
+func (s *Server) Register(g *grpc.Server) {
+	runpb.RegisterServicesServer(g, &services{Server: s})
+	runpb.RegisterRevisionsServer(g, &revisions{Server: s})
+	runpb.RegisterJobsServer(g, &jobs{Server: s})
+	runpb.RegisterExecutionsServer(g, &executions{Server: s})
+	runpb.RegisterWorkerPoolsServer(g, &workerPools{Server: s})
+	longrunningpb.RegisterOperationsServer(g, &operations{Server: s})
+}
+
+// Serve serves the APIs without TLS on the listener until the context is done.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	g := grpc.NewServer()
+	s.Register(g)
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			g.GracefulStop()
+		case <-stopped:
+		}
+	}()
+	return g.Serve(lis)
+}
+
+// done returns a done operation whose response is the resource, stored for the Operations API.
+// Like the API, its metadata is the resource too, e.g. the execution of a job run.
+func (s *Server) done(r store.Resource) (*longrunningpb.Operation, error) {
+	resp, err := anypb.New(r)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	op := &longrunningpb.Operation{
+		Name:     location(r.GetName()) + "/operations/" + store.UID(r.GetName(), s.Now().UnixNano(), len(s.operations)),
+		Metadata: resp,
+		Done:     true,
+		Result:   &longrunningpb.Operation_Response{Response: resp},
+	}
+	s.operations[op.Name] = op
+	return op, nil
+}
+
+// location returns the projects/*/locations/* prefix of a resource name.
+func location(name string) string {
+	parts := strings.SplitN(name, "/", 5)
+	return strings.Join(parts[:min(len(parts), 4)], "/")
+}
+
+// operations implements the Operations API, for the clients waiting for an operation.
+type operations struct {
+	longrunningpb.UnimplementedOperationsServer
+	*Server
+}
+
+func (o *operations) GetOperation(ctx context.Context, req *longrunningpb.GetOperationRequest) (*longrunningpb.Operation, error) {
+	o.Mu.Lock()
+	defer o.Mu.Unlock()
+	return store.Get(o.operations, "operation", req.GetName())
+}
+
+func (o *operations) WaitOperation(ctx context.Context, req *longrunningpb.WaitOperationRequest) (*longrunningpb.Operation, error) {
+	return o.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: req.GetName()})
+}
@@ -0,0 +1,156 @@
+package fake_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
+	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
+	"github.com/JulienBreux/run-cli/internal/run/fake"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serve serves the fixture of the docs, and points the API clients at the server.
+func serve(t *testing.T) {
+	file, err := os.Open("../../../docs/fake/fixture.yaml")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	f, err := fake.LoadFixture(file)
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- fake.New(f).Serve(ctx, lis) }()
+
+	if err := client.SetEndpoints(map[string]string{client.APIRun: "http://" + lis.Addr().String()}); err != nil {
+		t.Fatalf("failed to set endpoints: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.SetEndpoints(nil)
+		cancel()
+		assert.NoError(t, <-done)
+	})
+}
+
+func TestServer_Services(t *testing.T) {
+	serve(t)
+	ctx := context.Background()
+
+	services, err := api_service.List(context.Background(), "demo", "europe-west1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, services, 1) {
+		return
+	}
+	assert.Equal(t, "api", services[0].Name)
+
+	created, err := api_service.Create(ctx, "demo", "europe-west1", "worker", &runpb.Service{
+		Template: &runpb.RevisionTemplate{Containers: []*runpb.Container{{Image: "worker:1"}}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "projects/demo/locations/europe-west1/services/worker", created.Name)
+	assert.Equal(t, int64(1), created.Generation)
+	assert.NotEmpty(t, created.LatestReadyRevision)
+
+	created.Template.Containers[0].Image = "worker:2"
+	updated, err := api_service.Update(ctx, created)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(2), updated.Generation)
+	assert.NotEqual(t, created.LatestReadyRevision, updated.LatestReadyRevision)
+
+	// The etag of the first version is stale
+	_, err = api_service.Update(ctx, created)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = api_service.Create(ctx, "demo", "europe-west1", "worker", &runpb.Service{})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// The services are listed page by page.
+	gcp := &api_service.GCPClient{}
+	page, next, err := gcp.ListServicesPage(ctx, "demo", "europe-west1", 1, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, page, 1)
+	assert.NotEmpty(t, next)
+	page, next, err = gcp.ListServicesPage(ctx, "demo", "europe-west1", 1, next)
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Empty(t, next)
+}
+
+func TestServer_Jobs(t *testing.T) {
+	serve(t)
+
+	jobs, err := api_job.List(context.Background(), "demo", "europe-west1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, jobs, 1) {
+		return
+	}
+	assert.Equal(t, "projects/demo/locations/europe-west1/jobs/migrate", jobs[0].Name)
+
+	op, err := api_job.Execute(context.Background(), "demo", "europe-west1", "migrate")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, op.Wait(context.Background()))
+	assert.Equal(t, "1/1 tasks succeeded", op.Status().Progress)
+
+	job, err := api_job.GetRaw(context.Background(), "demo", "europe-west1", "migrate")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int32(1), job.ExecutionCount)
+
+	// Starting a job reads the execution from the metadata of the operation.
+	name, err := api_job.Start(context.Background(), "demo", "europe-west1", "migrate")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(name, "projects/demo/locations/europe-west1/jobs/migrate/executions/migrate-"), name)
+
+	_, err = api_job.Execute(context.Background(), "demo", "europe-west1", "missing")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_WorkerPools(t *testing.T) {
+	serve(t)
+
+	pools, err := api_workerpool.List(context.Background(), "demo", "europe-west1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, pools, 1) {
+		return
+	}
+	assert.Equal(t, "projects/demo/locations/europe-west1/workerPools/consumer", pools[0].Name)
+
+	pools, err = api_workerpool.List(context.Background(), "demo", "us-central1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, pools)
+}
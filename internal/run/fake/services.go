@@ -0,0 +1,134 @@
+package fake
+
+import (
+	"context"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	"github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// services implements the Services API. Each create or update deploys a new revision.
+type services struct {
+	runpb.UnimplementedServicesServer
+	*Server
+}
+
+func (s *services) ListServices(ctx context.Context, req *runpb.ListServicesRequest) (*runpb.ListServicesResponse, error) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	services, next, err := client.Paginate(store.List(s.Services, req.GetParent()), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &runpb.ListServicesResponse{Services: services, NextPageToken: next}, nil
+}
+
+func (s *services) GetService(ctx context.Context, req *runpb.GetServiceRequest) (*runpb.Service, error) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	return store.Get(s.Services, "service", req.GetName())
+}
+
+func (s *services) CreateService(ctx context.Context, req *runpb.CreateServiceRequest) (*longrunningpb.Operation, error) {
+	if req.GetServiceId() == "" || req.GetService() == nil {
+		return nil, status.Error(codes.InvalidArgument, "service and service_id are required")
+	}
+	svc := req.GetService()
+	svc.Name = req.GetParent() + "/services/" + req.GetServiceId()
+
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	if _, ok := s.Services[svc.Name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "service %q already exists", svc.Name)
+	}
+	if !req.GetValidateOnly() {
+		s.Deploy(svc, nil, s.Now(), false)
+	}
+	return s.done(svc)
+}
+
+func (s *services) UpdateService(ctx context.Context, req *runpb.UpdateServiceRequest) (*longrunningpb.Operation, error) {
+	svc := req.GetService()
+	if svc == nil {
+		return nil, status.Error(codes.InvalidArgument, "service is required")
+	}
+
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	live, ok := s.Services[svc.GetName()]
+	if !ok && !req.GetAllowMissing() {
+		return nil, status.Errorf(codes.NotFound, "service %q not found", svc.GetName())
+	}
+	if ok {
+		if err := store.CheckEtag(svc.GetEtag(), live.GetEtag()); err != nil {
+			return nil, err
+		}
+	}
+	if !req.GetValidateOnly() {
+		s.Deploy(svc, live, s.Now(), false)
+	}
+	return s.done(svc)
+}
+
+func (s *services) DeleteService(ctx context.Context, req *runpb.DeleteServiceRequest) (*longrunningpb.Operation, error) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	svc, err := store.Get(s.Services, "service", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CheckEtag(req.GetEtag(), svc.GetEtag()); err != nil {
+		return nil, err
+	}
+	if !req.GetValidateOnly() {
+		delete(s.Services, svc.Name)
+		for name, rev := range s.Revisions {
+			if rev.Service == svc.Name {
+				delete(s.Revisions, name)
+			}
+		}
+	}
+	return s.done(svc)
+}
+
+// revisions implements the Revisions API.
+type revisions struct {
+	runpb.UnimplementedRevisionsServer
+	*Server
+}
+
+func (r *revisions) ListRevisions(ctx context.Context, req *runpb.ListRevisionsRequest) (*runpb.ListRevisionsResponse, error) {
+	r.Mu.Lock()
+	defer r.Mu.Unlock()
+	revisions, next, err := client.Paginate(store.List(r.Revisions, req.GetParent()), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &runpb.ListRevisionsResponse{Revisions: revisions, NextPageToken: next}, nil
+}
+
+func (r *revisions) GetRevision(ctx context.Context, req *runpb.GetRevisionRequest) (*runpb.Revision, error) {
+	r.Mu.Lock()
+	defer r.Mu.Unlock()
+	return store.Get(r.Revisions, "revision", req.GetName())
+}
+
+func (r *revisions) DeleteRevision(ctx context.Context, req *runpb.DeleteRevisionRequest) (*longrunningpb.Operation, error) {
+	r.Mu.Lock()
+	defer r.Mu.Unlock()
+	rev, err := store.Get(r.Revisions, "revision", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if svc, ok := r.Services[rev.Service]; ok && svc.LatestReadyRevision == rev.Name {
+		return nil, status.Errorf(codes.FailedPrecondition, "revision %q is the latest ready revision of its service", rev.Name)
+	}
+	if !req.GetValidateOnly() {
+		delete(r.Revisions, rev.Name)
+	}
+	return r.done(rev)
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Hash returns a stable hash of the given values, so that the generated names and
+// identifiers are the same across runs, e.g. for screencasts.
+func Hash(values ...any) uint64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprint(h, values...)
+	return h.Sum64()
+}
+
+// UID returns a stable UUID of the given values.
+func UID(values ...any) string {
+	h := Hash(values...)
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", h>>32, h>>16&0xffff, h&0xffff, h>>48, h&0xffffffffffff)
+}
+
+// Etag returns a stable etag of the given values.
+func Etag(values ...any) string {
+	return fmt.Sprintf(`"%016x"`, Hash(values...))
+}
+
+// Suffix returns n stable lowercase letters and digits, as used in revision and execution names.
+func Suffix(n int, values ...any) string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	h := Hash(values...)
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = chars[h%uint64(len(chars))]
+		h /= uint64(len(chars))
+	}
+	return string(b)
+}
+
+// Succeeded returns a condition of the given type which succeeded at the given time.
+func Succeeded(conditionType string, at time.Time) *runpb.Condition {
+	return &runpb.Condition{
+		Type:               conditionType,
+		State:              runpb.Condition_CONDITION_SUCCEEDED,
+		LastTransitionTime: timestamppb.New(at),
+	}
+}
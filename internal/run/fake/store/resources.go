@@ -0,0 +1,194 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Deploy stores the service with a new revision of its template, deployed at the given time,
+// keeping the server set fields of its live version, if any. When the revision is failing,
+// the latest ready revision keeps serving the traffic.
+func (s *Store) Deploy(svc, live *runpb.Service, at time.Time, failing bool) {
+	now := timestamppb.New(at)
+	svc.Generation = 1
+	svc.CreateTime = now
+	svc.Uid = UID(svc.Name, at.UnixNano())
+	svc.LatestReadyRevision = ""
+	if s.User != "" {
+		svc.Creator = s.User
+	}
+	if live != nil {
+		svc.Generation = live.Generation + 1
+		svc.CreateTime = live.CreateTime
+		svc.Uid = live.Uid
+		svc.Creator = live.Creator
+		svc.LatestReadyRevision = live.LatestReadyRevision
+	}
+	if s.User != "" {
+		svc.LastModifier = s.User
+	}
+	svc.ObservedGeneration = svc.Generation
+	svc.UpdateTime = now
+	svc.Etag = Etag(svc.Name, svc.Generation, at.UnixNano())
+	svc.Uri = fmt.Sprintf("https://%s-%s.a.run.app", ShortName(svc.Name), Suffix(10, svc.Name))
+	svc.Urls = []string{svc.Uri}
+	svc.Reconciling = false
+
+	rev := s.newRevision(svc, failing)
+	s.Revisions[rev.Name] = rev
+	svc.LatestCreatedRevision = rev.Name
+
+	if failing {
+		svc.TerminalCondition = &runpb.Condition{
+			Type:               "Ready",
+			State:              runpb.Condition_CONDITION_FAILED,
+			Message:            rev.Conditions[0].Message,
+			LastTransitionTime: now,
+		}
+		svc.Conditions = []*runpb.Condition{{
+			Type:               "ConfigurationsReady",
+			State:              runpb.Condition_CONDITION_FAILED,
+			Message:            rev.Conditions[0].Message,
+			LastTransitionTime: now,
+		}, Succeeded("RoutesReady", at)}
+	} else {
+		svc.LatestReadyRevision = rev.Name
+		svc.TerminalCondition = Succeeded("Ready", at)
+		svc.Conditions = []*runpb.Condition{Succeeded("ConfigurationsReady", at), Succeeded("RoutesReady", at)}
+	}
+
+	if len(svc.Traffic) == 0 {
+		svc.Traffic = []*runpb.TrafficTarget{{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST, Percent: 100}}
+	}
+	svc.TrafficStatuses = nil
+	for _, t := range svc.Traffic {
+		revision := t.Revision
+		if t.Type == runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST {
+			revision = svc.LatestReadyRevision
+		}
+		svc.TrafficStatuses = append(svc.TrafficStatuses, &runpb.TrafficTargetStatus{
+			Type: t.Type, Revision: ShortName(revision), Percent: t.Percent, Tag: t.Tag,
+		})
+	}
+
+	s.Services[svc.Name] = svc
+}
+
+// newRevision returns the next revision of a service, e.g. api-00002-x7k.
+func (s *Store) newRevision(svc *runpb.Service, failing bool) *runpb.Revision {
+	tmpl := svc.GetTemplate()
+	if tmpl == nil {
+		tmpl = &runpb.RevisionTemplate{}
+	}
+	id := tmpl.GetRevision()
+	if id == "" {
+		id = fmt.Sprintf("%s-%05d-%s", ShortName(svc.Name), svc.Generation, Suffix(3, svc.Name, svc.Generation))
+	}
+	tmpl = proto.Clone(tmpl).(*runpb.RevisionTemplate)
+	name := svc.Name + "/revisions/" + id
+
+	at := svc.UpdateTime.AsTime()
+	conditions := []*runpb.Condition{Succeeded("Ready", at), Succeeded("ContainerHealthy", at), Succeeded("ResourcesAvailable", at)}
+	if failing {
+		message := fmt.Sprintf("The user-provided container failed to start and listen on the port defined provided by the PORT=8080 environment variable within the allocated timeout. Logs for this revision might contain more information. Revision '%s' is not ready and cannot serve traffic.", id)
+		conditions = []*runpb.Condition{{
+			Type:               "Ready",
+			State:              runpb.Condition_CONDITION_FAILED,
+			Message:            message,
+			LastTransitionTime: svc.UpdateTime,
+		}}
+	}
+	creator := svc.LastModifier
+	if creator == "" {
+		creator = svc.Creator
+	}
+
+	return &runpb.Revision{
+		Name:                          name,
+		Uid:                           UID(name, at.UnixNano()),
+		Generation:                    1,
+		Labels:                        tmpl.Labels,
+		Annotations:                   tmpl.Annotations,
+		CreateTime:                    svc.UpdateTime,
+		UpdateTime:                    svc.UpdateTime,
+		Service:                       svc.Name,
+		Scaling:                       tmpl.Scaling,
+		VpcAccess:                     tmpl.VpcAccess,
+		MaxInstanceRequestConcurrency: tmpl.MaxInstanceRequestConcurrency,
+		Timeout:                       tmpl.Timeout,
+		ServiceAccount:                tmpl.ServiceAccount,
+		Containers:                    tmpl.Containers,
+		Volumes:                       tmpl.Volumes,
+		ExecutionEnvironment:          tmpl.ExecutionEnvironment,
+		EncryptionKey:                 tmpl.EncryptionKey,
+		SessionAffinity:               tmpl.SessionAffinity,
+		NodeSelector:                  tmpl.NodeSelector,
+		Conditions:                    conditions,
+		ObservedGeneration:            1,
+		Creator:                       creator,
+		Etag:                          Etag(name, 1),
+	}
+}
+
+// PutJob stores the job, which is ready, keeping the server set fields of its live version, if any.
+func (s *Store) PutJob(job, live *runpb.Job) {
+	now := s.Now()
+	job.Generation = 1
+	job.CreateTime = timestamppb.New(now)
+	job.Uid = UID(job.Name, now.UnixNano())
+	job.ExecutionCount = 0
+	job.LatestCreatedExecution = nil
+	if s.User != "" {
+		job.Creator = s.User
+	}
+	if live != nil {
+		job.Generation = live.Generation + 1
+		job.CreateTime = live.CreateTime
+		job.Uid = live.Uid
+		job.Creator = live.Creator
+		job.ExecutionCount = live.ExecutionCount
+		job.LatestCreatedExecution = live.LatestCreatedExecution
+	}
+	if s.User != "" {
+		job.LastModifier = s.User
+	}
+	job.ObservedGeneration = job.Generation
+	job.UpdateTime = timestamppb.New(now)
+	job.Etag = Etag(job.Name, job.Generation, now.UnixNano())
+	job.Reconciling = false
+	job.TerminalCondition = Succeeded("Ready", now)
+
+	s.Jobs[job.Name] = job
+}
+
+// PutWorkerPool stores the worker pool, which is ready, keeping the server set fields of its
+// live version, if any.
+func (s *Store) PutWorkerPool(pool, live *runpb.WorkerPool) {
+	now := s.Now()
+	pool.Generation = 1
+	pool.CreateTime = timestamppb.New(now)
+	pool.Uid = UID(pool.Name, now.UnixNano())
+	if s.User != "" {
+		pool.Creator = s.User
+	}
+	if live != nil {
+		pool.Generation = live.Generation + 1
+		pool.CreateTime = live.CreateTime
+		pool.Uid = live.Uid
+		pool.Creator = live.Creator
+	}
+	if s.User != "" {
+		pool.LastModifier = s.User
+	}
+	pool.ObservedGeneration = pool.Generation
+	pool.UpdateTime = timestamppb.New(now)
+	pool.Etag = Etag(pool.Name, pool.Generation, now.UnixNano())
+	pool.Reconciling = false
+	pool.TerminalCondition = Succeeded("Ready", now)
+
+	s.WorkerPools[pool.Name] = pool
+}
@@ -0,0 +1,98 @@
+// Package store holds Cloud Run resources in memory, for the fake server and the demo mode to
+// list, get and update them the same way.
+package store
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Store holds the services, revisions, jobs and worker pools, keyed by full resource name.
+// Its maps and methods must be used with Mu held.
+type Store struct {
+	Mu          sync.Mutex
+	Services    map[string]*runpb.Service
+	Revisions   map[string]*runpb.Revision
+	Jobs        map[string]*runpb.Job
+	WorkerPools map[string]*runpb.WorkerPool
+
+	// Now is a variable for testing.
+	Now func() time.Time
+	// User is the creator and last modifier of the resources written, if set.
+	User string
+}
+
+// New returns an empty store.
+func New(now func() time.Time, user string) *Store {
+	return &Store{
+		Services:    map[string]*runpb.Service{},
+		Revisions:   map[string]*runpb.Revision{},
+		Jobs:        map[string]*runpb.Job{},
+		WorkerPools: map[string]*runpb.WorkerPool{},
+		Now:         now,
+		User:        user,
+	}
+}
+
+// Resource is a Cloud Run resource held by a store.
+type Resource interface {
+	proto.Message
+	GetName() string
+}
+
+// Get returns a copy of the resource of the given name, a NotFound error if it does not exist.
+func Get[R Resource](resources map[string]R, kind, name string) (R, error) {
+	r, ok := resources[name]
+	if !ok {
+		return r, status.Errorf(codes.NotFound, "%s %q not found", kind, name)
+	}
+	return proto.Clone(r).(R), nil
+}
+
+// List returns copies of the resources under the parent, sorted by name.
+func List[R Resource](resources map[string]R, parent string) []R {
+	var matching []R
+	for name, r := range resources {
+		if InParent(name, parent) {
+			matching = append(matching, proto.Clone(r).(R))
+		}
+	}
+	slices.SortFunc(matching, func(a, b R) int { return cmp.Compare(a.GetName(), b.GetName()) })
+	return matching
+}
+
+// InParent returns whether a resource is a direct child of the parent, named parent/collection/id.
+// The "-" wildcard of the parent matches any id, e.g. any location.
+func InParent(name, parent string) bool {
+	nameParts, parentParts := strings.Split(name, "/"), strings.Split(parent, "/")
+	if len(nameParts) != len(parentParts)+2 {
+		return false
+	}
+	for i, part := range parentParts {
+		if part != nameParts[i] && part != "-" {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckEtag returns an Aborted error if the etag of a request is set and not the current one.
+func CheckEtag(requested, current string) error {
+	if requested != "" && requested != current {
+		return status.Errorf(codes.Aborted, "etag %s does not match the current etag %s, the resource was modified", requested, current)
+	}
+	return nil
+}
+
+// ShortName returns the last part of a resource name.
+func ShortName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
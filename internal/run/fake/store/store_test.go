@@ -0,0 +1,64 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInParent(t *testing.T) {
+	assert.True(t, InParent("projects/p/locations/r/services/s", "projects/p/locations/r"))
+	assert.True(t, InParent("projects/p/locations/r/services/s", "projects/p/locations/-"))
+	assert.False(t, InParent("projects/p/locations/r/services/s", "projects/p/locations/other"))
+	assert.False(t, InParent("projects/p/locations/r", "projects/p/locations/r"))
+	assert.False(t, InParent("projects/p/locations/r/services/s/revisions/s-1", "projects/p/locations/r"))
+}
+
+func TestGetAndList(t *testing.T) {
+	s := New(time.Now, "")
+	s.Services["projects/p/locations/r/services/b"] = &runpb.Service{Name: "projects/p/locations/r/services/b"}
+	s.Services["projects/p/locations/r/services/a"] = &runpb.Service{Name: "projects/p/locations/r/services/a"}
+
+	svc, err := Get(s.Services, "service", "projects/p/locations/r/services/a")
+	assert.NoError(t, err)
+	svc.Description = "changed"
+	assert.Empty(t, s.Services[svc.Name].Description, "Get returns a copy")
+
+	_, err = Get(s.Services, "service", "projects/p/locations/r/services/missing")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	list := List(s.Services, "projects/p/locations/-")
+	assert.Len(t, list, 2)
+	assert.Equal(t, "projects/p/locations/r/services/a", list[0].Name)
+}
+
+func TestDeploy(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := New(func() time.Time { return at }, "me@example.com")
+
+	svc := &runpb.Service{Name: "projects/p/locations/r/services/api"}
+	s.Deploy(svc, nil, at, false)
+	assert.Equal(t, int64(1), svc.Generation)
+	assert.Equal(t, "me@example.com", svc.Creator)
+	assert.Equal(t, svc.LatestCreatedRevision, svc.LatestReadyRevision)
+	assert.Len(t, s.Revisions, 1)
+
+	failing := &runpb.Service{Name: svc.Name}
+	s.Deploy(failing, svc, at.Add(time.Hour), true)
+	assert.Equal(t, int64(2), failing.Generation)
+	assert.Equal(t, svc.Uid, failing.Uid)
+	assert.NotEqual(t, svc.Etag, failing.Etag)
+	assert.NotEqual(t, failing.LatestCreatedRevision, failing.LatestReadyRevision)
+	assert.Equal(t, ShortName(svc.LatestReadyRevision), failing.TrafficStatuses[0].Revision)
+	assert.Equal(t, runpb.Condition_CONDITION_FAILED, failing.TerminalCondition.State)
+}
+
+func TestCheckEtag(t *testing.T) {
+	assert.NoError(t, CheckEtag("", `"a"`))
+	assert.NoError(t, CheckEtag(`"a"`, `"a"`))
+	assert.Equal(t, codes.Aborted, status.Code(CheckEtag(`"b"`, `"a"`)))
+}
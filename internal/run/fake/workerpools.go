@@ -0,0 +1,86 @@
+package fake
+
+import (
+	"context"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/JulienBreux/run-cli/internal/run/fake/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workerPools implements the WorkerPools API.
+type workerPools struct {
+	runpb.UnimplementedWorkerPoolsServer
+	*Server
+}
+
+func (w *workerPools) ListWorkerPools(ctx context.Context, req *runpb.ListWorkerPoolsRequest) (*runpb.ListWorkerPoolsResponse, error) {
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	return &runpb.ListWorkerPoolsResponse{WorkerPools: store.List(w.WorkerPools, req.GetParent())}, nil
+}
+
+func (w *workerPools) GetWorkerPool(ctx context.Context, req *runpb.GetWorkerPoolRequest) (*runpb.WorkerPool, error) {
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	return store.Get(w.WorkerPools, "worker pool", req.GetName())
+}
+
+func (w *workerPools) CreateWorkerPool(ctx context.Context, req *runpb.CreateWorkerPoolRequest) (*longrunningpb.Operation, error) {
+	if req.GetWorkerPoolId() == "" || req.GetWorkerPool() == nil {
+		return nil, status.Error(codes.InvalidArgument, "worker_pool and worker_pool_id are required")
+	}
+	pool := req.GetWorkerPool()
+	pool.Name = req.GetParent() + "/workerPools/" + req.GetWorkerPoolId()
+
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	if _, ok := w.WorkerPools[pool.Name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "worker pool %q already exists", pool.Name)
+	}
+	if !req.GetValidateOnly() {
+		w.PutWorkerPool(pool, nil)
+	}
+	return w.done(pool)
+}
+
+func (w *workerPools) UpdateWorkerPool(ctx context.Context, req *runpb.UpdateWorkerPoolRequest) (*longrunningpb.Operation, error) {
+	pool := req.GetWorkerPool()
+	if pool == nil {
+		return nil, status.Error(codes.InvalidArgument, "worker_pool is required")
+	}
+
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	live, ok := w.WorkerPools[pool.GetName()]
+	if !ok && !req.GetAllowMissing() {
+		return nil, status.Errorf(codes.NotFound, "worker pool %q not found", pool.GetName())
+	}
+	if ok {
+		if err := store.CheckEtag(pool.GetEtag(), live.GetEtag()); err != nil {
+			return nil, err
+		}
+	}
+	if !req.GetValidateOnly() {
+		w.PutWorkerPool(pool, live)
+	}
+	return w.done(pool)
+}
+
+func (w *workerPools) DeleteWorkerPool(ctx context.Context, req *runpb.DeleteWorkerPoolRequest) (*longrunningpb.Operation, error) {
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+	pool, err := store.Get(w.WorkerPools, "worker pool", req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CheckEtag(req.GetEtag(), pool.GetEtag()); err != nil {
+		return nil, err
+	}
+	if !req.GetValidateOnly() {
+		delete(w.WorkerPools, pool.Name)
+	}
+	return w.done(pool)
+}
@@ -0,0 +1,185 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Operations of a change.
+const (
+	OpAdd    = "+"
+	OpRemove = "-"
+	OpUpdate = "~"
+)
+
+// Change represents the change of a single field.
+type Change struct {
+	Path string
+	Op   string
+	Old  any
+	New  any
+}
+
+// String returns the change as a line of a plan.
+func (c Change) String() string {
+	switch c.Op {
+	case OpAdd:
+		return fmt.Sprintf("%s %s: %s", c.Op, c.Path, value(c.New))
+	case OpRemove:
+		return fmt.Sprintf("%s %s: %s", c.Op, c.Path, value(c.Old))
+	}
+	return fmt.Sprintf("%s %s: %s => %s", c.Op, c.Path, value(c.Old), value(c.New))
+}
+
+// ToMap converts a proto message to a generic map using its JSON field names.
+func ToMap(m proto.Message) (map[string]any, error) {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// FromMap fills a proto message from a generic map using its JSON field names.
+func FromMap(doc map[string]any, m proto.Message) error {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(b, m)
+}
+
+// Strip removes the given top level fields from doc.
+func Strip(doc map[string]any, fields ...string) {
+	for _, f := range fields {
+		delete(doc, f)
+	}
+}
+
+// Merge returns live with the fields of desired applied on top of it.
+// Objects are merged recursively, lists of objects are merged item by item
+// and take the length of the desired list, other values are replaced.
+func Merge(live, desired map[string]any) map[string]any {
+	merged := make(map[string]any, len(live))
+	for k, v := range live {
+		merged[k] = v
+	}
+	for k, v := range desired {
+		merged[k] = mergeValue(merged[k], v)
+	}
+	return merged
+}
+
+func mergeValue(live, desired any) any {
+	switch d := desired.(type) {
+	case map[string]any:
+		if l, ok := live.(map[string]any); ok {
+			return Merge(l, d)
+		}
+	case []any:
+		l, ok := live.([]any)
+		if !ok {
+			return d
+		}
+		merged := make([]any, len(d))
+		for i, item := range d {
+			if i < len(l) {
+				if _, isMap := item.(map[string]any); isMap {
+					merged[i] = mergeValue(l[i], item)
+					continue
+				}
+			}
+			merged[i] = item
+		}
+		return merged
+	}
+	return desired
+}
+
+// Diff returns the field level changes from old to new, ordered by path.
+func Diff(old, new map[string]any) []Change {
+	var changes []Change
+	diff("", old, new, &changes)
+	return changes
+}
+
+func diff(path string, old, new any, changes *[]Change) {
+	if reflect.DeepEqual(old, new) {
+		return
+	}
+
+	switch {
+	case old == nil:
+		*changes = append(*changes, Change{Path: path, Op: OpAdd, New: new})
+		return
+	case new == nil:
+		*changes = append(*changes, Change{Path: path, Op: OpRemove, Old: old})
+		return
+	}
+
+	oldMap, oldIsMap := old.(map[string]any)
+	newMap, newIsMap := new.(map[string]any)
+	if oldIsMap && newIsMap {
+		for _, k := range keys(oldMap, newMap) {
+			diff(join(path, k), oldMap[k], newMap[k], changes)
+		}
+		return
+	}
+
+	oldList, oldIsList := old.([]any)
+	newList, newIsList := new.([]any)
+	if oldIsList && newIsList {
+		for i := 0; i < len(oldList) || i < len(newList); i++ {
+			var o, n any
+			if i < len(oldList) {
+				o = oldList[i]
+			}
+			if i < len(newList) {
+				n = newList[i]
+			}
+			diff(fmt.Sprintf("%s[%d]", path, i), o, n, changes)
+		}
+		return
+	}
+
+	*changes = append(*changes, Change{Path: path, Op: OpUpdate, Old: old, New: new})
+}
+
+// keys returns the sorted keys of both maps.
+func keys(a, b map[string]any) []string {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func value(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,89 @@
+package manifest
+
+import (
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapConversion(t *testing.T) {
+	doc, err := ToMap(&runpb.Service{Name: "s1", Description: "my service"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "s1", "description": "my service"}, doc)
+
+	Strip(doc, "name", "uid")
+	assert.Equal(t, map[string]any{"description": "my service"}, doc)
+
+	s := &runpb.Service{}
+	assert.NoError(t, FromMap(doc, s))
+	assert.Equal(t, "my service", s.Description)
+
+	assert.Error(t, FromMap(map[string]any{"unknown": true}, s))
+}
+
+func TestMerge(t *testing.T) {
+	live := map[string]any{
+		"description": "old",
+		"labels":      map[string]any{"team": "a", "env": "prod"},
+		"template": map[string]any{
+			"containers": []any{
+				map[string]any{"image": "app:v1", "ports": []any{map[string]any{"containerPort": 8080.0}}},
+				map[string]any{"image": "sidecar:v1"},
+			},
+		},
+		"customAudiences": []any{"a", "b"},
+	}
+	desired := map[string]any{
+		"labels": map[string]any{"team": "b"},
+		"template": map[string]any{
+			"containers": []any{map[string]any{"image": "app:v2"}},
+		},
+		"customAudiences": []any{"c"},
+	}
+
+	assert.Equal(t, map[string]any{
+		"description": "old",
+		"labels":      map[string]any{"team": "b", "env": "prod"},
+		"template": map[string]any{
+			"containers": []any{
+				map[string]any{"image": "app:v2", "ports": []any{map[string]any{"containerPort": 8080.0}}},
+			},
+		},
+		"customAudiences": []any{"c"},
+	}, Merge(live, desired))
+
+	// live is left untouched
+	assert.Equal(t, "a", live["labels"].(map[string]any)["team"])
+}
+
+func TestDiff(t *testing.T) {
+	old := map[string]any{
+		"description": "old",
+		"labels":      map[string]any{"env": "prod"},
+		"template": map[string]any{
+			"containers": []any{map[string]any{"image": "app:v1"}, map[string]any{"image": "sidecar:v1"}},
+		},
+	}
+	new := map[string]any{
+		"labels": map[string]any{"env": "prod", "team": "a"},
+		"template": map[string]any{
+			"containers": []any{map[string]any{"image": "app:v2"}},
+		},
+	}
+
+	changes := Diff(old, new)
+	assert.Equal(t, []Change{
+		{Path: "description", Op: OpRemove, Old: "old"},
+		{Path: "labels.team", Op: OpAdd, New: "a"},
+		{Path: "template.containers[0].image", Op: OpUpdate, Old: "app:v1", New: "app:v2"},
+		{Path: "template.containers[1]", Op: OpRemove, Old: map[string]any{"image": "sidecar:v1"}},
+	}, changes)
+
+	assert.Equal(t, `- description: "old"`, changes[0].String())
+	assert.Equal(t, `+ labels.team: "a"`, changes[1].String())
+	assert.Equal(t, `~ template.containers[0].image: "app:v1" => "app:v2"`, changes[2].String())
+	assert.Equal(t, `- template.containers[1]: {"image":"sidecar:v1"}`, changes[3].String())
+
+	assert.Empty(t, Diff(old, old))
+}
@@ -0,0 +1,96 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of resources supported in manifests.
+const (
+	KindService = "Service"
+	KindJob     = "Job"
+)
+
+// Manifest represents a declarative Cloud Run resource.
+//
+// The spec uses the JSON field names of the Cloud Run Admin API v2 resources,
+// so the output of `run describe --raw` can be used as a spec.
+type Manifest struct {
+	Kind     string          `json:"kind"`
+	Metadata Metadata        `json:"metadata"`
+	Spec     json.RawMessage `json:"spec"`
+}
+
+// Metadata identifies the resource of a manifest.
+type Metadata struct {
+	Name    string `json:"name"`
+	Project string `json:"project,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// Parse parses the YAML or JSON documents of r into manifests.
+func Parse(r io.Reader) ([]Manifest, error) {
+	var manifests []Manifest
+
+	dec := yaml.NewDecoder(r)
+	for i := 1; ; i++ {
+		var doc any
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if doc == nil {
+			continue
+		}
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+
+		var m Manifest
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		manifests = append(manifests, m)
+	}
+
+	return manifests, nil
+}
+
+func (m *Manifest) validate() error {
+	switch {
+	case strings.EqualFold(m.Kind, KindService):
+		m.Kind = KindService
+	case strings.EqualFold(m.Kind, KindJob):
+		m.Kind = KindJob
+	case m.Kind == "":
+		return fmt.Errorf("missing kind, expected %s or %s", KindService, KindJob)
+	default:
+		return fmt.Errorf("unsupported kind %q, expected %s or %s", m.Kind, KindService, KindJob)
+	}
+
+	if m.Metadata.Name == "" {
+		return fmt.Errorf("missing metadata.name")
+	}
+	if len(m.Spec) == 0 || string(m.Spec) == "null" {
+		return fmt.Errorf("missing spec")
+	}
+	return nil
+}
+
+// String returns the kind and name of the manifest, e.g. service/my-service.
+func (m Manifest) String() string {
+	return strings.ToLower(m.Kind) + "/" + m.Metadata.Name
+}
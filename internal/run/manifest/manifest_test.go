@@ -0,0 +1,63 @@
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	input := `kind: service
+metadata:
+  name: s1
+  region: europe-west1
+spec:
+  template:
+    containers:
+    - image: gcr.io/p/app:v2
+---
+{"kind": "Job", "metadata": {"name": "j1", "project": "p"}, "spec": {"template": {"taskCount": 2}}}
+`
+	manifests, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Len(t, manifests, 2)
+
+	assert.Equal(t, KindService, manifests[0].Kind)
+	assert.Equal(t, Metadata{Name: "s1", Region: "europe-west1"}, manifests[0].Metadata)
+	assert.JSONEq(t, `{"template":{"containers":[{"image":"gcr.io/p/app:v2"}]}}`, string(manifests[0].Spec))
+	assert.Equal(t, "service/s1", manifests[0].String())
+
+	assert.Equal(t, KindJob, manifests[1].Kind)
+	assert.Equal(t, Metadata{Name: "j1", Project: "p"}, manifests[1].Metadata)
+	assert.Equal(t, "job/j1", manifests[1].String())
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"Missing kind", "metadata: {name: s1}\nspec: {}", "document 1: missing kind, expected Service or Job"},
+		{"Unsupported kind", "kind: WorkerPool\nmetadata: {name: w1}\nspec: {}", `document 1: unsupported kind "WorkerPool", expected Service or Job`},
+		{"Missing name", "kind: Service\nspec: {}", "document 1: missing metadata.name"},
+		{"Missing spec", "kind: Service\nmetadata: {name: s1}", "document 1: missing spec"},
+		{"Second document", "kind: Job\nmetadata: {name: j1}\nspec: {}\n---\nkind: Job\n", "document 2: missing metadata.name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := Parse(strings.NewReader("kind: [Service"))
+	assert.ErrorContains(t, err, "document 1:")
+}
+
+func TestParse_Empty(t *testing.T) {
+	manifests, err := Parse(strings.NewReader("---\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, manifests)
+}
@@ -1,7 +1,9 @@
 package info
 
 type Info struct {
-	User    string
-	Project string
-	Region  string
+	User     string
+	Project  string
+	Region   string
+	Context  string
+	ReadOnly bool
 }
@@ -0,0 +1,33 @@
+package task
+
+import (
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
+)
+
+// Task represents a task of a Cloud Run job execution.
+type Task struct {
+	Name              string                 `json:"name"`
+	Job               string                 `json:"job"`
+	Execution         string                 `json:"execution"`
+	Index             int32                  `json:"index"`
+	Retried           int32                  `json:"retried"`
+	MaxRetries        int32                  `json:"maxRetries"`
+	CreateTime        time.Time              `json:"createTime"`
+	ScheduledTime     time.Time              `json:"scheduledTime"`
+	StartTime         time.Time              `json:"startTime"`
+	CompletionTime    time.Time              `json:"completionTime"`
+	LastAttemptResult *AttemptResult         `json:"lastAttemptResult"`
+	LogURI            string                 `json:"logUri"`
+	Region            string                 `json:"region"`
+	Conditions        []*condition.Condition `json:"conditions"`
+	TerminalCondition *condition.Condition   `json:"terminalCondition"`
+}
+
+// AttemptResult is the result of the last attempt of a task.
+type AttemptResult struct {
+	ExitCode   int32  `json:"exitCode"`
+	TermSignal int32  `json:"termSignal"`
+	Message    string `json:"message"` // The failure message, empty when the attempt succeeded.
+}
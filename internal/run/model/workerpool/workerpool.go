@@ -13,7 +13,7 @@ type WorkerPool struct {
 	CreateTime           time.Time             `json:"createTime"`
 	UpdateTime           time.Time             `json:"updateTime"`
 	DeleteTime           time.Time             `json:"deleteTime"`
-	State                string                `json:"state"` // PENDING, ACTIVE, DELETE_REQUESTED, DELETING, SUSPENDED
+	State                string                `json:"state"` // PENDING, ACTIVE, FAILED, DELETE_REQUESTED, DELETING, SUSPENDED
 	DisplayName          string                `json:"displayName"`
 	Annotations          map[string]string     `json:"annotations"`
 	Labels               map[string]string     `json:"labels"`
@@ -1,21 +1,30 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
-	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
 	"github.com/JulienBreux/run-cli/internal/run/auth"
 	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/JulienBreux/run-cli/internal/run/demo"
 	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
 	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/contexts"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/domainmapping"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/job"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/operation"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/project"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/region"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/service"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/workerpool"
 	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
@@ -38,8 +47,14 @@ var (
 	currentInfo    info.Info
 	currentConfig  *config.Config
 
+	// cancelLoad cancels the loads of the current page, e.g. of its next rows.
+	cancelLoad context.CancelFunc
+	// loading tells whether the current page is loading its first rows.
+	loading bool
+
 	projectModal tview.Primitive
 	regionModal  tview.Primitive
+	contextModal tview.Primitive
 
 	loadingPages   *tview.Pages
 	loadingSpinner *spinner.Spinner
@@ -47,12 +62,18 @@ var (
 	footerPages *tview.Pages
 	errorView   *tview.TextView
 
+	// kinds are the list pages of the kinds of resources.
+	kinds = resource.Registry{service.Kind, job.Kind, workerpool.Kind, domainmapping.Kind}
+
 	konamiBuffer []string
 	konamiCode   = []string{
 		"Up", "Up", "Down", "Down", "Left", "Right", "Left", "Right", "b", "a",
 	}
 )
 
+// errLoadCancelled is shown when a load is cancelled with Esc.
+var errLoadCancelled = errors.New("loading cancelled, press r to refresh")
+
 const (
 	FULLSCREEN   = true
 	ENABLE_MOUSE = false
@@ -61,13 +82,8 @@ const (
 	LOADER_PAGE_ID  = "loader"
 	LAYOUT_PAGE_ID  = "layout"
 
-	CONSOLE_URL               = "https://console.cloud.google.com/run?project=%s"
-	CONSOLE_SERVICE_URL       = "https://console.cloud.google.com/run/detail/%s/%s/metrics?project=%s"
-	CONSOLE_JOB_LIST_URL      = "https://console.cloud.google.com/run/jobs?project=%s"
-	CONSOLE_JOB_DETAIL_URL    = "https://console.cloud.google.com/run/jobs/details/%s/%s/metrics?project=%s"
-	CONSOLE_WORKER_LIST_URL   = "https://console.cloud.google.com/run/workerpools?project=%s"
-	CONSOLE_WORKER_DETAIL_URL = "https://console.cloud.google.com/run/workerpools/details/%s/%s?project=%s"
-	RELEASE_NOTES_URL         = "https://docs.cloud.google.com/run/docs/release-notes"
+	CONSOLE_URL       = "https://console.cloud.google.com/run?project=%s"
+	RELEASE_NOTES_URL = "https://docs.cloud.google.com/run/docs/release-notes"
 )
 
 // Run runs the application.
@@ -88,6 +104,11 @@ func Run(cfg *config.Config) error {
 	mainLoader = loader.New(app)
 	rootPages.AddPage(LOADER_PAGE_ID, mainLoader, true, true)
 
+	// The lists of the demo mode and of overridden endpoints, e.g. a fake server, aren't cached.
+	listcache.SetEnabled(!demo.Enabled() && len(cfg.Endpoints) == 0)
+
+	api_operation.OnChange(onOperationChange)
+
 	// Start initialization in background
 	go initializeApp(cfg)
 
@@ -96,13 +117,29 @@ func Run(cfg *config.Config) error {
 		Run()
 }
 
+// applyContext applies the current context of the configuration to the info and the API calls.
+func applyContext() {
+	ctx := currentConfig.Current()
+	if ctx == nil {
+		ctx = &config.Context{}
+	}
+	currentInfo.Context = ctx.Name
+	currentInfo.ReadOnly = ctx.ReadOnly
+	client.Impersonate(ctx.ImpersonateServiceAccount)
+	client.SetReadOnly(ctx.ReadOnly)
+}
+
 func initializeApp(cfg *config.Config) {
 	// Simulate a small delay or just wait for heavy lifting
 	// This helps the UI render the loader first
 	time.Sleep(100 * time.Millisecond)
 
 	// 1. Load Auth/Info (Potentially slow)
-	if realInfo, err := auth.GetInfo(); err == nil {
+	getInfo := auth.GetInfo
+	if demo.Enabled() {
+		getInfo = demo.Info
+	}
+	if realInfo, err := getInfo(); err == nil {
 		currentInfo.User = realInfo.User
 		currentInfo.Project = realInfo.Project
 		currentInfo.Region = realInfo.Region
@@ -114,56 +151,86 @@ func initializeApp(cfg *config.Config) {
 	if cfg.Project != "" {
 		currentInfo.Project = cfg.Project
 	}
+	applyContext()
+	// The regions are fetched ahead, for the region modal to open at once.
+	go api_region.List(context.Background(), currentInfo.Project)
+
+	// 2. Show the cached services at once, if any, while they are refreshed.
+	project.LoadCached()
+	servicesCtx := newLoad()
+	var refreshed time.Time
+	var cached bool
+	app.QueueUpdateDraw(func() {
+		mainLayout := buildLayout()
+		rootPages.AddPage(LAYOUT_PAGE_ID, mainLayout, true, false)
+		if refreshed, cached = service.Kind.LoadCached(currentInfo); cached {
+			showServices()
+			showLoading()
+		}
+	})
 
-	// 2. Pre-load Data (Projects and Services) in parallel
+	// 3. Pre-load Data (Projects and Services) in parallel
 	var services []model_service.Service
+	var servicesNext string
+	var servicesErr error
 	var wg sync.WaitGroup
 	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
 		mainLoader.Spinner.SetContext("Projects...")
-		_ = project.PreLoad()
+		_ = project.PreLoad(context.Background())
 	}()
 
 	go func() {
 		defer wg.Done()
 		mainLoader.Spinner.SetContext("Services...")
-		if svcs, err := service.Fetch(currentInfo.Project, currentInfo.Region); err == nil {
-			services = svcs
-		}
+		services, servicesNext, servicesErr = service.Kind.Fetch(servicesCtx, currentInfo.Project, currentInfo.Region, "")
 	}()
 
 	wg.Wait()
 
 	app.QueueUpdateDraw(func() {
-		// 3. Build the main layout
-		mainLayout := buildLayout()
-		rootPages.AddPage(LAYOUT_PAGE_ID, mainLayout, true, false)
-
-		// 4. Populate Services
-		service.Load(services)
-
-		// 5. Switch to main layout
-		rootPages.SwitchToPage(LAYOUT_PAGE_ID)
+		if !cached {
+			showServices()
+		}
+		// The cached services were left or their refresh cancelled.
+		if servicesCtx.Err() != nil {
+			return
+		}
 
-		// 6. Set initial state manually (skip reloading)
-		previousPageID = ""
-		currentPageID = service.LIST_PAGE_ID
-		pages.SwitchToPage(service.LIST_PAGE_ID)
-		service.Shortcuts()
-		hideLoading()
+		// 4. Populate Services, the cached ones are kept when they can't be refreshed.
+		if cached && servicesErr != nil && api_region.Failed(servicesErr) == nil {
+			onLoaded(&listcache.StaleError{Err: servicesErr, Refreshed: refreshed})
+			return
+		}
+		service.Kind.Load(services)
+		if servicesErr == nil {
+			service.Kind.Save(currentInfo)
+		}
+		service.Kind.SetFailedRegions(api_region.Failed(servicesErr))
+		service.Kind.LoadMore(servicesCtx, app, currentInfo, servicesNext, onLoaded)
+		onLoaded(servicesErr)
 	})
 }
 
+// showServices switches from the loader to the list of services, without reloading it.
+func showServices() {
+	rootPages.SwitchToPage(LAYOUT_PAGE_ID)
+	previousPageID = ""
+	currentPageID = service.LIST_PAGE_ID
+	pages.SwitchToPage(service.LIST_PAGE_ID)
+	service.Kind.Shortcuts()
+}
+
 // buildLayout constructs the main application UI
 func buildLayout() *tview.Flex {
 	pages = tview.NewPages()
 	// Lists
-	pages.AddPage(service.LIST_PAGE_ID, service.List(app).Table, true, true)
-	pages.AddPage(job.LIST_PAGE_ID, job.List(app).Table, true, true)
-	pages.AddPage(workerpool.LIST_PAGE_ID, workerpool.List(app).Table, true, true)
-	pages.AddPage(domainmapping.LIST_PAGE_ID, domainmapping.List(app).Table, true, true)
+	for _, k := range kinds {
+		pages.AddPage(k.ID(), k.Build(app), true, true)
+	}
+	pages.AddPage(operation.LIST_PAGE_ID, operation.List(app).Table, true, true)
 
 	// Dashboards
 	pages.AddPage(service.DASHBOARD_PAGE_ID, service.Dashboard(app), true, false)
@@ -211,54 +278,25 @@ func shortcuts(event *tcell.EventKey) *tcell.EventKey {
 	// Navigation.
 	if event.Key() == tcell.KeyCtrlZ {
 		u := fmt.Sprintf(CONSOLE_URL, currentInfo.Project)
-
-		switch currentPageID {
-		case service.LIST_PAGE_ID:
-			name, region := service.GetSelectedService()
-			if name != "" && region != "" {
-				u = fmt.Sprintf(CONSOLE_SERVICE_URL, region, name, currentInfo.Project)
-			}
-		case job.LIST_PAGE_ID:
-			name, region := job.GetSelectedJob()
-			if name != "" && region != "" {
-				u = fmt.Sprintf(CONSOLE_JOB_DETAIL_URL, region, name, currentInfo.Project)
-			} else {
-				u = fmt.Sprintf(CONSOLE_JOB_LIST_URL, currentInfo.Project)
-			}
-		case workerpool.LIST_PAGE_ID:
-			name, region := workerpool.GetSelectedWorkerPool()
-			if name != "" && region != "" {
-				u = fmt.Sprintf(CONSOLE_WORKER_DETAIL_URL, region, name, currentInfo.Project)
-			} else {
-				u = fmt.Sprintf(CONSOLE_WORKER_LIST_URL, currentInfo.Project)
+		if k := kinds.Lookup(currentPageID); k != nil {
+			if kindURL := k.ConsoleURL(currentInfo.Project); kindURL != "" {
+				u = kindURL
 			}
 		}
 
-		if !strings.HasSuffix(os.Args[0], ".test") {
-			_ = browser.OpenURL(u)
-		}
+		openURL(u)
 		return nil
 	}
 	if event.Key() == tcell.KeyCtrlL {
-		if !strings.HasSuffix(os.Args[0], ".test") {
-			_ = browser.OpenURL(RELEASE_NOTES_URL)
-		}
-		return nil
-	}
-	if event.Key() == service.LIST_PAGE_SHORTCUT {
-		switchTo(service.LIST_PAGE_ID)
+		openURL(RELEASE_NOTES_URL)
 		return nil
 	}
-	if event.Key() == job.LIST_PAGE_SHORTCUT {
-		switchTo(job.LIST_PAGE_ID)
+	if k := kinds.ByKey(event.Key()); k != nil {
+		switchTo(k.ID())
 		return nil
 	}
-	if event.Key() == workerpool.LIST_PAGE_SHORTCUT {
-		switchTo(workerpool.LIST_PAGE_ID)
-		return nil
-	}
-	if event.Key() == domainmapping.LIST_PAGE_SHORTCUT {
-		switchTo(domainmapping.LIST_PAGE_ID)
+	if event.Key() == operation.LIST_PAGE_SHORTCUT {
+		switchTo(operation.LIST_PAGE_ID)
 		return nil
 	}
 
@@ -271,6 +309,15 @@ func shortcuts(event *tcell.EventKey) *tcell.EventKey {
 		openRegionModal()
 		return nil
 	}
+	if event.Key() == contexts.MODAL_PAGE_SHORTCUT {
+		openContextModal()
+		return nil
+	}
+
+	// The actions of the dashboards, e.g. esc goes back from the tasks of an execution to the executions.
+	if currentPageID == job.DASHBOARD_PAGE_ID && job.DashboardHandleKey(event, host{}) {
+		return nil
+	}
 
 	if event.Key() == tcell.KeyEscape {
 		if currentPageID == service.DASHBOARD_PAGE_ID {
@@ -281,121 +328,23 @@ func shortcuts(event *tcell.EventKey) *tcell.EventKey {
 			switchTo(job.LIST_PAGE_ID)
 			return nil
 		}
-	}
-
-	// Open URL for Service list
-	if currentPageID == service.LIST_PAGE_ID {
-		if event.Key() == tcell.KeyEnter {
-			switchTo(service.DASHBOARD_PAGE_ID)
-			return nil
-		}
-		if event.Rune() == 'r' {
-			switchTo(service.LIST_PAGE_ID)
-			return nil
-		}
-		if event.Rune() == 'l' {
-			name, region := service.GetSelectedService()
-			if name != "" {
-				openLogModal(name, region, "service")
-			}
-			return nil
-		}
-		if event.Rune() == 'd' {
-			if s := service.GetSelectedServiceFull(); s != nil {
-				openDescribeModal(s, s.Name)
-			}
-			return nil
-		}
-		if event.Rune() == 's' {
-			if s := service.GetSelectedServiceFull(); s != nil {
-				openServiceScaleModal(s)
-			}
-			return nil
-		}
-		if result := service.HandleShortcuts(event); result == nil {
-			return nil
-		}
-	}
-
-	// Job List
-	if currentPageID == job.LIST_PAGE_ID {
-		if event.Key() == tcell.KeyEnter {
-			switchTo(job.DASHBOARD_PAGE_ID)
-			return nil
-		}
-		if event.Rune() == 'r' {
-			switchTo(job.LIST_PAGE_ID)
-			return nil
-		}
-		if event.Rune() == 'l' {
-			name, region := job.GetSelectedJob()
-			if name != "" {
-				openLogModal(name, region, "job")
-			}
-			return nil
-		}
-		if event.Rune() == 'd' {
-			if j := job.GetSelectedJobFull(); j != nil {
-				openDescribeModal(j, j.Name)
-			}
-			return nil
-		}
-		if event.Rune() == 'x' {
-			name, region := job.GetSelectedJob()
-			if name != "" {
-				showLoading()
-				go func() {
-					_, err := api_job.Execute(currentInfo.Project, region, name)
-					app.QueueUpdateDraw(func() {
-						if err != nil {
-							showError(err)
-						} else {
-							switchTo(job.LIST_PAGE_ID)
-						}
-					})
-				}()
-			}
+		// Esc closes the modals, otherwise it cancels the load of the page.
+		if frontPage == LAYOUT_PAGE_ID && stopLoad() {
+			hideLoading()
+			showWarning(errLoadCancelled)
 			return nil
 		}
 	}
 
-	// Worker List
-	if currentPageID == workerpool.LIST_PAGE_ID {
-		if event.Rune() == 'r' {
-			switchTo(workerpool.LIST_PAGE_ID)
-			return nil
-		}
-		if event.Rune() == 'd' {
-			if w := workerpool.GetSelectedWorkerPoolFull(); w != nil {
-				openDescribeModal(w, w.Name)
-			}
-			return nil
-		}
-		if event.Rune() == 's' {
-			if w := workerpool.GetSelectedWorkerPoolFull(); w != nil {
-				openWorkerPoolScaleModal(w)
-			}
-			return nil
-		}
+	// The actions of the selected resource.
+	if k := kinds.Lookup(currentPageID); k != nil && k.HandleKey(event, host{}) {
+		return nil
 	}
 
-	// Domain Mapping List
-	if currentPageID == domainmapping.LIST_PAGE_ID {
-		if event.Key() == tcell.KeyEnter {
-			if dm := domainmapping.GetSelectedDomainMappingFull(); dm != nil {
-				openDomainMappingInfoModal(dm)
-			}
-			return nil
-		}
+	// Operations
+	if currentPageID == operation.LIST_PAGE_ID {
 		if event.Rune() == 'r' {
-			switchTo(domainmapping.LIST_PAGE_ID)
-			return nil
-		}
-		if event.Rune() == 'o' {
-			u := domainmapping.GetSelectedDomainURL()
-			if u != "" && !strings.HasSuffix(os.Args[0], ".test") {
-				_ = browser.OpenURL(u)
-			}
+			operation.Render()
 			return nil
 		}
 	}
@@ -418,51 +367,147 @@ func showError(err error) {
 	footerPages.SwitchToPage("error")
 }
 
+// showWarning shows an error which did not prevent the page from loading, e.g. failed regions.
+func showWarning(err error) {
+	errorView.SetText(fmt.Sprintf("[yellow]⚠ %s", err.Error()))
+	footerPages.SwitchToPage("error")
+}
+
+// newLoad cancels the loads of the current page, if any, and returns the context of the loads of the
+// next one. The results of a cancelled load are never rendered.
+func newLoad() context.Context {
+	stopLoad()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelLoad = cancel
+	loading = true
+	return ctx
+}
+
+// stopLoad cancels the loads of the current page and returns whether its first rows were loading.
+func stopLoad() bool {
+	if cancelLoad != nil {
+		cancelLoad()
+		cancelLoad = nil
+	}
+	wasLoading := loading
+	loading = false
+	return wasLoading
+}
+
+// onLoaded reports the result of a load of the current page.
+func onLoaded(err error) {
+	loading = false
+	var stale *listcache.StaleError
+	switch {
+	case api_region.Failed(err) != nil, errors.As(err, &stale):
+		hideLoading()
+		showWarning(err)
+	case err != nil:
+		showError(err)
+	default:
+		hideLoading()
+	}
+}
+
 func switchTo(pageID string) {
 	previousPageID = currentPageID
 	currentPageID = pageID
 	pages.SwitchToPage(pageID)
 
-	callback := func(err error) {
-		if err != nil {
-			showError(err)
-		} else {
-			hideLoading()
-		}
+	// Leaving a page cancels its load.
+	stopLoad()
+
+	if k := kinds.Lookup(pageID); k != nil {
+		k.Shortcuts()
+		showLoading()
+		k.Reload(newLoad(), app, currentInfo, onLoaded)
+		return
 	}
 
 	switch pageID {
-	case service.LIST_PAGE_ID:
-		service.Shortcuts()
-		showLoading()
-		service.ListReload(app, currentInfo, callback)
 	case service.DASHBOARD_PAGE_ID:
-		if s := service.GetSelectedServiceFull(); s != nil {
+		if s := service.Kind.Selected(); s != nil {
 			service.DashboardShortcuts()
 			showLoading()
-			service.DashboardReload(app, currentInfo, s, callback)
+			service.DashboardReload(newLoad(), app, currentInfo, s, onLoaded)
 		}
 	case job.DASHBOARD_PAGE_ID:
-		if j := job.GetSelectedJobFull(); j != nil {
-			job.DashboardShortcuts()
+		if j := job.Kind.Selected(); j != nil {
 			showLoading()
-			job.DashboardReload(app, currentInfo, j, callback)
+			job.DashboardReload(newLoad(), app, currentInfo, j, onLoaded)
+			// The shortcuts depend on whether the reload kept the tasks of an execution shown.
+			job.DashboardShortcuts()
 		}
-	case job.LIST_PAGE_ID:
-		job.Shortcuts()
-		showLoading()
-		job.ListReload(app, currentInfo, callback)
-	case workerpool.LIST_PAGE_ID:
-		workerpool.Shortcuts()
-		showLoading()
-		workerpool.ListReload(app, currentInfo, callback)
-	case domainmapping.LIST_PAGE_ID:
-		domainmapping.Shortcuts()
-		showLoading()
-		domainmapping.ListReload(app, currentInfo, callback)
+	case operation.LIST_PAGE_ID:
+		// The operations are in memory, there is nothing to load.
+		operation.Shortcuts()
+		hideLoading()
+		operation.Render()
 	}
 }
 
+// openURL opens a URL in the browser, except in the tests.
+func openURL(u string) {
+	if !strings.HasSuffix(os.Args[0], ".test") {
+		_ = browser.OpenURL(u)
+	}
+}
+
+// host runs the actions of the kinds of resources in the app.
+type host struct{}
+
+var _ resource.Host = host{}
+
+func (host) App() *tview.Application { return app }
+
+func (host) Info() info.Info { return currentInfo }
+
+func (host) OpenModal(id string, build func(close func()) tview.Primitive) { openModal(id, build) }
+
+func (host) SwitchTo(pageID string) { switchTo(pageID) }
+
+func (host) OpenURL(u string) { openURL(u) }
+
+func (host) ShowError(err error) { showError(err) }
+
+// Start starts an operation, followed in the operations panel, and reloads the page it was started
+// from.
+func (host) Start(start func(ctx context.Context) (*api_operation.Operation, error)) {
+	pageID := currentPageID
+	showLoading()
+	go func() {
+		op, err := start(context.Background())
+		// Tracking notifies onOperationChange, which queues an update: it's done before queueing this one.
+		if err == nil {
+			api_operation.Track(op)
+		}
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				showError(err)
+				return
+			}
+			switchTo(pageID)
+		})
+	}()
+}
+
+// onOperationChange renders the operations when they change, and reports the failed ones wherever
+// the user is, for them not to go unnoticed once their modal is closed.
+// It doesn't block, for operations to be tracked from the main goroutine too, e.g. in an update.
+func onOperationChange(o *api_operation.Operation) {
+	go app.QueueUpdateDraw(func() {
+		if pages == nil {
+			return
+		}
+		if currentPageID == operation.LIST_PAGE_ID {
+			operation.Render()
+		}
+		if status := o.Status(); status.Done && status.Err != nil {
+			showError(fmt.Errorf("%s of %s failed, press ctrl-o for the operations: %w", o.Kind, o.Target, status.Err))
+		}
+	})
+}
+
 func checkKonamiCode(event *tcell.EventKey) bool {
 	var key string
 	switch event.Key() {
@@ -1,15 +1,20 @@
 package app
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
 	"github.com/JulienBreux/run-cli/internal/run/config"
 	model_job "github.com/JulienBreux/run-cli/internal/run/model/job"
 	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
 	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/describe"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/domainmapping"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/job"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/log"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/operation"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/service"
 	service_scale "github.com/JulienBreux/run-cli/internal/run/tui/app/service/scale"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/workerpool"
@@ -66,6 +71,8 @@ func TestShortcuts_Navigation(t *testing.T) {
 		{"To Service List", service.LIST_PAGE_SHORTCUT, service.LIST_PAGE_ID},
 		{"To Job List", job.LIST_PAGE_SHORTCUT, job.LIST_PAGE_ID},
 		{"To WorkerPool List", workerpool.LIST_PAGE_SHORTCUT, workerpool.LIST_PAGE_ID},
+		{"To Domain Mapping List", domainmapping.LIST_PAGE_SHORTCUT, domainmapping.LIST_PAGE_ID},
+		{"To Operations", operation.LIST_PAGE_SHORTCUT, operation.LIST_PAGE_ID},
 	}
 
 	for _, tt := range tests {
@@ -101,6 +108,38 @@ func TestShortcuts_Escape(t *testing.T) {
 	assert.Equal(t, service.LIST_PAGE_ID, currentPageID)
 }
 
+func TestShortcuts_EscapeCancelsLoad(t *testing.T) {
+	setupTestApp()
+	rootPages.AddPage(LAYOUT_PAGE_ID, tview.NewBox(), true, true)
+	buildLayout()
+	currentPageID = service.LIST_PAGE_ID
+
+	ctx := newLoad()
+	event := tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)
+	result := shortcuts(event)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.Nil(t, cancelLoad)
+	assert.Contains(t, errorView.GetText(true), "loading cancelled")
+
+	// Without a load in flight, Esc is left to the page.
+	result = shortcuts(event)
+	assert.Equal(t, event, result)
+}
+
+func TestNewLoad(t *testing.T) {
+	first := newLoad()
+	second := newLoad()
+
+	assert.ErrorIs(t, first.Err(), context.Canceled)
+	assert.NoError(t, second.Err())
+
+	assert.True(t, stopLoad())
+	assert.ErrorIs(t, second.Err(), context.Canceled)
+	assert.False(t, stopLoad())
+}
+
 func TestShortcuts_OpenConsole(t *testing.T) {
 	setupTestApp()
 	buildLayout()
@@ -115,10 +154,8 @@ func TestShortcuts_OpenConsole(t *testing.T) {
 	currentPageID = service.LIST_PAGE_ID
 	
 	// Populate Service Table
-	svcTable := service.List(app).Table
-	svcTable.SetCell(1, 0, tview.NewTableCell("s1"))
-	svcTable.SetCell(1, 1, tview.NewTableCell("r1"))
-	svcTable.Select(1, 0)
+	service.Kind.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
+	service.Kind.Table().Table.Select(1, 0)
 	
 	eventService := tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModNone)
 	resultService := shortcuts(eventService)
@@ -126,10 +163,8 @@ func TestShortcuts_OpenConsole(t *testing.T) {
 	
 	// Job List
 	currentPageID = job.LIST_PAGE_ID
-	jobTable := job.List(app).Table
-	jobTable.SetCell(1, 0, tview.NewTableCell("j1"))
-	jobTable.SetCell(1, 3, tview.NewTableCell("r1")) // Region is col 3
-	jobTable.Select(1, 0)
+	job.Kind.Load([]model_job.Job{{Name: "j1", Region: "r1"}})
+	job.Kind.Table().Table.Select(1, 0)
 	
 	eventJob := tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModNone)
 	resultJob := shortcuts(eventJob)
@@ -137,10 +172,8 @@ func TestShortcuts_OpenConsole(t *testing.T) {
 	
 	// WorkerPool List
 	currentPageID = workerpool.LIST_PAGE_ID
-	wpTable := workerpool.List(app).Table
-	wpTable.SetCell(1, 0, tview.NewTableCell("wp1"))
-	wpTable.SetCell(1, 1, tview.NewTableCell("r1"))
-	wpTable.Select(1, 0)
+	workerpool.Kind.Load([]model_workerpool.WorkerPool{{DisplayName: "wp1", Region: "r1"}})
+	workerpool.Kind.Table().Table.Select(1, 0)
 	
 	eventWP := tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModNone)
 	resultWP := shortcuts(eventWP)
@@ -204,8 +237,8 @@ func TestSwitchTo(t *testing.T) {
 	
 	// Test Dashboard
 	// Needs selection?
-	// switchTo Dashboard checks GetSelectedServiceFull. If nil, it might skip reload?
-	// No, it checks `if s := service.GetSelectedServiceFull(); s != nil`.
+	// switchTo Dashboard checks service.Kind.Selected(). If nil, it might skip reload?
+	// No, it checks `if s := service.Kind.Selected(); s != nil`.
 	// If nil, it just switches page? No, the block is inside if.
 	// `pages.SwitchToPage(pageID)` is called unconditionally at start.
 	
@@ -229,9 +262,8 @@ func TestShortcuts_Detailed(t *testing.T) {
 	currentPageID = service.LIST_PAGE_ID
 	
 	// Populate Service Table
-	svcTable := service.List(app).Table
-	svcTable.SetCell(1, 0, tview.NewTableCell("s1"))
-	svcTable.SetCell(1, 1, tview.NewTableCell("r1"))
+	svcTable := service.Kind.Table().Table
+	service.Kind.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
 	svcTable.Select(1, 0)
 	
 	// Enter -> Dashboard
@@ -249,22 +281,14 @@ func TestShortcuts_Detailed(t *testing.T) {
 	// 'l' -> Log Modal
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
 	// Verify page changed?
-	// openLogModal changes currentPageID to log.MODAL_PAGE_ID? No, it adds page.
-	// But it sets focus.
+	// The log modal is opened on top of the list.
 	
 	// 'd' -> Describe
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone))
 	
 	// 's' -> Scale
-	// This requires GetSelectedServiceFull returning a struct.
-	// We only populated the table visually.
-	// service.Load() populates 'services' slice.
-	// We need to populate that slice too?
-	// service.Load([]model_service.Service{{Name: "s1"}})
-	// We can't access 'service.Load' from here easily? Yes we can, it is exported.
-	
 	// Populate Service Data
-	service.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
+	service.Kind.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
 	svcTable.Select(1, 0) // Re-select because Load clears table
 	
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone))
@@ -273,10 +297,8 @@ func TestShortcuts_Detailed(t *testing.T) {
 	currentPageID = job.LIST_PAGE_ID
 	
 	// Populate Job Table
-	jobTable := job.List(app).Table
-	jobTable.SetCell(1, 0, tview.NewTableCell("j1"))
-	jobTable.SetCell(1, 3, tview.NewTableCell("r1"))
-	jobTable.Select(1, 0)
+	job.Kind.Load([]model_job.Job{{Name: "j1", Region: "r1"}})
+	job.Kind.Table().Table.Select(1, 0)
 	
 	// 'x' -> Execute (async)
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone))
@@ -285,10 +307,8 @@ func TestShortcuts_Detailed(t *testing.T) {
 	currentPageID = workerpool.LIST_PAGE_ID
 	
 	// Populate WP Table
-	wpTable := workerpool.List(app).Table
-	wpTable.SetCell(1, 0, tview.NewTableCell("wp1"))
-	wpTable.SetCell(1, 1, tview.NewTableCell("r1"))
-	wpTable.Select(1, 0)
+	workerpool.Kind.Load([]model_workerpool.WorkerPool{{DisplayName: "wp1", Region: "r1"}})
+	workerpool.Kind.Table().Table.Select(1, 0)
 	
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone))
 }
@@ -299,9 +319,9 @@ func TestShortcuts_Modals(t *testing.T) {
 	
 	// --- Service Modals ---
 	currentPageID = service.LIST_PAGE_ID
-	svcTable := service.List(app).Table
+	svcTable := service.Kind.Table().Table
 	// Populate with full struct for Describe/Scale
-	service.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
+	service.Kind.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
 	svcTable.Select(1, 0)
 	
 	// Log
@@ -320,9 +340,9 @@ func TestShortcuts_Modals(t *testing.T) {
 	// Scale
 	// Ensure selection is preserved/re-applied
 	// Re-load data to ensure state consistency
-	service.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
+	service.Kind.Load([]model_service.Service{{Name: "s1", Region: "r1"}})
 	svcTable.Select(1, 0)
-	assert.NotNil(t, service.GetSelectedServiceFull(), "Service selection lost before Scale shortcut")
+	assert.NotNil(t, service.Kind.Selected(), "Service selection lost before Scale shortcut")
 
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone))
 	assert.Equal(t, service_scale.MODAL_PAGE_ID, currentPageID)
@@ -331,17 +351,8 @@ func TestShortcuts_Modals(t *testing.T) {
 	
 	// --- Job Modals ---
 	currentPageID = job.LIST_PAGE_ID
-	job.List(app) // ensure table init
-	// Populate data (Job package doesn't have public Load yet? It has 'jobs' var)
-	// We can't easily inject data into 'jobs' var from here as it is unexported in job package?
-	// Wait, 'jobs' in job package is unexported. 'GetSelectedJobFull' reads it.
-	// We can't use 'GetSelectedJobFull' if we can't populate 'jobs'.
-	// But 'GetSelectedJob' (used for Logs) reads from Table.
-	
-	// So we can test Logs for Job.
-	jobTable := job.List(app).Table
-	jobTable.SetCell(1, 0, tview.NewTableCell("j1"))
-	jobTable.SetCell(1, 3, tview.NewTableCell("r1"))
+	jobTable := job.Kind.Table().Table
+	job.Kind.Load([]model_job.Job{{Name: "j1", Region: "r1"}})
 	jobTable.Select(1, 0)
 	
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
@@ -350,7 +361,7 @@ func TestShortcuts_Modals(t *testing.T) {
 	currentPageID = job.LIST_PAGE_ID
 	
 	// Describe for Job
-	job.Load([]model_job.Job{{Name: "j1", Region: "r1"}})
+	job.Kind.Load([]model_job.Job{{Name: "j1", Region: "r1"}})
 	jobTable.Select(1, 0)
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone))
 	assert.Equal(t, describe.MODAL_PAGE_ID, currentPageID)
@@ -359,8 +370,8 @@ func TestShortcuts_Modals(t *testing.T) {
 	
 	// --- WorkerPool Modals ---
 	currentPageID = workerpool.LIST_PAGE_ID
-	wpTable := workerpool.List(app).Table
-	workerpool.Load([]model_workerpool.WorkerPool{{DisplayName: "wp1", Region: "r1"}})
+	wpTable := workerpool.Kind.Table().Table
+	workerpool.Kind.Load([]model_workerpool.WorkerPool{{DisplayName: "wp1", Region: "r1"}})
 	wpTable.Select(1, 0)
 	
 	// Describe for WorkerPool
@@ -369,11 +380,17 @@ func TestShortcuts_Modals(t *testing.T) {
 	rootPages.RemovePage(describe.MODAL_PAGE_ID)
 	currentPageID = workerpool.LIST_PAGE_ID
 	
+	// Logs for WorkerPool
+	shortcuts(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
+	assert.Equal(t, log.MODAL_PAGE_ID, currentPageID)
+	rootPages.RemovePage(log.MODAL_PAGE_ID)
+	currentPageID = workerpool.LIST_PAGE_ID
+	
 	// Scale for WorkerPool
 	// Ensure selection is preserved/re-applied
-	workerpool.Load([]model_workerpool.WorkerPool{{DisplayName: "wp1", Region: "r1"}})
+	workerpool.Kind.Load([]model_workerpool.WorkerPool{{DisplayName: "wp1", Region: "r1"}})
 	wpTable.Select(1, 0)
-	assert.NotNil(t, workerpool.GetSelectedWorkerPoolFull(), "WorkerPool selection lost before Scale shortcut")
+	assert.NotNil(t, workerpool.Kind.Selected(), "WorkerPool selection lost before Scale shortcut")
 
 	shortcuts(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone))
 	assert.Equal(t, workerpool_scale.MODAL_PAGE_ID, currentPageID)
@@ -403,3 +420,34 @@ func TestRun(t *testing.T) {
 	
 	// Let's rely on what we have. 41% is low.
 }
+
+func TestHostStart_TracksOperation(t *testing.T) {
+	setupTestApp()
+	buildLayout()
+	api_operation.OnChange(onOperationChange)
+	t.Cleanup(func() { api_operation.OnChange(nil) })
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	_ = screen.Init()
+	app.SetScreen(screen)
+	go func() { _ = app.Run() }()
+	defer app.Stop()
+
+	switchTo(operation.LIST_PAGE_ID)
+	started := len(api_operation.List())
+	host{}.Start(func(ctx context.Context) (*api_operation.Operation, error) {
+		p := api_operation.Completed("operation-1", "done")
+		return api_operation.New("Scale", "service-1", p, func(s string) string { return s }), nil
+	})
+	assert.Eventually(t, func() bool { return len(api_operation.List()) == started+1 }, time.Second, 10*time.Millisecond)
+
+	// The app still runs its updates once the operation is tracked.
+	drawn := make(chan struct{})
+	go app.QueueUpdateDraw(func() { close(drawn) })
+	select {
+	case <-drawn:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the app is blocked once an operation is started")
+	}
+	assert.Equal(t, operation.LIST_PAGE_ID, currentPageID)
+}
@@ -0,0 +1,174 @@
+package contexts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	MODAL_PAGE_ID       = "modal-contexts"
+	MODAL_PAGE_SHORTCUT = tcell.KeyCtrlX
+)
+
+// ContextSelector represents the context selection modal component.
+type ContextSelector struct {
+	*tview.Grid
+	Content *tview.Flex
+	Input   *tview.InputField
+	List    *tview.List
+	Filter  func(string)
+	Submit  func()
+}
+
+// ContextModal returns a centered modal primitive with search and list
+func ContextModal(app *tview.Application, contexts []config.Context, current string, onSelect func(ctx config.Context), closeModal func()) *ContextSelector {
+	// --- Data ---
+	var filteredContexts []config.Context
+
+	// --- Components ---
+
+	// Input
+	input := tview.NewInputField().
+		SetLabel("Search: ").
+		SetFieldWidth(30).
+		SetLabelColor(tcell.ColorYellow)
+
+	// List
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true).
+		SetSelectedBackgroundColor(tcell.ColorDarkBlue)
+
+	list.SetBorder(true).SetTitle(" Results ")
+
+	// Buttons
+	btnSelect := tview.NewButton("Select").SetStyle(tcell.StyleDefault.Background(tcell.ColorDarkGreen))
+	btnCancel := tview.NewButton("Cancel").SetStyle(tcell.StyleDefault.Background(tcell.ColorDarkRed))
+
+	// --- Logic ---
+
+	populateList := func(filter string) {
+		list.Clear()
+		filteredContexts = nil
+		filter = strings.ToLower(filter)
+		for _, ctx := range contexts {
+			if strings.Contains(strings.ToLower(ctx.Name+" "+ctx.Project+" "+ctx.Region), filter) {
+				filteredContexts = append(filteredContexts, ctx)
+				list.AddItem(Label(ctx, ctx.Name == current), "", 0, nil)
+			}
+		}
+	}
+
+	// Init List
+	populateList("")
+
+	// Events
+	input.SetChangedFunc(populateList)
+
+	submit := func() {
+		idx := list.GetCurrentItem()
+		if idx != -1 && idx < len(filteredContexts) {
+			onSelect(filteredContexts[idx])
+			closeModal()
+		}
+	}
+
+	btnSelect.SetSelectedFunc(submit)
+	btnCancel.SetSelectedFunc(closeModal)
+
+	// Allow selecting items directly from the list
+	list.SetSelectedFunc(func(i int, s1, s2 string, r rune) {
+		submit()
+	})
+
+	// --- Layout (The Box) ---
+
+	// 1. Flex for Buttons
+	buttons := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(btnSelect, 12, 1, false).
+		AddItem(nil, 2, 0, false). // Space between buttons
+		AddItem(btnCancel, 12, 1, false).
+		AddItem(nil, 0, 1, false)
+
+	// 2. Main Content Flex (Vertical)
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).   // Search Bar
+		AddItem(nil, 1, 0, false).    // Padding
+		AddItem(list, 0, 1, false).   // List (Takes remaining space in the box)
+		AddItem(nil, 1, 0, false).    // Padding
+		AddItem(buttons, 1, 0, false) // Buttons
+
+	title := " Select Context "
+	if len(contexts) == 0 {
+		title = " No Context, see 'run config set-context' "
+	}
+	content.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignCenter)
+
+	// --- Navigation (Tab Cycling) ---
+	content.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeModal()
+			return nil
+		}
+		if event.Key() == tcell.KeyTab {
+			switch {
+			case input.HasFocus():
+				app.SetFocus(list)
+			case list.HasFocus():
+				app.SetFocus(btnSelect)
+			case btnSelect.HasFocus():
+				app.SetFocus(btnCancel)
+			case btnCancel.HasFocus():
+				app.SetFocus(input)
+			}
+			return nil
+		}
+		// Convenience: Down arrow from Input goes to List
+		if input.HasFocus() && event.Key() == tcell.KeyDown {
+			app.SetFocus(list)
+			return nil
+		}
+		return event
+	})
+
+	// --- Centering (The Grid) ---
+	grid := tview.NewGrid().
+		SetColumns(0, 80, 0).
+		SetRows(0, 20, 0).
+		AddItem(content, 1, 1, 1, 1, 0, 0, true)
+
+	return &ContextSelector{
+		Grid:    grid,
+		Content: content,
+		Input:   input,
+		List:    list,
+		Filter:  populateList,
+		Submit:  submit,
+	}
+}
+
+// Label returns the list item of a context, e.g. "* prod-eu (my-prod, europe-west1, read-only)".
+func Label(ctx config.Context, current bool) string {
+	marker := "  "
+	if current {
+		marker = "* "
+	}
+	details := []string{ctx.Project}
+	if ctx.Region != "" {
+		details = append(details, ctx.Region)
+	}
+	if ctx.ImpersonateServiceAccount != "" {
+		details = append(details, "as "+ctx.ImpersonateServiceAccount)
+	}
+	if ctx.ReadOnly {
+		details = append(details, "read-only")
+	}
+	return fmt.Sprintf("%s%s (%s)", marker, ctx.Name, strings.Join(details, ", "))
+}
@@ -0,0 +1,83 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/JulienBreux/run-cli/internal/run/config"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+var testContexts = []config.Context{
+	{Name: "prod-eu", Project: "prod", Region: "europe-west1", ReadOnly: true},
+	{Name: "staging-us", Project: "staging", Region: "us-central1", ImpersonateServiceAccount: "deployer@staging.iam.gserviceaccount.com"},
+}
+
+func TestContextModal(t *testing.T) {
+	app := tview.NewApplication()
+	selector := ContextModal(app, testContexts, "prod-eu", func(config.Context) {}, func() {})
+
+	assert.NotNil(t, selector)
+	assert.Equal(t, 2, selector.List.GetItemCount())
+	assert.Equal(t, " Select Context ", selector.Content.GetTitle())
+
+	text, _ := selector.List.GetItemText(0)
+	assert.Equal(t, "* prod-eu (prod, europe-west1, read-only)", text)
+
+	// Filter on the project
+	selector.Filter("staging")
+	assert.Equal(t, 1, selector.List.GetItemCount())
+	text, _ = selector.List.GetItemText(0)
+	assert.Equal(t, "  staging-us (staging, us-central1, as deployer@staging.iam.gserviceaccount.com)", text)
+
+	selector.Filter("unknown")
+	assert.Equal(t, 0, selector.List.GetItemCount())
+}
+
+func TestContextModal_Empty(t *testing.T) {
+	selector := ContextModal(tview.NewApplication(), nil, "", func(config.Context) {}, func() {})
+
+	assert.Equal(t, 0, selector.List.GetItemCount())
+	assert.Contains(t, selector.Content.GetTitle(), "run config set-context")
+}
+
+func TestSubmit(t *testing.T) {
+	app := tview.NewApplication()
+	var selected config.Context
+	closed := false
+
+	selector := ContextModal(app, testContexts, "", func(ctx config.Context) { selected = ctx }, func() { closed = true })
+
+	selector.Filter("us-central1")
+	selector.List.SetCurrentItem(0)
+	selector.Submit()
+
+	assert.True(t, closed)
+	assert.Equal(t, "staging-us", selected.Name)
+
+	// Nothing to submit
+	closed = false
+	selector.Filter("unknown")
+	selector.Submit()
+	assert.False(t, closed)
+}
+
+func TestInputCapture(t *testing.T) {
+	app := tview.NewApplication()
+	closed := false
+
+	selector := ContextModal(app, testContexts, "", func(config.Context) {}, func() { closed = true })
+	handler := selector.Content.GetInputCapture()
+
+	assert.Nil(t, handler(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)))
+	assert.True(t, closed)
+
+	app.SetFocus(selector.Input)
+	handler(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+	assert.True(t, selector.List.HasFocus())
+
+	app.SetFocus(selector.Input)
+	handler(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	assert.True(t, selector.List.HasFocus())
+}
@@ -1,39 +1,19 @@
 package domainmapping
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	api_domainmapping "github.com/JulienBreux/run-cli/internal/run/api/domainmapping"
-	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
 	model_domainmapping "github.com/JulienBreux/run-cli/internal/run/model/domainmapping"
-	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
-	"github.com/JulienBreux/run-cli/internal/run/tui/component/table"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource"
 	"github.com/dustin/go-humanize"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-var (
-	listHeaders = []string{
-		"DOMAIN",
-		"MAPPED TO",
-		"REGION",
-		"ADDED BY",
-		"CREATED"}
-
-	listExpansions = []int{
-		2, // DOMAIN
-		2, // MAPPED TO
-		1, // REGION
-		2, // ADDED BY
-		2, // CREATED
-	}
-
-	listTable      *table.Table
-	domainMappings []model_domainmapping.DomainMapping
-)
-
 const (
 	LIST_PAGE_TITLE     = "Domain Mappings"
 	LIST_PAGE_ID        = "domainmappings-list"
@@ -43,94 +23,34 @@ const (
 
 var listDomainMappingsFunc = api_domainmapping.List
 
-// List returns a list of domain mappings.
-func List(app *tview.Application) *table.Table {
-	listTable = table.New(LIST_PAGE_TITLE)
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-
-	app.SetFocus(listTable.Table)
-
-	return listTable
-}
-
-// Load populates the table with the provided list of domain mappings.
-func Load(newDomainMappings []model_domainmapping.DomainMapping) {
-	domainMappings = newDomainMappings
-	render(domainMappings)
-}
-
-func ListReload(app *tview.Application, currentInfo info.Info, onResult func(error)) {
-	listTable.Table.Clear()
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-	listTable.Table.SetTitle(fmt.Sprintf(" %s loading ", LIST_PAGE_TITLE))
-
-	app.SetFocus(listTable.Table)
-
-	go func() {
-		// Fetch real data
-		var err error
-		domainMappings, err = listDomainMappingsFunc(currentInfo.Project, currentInfo.Region)
-
-		app.QueueUpdateDraw(func() {
-			defer func() {
-				if len(domainMappings) == 0 {
-					listTable.Table.Clear()
-					listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-				}
-				onResult(err)
-			}()
-
-			if err != nil {
-				return
-			}
-
-			render(domainMappings)
-		})
-	}()
-}
-
-func render(dms []model_domainmapping.DomainMapping) {
-	listTable.Table.Clear()
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-
-	for i, dm := range dms {
-		row := i + 1 // +1 for header row
-
-		listTable.Table.SetCell(row, 0, tview.NewTableCell(dm.Name))
-		listTable.Table.SetCell(row, 1, tview.NewTableCell(dm.RouteName))
-		listTable.Table.SetCell(row, 2, tview.NewTableCell(dm.Region))
-		listTable.Table.SetCell(row, 3, tview.NewTableCell(dm.Creator))
-		listTable.Table.SetCell(row, 4, tview.NewTableCell(humanize.Time(dm.CreateTime)))
-	}
-
-	// Refresh title
-	listTable.Table.SetTitle(fmt.Sprintf(" %s (%d) ", LIST_PAGE_TITLE, len(dms)))
-}
-
-// GetSelectedDomainMappingFull returns the full domain mapping object for the selected row.
-func GetSelectedDomainMappingFull() *model_domainmapping.DomainMapping {
-	row, _ := listTable.Table.GetSelection()
-	if row < 1 || len(domainMappings) == 0 {
-		return nil
-	}
-	return &domainMappings[row-1]
-}
-
-// GetSelectedDomainURL returns the URL of the currently selected domain mapping.
-func GetSelectedDomainURL() string {
-	row, _ := listTable.Table.GetSelection()
-	if row < 1 { // Header row or no selection
-		return ""
-	}
-	// 0: Domain
-	domain := listTable.Table.GetCell(row, 0).Text
-	return fmt.Sprintf("https://%s", domain)
-}
-
-func Shortcuts() {
-	footer.ContextShortcutView.Clear()
-	shortcuts := `[dodgerblue]<r> [white]Refresh  [dodgerblue]<o> [white]Open URL  [dodgerblue]<enter> [white]Info`
-	footer.ContextShortcutView.SetText(shortcuts)
+// Kind is the kind of the domain mappings.
+var Kind = &resource.Kind[model_domainmapping.DomainMapping]{
+	Title:    LIST_PAGE_TITLE,
+	PageID:   LIST_PAGE_ID,
+	Shortcut: LIST_PAGE_SHORTCUT,
+	Columns: []resource.Column[model_domainmapping.DomainMapping]{
+		{Header: "DOMAIN", Expansion: 2, Value: func(dm model_domainmapping.DomainMapping) string { return dm.Name }},
+		{Header: "MAPPED TO", Expansion: 2, Value: func(dm model_domainmapping.DomainMapping) string { return dm.RouteName }},
+		{Header: "REGION", Expansion: 1, Value: func(dm model_domainmapping.DomainMapping) string { return dm.Region }},
+		{Header: "ADDED BY", Expansion: 2, Value: func(dm model_domainmapping.DomainMapping) string { return dm.Creator }},
+		{Header: "CREATED", Expansion: 2, Value: func(dm model_domainmapping.DomainMapping) string { return humanize.Time(dm.CreateTime) }},
+	},
+	Fetch: resource.All(func(ctx context.Context, project, region string) ([]model_domainmapping.DomainMapping, error) {
+		return listDomainMappingsFunc(ctx, project, region)
+	}),
+	CacheKind: listcache.KindDomainMappings,
+	Name:      func(dm model_domainmapping.DomainMapping) string { return dm.Name },
+	Region:    func(dm model_domainmapping.DomainMapping) string { return dm.Region },
+	Actions: []resource.Action[model_domainmapping.DomainMapping]{
+		{Key: "o", Label: "Open URL", Run: func(h resource.Host, dm *model_domainmapping.DomainMapping) {
+			h.OpenURL(fmt.Sprintf("https://%s", dm.Name))
+		}},
+		{Key: "enter", Label: "Info", Run: func(h resource.Host, dm *model_domainmapping.DomainMapping) {
+			h.OpenModal(MODAL_PAGE_ID, func(close func()) tview.Primitive {
+				return DomainMappingInfoModal(h.App(), dm, close)
+			})
+		}},
+	},
 }
 
 // DomainMappingInfoModal creates a modal to display info for the domain mapping.
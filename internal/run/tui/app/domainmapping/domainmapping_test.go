@@ -1,205 +1,61 @@
 package domainmapping
 
 import (
-	"errors"
 	"testing"
-	"time"
 
 	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
-	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
 	model_domainmapping "github.com/JulienBreux/run-cli/internal/run/model/domainmapping"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource/resourcetest"
 	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestList(t *testing.T) {
+func TestKind(t *testing.T) {
 	app := tview.NewApplication()
-	tbl := List(app)
-	assert.NotNil(t, tbl)
-	assert.Equal(t, LIST_PAGE_TITLE, tbl.Title)
-}
-
-func TestLoad(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
+	assert.NotNil(t, Kind.Build(app))
+	assert.Equal(t, LIST_PAGE_TITLE, Kind.Table().Title)
 
-	newDMs := []model_domainmapping.DomainMapping{
+	Kind.Load([]model_domainmapping.DomainMapping{
 		{
-			Name:      "example.com",
-			Region:    "us-central1",
-			RouteName: "service-1",
+			Name:       "example.com",
+			RouteName:  "service-1",
+			Region:     "us-central1",
+			Creator:    "user@example.com",
+			Conditions: []*condition.Condition{{Type: "Ready", State: "True"}},
 		},
-	}
-
-	Load(newDMs)
-
-	assert.Equal(t, newDMs, domainMappings)
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "example.com", listTable.Table.GetCell(1, 0).Text)
-}
-
-func TestListReload(t *testing.T) {
-	app := tview.NewApplication()
-	simScreen := tcell.NewSimulationScreen("UTF-8")
-	if err := simScreen.Init(); err != nil {
-		t.Fatalf("failed to init sim screen: %v", err)
-	}
-	app.SetScreen(simScreen)
-
-	List(app)
-
-	// Mock listDomainMappingsFunc
-	originalListDomainMappingsFunc := listDomainMappingsFunc
-	defer func() { listDomainMappingsFunc = originalListDomainMappingsFunc }()
-
-	expectedDMs := []model_domainmapping.DomainMapping{
-		{Name: "reloaded.example.com"},
-	}
-	listDomainMappingsFunc = func(projectID, region string) ([]model_domainmapping.DomainMapping, error) {
-		return expectedDMs, nil
-	}
-
-	ListReload(app, info.Info{}, func(err error) {
-		assert.NoError(t, err)
-		app.Stop()
 	})
 
-	// Timeout safety
-	go func() {
-		time.Sleep(2 * time.Second)
-		app.Stop()
-	}()
-
-	err := app.Run()
-	assert.NoError(t, err)
-
-	assert.Equal(t, expectedDMs, domainMappings)
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "reloaded.example.com", listTable.Table.GetCell(1, 0).Text)
-}
-
-func TestListReload_Error(t *testing.T) {
-	app := tview.NewApplication()
-	simScreen := tcell.NewSimulationScreen("UTF-8")
-	if err := simScreen.Init(); err != nil {
-		t.Fatalf("failed to init sim screen: %v", err)
-	}
-	app.SetScreen(simScreen)
-
-	List(app)
-
-	originalListDomainMappingsFunc := listDomainMappingsFunc
-	defer func() { listDomainMappingsFunc = originalListDomainMappingsFunc }()
-
-	listDomainMappingsFunc = func(projectID, region string) ([]model_domainmapping.DomainMapping, error) {
-		return nil, errors.New("fetch error")
-	}
-
-	ListReload(app, info.Info{}, func(err error) {
-		assert.Error(t, err)
-		app.Stop()
-	})
-
-	go func() {
-		time.Sleep(2 * time.Second)
-		app.Stop()
-	}()
-
-	err := app.Run()
-	assert.NoError(t, err)
-}
-
-func TestGetSelectedDomainMappingFull(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-
-	// Manually populate internal state
-	domainMappings = []model_domainmapping.DomainMapping{
-		{
-			Name:   "example.com",
-			Region: "us-central1",
-		},
-	}
-
-	// Manually populate table
-	row := 1
-	listTable.Table.SetCell(row, 0, tview.NewTableCell("example.com"))
-
-	// Select Row 1
-	listTable.Table.Select(row, 0)
+	tbl := Kind.Table().Table
+	assert.Equal(t, 2, tbl.GetRowCount())
+	assert.Equal(t, "example.com", tbl.GetCell(1, 0).Text)
+	assert.Equal(t, "service-1", tbl.GetCell(1, 1).Text)
+	assert.Equal(t, "us-central1", tbl.GetCell(1, 2).Text)
+	assert.Equal(t, "user@example.com", tbl.GetCell(1, 3).Text)
 
-	dm := GetSelectedDomainMappingFull()
-	assert.NotNil(t, dm)
-	assert.Equal(t, "example.com", dm.Name)
-
-	// Test header selection
-	listTable.Table.Select(0, 0)
-	dmFull := GetSelectedDomainMappingFull()
-	assert.Nil(t, dmFull)
+	// The console has no page for the domain mappings.
+	tbl.Select(1, 0)
+	assert.Empty(t, Kind.ConsoleURL("p"))
 }
 
-func TestGetSelectedDomainURL(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-
-	// Manually populate table
-	row := 1
-	listTable.Table.SetCell(row, 0, tview.NewTableCell("example.com"))
-
-	// Select Row 1
-	listTable.Table.Select(row, 0)
+func TestActions(t *testing.T) {
+	h := &resourcetest.Host{Application: tview.NewApplication()}
+	Kind.Build(h.Application)
+	Kind.Load([]model_domainmapping.DomainMapping{{Name: "example.com"}})
+	Kind.Table().Table.Select(1, 0)
 
-	url := GetSelectedDomainURL()
-	assert.Equal(t, "https://example.com", url)
-
-	// Test header selection
-	listTable.Table.Select(0, 0)
-	url = GetSelectedDomainURL()
-	assert.Equal(t, "", url)
-}
-
-func TestGetSelectedDomainMappingFull_Empty(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-	domainMappings = []model_domainmapping.DomainMapping{} // Ensure empty
+	assert.True(t, Kind.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'o', tcell.ModNone), h))
+	assert.True(t, Kind.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), h))
+	// The domain mappings can't be described.
+	assert.False(t, Kind.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone), h))
 
-	dm := GetSelectedDomainMappingFull()
-	assert.Nil(t, dm)
-}
+	assert.Equal(t, []string{"https://example.com"}, h.URLs)
+	assert.Equal(t, []string{MODAL_PAGE_ID}, h.Modals)
 
-func TestShortcuts(t *testing.T) {
 	_ = footer.New()
-
-	assert.NotPanics(t, func() {
-		Shortcuts()
-	})
-
-	assert.Contains(t, footer.ContextShortcutView.GetText(true), "Refresh")
-	assert.Contains(t, footer.ContextShortcutView.GetText(true), "Open URL")
-	assert.Contains(t, footer.ContextShortcutView.GetText(true), "Info")
-}
-
-func TestRender(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-
-	testDMs := []model_domainmapping.DomainMapping{
-		{
-			Name:       "example.com",
-			Region:     "us-central1",
-			Creator:    "user@example.com",
-			Conditions: []*condition.Condition{{Type: "Ready", State: "True"}},
-		},
-	}
-
-	render(testDMs)
-
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "example.com", listTable.Table.GetCell(1, 0).Text)
-	assert.Equal(t, "us-central1", listTable.Table.GetCell(1, 2).Text)
-	assert.Equal(t, "user@example.com", listTable.Table.GetCell(1, 3).Text)
+	Kind.Shortcuts()
+	assert.Equal(t, "<r> Refresh  <o> Open URL  <enter> Info", footer.ContextShortcutView.GetText(true))
 }
 
 func TestDomainMappingInfoModal(t *testing.T) {
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -25,13 +26,15 @@ var (
 	dashboardPages      *tview.Pages
 	dashboardJob        *model_job.Job
 	dashboardExecutions []model_execution.Execution
+	// dashboardCtx is the context of the load of the dashboard, done when leaving it.
+	dashboardCtx = context.Background()
 
 	// Executions tab components
 	executionsTable  *table.Table
 	executionsDetail *tview.TextView
 )
 
-var listExecutionsFunc = api_execution.List
+var listExecutionsPageFunc = api_execution.ListPage
 
 // Dashboard returns the dashboard primitive.
 func Dashboard(app *tview.Application) *tview.Flex {
@@ -42,7 +45,9 @@ func Dashboard(app *tview.Application) *tview.Flex {
 	dashboardPages = tview.NewPages()
 
 	// Executions View
-	dashboardPages.AddPage("Executions", buildExecutionsTab(), true, true)
+	dashboardPages.AddPage(EXECUTIONS_TAB, buildExecutionsTab(), true, true)
+	// Tasks View, of the execution drilled down into
+	dashboardPages.AddPage(TASKS_TAB, buildTasksTab(), true, false)
 
 	dashboardFlex = tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(dashboardHeader, 1, 0, false).
@@ -64,7 +69,7 @@ func buildExecutionsTab() tview.Primitive {
 		SetWrap(true)
 	executionsDetail.SetBorder(true).SetTitle(" Execution Details ")
 
-	executionsTable.Table.SetSelectionChangedFunc(func(row, column int) {
+	executionsTable.SetSelectionChangedFunc(func(row, column int) {
 		updateExecutionDetail(row)
 	})
 
@@ -114,62 +119,108 @@ func updateExecutionDetail(row int) {
 }
 
 // DashboardReload reloads the dashboard for a specific job.
-func DashboardReload(app *tview.Application, currentInfo info.Info, job *model_job.Job, onResult func(error)) {
+// Coming back to the same job, e.g. from the logs of a task, keeps the tasks of its execution shown.
+func DashboardReload(ctx context.Context, app *tview.Application, currentInfo info.Info, job *model_job.Job, onResult func(error)) {
+	if dashboardJob == nil || dashboardJob.Name != job.Name {
+		closeTasks()
+	}
 	dashboardJob = job
-	dashboardHeader.SetText(fmt.Sprintf("[lightcyan]Job: [white]%s", shortName(job.Name)))
+	dashboardCtx = ctx
+	updateHeader()
+
+	executionsTable.SetLoadMore(nil)
+	go loadExecutions(ctx, app, currentInfo, job, "", onResult)
+	if tasksExecution != nil {
+		reloadTasks(ctx, app, currentInfo, onResult)
+	}
+}
+
+// updateHeader shows the job, and the execution whose tasks are shown, if any.
+func updateHeader() {
+	header := fmt.Sprintf("[lightcyan]Job: [white]%s", shortName(dashboardJob.Name))
+	if tasksExecution != nil {
+		header += fmt.Sprintf("  [lightcyan]Execution: [white]%s", shortName(tasksExecution.Name))
+	}
+	dashboardHeader.SetText(header)
+}
 
-	go func() {
-		var err error
-		dashboardExecutions, err = listExecutionsFunc(currentInfo.Project, job.Region, job.Name)
+// loadExecutions loads a page of executions of a job, the first one for an empty token, and the next
+// ones as the user scrolls.
+func loadExecutions(ctx context.Context, app *tview.Application, currentInfo info.Info, job *model_job.Job, pageToken string, onResult func(error)) {
+	executions, next, err := listExecutionsPageFunc(ctx, currentInfo.Project, job.Region, job.Name, pageToken)
 
-		app.QueueUpdateDraw(func() {
+	app.QueueUpdateDraw(func() {
+		// The result of a cancelled load is stale, e.g. of the previous job.
+		if ctx.Err() != nil {
+			return
+		}
+
+		first := pageToken == ""
+		if first {
+			dashboardExecutions = nil
 			executionsTable.Table.Clear()
 			executionsTable.SetHeadersWithExpansions(
 				[]string{"NAME", "STATUS", "CREATED", "DURATION", "TASKS (S/F)"},
 				[]int{2, 1, 1, 1, 1},
 			)
+		}
+
+		if err != nil {
+			onResult(err)
+			return
+		}
+
+		from := len(dashboardExecutions)
+		dashboardExecutions = append(dashboardExecutions, executions...)
+		renderExecutions(from)
 
-			if err != nil {
-				onResult(err)
-				return
-			}
-
-			for i, exec := range dashboardExecutions {
-				row := i + 1
-
-				status := "-"
-				if exec.TerminalCondition != nil {
-					status = exec.TerminalCondition.State
-				}
-
-				duration := "-"
-				if !exec.CompletionTime.IsZero() {
-					duration = exec.CompletionTime.Sub(exec.StartTime).Round(time.Second).String()
-				}
-
-				tasks := fmt.Sprintf("%d (%d/%d)", exec.TaskCount, exec.SucceededCount, exec.FailedCount)
-
-				executionsTable.Table.SetCell(row, 0, tview.NewTableCell(shortName(exec.Name)))
-				executionsTable.Table.SetCell(row, 1, tview.NewTableCell(status))
-				executionsTable.Table.SetCell(row, 2, tview.NewTableCell(humanize.Time(exec.CreateTime)))
-				executionsTable.Table.SetCell(row, 3, tview.NewTableCell(duration))
-				executionsTable.Table.SetCell(row, 4, tview.NewTableCell(tasks))
-			}
-
-			executionsTable.Table.SetTitle(fmt.Sprintf(" Executions (%d) ", len(dashboardExecutions)))
-			if len(dashboardExecutions) > 0 {
-				executionsTable.Table.Select(1, 0)
-				updateExecutionDetail(1)
-			}
-			onResult(nil)
-		})
-	}()
+		executionsTable.Table.SetTitle(fmt.Sprintf(" Executions (%s) ", table.Count(len(dashboardExecutions), int(job.ExecutionCount), next != "")))
+		if first && len(dashboardExecutions) > 0 {
+			executionsTable.Table.Select(1, 0)
+			updateExecutionDetail(1)
+		}
+		if next != "" {
+			executionsTable.SetLoadMore(func() {
+				go loadExecutions(ctx, app, currentInfo, job, next, onResult)
+			})
+		}
+		onResult(nil)
+	})
 }
 
-// DashboardShortcuts sets the shortcuts for the dashboard.
+// renderExecutions renders the rows of the executions from the given index.
+func renderExecutions(from int) {
+	for i := from; i < len(dashboardExecutions); i++ {
+		exec := dashboardExecutions[i]
+		row := i + 1
+
+		status := "-"
+		if exec.TerminalCondition != nil {
+			status = exec.TerminalCondition.State
+		}
+
+		duration := "-"
+		if !exec.CompletionTime.IsZero() {
+			duration = exec.CompletionTime.Sub(exec.StartTime).Round(time.Second).String()
+		}
+
+		tasks := fmt.Sprintf("%d (%d/%d)", exec.TaskCount, exec.SucceededCount, exec.FailedCount)
+
+		executionsTable.Table.SetCell(row, 0, tview.NewTableCell(shortName(exec.Name)))
+		executionsTable.Table.SetCell(row, 1, tview.NewTableCell(status))
+		executionsTable.Table.SetCell(row, 2, tview.NewTableCell(humanize.Time(exec.CreateTime)))
+		executionsTable.Table.SetCell(row, 3, tview.NewTableCell(duration))
+		executionsTable.Table.SetCell(row, 4, tview.NewTableCell(tasks))
+	}
+}
+
+// DashboardShortcuts sets the shortcuts for the dashboard, of the executions or of the tasks of one.
 func DashboardShortcuts() {
 	footer.ContextShortcutView.Clear()
-	shortcuts := `[dodgerblue]<esc> [white]Back  [dodgerblue]<tab> [white]Next Tab  [dodgerblue]<shift-tab> [white]Prev Tab`
+	shortcuts := `[dodgerblue]<esc> [white]Back  [dodgerblue]<enter> [white]Tasks  [dodgerblue]<c> [white]Cancel  [dodgerblue]<d> [white]Delete  [dodgerblue]<r> [white]Re-run  [dodgerblue]<tab> [white]Next Tab  [dodgerblue]<shift-tab> [white]Prev Tab`
+	if tasksExecution != nil {
+		shortcuts = `[dodgerblue]<esc> [white]Executions  [dodgerblue]<l> [white]Logs`
+	}
 	footer.ContextShortcutView.SetText(shortcuts)
 }
 
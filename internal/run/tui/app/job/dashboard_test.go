@@ -1,9 +1,12 @@
 package job
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
 	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
 	"github.com/JulienBreux/run-cli/internal/run/model/common/container"
 	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
@@ -70,14 +73,14 @@ func TestDashboardReload(t *testing.T) {
 	}
 
 	// Mock API
-	originalListExecutionsFunc := listExecutionsFunc
-	defer func() { listExecutionsFunc = originalListExecutionsFunc }()
-	listExecutionsFunc = func(project, region, jobName string) ([]model_execution.Execution, error) {
-		return mockExecutions, nil
+	originalListExecutionsPageFunc := listExecutionsPageFunc
+	defer func() { listExecutionsPageFunc = originalListExecutionsPageFunc }()
+	listExecutionsPageFunc = func(ctx context.Context, project, region, jobName, pageToken string) ([]model_execution.Execution, string, error) {
+		return mockExecutions, "", nil
 	}
 
 	// Call Reload
-	DashboardReload(app, info.Info{Project: "p"}, mockJob, func(err error) {
+	DashboardReload(context.Background(), app, info.Info{Project: "p"}, mockJob, func(err error) {
 		assert.NoError(t, err)
 		app.Stop()
 	})
@@ -97,6 +100,55 @@ func TestDashboardReload(t *testing.T) {
 	assert.Equal(t, "exec-1", executionsTable.Table.GetCell(1, 0).Text)
 }
 
+func TestDashboardReload_LoadMore(t *testing.T) {
+	app := tview.NewApplication()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.SetScreen(screen)
+
+	Dashboard(app) // Initialize
+
+	mockJob := &model_job.Job{Name: "test-job", Region: "us-central1", ExecutionCount: 60}
+	mockExecutions := make([]model_execution.Execution, 60)
+	for i := range mockExecutions {
+		mockExecutions[i] = model_execution.Execution{Name: fmt.Sprintf("exec-%d", i)}
+	}
+
+	originalListExecutionsPageFunc := listExecutionsPageFunc
+	defer func() { listExecutionsPageFunc = originalListExecutionsPageFunc }()
+	var tokens []string
+	listExecutionsPageFunc = func(ctx context.Context, project, region, jobName, pageToken string) ([]model_execution.Execution, string, error) {
+		tokens = append(tokens, pageToken)
+		return client.Paginate(mockExecutions, client.PageSize, pageToken)
+	}
+
+	var titles []string
+	DashboardReload(context.Background(), app, info.Info{Project: "p"}, mockJob, func(err error) {
+		assert.NoError(t, err)
+		titles = append(titles, executionsTable.Table.GetTitle())
+		if len(titles) == 1 {
+			// Scrolling near the end of the loaded rows loads the next page.
+			executionsTable.Table.Select(45, 0)
+			return
+		}
+		app.Stop()
+	})
+
+	go func() {
+		time.Sleep(1 * time.Second)
+		app.Stop()
+	}()
+	_ = app.Run()
+
+	assert.Equal(t, []string{"", "50"}, tokens)
+	assert.Equal(t, []string{" Executions (loaded 50 of ~60) ", " Executions (60) "}, titles)
+	assert.Len(t, dashboardExecutions, 60)
+	assert.Equal(t, 61, executionsTable.Table.GetRowCount())
+	assert.Equal(t, "exec-59", executionsTable.Table.GetCell(60, 0).Text)
+}
+
 func TestDashboardShortcuts(t *testing.T) {
 	_ = footer.New()
 	
@@ -0,0 +1,89 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
+	api_execution "github.com/JulienBreux/run-cli/internal/run/api/job/execution"
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/confirm"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var (
+	cancelExecutionFunc = api_execution.Cancel
+	deleteExecutionFunc = api_execution.Delete
+	getExecutionFunc    = api_execution.GetRaw
+	rerunJobFunc        = api_job.Rerun
+)
+
+// handleExecutionKey runs the action of the key on the selected execution and returns whether there
+// was one: c cancels it, d deletes it and r runs its job again with the same overrides, once confirmed.
+// The keys are only handled when the executions table has the focus, for the selected execution to
+// be the one the user sees.
+func handleExecutionKey(event *tcell.EventKey, h resource.Host) bool {
+	if event.Key() != tcell.KeyRune || !executionsTable.Table.HasFocus() {
+		return false
+	}
+
+	var action func(h resource.Host, exec model_execution.Execution)
+	switch event.Rune() {
+	case 'c':
+		action = cancelExecution
+	case 'd':
+		action = deleteExecution
+	case 'r':
+		action = rerunExecution
+	default:
+		return false
+	}
+
+	if row, _ := executionsTable.Table.GetSelection(); row >= 1 && row <= len(dashboardExecutions) {
+		action(h, dashboardExecutions[row-1])
+	}
+	return true
+}
+
+// cancelExecution cancels an execution, the cancellation is followed in the operations panel.
+func cancelExecution(h resource.Host, exec model_execution.Execution) {
+	project, jobName := h.Info().Project, dashboardJob.Name
+	text := fmt.Sprintf("Cancel execution %s?\nIts running tasks are stopped.", shortName(exec.Name))
+	confirmAndStart(h, text, func(ctx context.Context) (*api_operation.Operation, error) {
+		return cancelExecutionFunc(ctx, project, exec.Region, jobName, exec.Name)
+	})
+}
+
+// deleteExecution deletes an execution, the deletion is followed in the operations panel.
+func deleteExecution(h resource.Host, exec model_execution.Execution) {
+	project, jobName := h.Info().Project, dashboardJob.Name
+	text := fmt.Sprintf("Delete execution %s?\nIt is cancelled if it is running.", shortName(exec.Name))
+	confirmAndStart(h, text, func(ctx context.Context) (*api_operation.Operation, error) {
+		return deleteExecutionFunc(ctx, project, exec.Region, jobName, exec.Name)
+	})
+}
+
+// rerunExecution executes the job of an execution again, with the same overrides, the new execution is
+// followed in the operations panel.
+func rerunExecution(h resource.Host, exec model_execution.Execution) {
+	project, jobName := h.Info().Project, dashboardJob.Name
+	text := fmt.Sprintf("Run job %s again with the overrides of execution %s?", shortName(jobName), shortName(exec.Name))
+	confirmAndStart(h, text, func(ctx context.Context) (*api_operation.Operation, error) {
+		// The overrides are those of the template the execution ran with, which the list doesn't hold.
+		execution, err := getExecutionFunc(ctx, project, exec.Region, jobName, exec.Name)
+		if err != nil {
+			return nil, err
+		}
+		return rerunJobFunc(ctx, project, exec.Region, execution)
+	})
+}
+
+// confirmAndStart starts an operation once confirmed.
+func confirmAndStart(h resource.Host, text string, start func(ctx context.Context) (*api_operation.Operation, error)) {
+	h.OpenModal(confirm.MODAL_PAGE_ID, func(close func()) tview.Primitive {
+		return confirm.New(text, func() { h.Start(start) }, close)
+	})
+}
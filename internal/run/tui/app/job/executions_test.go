@@ -0,0 +1,91 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
+	model_job "github.com/JulienBreux/run-cli/internal/run/model/job"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource/resourcetest"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/confirm"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleExecutionKey(t *testing.T) {
+	originalCancel, originalDelete := cancelExecutionFunc, deleteExecutionFunc
+	originalGet, originalRerun := getExecutionFunc, rerunJobFunc
+	defer func() {
+		cancelExecutionFunc, deleteExecutionFunc = originalCancel, originalDelete
+		getExecutionFunc, rerunJobFunc = originalGet, originalRerun
+	}()
+
+	var calls []string
+	cancelExecutionFunc = func(ctx context.Context, project, region, jobName, executionName string) (*api_operation.Operation, error) {
+		calls = append(calls, "cancel "+project+" "+region+" "+jobName+" "+executionName)
+		return nil, nil
+	}
+	deleteExecutionFunc = func(ctx context.Context, project, region, jobName, executionName string) (*api_operation.Operation, error) {
+		calls = append(calls, "delete "+project+" "+region+" "+jobName+" "+executionName)
+		return nil, nil
+	}
+	getExecutionFunc = func(ctx context.Context, project, region, jobName, executionName string) (*runpb.Execution, error) {
+		return &runpb.Execution{Name: executionName, Job: "j", TaskCount: 3}, nil
+	}
+	rerunJobFunc = func(ctx context.Context, project, region string, execution *runpb.Execution) (*api_operation.Operation, error) {
+		calls = append(calls, "rerun "+project+" "+region+" "+execution.Name)
+		return nil, nil
+	}
+
+	Dashboard(tview.NewApplication())
+	dashboardJob = &model_job.Job{Name: "projects/p/locations/r/jobs/j", Region: "r"}
+	dashboardExecutions = []model_execution.Execution{{Name: "projects/p/locations/r/jobs/j/executions/j-abcde", Region: "r"}}
+	renderExecutions(0)
+	executionsTable.Table.Select(1, 0)
+
+	// Nothing is done when another tab has the focus
+	h := &resourcetest.Host{Application: tview.NewApplication()}
+	assert.False(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone), h))
+	assert.Empty(t, h.Modals)
+	executionsTable.Table.Focus(nil)
+
+	// focus focuses a primitive, and the primitives it delegates the focus to
+	var focus func(p tview.Primitive)
+	focus = func(p tview.Primitive) { p.Focus(focus) }
+	// answer answers the confirmation opened on h, yes with enter and no with esc
+	answer := func(h *resourcetest.Host, key tcell.Key) {
+		focus(h.Modal)
+		h.Modal.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), focus)
+	}
+
+	execution := "projects/p/locations/r/jobs/j/executions/j-abcde"
+	tests := []struct {
+		key  rune
+		call string
+	}{
+		{'c', "cancel p r projects/p/locations/r/jobs/j " + execution},
+		{'d', "delete p r projects/p/locations/r/jobs/j " + execution},
+		{'r', "rerun p r " + execution},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.key), func(t *testing.T) {
+			// Nothing is done until confirmed
+			calls = nil
+			h := &resourcetest.Host{Application: tview.NewApplication()}
+			assert.True(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyRune, tt.key, tcell.ModNone), h))
+			assert.Equal(t, []string{confirm.MODAL_PAGE_ID}, h.Modals)
+			answer(h, tcell.KeyEscape)
+			assert.Empty(t, calls)
+
+			assert.True(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyRune, tt.key, tcell.ModNone), h))
+			answer(h, tcell.KeyEnter)
+			assert.Equal(t, []string{tt.call}, calls)
+		})
+	}
+
+	// The other keys are left to the app
+	assert.False(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModNone), &resourcetest.Host{}))
+}
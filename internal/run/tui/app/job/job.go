@@ -1,147 +1,81 @@
-// TODO: Refactor with service and worker pool
 package job
 
 import (
+	"context"
 	"fmt"
-	"strings"
 
 	api_job "github.com/JulienBreux/run-cli/internal/run/api/job"
-	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
 	model_job "github.com/JulienBreux/run-cli/internal/run/model/job"
-	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
-	"github.com/JulienBreux/run-cli/internal/run/tui/component/table"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource"
 	"github.com/dustin/go-humanize"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
-)
-
-var (
-	listHeaders = []string{
-		"NAME",
-		"STATUS OF LAST EXECUTION",
-		"LAST EXECUTED",
-		"REGION",
-		"CREATED BY"}
-
-	listExpansions = []int{
-		2, // NAME
-		2, // STATUS OF LAST EXECUTION
-		2, // LAST EXECUTED
-		1, // REGION
-		2, // CREATED BY
-	}
-
-	listTable *table.Table
-	jobs      []model_job.Job
 )
 
 const (
 	LIST_PAGE_TITLE    = "Jobs"
 	LIST_PAGE_ID       = "jobs-list"
 	LIST_PAGE_SHORTCUT = tcell.KeyCtrlJ
-)
 
-var listJobsFunc = api_job.List
-
-// List returns a list of jobs.
-func List(app *tview.Application) *table.Table {
-	listTable = table.New(LIST_PAGE_TITLE)
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-	app.SetFocus(listTable.Table)
-	return listTable
-}
-
-// Load populates the table with the provided list of jobs.
-func Load(newJobs []model_job.Job) {
-	jobs = newJobs
-	render(jobs)
-}
-
-func ListReload(app *tview.Application, currentInfo info.Info, onResult func(error)) {
-	listTable.Table.Clear()
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-	listTable.Table.SetTitle(fmt.Sprintf(" %s loading ", LIST_PAGE_TITLE))
-
-	app.SetFocus(listTable.Table)
-
-	go func() {
-		// Fetch real data
-		var err error
-		jobs, err = listJobsFunc(currentInfo.Project, currentInfo.Region)
+	CONSOLE_LIST_URL   = "https://console.cloud.google.com/run/jobs?project=%s"
+	CONSOLE_DETAIL_URL = "https://console.cloud.google.com/run/jobs/details/%s/%s/metrics?project=%s"
+)
 
-		app.QueueUpdateDraw(func() {
-			defer func() {
-				if len(jobs) == 0 {
-					listTable.Table.Clear()
-					listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-				}
-				onResult(err)
-			}()
+var (
+	listJobsFunc   = api_job.List
+	executeJobFunc = api_job.Execute
+)
 
-			if err != nil {
-				// listTable.Table.SetTitle(fmt.Sprintf(" %s (Error) ", LIST_PAGE_TITLE)) // Removed error from title
-				return
+// Kind is the kind of the jobs.
+var Kind = &resource.Kind[model_job.Job]{
+	Title:    LIST_PAGE_TITLE,
+	PageID:   LIST_PAGE_ID,
+	Shortcut: LIST_PAGE_SHORTCUT,
+	Columns: []resource.Column[model_job.Job]{
+		{Header: "NAME", Expansion: 2, Value: func(j model_job.Job) string { return shortName(j.Name) }},
+		{Header: "STATUS OF LAST EXECUTION", Expansion: 2, Value: func(j model_job.Job) string {
+			if j.TerminalCondition == nil {
+				return "-"
 			}
-
-			render(jobs)
-		})
-	}()
-}
-
-func render(jobs []model_job.Job) {
-	listTable.Table.Clear()
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-
-	for i, j := range jobs {
-		// Extract info
-		nameParts := strings.Split(j.Name, "/")
-		displayName := nameParts[len(nameParts)-1]
-
-		status := "-"
-		if j.TerminalCondition != nil {
-			status = j.TerminalCondition.State
-		}
-
-		lastExecuted := "-"
-		if j.LatestCreatedExecution != nil {
-			lastExecuted = humanize.Time(j.LatestCreatedExecution.CreateTime)
+			return j.TerminalCondition.State
+		}},
+		{Header: "LAST EXECUTED", Expansion: 2, Value: func(j model_job.Job) string {
+			if j.LatestCreatedExecution == nil {
+				return "-"
+			}
+			return humanize.Time(j.LatestCreatedExecution.CreateTime)
+		}},
+		{Header: "REGION", Expansion: 1, Value: func(j model_job.Job) string { return j.Region }},
+		{Header: "CREATED BY", Expansion: 2, Value: func(j model_job.Job) string { return j.Creator }},
+	},
+	Fetch: resource.All(func(ctx context.Context, project, region string) ([]model_job.Job, error) {
+		return listJobsFunc(ctx, project, region)
+	}),
+	CacheKind: listcache.KindJobs,
+	Name:      func(j model_job.Job) string { return shortName(j.Name) },
+	Region:    func(j model_job.Job) string { return j.Region },
+	Describe:  true,
+	Logs:      api_log.KindJob,
+	Console: func(project string, j *model_job.Job) string {
+		if j == nil {
+			return fmt.Sprintf(CONSOLE_LIST_URL, project)
 		}
-
-		row := i + 1 // +1 for header row
-		listTable.Table.SetCell(row, 0, tview.NewTableCell(displayName))
-		listTable.Table.SetCell(row, 1, tview.NewTableCell(status))
-		listTable.Table.SetCell(row, 2, tview.NewTableCell(lastExecuted))
-		listTable.Table.SetCell(row, 3, tview.NewTableCell(j.Region))
-		listTable.Table.SetCell(row, 4, tview.NewTableCell(j.Creator))
-	}
-
-	// Refresh title
-	listTable.Table.SetTitle(fmt.Sprintf(" %s (%d) ", LIST_PAGE_TITLE, len(jobs)))
-}
-
-// GetSelectedJob returns the Name and Region of the selected job.
-func GetSelectedJob() (string, string) {
-	row, _ := listTable.Table.GetSelection()
-	if row < 1 { // Header row or no selection
-		return "", ""
-	}
-	// 0: Name, 3: Region
-	name := listTable.Table.GetCell(row, 0).Text
-	region := listTable.Table.GetCell(row, 3).Text
-	return name, region
-}
-
-// GetSelectedJobFull returns the full job object for the selected row.
-func GetSelectedJobFull() *model_job.Job {
-	row, _ := listTable.Table.GetSelection()
-	if row < 1 || len(jobs) == 0 {
-		return nil
-	}
-	return &jobs[row-1]
+		return fmt.Sprintf(CONSOLE_DETAIL_URL, j.Region, shortName(j.Name), project)
+	},
+	Actions: []resource.Action[model_job.Job]{
+		{Key: "x", Label: "Execute", Run: execute},
+		{Key: "enter", Label: "Details", Run: func(h resource.Host, j *model_job.Job) {
+			h.SwitchTo(DASHBOARD_PAGE_ID)
+		}},
+	},
 }
 
-func Shortcuts() {
-	footer.ContextShortcutView.Clear()
-	shortcuts := `[dodgerblue]<r> [white]Refresh  [dodgerblue]<d> [white]Describe  [dodgerblue]<l> [white]Logs  [dodgerblue]<x> [white]Execute  [dodgerblue]<enter> [white]Details`
-	footer.ContextShortcutView.SetText(shortcuts)
+// execute executes a job, the execution is followed in the operations panel.
+func execute(h resource.Host, j *model_job.Job) {
+	project, region, name := h.Info().Project, j.Region, shortName(j.Name)
+	h.Start(func(ctx context.Context) (*api_operation.Operation, error) {
+		return executeJobFunc(ctx, project, region, name)
+	})
 }
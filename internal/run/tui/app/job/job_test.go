@@ -1,127 +1,26 @@
 package job
 
 import (
-	"errors"
+	"context"
 	"testing"
 	"time"
 
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
 	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
-	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
 	model_job "github.com/JulienBreux/run-cli/internal/run/model/job"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource/resourcetest"
 	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestList(t *testing.T) {
+func TestKind(t *testing.T) {
 	app := tview.NewApplication()
-	tbl := List(app)
-	assert.NotNil(t, tbl)
-	assert.Equal(t, LIST_PAGE_TITLE, tbl.Title)
-}
-
-func TestLoad(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-
-	newJobs := []model_job.Job{
-		{
-			Name:              "projects/p/locations/r/jobs/job-1",
-			Region:            "us-central1",
-			TerminalCondition: &condition.Condition{State: "Succeeded"},
-		},
-	}
-
-	Load(newJobs)
-
-	assert.Equal(t, newJobs, jobs)
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "job-1", listTable.Table.GetCell(1, 0).Text)
-}
-
-func TestListReload(t *testing.T) {
-	app := tview.NewApplication()
-	simScreen := tcell.NewSimulationScreen("UTF-8")
-	if err := simScreen.Init(); err != nil {
-		t.Fatalf("failed to init sim screen: %v", err)
-	}
-	app.SetScreen(simScreen)
-
-	List(app)
-
-	// Mock listJobsFunc
-	originalListJobsFunc := listJobsFunc
-	defer func() { listJobsFunc = originalListJobsFunc }()
-
-	expectedJobs := []model_job.Job{
-		{Name: "projects/p/locations/r/jobs/job-reloaded"},
-	}
-	listJobsFunc = func(projectID, region string) ([]model_job.Job, error) {
-		return expectedJobs, nil
-	}
-
-	// ListReload calls api_job.List which we mocked.
-	// It then calls app.QueueUpdateDraw.
-	// We need to run the app to process the queue.
-	// The callback passed to ListReload calls app.Stop() to exit the Run loop.
-
-	ListReload(app, info.Info{}, func(err error) {
-		assert.NoError(t, err)
-		app.Stop()
-	})
-
-	// Timeout safety
-	go func() {
-		time.Sleep(2 * time.Second)
-		app.Stop()
-	}()
-
-	err := app.Run()
-	assert.NoError(t, err)
-
-	assert.Equal(t, expectedJobs, jobs)
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "job-reloaded", listTable.Table.GetCell(1, 0).Text)
-}
-
-func TestListReload_Error(t *testing.T) {
-	app := tview.NewApplication()
-	simScreen := tcell.NewSimulationScreen("UTF-8")
-	if err := simScreen.Init(); err != nil {
-		t.Fatalf("failed to init sim screen: %v", err)
-	}
-	app.SetScreen(simScreen)
-
-	List(app)
-
-	originalListJobsFunc := listJobsFunc
-	defer func() { listJobsFunc = originalListJobsFunc }()
+	assert.NotNil(t, Kind.Build(app))
+	assert.Equal(t, LIST_PAGE_TITLE, Kind.Table().Title)
 
-	listJobsFunc = func(projectID, region string) ([]model_job.Job, error) {
-		return nil, errors.New("fetch error")
-	}
-
-	ListReload(app, info.Info{}, func(err error) {
-		assert.Error(t, err)
-		app.Stop()
-	})
-
-	go func() {
-		time.Sleep(2 * time.Second)
-		app.Stop()
-	}()
-
-	err := app.Run()
-	assert.NoError(t, err)
-}
-
-func TestGetSelectedJob(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-
-	// Manually populate internal state
-	jobs = []model_job.Job{
+	Kind.Load([]model_job.Job{
 		{
 			Name:                   "projects/p/locations/us-central1/jobs/job-1",
 			Region:                 "us-central1",
@@ -129,68 +28,48 @@ func TestGetSelectedJob(t *testing.T) {
 			TerminalCondition:      &condition.Condition{State: "Succeeded"},
 			LatestCreatedExecution: &model_job.ExecutionReference{CreateTime: time.Now()},
 		},
-	}
-
-	// Manually populate table (simplified, just what GetSelectedJob needs)
-	// 0: Name, 3: Region
-	row := 1
-	listTable.Table.SetCell(row, 0, tview.NewTableCell("job-1"))
-	listTable.Table.SetCell(row, 3, tview.NewTableCell("us-central1"))
-
-	// Select Row 1
-	listTable.Table.Select(row, 0)
-
-	name, region := GetSelectedJob()
-	assert.Equal(t, "job-1", name)
-	assert.Equal(t, "us-central1", region)
-
-	j := GetSelectedJobFull()
-	assert.NotNil(t, j)
-	assert.Equal(t, "projects/p/locations/us-central1/jobs/job-1", j.Name)
-
-	// Test header selection
-	listTable.Table.Select(0, 0)
-	name, _ = GetSelectedJob()
-	assert.Equal(t, "", name)
-
-	jFull := GetSelectedJobFull()
-	assert.Nil(t, jFull)
-}
-
-func TestGetSelectedJobFull_Empty(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-	jobs = []model_job.Job{} // Ensure empty
-
-	j := GetSelectedJobFull()
-	assert.Nil(t, j)
-}
-
-func TestShortcuts(t *testing.T) {
-	_ = footer.New()
-
-	assert.NotPanics(t, func() {
-		Shortcuts()
+		{Name: "job-2"},
 	})
 
-	assert.Contains(t, footer.ContextShortcutView.GetText(true), "Refresh")
+	tbl := Kind.Table().Table
+	assert.Equal(t, 3, tbl.GetRowCount())
+	assert.Equal(t, "job-1", tbl.GetCell(1, 0).Text)
+	assert.Equal(t, "Succeeded", tbl.GetCell(1, 1).Text)
+	assert.Equal(t, "now", tbl.GetCell(1, 2).Text)
+	assert.Equal(t, "us-central1", tbl.GetCell(1, 3).Text)
+	assert.Equal(t, "user@example.com", tbl.GetCell(1, 4).Text)
+	assert.Equal(t, "-", tbl.GetCell(2, 1).Text)
+	assert.Equal(t, "-", tbl.GetCell(2, 2).Text)
+
+	// The console shows the list of jobs, or the metrics of the selected one.
+	tbl.Select(0, 0)
+	assert.Equal(t, "https://console.cloud.google.com/run/jobs?project=p", Kind.ConsoleURL("p"))
+	tbl.Select(1, 0)
+	assert.Equal(t, "https://console.cloud.google.com/run/jobs/details/us-central1/job-1/metrics?project=p", Kind.ConsoleURL("p"))
 }
 
-func TestRender(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
+func TestActions(t *testing.T) {
+	origExecute := executeJobFunc
+	defer func() { executeJobFunc = origExecute }()
 
-	testJobs := []model_job.Job{
-		{
-			Name:              "projects/p/locations/r/jobs/job-1",
-			Region:            "us-central1",
-			TerminalCondition: &condition.Condition{State: "Succeeded"},
-		},
+	var executed []string
+	executeJobFunc = func(ctx context.Context, project, region, jobName string) (*api_operation.Operation, error) {
+		executed = append(executed, project, region, jobName)
+		return nil, nil
 	}
 
-	render(testJobs)
+	h := &resourcetest.Host{}
+	Kind.Build(tview.NewApplication())
+	Kind.Load([]model_job.Job{{Name: "projects/p/locations/us-central1/jobs/job-1", Region: "us-central1"}})
+	Kind.Table().Table.Select(1, 0)
 
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "job-1", listTable.Table.GetCell(1, 0).Text)
-	assert.Equal(t, "Succeeded", listTable.Table.GetCell(1, 1).Text)
+	assert.True(t, Kind.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), h))
+	assert.True(t, Kind.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), h))
+
+	assert.Equal(t, []string{"p", "us-central1", "job-1"}, executed)
+	assert.Equal(t, []string{DASHBOARD_PAGE_ID}, h.Switched)
+
+	_ = footer.New()
+	Kind.Shortcuts()
+	assert.Equal(t, "<r> Refresh  <d> Describe  <l> Logs  <x> Execute  <enter> Details", footer.ContextShortcutView.GetText(true))
 }
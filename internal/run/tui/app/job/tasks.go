@@ -0,0 +1,210 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	api_task "github.com/JulienBreux/run-cli/internal/run/api/job/execution/task"
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	model_task "github.com/JulienBreux/run-cli/internal/run/model/job/task"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/log"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/table"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	EXECUTIONS_TAB = "Executions"
+	TASKS_TAB      = "Tasks"
+)
+
+var (
+	tasksTable     *table.Table
+	tasksExecution *model_execution.Execution
+	dashboardTasks []model_task.Task
+	// cancelTasks cancels the load of the tasks shown, nil when none are.
+	cancelTasks context.CancelFunc
+)
+
+var listTasksPageFunc = api_task.ListPage
+
+var tasksHeaders = []string{"INDEX", "STATUS", "ATTEMPTS", "EXIT CODE", "STARTED", "ENDED", "LAST FAILURE"}
+
+var tasksExpansions = []int{1, 1, 1, 1, 2, 2, 4}
+
+func buildTasksTab() tview.Primitive {
+	tasksTable = table.New(" Tasks ")
+	tasksTable.SetHeadersWithExpansions(tasksHeaders, tasksExpansions)
+	return tasksTable.Table
+}
+
+// DashboardHandleKey runs the action of the key on the dashboard and returns whether there was one:
+// enter drills down from an execution into its tasks, esc goes back to the executions, and l opens the
+// logs of the selected task. The selected execution can also be cancelled, deleted or run again, see
+// handleExecutionKey.
+func DashboardHandleKey(event *tcell.EventKey, h resource.Host) bool {
+	if tasksExecution == nil {
+		if event.Key() == tcell.KeyEnter {
+			if row, _ := executionsTable.Table.GetSelection(); row >= 1 && row <= len(dashboardExecutions) {
+				openTasks(h, dashboardExecutions[row-1])
+			}
+			return true
+		}
+		return handleExecutionKey(event, h)
+	}
+
+	switch {
+	case event.Key() == tcell.KeyEscape:
+		closeTasks()
+		updateHeader()
+		DashboardShortcuts()
+		return true
+	case event.Key() == tcell.KeyRune && event.Rune() == 'l':
+		if row, _ := tasksTable.Table.GetSelection(); row >= 1 && row <= len(dashboardTasks) {
+			openTaskLogs(h, dashboardTasks[row-1])
+		}
+		return true
+	}
+	return false
+}
+
+// openTasks shows the tasks of an execution, in place of the executions. Their load is cancelled
+// with the one of the dashboard, e.g. when going back to the jobs.
+func openTasks(h resource.Host, exec model_execution.Execution) {
+	tasksExecution = &exec
+	tasksTable.Table.Clear()
+	tasksTable.SetHeadersWithExpansions(tasksHeaders, tasksExpansions)
+	tasksTable.Table.SetTitle(fmt.Sprintf(" Tasks of %s ", shortName(exec.Name)))
+	dashboardPages.SwitchToPage(TASKS_TAB)
+	updateHeader()
+	DashboardShortcuts()
+
+	reloadTasks(dashboardCtx, h.App(), h.Info(), func(err error) {
+		if err != nil {
+			h.ShowError(err)
+		}
+	})
+}
+
+// reloadTasks cancels the load of the tasks shown, if any, and loads them again.
+func reloadTasks(ctx context.Context, app *tview.Application, currentInfo info.Info, onResult func(error)) {
+	if cancelTasks != nil {
+		cancelTasks()
+	}
+	ctx, cancelTasks = context.WithCancel(ctx)
+
+	tasksTable.SetLoadMore(nil)
+	go loadTasks(ctx, app, currentInfo, dashboardJob.Name, *tasksExecution, "", onResult)
+}
+
+// closeTasks cancels the load of the tasks, if any, and shows the executions back.
+func closeTasks() {
+	if cancelTasks != nil {
+		cancelTasks()
+		cancelTasks = nil
+	}
+	tasksExecution = nil
+	dashboardTasks = nil
+	dashboardPages.SwitchToPage(EXECUTIONS_TAB)
+}
+
+// loadTasks loads a page of tasks of an execution, the first one for an empty token, and the next ones
+// as the user scrolls.
+func loadTasks(ctx context.Context, app *tview.Application, currentInfo info.Info, jobName string, exec model_execution.Execution, pageToken string, onResult func(error)) {
+	tasks, next, err := listTasksPageFunc(ctx, currentInfo.Project, exec.Region, jobName, shortName(exec.Name), pageToken)
+
+	app.QueueUpdateDraw(func() {
+		// The result of a cancelled load is stale, e.g. of the previous execution.
+		if ctx.Err() != nil {
+			return
+		}
+
+		first := pageToken == ""
+		if first {
+			dashboardTasks = nil
+			tasksTable.Table.Clear()
+			tasksTable.SetHeadersWithExpansions(tasksHeaders, tasksExpansions)
+		}
+
+		if err != nil {
+			onResult(err)
+			return
+		}
+
+		from := len(dashboardTasks)
+		dashboardTasks = append(dashboardTasks, tasks...)
+		renderTasks(from)
+
+		tasksTable.Table.SetTitle(fmt.Sprintf(" Tasks of %s (%s) ", shortName(exec.Name), table.Count(len(dashboardTasks), int(exec.TaskCount), next != "")))
+		if first && len(dashboardTasks) > 0 {
+			tasksTable.Table.Select(1, 0)
+		}
+		if next != "" {
+			tasksTable.SetLoadMore(func() {
+				go loadTasks(ctx, app, currentInfo, jobName, exec, next, onResult)
+			})
+		}
+		onResult(nil)
+	})
+}
+
+// renderTasks renders the rows of the tasks from the given index.
+func renderTasks(from int) {
+	for i := from; i < len(dashboardTasks); i++ {
+		task := dashboardTasks[i]
+		row := i + 1
+
+		status := "-"
+		if task.TerminalCondition != nil {
+			status = task.TerminalCondition.State
+		}
+
+		// A task is attempted once, and once more for each retry.
+		attempts := "0"
+		if !task.StartTime.IsZero() {
+			attempts = fmt.Sprintf("%d/%d", task.Retried+1, task.MaxRetries+1)
+		}
+
+		exitCode, failure := "-", ""
+		if task.LastAttemptResult != nil {
+			exitCode = strconv.Itoa(int(task.LastAttemptResult.ExitCode))
+			failure = task.LastAttemptResult.Message
+		}
+
+		tasksTable.Table.SetCell(row, 0, tview.NewTableCell(strconv.Itoa(int(task.Index))))
+		tasksTable.Table.SetCell(row, 1, tview.NewTableCell(status))
+		tasksTable.Table.SetCell(row, 2, tview.NewTableCell(attempts))
+		tasksTable.Table.SetCell(row, 3, tview.NewTableCell(exitCode))
+		tasksTable.Table.SetCell(row, 4, tview.NewTableCell(formatTime(task.StartTime)))
+		tasksTable.Table.SetCell(row, 5, tview.NewTableCell(formatTime(task.CompletionTime)))
+		tasksTable.Table.SetCell(row, 6, tview.NewTableCell(failure))
+	}
+}
+
+// openTaskLogs opens the logs of a task, those of its job filtered by execution and task index.
+func openTaskLogs(h resource.Host, task model_task.Task) {
+	filter, err := api_log.Filter(api_log.KindJob, shortName(dashboardJob.Name), dashboardJob.Region)
+	if err != nil {
+		h.ShowError(err)
+		return
+	}
+	filter += " " + api_log.TaskFilter(shortName(tasksExecution.Name), task.Index)
+
+	title := shortName(task.Name)
+	h.OpenModal(log.MODAL_PAGE_ID, func(close func()) tview.Primitive {
+		return log.LogModal(h.App(), h.Info().Project, filter, title, close)
+	})
+}
+
+// formatTime formats a time of a task, - when it is unset, e.g. the end of a running task.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
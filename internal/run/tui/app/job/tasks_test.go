@@ -0,0 +1,211 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/model/common/condition"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	model_job "github.com/JulienBreux/run-cli/internal/run/model/job"
+	model_execution "github.com/JulienBreux/run-cli/internal/run/model/job/execution"
+	model_task "github.com/JulienBreux/run-cli/internal/run/model/job/task"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/log"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource/resourcetest"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTasks(t *testing.T) {
+	app := tview.NewApplication()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	_ = screen.Init()
+	app.SetScreen(screen)
+	Dashboard(app)
+
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockTasks := []model_task.Task{
+		{
+			Name:              "j-abcde-task0",
+			Index:             0,
+			MaxRetries:        3,
+			StartTime:         start,
+			CompletionTime:    start.Add(time.Minute),
+			LastAttemptResult: &model_task.AttemptResult{},
+			TerminalCondition: &condition.Condition{State: "CONDITION_SUCCEEDED"},
+		},
+		{
+			Name:              "j-abcde-task1",
+			Index:             1,
+			Retried:           3,
+			MaxRetries:        3,
+			StartTime:         start,
+			CompletionTime:    start.Add(2 * time.Minute),
+			LastAttemptResult: &model_task.AttemptResult{ExitCode: 1, Message: "The container exited with an error."},
+			TerminalCondition: &condition.Condition{State: "CONDITION_FAILED"},
+		},
+		{Name: "j-abcde-task2", Index: 2, MaxRetries: 3},
+	}
+
+	originalListTasksPageFunc := listTasksPageFunc
+	defer func() { listTasksPageFunc = originalListTasksPageFunc }()
+	var gotJob, gotExecution string
+	listTasksPageFunc = func(ctx context.Context, project, region, jobName, executionName, pageToken string) ([]model_task.Task, string, error) {
+		gotJob, gotExecution = jobName, executionName
+		return mockTasks, "", nil
+	}
+
+	exec := model_execution.Execution{Name: "projects/p/locations/r/jobs/j/executions/j-abcde", Region: "r", TaskCount: 3}
+	go loadTasks(context.Background(), app, info.Info{Project: "p"}, "projects/p/locations/r/jobs/j", exec, "", func(err error) {
+		assert.NoError(t, err)
+		app.Stop()
+	})
+	go func() {
+		time.Sleep(2 * time.Second)
+		app.Stop()
+	}()
+	assert.NoError(t, app.Run())
+
+	assert.Equal(t, "projects/p/locations/r/jobs/j", gotJob)
+	assert.Equal(t, "j-abcde", gotExecution)
+	assert.Equal(t, mockTasks, dashboardTasks)
+
+	tbl := tasksTable.Table
+	assert.Equal(t, " Tasks of j-abcde (3) ", tbl.GetTitle())
+	assert.Equal(t, 4, tbl.GetRowCount())
+	assert.Equal(t, "1", tbl.GetCell(2, 0).Text)
+	assert.Equal(t, "CONDITION_FAILED", tbl.GetCell(2, 1).Text)
+	assert.Equal(t, "4/4", tbl.GetCell(2, 2).Text)
+	assert.Equal(t, "1", tbl.GetCell(2, 3).Text)
+	assert.Equal(t, "2026-01-01 12:00:00", tbl.GetCell(2, 4).Text)
+	assert.Equal(t, "2026-01-01 12:02:00", tbl.GetCell(2, 5).Text)
+	assert.Equal(t, "The container exited with an error.", tbl.GetCell(2, 6).Text)
+	assert.Equal(t, "1/4", tbl.GetCell(1, 2).Text)
+	assert.Equal(t, "0", tbl.GetCell(1, 3).Text)
+	// A pending task has no attempt yet
+	assert.Equal(t, "-", tbl.GetCell(3, 1).Text)
+	assert.Equal(t, "0", tbl.GetCell(3, 2).Text)
+	assert.Equal(t, "-", tbl.GetCell(3, 3).Text)
+	assert.Equal(t, "-", tbl.GetCell(3, 5).Text)
+}
+
+func TestDashboardHandleKey(t *testing.T) {
+	_ = footer.New()
+	h := &resourcetest.Host{Application: tview.NewApplication()}
+	Dashboard(h.Application)
+
+	originalListTasksPageFunc := listTasksPageFunc
+	defer func() { listTasksPageFunc = originalListTasksPageFunc }()
+	executions := make(chan string, 1)
+	listTasksPageFunc = func(ctx context.Context, project, region, jobName, executionName, pageToken string) ([]model_task.Task, string, error) {
+		executions <- executionName
+		return nil, "", nil
+	}
+
+	dashboardJob = &model_job.Job{Name: "projects/p/locations/r/jobs/j", Region: "r"}
+	dashboardExecutions = []model_execution.Execution{{Name: "projects/p/locations/r/jobs/j/executions/j-abcde", Region: "r"}}
+	renderExecutions(0)
+	executionsTable.Table.Select(1, 0)
+
+	// Enter drills down into the tasks of the selected execution
+	assert.True(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), h))
+	assert.Equal(t, "j-abcde", <-executions)
+	name, _ := dashboardPages.GetFrontPage()
+	assert.Equal(t, TASKS_TAB, name)
+	assert.Contains(t, dashboardHeader.GetText(true), "Execution: j-abcde")
+	assert.Equal(t, "<esc> Executions  <l> Logs", footer.ContextShortcutView.GetText(true))
+
+	// l opens the logs of the selected task
+	dashboardTasks = []model_task.Task{{Name: "j-abcde-task0"}}
+	renderTasks(0)
+	tasksTable.Table.Select(1, 0)
+	assert.True(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone), h))
+	assert.Equal(t, []string{log.MODAL_PAGE_ID}, h.Modals)
+
+	// Esc goes back to the executions
+	assert.True(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), h))
+	name, _ = dashboardPages.GetFrontPage()
+	assert.Equal(t, EXECUTIONS_TAB, name)
+	assert.Nil(t, tasksExecution)
+	assert.NotContains(t, dashboardHeader.GetText(true), "Execution")
+	assert.Contains(t, footer.ContextShortcutView.GetText(true), "<enter> Tasks")
+
+	// Esc on the executions goes back to the jobs, which the app does
+	assert.False(t, DashboardHandleKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), h))
+}
+
+func TestDashboardReload_KeepsTasks(t *testing.T) {
+	app := tview.NewApplication()
+	Dashboard(app)
+
+	// The loads are only awaited, the app isn't running.
+	loads := make(chan string, 3)
+	originalListTasksPageFunc := listTasksPageFunc
+	defer func() { listTasksPageFunc = originalListTasksPageFunc }()
+	listTasksPageFunc = func(ctx context.Context, project, region, jobName, executionName, pageToken string) ([]model_task.Task, string, error) {
+		loads <- executionName
+		return nil, "", nil
+	}
+	originalListExecutionsPageFunc := listExecutionsPageFunc
+	defer func() { listExecutionsPageFunc = originalListExecutionsPageFunc }()
+	listExecutionsPageFunc = func(ctx context.Context, project, region, jobName, pageToken string) ([]model_execution.Execution, string, error) {
+		loads <- jobName
+		return nil, "", nil
+	}
+
+	job := &model_job.Job{Name: "projects/p/locations/r/jobs/j", Region: "r"}
+	dashboardJob = job
+	tasksExecution = &model_execution.Execution{Name: "j-abcde"}
+	dashboardPages.SwitchToPage(TASKS_TAB)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Coming back from the logs of a task keeps its execution
+	DashboardReload(ctx, app, info.Info{Project: "p"}, job, func(error) {})
+	assert.NotNil(t, tasksExecution)
+	assert.Contains(t, dashboardHeader.GetText(true), "Execution: j-abcde")
+
+	// Another job shows its executions
+	DashboardReload(ctx, app, info.Info{Project: "p"}, &model_job.Job{Name: "projects/p/locations/r/jobs/other"}, func(error) {})
+	assert.Nil(t, tasksExecution)
+	name, _ := dashboardPages.GetFrontPage()
+	assert.Equal(t, EXECUTIONS_TAB, name)
+
+	var loaded []string
+	for range 3 {
+		loaded = append(loaded, <-loads)
+	}
+	assert.ElementsMatch(t, []string{job.Name, "j-abcde", "projects/p/locations/r/jobs/other"}, loaded)
+}
+
+func TestOpenTasks_CancelledWithDashboard(t *testing.T) {
+	_ = footer.New()
+	h := &resourcetest.Host{Application: tview.NewApplication()}
+	Dashboard(h.Application)
+
+	originalListExecutionsPageFunc := listExecutionsPageFunc
+	defer func() { listExecutionsPageFunc = originalListExecutionsPageFunc }()
+	listExecutionsPageFunc = func(ctx context.Context, project, region, jobName, pageToken string) ([]model_execution.Execution, string, error) {
+		return nil, "", nil
+	}
+	originalListTasksPageFunc := listTasksPageFunc
+	defer func() { listTasksPageFunc = originalListTasksPageFunc }()
+	loads := make(chan context.Context, 1)
+	listTasksPageFunc = func(ctx context.Context, project, region, jobName, executionName, pageToken string) ([]model_task.Task, string, error) {
+		loads <- ctx
+		return nil, "", nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	DashboardReload(ctx, h.Application, info.Info{Project: "p"}, &model_job.Job{Name: "projects/p/locations/r/jobs/j", Region: "r"}, func(error) {})
+	openTasks(h, model_execution.Execution{Name: "projects/p/locations/r/jobs/j/executions/j-abcde", Region: "r"})
+	tasksCtx := <-loads
+	assert.NoError(t, tasksCtx.Err())
+
+	// Leaving the dashboard cancels the load of its tasks
+	cancel()
+	assert.Error(t, tasksCtx.Err())
+	closeTasks()
+}
@@ -0,0 +1,101 @@
+// Package listcache caches the lists of the TUI on disk, per kind, project and region, for them to be
+// shown at once on startup while they are refreshed, and to be browsed while the network is unavailable.
+package listcache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/cache"
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	// version of the cached lists, to be bumped when the models change for the older lists to be ignored.
+	version = 1
+	// TTL is the age after which a cached list is no longer shown, even while offline.
+	TTL = 7 * 24 * time.Hour
+)
+
+// Kinds of the cached lists.
+const (
+	KindProjects       = "projects"
+	KindServices       = "services"
+	KindJobs           = "jobs"
+	KindWorkerPools    = "workerpools"
+	KindDomainMappings = "domainmappings"
+)
+
+// Variables for dependency injection
+var newCache = func() (*cache.Cache, error) { return cache.New(fmt.Sprintf("lists/v%d", version)) }
+
+var (
+	mu      sync.Mutex
+	enabled bool
+)
+
+// SetEnabled enables or disables the cache. It is disabled by default, e.g. for the demo mode and the
+// tests not to read or overwrite the lists of the user.
+func SetEnabled(on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = on
+}
+
+// Load returns the cached list of a kind of resources of a project and region, and when it was
+// refreshed. It returns false when the cache is disabled or the list is missing or older than TTL.
+func Load[T any](kind, project, region string) ([]T, time.Time, bool) {
+	c := open()
+	if c == nil {
+		return nil, time.Time{}, false
+	}
+	var items []T
+	refreshed, ok := c.Lookup(key(kind, project, region), TTL, &items)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	return items, refreshed, true
+}
+
+// Save caches the list of a kind of resources of a project and region, ignoring the errors as the
+// cache is only an optimization.
+func Save[T any](kind, project, region string, items []T) {
+	if c := open(); c != nil {
+		_ = c.Set(key(kind, project, region), items)
+	}
+}
+
+// StaleError is the error of a list which could not be refreshed, e.g. while offline, and is shown
+// from the cache instead.
+type StaleError struct {
+	Err       error
+	Refreshed time.Time
+}
+
+func (e *StaleError) Error() string {
+	return fmt.Sprintf("showing the list refreshed %s: %v", humanize.Time(e.Refreshed), e.Err)
+}
+
+func (e *StaleError) Unwrap() error {
+	return e.Err
+}
+
+// open returns the cache, nil when it is disabled or unavailable.
+func open() *cache.Cache {
+	mu.Lock()
+	on := enabled
+	mu.Unlock()
+	if !on {
+		return nil
+	}
+	c, err := newCache()
+	if err != nil {
+		return nil
+	}
+	return c
+}
+
+func key(kind, project, region string) string {
+	return kind + "/" + project + "/" + region
+}
@@ -0,0 +1,70 @@
+package listcache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JulienBreux/run-cli/internal/run/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func setupCache(t *testing.T) {
+	dir := t.TempDir()
+	origNewCache := newCache
+	t.Cleanup(func() {
+		newCache = origNewCache
+		SetEnabled(false)
+	})
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+	newCache = func() (*cache.Cache, error) { return cache.New("lists-test") }
+	SetEnabled(true)
+}
+
+func TestLoadSave(t *testing.T) {
+	setupCache(t)
+
+	_, _, ok := Load[item](KindServices, "p", "r")
+	assert.False(t, ok)
+
+	Save(KindServices, "p", "r", []item{{Name: "s1"}})
+	items, refreshed, ok := Load[item](KindServices, "p", "r")
+	assert.True(t, ok)
+	assert.Equal(t, []item{{Name: "s1"}}, items)
+	assert.WithinDuration(t, time.Now(), refreshed, time.Minute)
+
+	// The lists are cached per kind, project and region.
+	_, _, ok = Load[item](KindJobs, "p", "r")
+	assert.False(t, ok)
+	_, _, ok = Load[item](KindServices, "p", "other")
+	assert.False(t, ok)
+}
+
+func TestLoadSave_Disabled(t *testing.T) {
+	setupCache(t)
+	SetEnabled(false)
+
+	Save(KindServices, "p", "r", []item{{Name: "s1"}})
+	SetEnabled(true)
+	_, _, ok := Load[item](KindServices, "p", "r")
+	assert.False(t, ok)
+
+	newCache = func() (*cache.Cache, error) { return nil, assert.AnError }
+	Save(KindServices, "p", "r", []item{{Name: "s1"}})
+	_, _, ok = Load[item](KindServices, "p", "r")
+	assert.False(t, ok)
+}
+
+func TestStaleError(t *testing.T) {
+	err := &StaleError{Err: assert.AnError, Refreshed: time.Now().Add(-5 * time.Minute)}
+	assert.Equal(t, "showing the list refreshed 5 minutes ago: "+assert.AnError.Error(), err.Error())
+	assert.ErrorIs(t, err, assert.AnError)
+
+	var stale *StaleError
+	assert.True(t, errors.As(error(err), &stale))
+}
@@ -1,28 +1,22 @@
 package app
 
 import (
-	"fmt"
-
+	"github.com/JulienBreux/run-cli/internal/run/config"
 	model_project "github.com/JulienBreux/run-cli/internal/run/model/common/project"
-	model_domainmapping "github.com/JulienBreux/run-cli/internal/run/model/domainmapping"
-	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
-	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/contexts"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/credits"
-	"github.com/JulienBreux/run-cli/internal/run/tui/app/describe"
-	"github.com/JulienBreux/run-cli/internal/run/tui/app/domainmapping"
-	"github.com/JulienBreux/run-cli/internal/run/tui/app/log"
 		"github.com/JulienBreux/run-cli/internal/run/tui/app/project"
 		"github.com/JulienBreux/run-cli/internal/run/tui/app/region"
-		service_scale "github.com/JulienBreux/run-cli/internal/run/tui/app/service/scale"
-		workerpool_scale "github.com/JulienBreux/run-cli/internal/run/tui/app/workerpool/scale"
 		"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
 		"github.com/JulienBreux/run-cli/internal/run/tui/component/header"
+		"github.com/rivo/tview"
 	)
 	
 	func openProjectModal() {
 		projectModal = project.ProjectModal(app, func(selectedProject model_project.Project) {
 			currentInfo.Project = selectedProject.Name
-			currentConfig.Project = selectedProject.Name
+			currentConfig.SetProject(selectedProject.Name)
+			applyContext()
 			if err := currentConfig.Save(); err != nil {
 				showError(err)
 				return
@@ -43,9 +37,10 @@ import (
 	}
 	
 	func openRegionModal() {
-		regionModal = region.RegionModal(app, func(selectedRegion string) {
+		regionModal = region.RegionModal(app, currentInfo.Project, func(selectedRegion string) {
 			currentInfo.Region = selectedRegion
-			currentConfig.Region = selectedRegion
+			currentConfig.SetRegion(selectedRegion)
+			applyContext()
 			if err := currentConfig.Save(); err != nil {
 				showError(err)
 				return
@@ -65,70 +60,50 @@ import (
 		app.SetFocus(regionModal)
 	}
 	
-	func openLogModal(name, region, logType string) {
-		var filter string
-		switch logType {
-		case "service":
-			filter = fmt.Sprintf(`resource.type="cloud_run_revision" resource.labels.service_name="%s" resource.labels.location="%s"`, name, region)
-		case "job":
-			filter = fmt.Sprintf(`resource.type="cloud_run_job" resource.labels.job_name="%s" resource.labels.location="%s"`, name, region)
-		}
-	
-		logModal := log.LogModal(app, currentInfo.Project, filter, name, func() {
-			rootPages.RemovePage(log.MODAL_PAGE_ID)
-			switchTo(previousPageID)
-		})
-	
-		rootPages.AddPage(log.MODAL_PAGE_ID, logModal, true, true)
-	
-		previousPageID = currentPageID
-		currentPageID = log.MODAL_PAGE_ID
-	
-		footer.ContextShortcutView.Clear()
-		app.SetFocus(logModal)
-	}
-	
-	func openDescribeModal(resource any, title string) {
-		describeModal := describe.DescribeModal(app, resource, title, func() {
-			rootPages.RemovePage(describe.MODAL_PAGE_ID)
+	func openContextModal() {
+		contextModal = contexts.ContextModal(app, currentConfig.Contexts, currentConfig.CurrentContext, func(selectedContext config.Context) {
+			if err := currentConfig.UseContext(selectedContext.Name); err != nil {
+				showError(err)
+				return
+			}
+			currentInfo.Project = currentConfig.Project
+			if currentConfig.Region != "" {
+				currentInfo.Region = currentConfig.Region
+			}
+			applyContext()
+			if err := currentConfig.Save(); err != nil {
+				showError(err)
+				return
+			}
+			header.UpdateInfo(currentInfo)
+		}, func() {
+			rootPages.RemovePage(contexts.MODAL_PAGE_ID)
 			switchTo(previousPageID)
 		})
 	
-		rootPages.AddPage(describe.MODAL_PAGE_ID, describeModal, true, true)
-	
-		previousPageID = currentPageID
-		currentPageID = describe.MODAL_PAGE_ID
-	
-		footer.ContextShortcutView.Clear()
-		app.SetFocus(describeModal)
-	}
-	
-	func openServiceScaleModal(s *model_service.Service) {
-		scaleModal := service_scale.Modal(app, s, rootPages, func() {
-			rootPages.RemovePage(service_scale.MODAL_PAGE_ID)
-			switchTo(previousPageID)
-		})
+		rootPages.AddPage(contexts.MODAL_PAGE_ID, contextModal, true, true)
 	
-		rootPages.AddPage(service_scale.MODAL_PAGE_ID, scaleModal, true, true)
 		previousPageID = currentPageID
-		currentPageID = service_scale.MODAL_PAGE_ID
+		currentPageID = contexts.MODAL_PAGE_ID
 	
 		footer.ContextShortcutView.Clear()
-		app.SetFocus(scaleModal)
+		app.SetFocus(contextModal)
 	}
 	
-	func openWorkerPoolScaleModal(w *model_workerpool.WorkerPool) {
-		scaleModal := workerpool_scale.Modal(app, w, rootPages, func() {
-			rootPages.RemovePage(workerpool_scale.MODAL_PAGE_ID)
+	// openModal shows the modal page id, built with the function closing it and going back to the
+	// previous page.
+	func openModal(id string, build func(close func()) tview.Primitive) {
+		modal := build(func() {
+			rootPages.RemovePage(id)
 			switchTo(previousPageID)
 		})
 	
-		rootPages.AddPage(workerpool_scale.MODAL_PAGE_ID, scaleModal, true, true)
+		rootPages.AddPage(id, modal, true, true)
 		previousPageID = currentPageID
-		currentPageID = workerpool_scale.MODAL_PAGE_ID
+		currentPageID = id
 	
 		footer.ContextShortcutView.Clear()
-		app.SetFocus(scaleModal)
+		app.SetFocus(modal)
 	}
 	
 	func openCreditsModal() {
@@ -145,20 +120,6 @@ import (
 		app.SetFocus(c)
 		c.StartAnimation()
 	}
-	
-	func openDomainMappingInfoModal(dm *model_domainmapping.DomainMapping) {
-		modal := domainmapping.DomainMappingInfoModal(app, dm, func() {
-			rootPages.RemovePage(domainmapping.MODAL_PAGE_ID)
-			switchTo(previousPageID)
-		})
-	
-		rootPages.AddPage(domainmapping.MODAL_PAGE_ID, modal, true, true)
-		previousPageID = currentPageID
-		currentPageID = domainmapping.MODAL_PAGE_ID
-	
-		footer.ContextShortcutView.Clear()
-		app.SetFocus(modal)
-	}
 
 		
 
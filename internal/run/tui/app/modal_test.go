@@ -4,15 +4,16 @@ import (
 	"os"
 	"testing"
 
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/config"
 	model_project "github.com/JulienBreux/run-cli/internal/run/model/common/project"
-	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
-	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/contexts"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/describe"
-	"github.com/JulienBreux/run-cli/internal/run/tui/app/log"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/project"
 	"github.com/JulienBreux/run-cli/internal/run/tui/app/region"
-	service_scale "github.com/JulienBreux/run-cli/internal/run/tui/app/service/scale"
-	workerpool_scale "github.com/JulienBreux/run-cli/internal/run/tui/app/workerpool/scale"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/service"
+	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,6 +31,8 @@ func TestOpenProjectModal(t *testing.T) {
 func TestOpenRegionModal(t *testing.T) {
 	setupTestApp()
 	buildLayout()
+	api_region.SetAllowed(api_region.Builtin())
+	defer api_region.SetAllowed(nil)
 
 	openRegionModal()
 
@@ -58,6 +61,8 @@ func TestModalCallbacks(t *testing.T) {
 	assert.Equal(t, "new-p", currentConfig.Project)
 	
 	// 2. Region Callback
+	api_region.SetAllowed(api_region.Builtin())
+	defer api_region.SetAllowed(nil)
 	openRegionModal()
 	selReg := regionModal.(*region.RegionSelector)
 	// Find specific region item
@@ -69,41 +74,58 @@ func TestModalCallbacks(t *testing.T) {
 	assert.Equal(t, "us-east1", currentConfig.Region)
 }
 
-func TestOpenLogModal(t *testing.T) {
+func TestOpenContextModal(t *testing.T) {
 	setupTestApp()
 	buildLayout()
+	t.Setenv("HOME", t.TempDir())
 
-	openLogModal("my-service", "us-central1", "service")
+	currentConfig.Contexts = []config.Context{
+		{Name: "prod-eu", Project: "prod", Region: "europe-west1", ReadOnly: true},
+	}
+	defer func() { client.SetReadOnly(false) }()
 
-	assert.Equal(t, log.MODAL_PAGE_ID, currentPageID)
-}
-
-func TestOpenDescribeModal(t *testing.T) {
-	setupTestApp()
-	buildLayout()
+	openContextModal()
+	assert.Equal(t, contexts.MODAL_PAGE_ID, currentPageID)
 
-	res := map[string]string{"foo": "bar"}
-	openDescribeModal(res, "Title")
-
-	assert.Equal(t, describe.MODAL_PAGE_ID, currentPageID)
-}
+	sel := contextModal.(*contexts.ContextSelector)
+	sel.List.SetCurrentItem(0)
+	sel.Submit()
 
-func TestOpenServiceScaleModal(t *testing.T) {
-	setupTestApp()
-	buildLayout()
+	assert.Equal(t, "prod-eu", currentConfig.CurrentContext)
+	assert.Equal(t, "prod", currentInfo.Project)
+	assert.Equal(t, "europe-west1", currentInfo.Region)
+	assert.Equal(t, "prod-eu", currentInfo.Context)
+	assert.True(t, currentInfo.ReadOnly)
+	assert.ErrorIs(t, client.CheckWritable(), client.ErrReadOnly)
 
-	svc := &model_service.Service{Name: "s1"}
-	openServiceScaleModal(svc)
+	// Picking another project leaves the context
+	project.CachedProjects = []model_project.Project{{Name: "other"}}
+	openProjectModal()
+	selProject := projectModal.(*project.ProjectSelector)
+	selProject.List.SetCurrentItem(0)
+	selProject.Submit()
 
-	assert.Equal(t, service_scale.MODAL_PAGE_ID, currentPageID)
+	assert.Empty(t, currentConfig.CurrentContext)
+	assert.Empty(t, currentInfo.Context)
+	assert.NoError(t, client.CheckWritable())
 }
 
-func TestOpenWorkerPoolScaleModal(t *testing.T) {
+func TestOpenModal(t *testing.T) {
 	setupTestApp()
 	buildLayout()
+	currentPageID = service.LIST_PAGE_ID
+
+	var closeModal func()
+	openModal(describe.MODAL_PAGE_ID, func(close func()) tview.Primitive {
+		closeModal = close
+		return tview.NewBox()
+	})
 
-	wp := &model_workerpool.WorkerPool{DisplayName: "wp1"}
-	openWorkerPoolScaleModal(wp)
+	assert.Equal(t, describe.MODAL_PAGE_ID, currentPageID)
+	assert.True(t, rootPages.HasPage(describe.MODAL_PAGE_ID))
 
-	assert.Equal(t, workerpool_scale.MODAL_PAGE_ID, currentPageID)
+	// Closing the modal goes back to the previous page.
+	closeModal()
+	assert.False(t, rootPages.HasPage(describe.MODAL_PAGE_ID))
+	assert.Equal(t, service.LIST_PAGE_ID, currentPageID)
 }
@@ -0,0 +1,94 @@
+package operation
+
+import (
+	"fmt"
+	"time"
+
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/table"
+	"github.com/dustin/go-humanize"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var (
+	listHeaders = []string{
+		"KIND",
+		"TARGET",
+		"STARTED",
+		"PROGRESS",
+		"RESULT"}
+
+	listExpansions = []int{
+		1, // KIND
+		3, // TARGET
+		1, // STARTED
+		2, // PROGRESS
+		3, // RESULT
+	}
+
+	listTable *table.Table
+)
+
+const (
+	LIST_PAGE_TITLE    = "Operations"
+	LIST_PAGE_ID       = "operations-list"
+	LIST_PAGE_SHORTCUT = tcell.KeyCtrlO
+)
+
+var listOperationsFunc = api_operation.List
+
+// List returns the table of the in-flight and recent operations.
+func List(app *tview.Application) *table.Table {
+	listTable = table.New(LIST_PAGE_TITLE)
+	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
+
+	app.SetFocus(listTable.Table)
+
+	return listTable
+}
+
+// Render renders the operations as of their last poll, keeping the selected row.
+func Render() {
+	row, _ := listTable.Table.GetSelection()
+	listTable.Table.Clear()
+	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
+
+	operations := listOperationsFunc()
+	running := 0
+	for i, o := range operations {
+		status := o.Status()
+		if !status.Done {
+			running++
+		}
+
+		listTable.Table.SetCell(i+1, 0, tview.NewTableCell(o.Kind))
+		listTable.Table.SetCell(i+1, 1, tview.NewTableCell(o.Target))
+		listTable.Table.SetCell(i+1, 2, tview.NewTableCell(humanize.Time(o.Started)))
+		listTable.Table.SetCell(i+1, 3, tview.NewTableCell(status.Progress))
+		listTable.Table.SetCell(i+1, 4, tview.NewTableCell(result(o, status)))
+	}
+	if row > 0 && row <= len(operations) {
+		listTable.Table.Select(row, 0)
+	}
+
+	listTable.Table.SetTitle(fmt.Sprintf(" %s (%d running) ", LIST_PAGE_TITLE, running))
+}
+
+// result describes the result of an operation, with its duration once done.
+func result(o *api_operation.Operation, status api_operation.Status) string {
+	switch {
+	case !status.Done:
+		return "[yellow]running[-]"
+	case status.Err != nil:
+		return fmt.Sprintf("[red]failed: %s[-]", tview.Escape(status.Err.Error()))
+	}
+	return fmt.Sprintf("[green]succeeded[-] in %s", status.Ended.Sub(o.Started).Round(time.Second))
+}
+
+func Shortcuts() {
+	footer.ContextShortcutView.Clear()
+	shortcuts := `[dodgerblue]<r> [white]Refresh`
+	footer.ContextShortcutView.SetText(shortcuts)
+}
@@ -0,0 +1,48 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	app := tview.NewApplication()
+	tbl := List(app)
+	assert.NotNil(t, tbl)
+	assert.Equal(t, LIST_PAGE_TITLE, tbl.Title)
+}
+
+func TestRender(t *testing.T) {
+	app := tview.NewApplication()
+	_ = List(app)
+
+	succeeded := api_operation.New("Scale", "service s1 (r)", api_operation.Completed("operation-1", "ready"), func(s string) string { return s })
+	assert.NoError(t, succeeded.Wait(context.Background()))
+	running := api_operation.New("Execute", "job j1 (r)", api_operation.Completed("operation-2", "starting"), func(s string) string { return s })
+
+	original := listOperationsFunc
+	defer func() { listOperationsFunc = original }()
+	listOperationsFunc = func() []*api_operation.Operation {
+		return []*api_operation.Operation{running, succeeded}
+	}
+
+	Render()
+
+	assert.Equal(t, 3, listTable.Table.GetRowCount())
+	assert.Equal(t, "Execute", listTable.Table.GetCell(1, 0).Text)
+	assert.Equal(t, "job j1 (r)", listTable.Table.GetCell(1, 1).Text)
+	assert.Equal(t, "[yellow]running[-]", listTable.Table.GetCell(1, 4).Text)
+	assert.Equal(t, "ready", listTable.Table.GetCell(2, 3).Text)
+	assert.Equal(t, "[green]succeeded[-] in 0s", listTable.Table.GetCell(2, 4).Text)
+	assert.Equal(t, " Operations (1 running) ", listTable.Table.GetTitle())
+}
+
+func TestResult(t *testing.T) {
+	o := &api_operation.Operation{Kind: "Scale", Target: "service s1 (r)"}
+	assert.Equal(t, "[red]failed: revision [failed[][-]", result(o, api_operation.Status{Done: true, Err: errors.New("revision [failed]")}))
+}
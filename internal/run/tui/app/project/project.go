@@ -1,10 +1,12 @@
 package project
 
 import (
+	"context"
 	"strings"
 
 	api_project "github.com/JulienBreux/run-cli/internal/run/api/project"
 	model "github.com/JulienBreux/run-cli/internal/run/model/common/project"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -18,11 +20,29 @@ var (
 	CachedProjects []model.Project
 )
 
-// PreLoad fetches the projects and caches them.
-func PreLoad() error {
-	var err error
-	CachedProjects, err = api_project.List()
-	return err
+var listProjectsFunc = api_project.List
+
+// PreLoad fetches the projects and caches them, also on disk. The projects cached on disk are used
+// when they can't be fetched, e.g. while offline.
+func PreLoad(ctx context.Context) error {
+	projects, err := listProjectsFunc(ctx)
+	if err != nil {
+		LoadCached()
+		return err
+	}
+	CachedProjects = projects
+	listcache.Save(listcache.KindProjects, "", "", projects)
+	return nil
+}
+
+// LoadCached loads the projects cached on disk, unless they are already loaded.
+func LoadCached() {
+	if len(CachedProjects) > 0 {
+		return
+	}
+	if projects, _, ok := listcache.Load[model.Project](listcache.KindProjects, "", ""); ok {
+		CachedProjects = projects
+	}
 }
 
 // ProjectSelector represents the project selection modal component.
@@ -44,7 +64,7 @@ func ProjectModal(app *tview.Application, onSelect func(project model.Project),
 	if len(CachedProjects) > 0 {
 		projects = CachedProjects
 	} else {
-		projects, err = api_project.List()
+		projects, err = listProjectsFunc(context.Background())
 		if err != nil {
 			projects = []model.Project{}
 		} else {
@@ -1,14 +1,40 @@
 package project
 
 import (
+	"context"
 	"testing"
 
 	model "github.com/JulienBreux/run-cli/internal/run/model/common/project"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestPreLoad(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	listcache.SetEnabled(true)
+	defer listcache.SetEnabled(false)
+	defer func() { CachedProjects = nil }()
+
+	origList := listProjectsFunc
+	defer func() { listProjectsFunc = origList }()
+
+	listProjectsFunc = func(ctx context.Context) ([]model.Project, error) {
+		return []model.Project{{Name: "p1"}}, nil
+	}
+	assert.NoError(t, PreLoad(context.Background()))
+	assert.Equal(t, []model.Project{{Name: "p1"}}, CachedProjects)
+
+	// The projects cached on disk are used when they can't be fetched, e.g. while offline.
+	CachedProjects = nil
+	listProjectsFunc = func(ctx context.Context) ([]model.Project, error) {
+		return nil, assert.AnError
+	}
+	assert.ErrorIs(t, PreLoad(context.Background()), assert.AnError)
+	assert.Equal(t, []model.Project{{Name: "p1"}}, CachedProjects)
+}
+
 func TestProjectModal(t *testing.T) {
 	app := tview.NewApplication()
 	
@@ -1,6 +1,7 @@
 package region
 
 import (
+	"context"
 	"strings"
 
 	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
@@ -23,10 +24,10 @@ type RegionSelector struct {
 	Submit  func()
 }
 
-// RegionModal returns a centered modal primitive with search and list
-func RegionModal(app *tview.Application, onSelect func(region string), closeModal func()) *RegionSelector {
+// RegionModal returns a centered modal primitive with search and list of the regions of a project
+func RegionModal(app *tview.Application, project string, onSelect func(region string), closeModal func()) *RegionSelector {
 	// --- Data ---
-	regions := append([]string{"- (All Regions)"}, api_region.List()...)
+	regions := append([]string{"- (All Regions)"}, api_region.List(context.Background(), project)...)
 	var filteredRegions []string
 
 	// --- Components ---
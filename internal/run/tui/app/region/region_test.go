@@ -11,7 +11,7 @@ import (
 
 func TestRegionModal_Init(t *testing.T) {
 	app := tview.NewApplication()
-	selector := RegionModal(app, func(s string) {}, func() {})
+	selector := RegionModal(app, "", func(s string) {}, func() {})
 
 	assert.NotNil(t, selector)
 	assert.NotNil(t, selector.Input)
@@ -25,7 +25,7 @@ func TestRegionModal_Init(t *testing.T) {
 
 func TestRegionModal_Filtering(t *testing.T) {
 	app := tview.NewApplication()
-	selector := RegionModal(app, func(s string) {}, func() {})
+	selector := RegionModal(app, "", func(s string) {}, func() {})
 
 	// Initial state: All regions + special option
 	initialCount := selector.List.GetItemCount()
@@ -58,7 +58,7 @@ func TestRegionModal_Selection(t *testing.T) {
 		closed = true
 	}
 
-	selector := RegionModal(app, onSelect, closeModal)
+	selector := RegionModal(app, "", onSelect, closeModal)
 
 	// Test selecting a specific region
 	selector.Filter("europe-west1")
@@ -99,7 +99,7 @@ func TestInputCapture(t *testing.T) {
 	closed := false
 	closeModal := func() { closed = true }
 	
-	selector := RegionModal(app, func(s string) {}, closeModal)
+	selector := RegionModal(app, "", func(s string) {}, closeModal)
 	handler := selector.Content.GetInputCapture()
 	
 	// Test Escape
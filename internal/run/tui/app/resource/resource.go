@@ -0,0 +1,398 @@
+// Package resource declares the kinds of resources listed by the TUI, e.g. services: their columns,
+// fetcher, describe, logs, console URL and actions. The list pages, their shortcuts and the shared
+// actions are derived from the declarations, for a new kind not to be copied across the app.
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/describe"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/log"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/table"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Host runs the actions of the kinds, it is implemented by the app.
+type Host interface {
+	App() *tview.Application
+	Info() info.Info
+	// OpenModal shows the modal page id, built with the function closing it.
+	OpenModal(id string, build func(close func()) tview.Primitive)
+	// SwitchTo shows a page, e.g. a dashboard, and loads it.
+	SwitchTo(pageID string)
+	// OpenURL opens a URL in the browser.
+	OpenURL(u string)
+	// ShowError shows an error in the footer.
+	ShowError(err error)
+	// Start starts a long-running operation in the background, followed in the operations panel.
+	Start(start func(ctx context.Context) (*api_operation.Operation, error))
+}
+
+// Page is the list page of a kind of resources, see Kind.
+type Page interface {
+	// ID is the ID of the page.
+	ID() string
+	// Key is the key opening the page.
+	Key() tcell.Key
+	// Build builds the page.
+	Build(app *tview.Application) tview.Primitive
+	// Reload loads the page, see Kind.Reload.
+	Reload(ctx context.Context, app *tview.Application, currentInfo info.Info, onResult func(error))
+	// Shortcuts shows the shortcuts of the page in the footer.
+	Shortcuts()
+	// HandleKey runs the action of a key on the selected resource and returns whether the key has one.
+	HandleKey(event *tcell.EventKey, h Host) bool
+	// ConsoleURL returns the URL of the selected resource in the console, empty when the kind has none.
+	ConsoleURL(project string) string
+}
+
+// Column is a column of the list of a kind of resources.
+type Column[T any] struct {
+	Header string
+	// Expansion is the share of the width taken by the column.
+	Expansion int
+	Value     func(T) string
+}
+
+// Action is an action on the selected resource of a list, run with a key.
+type Action[T any] struct {
+	// Key is the rune of the action, or "enter".
+	Key   string
+	Label string
+	Run   func(h Host, item *T)
+}
+
+// Kind declares a kind of resources and holds the state of its list page.
+type Kind[T any] struct {
+	Title    string
+	PageID   string
+	Shortcut tcell.Key
+	Columns  []Column[T]
+	// Fetch fetches a page of the resources of a project and region from its token, empty for the
+	// first one. It returns the token of the next page, empty for the last one, see All.
+	Fetch func(ctx context.Context, project, region, pageToken string) ([]T, string, error)
+	// CacheKind is the kind of the cached lists, see listcache, empty for a list which isn't cached.
+	CacheKind string
+	// Name and Region return the name and region of a resource.
+	Name   func(T) string
+	Region func(T) string
+	// Describe tells whether the resources can be described, with <d>.
+	Describe bool
+	// Logs is the kind of the logs of the resources, see api_log.Filter, empty when they have none.
+	Logs string
+	// Console returns the URL of a resource in the console, or of the list when item is nil.
+	Console func(project string, item *T) string
+	// Actions are the actions of the kind, after the shared ones: refresh, describe and logs.
+	Actions []Action[T]
+
+	table *table.Table
+	items []T
+}
+
+var _ Page = (*Kind[any])(nil)
+
+// Registry is the list pages of the kinds, in the order of the app.
+type Registry []Page
+
+// Lookup returns the page of an ID, nil when it isn't the list of a kind.
+func (r Registry) Lookup(pageID string) Page {
+	for _, p := range r {
+		if p.ID() == pageID {
+			return p
+		}
+	}
+	return nil
+}
+
+// ByKey returns the page opened by a key, nil when there is none.
+func (r Registry) ByKey(key tcell.Key) Page {
+	for _, p := range r {
+		if p.Key() == key {
+			return p
+		}
+	}
+	return nil
+}
+
+// All returns the fetcher of a list which isn't paginated.
+func All[T any](list func(ctx context.Context, project, region string) ([]T, error)) func(ctx context.Context, project, region, pageToken string) ([]T, string, error) {
+	return func(ctx context.Context, project, region, pageToken string) ([]T, string, error) {
+		items, err := list(ctx, project, region)
+		return items, "", err
+	}
+}
+
+func (k *Kind[T]) ID() string { return k.PageID }
+
+func (k *Kind[T]) Key() tcell.Key { return k.Shortcut }
+
+// Build builds the table of the list.
+func (k *Kind[T]) Build(app *tview.Application) tview.Primitive {
+	k.table = table.New(k.Title)
+	k.setHeaders()
+	app.SetFocus(k.table.Table)
+	return k.table.Table
+}
+
+// Table returns the table of the list, once built.
+func (k *Kind[T]) Table() *table.Table {
+	return k.table
+}
+
+// Items returns the listed resources.
+func (k *Kind[T]) Items() []T {
+	return k.items
+}
+
+// Load populates the table with the resources.
+func (k *Kind[T]) Load(items []T) {
+	k.items = items
+	k.render()
+}
+
+// Selected returns the selected resource, nil when there is none.
+func (k *Kind[T]) Selected() *T {
+	row, _ := k.table.Table.GetSelection()
+	if row < 1 || row > len(k.items) {
+		return nil
+	}
+	return &k.items[row-1]
+}
+
+// LoadCached populates the table with the cached resources of the project and region, if any, marked
+// as stale, and returns when they were refreshed and whether there were some.
+func (k *Kind[T]) LoadCached(currentInfo info.Info) (time.Time, bool) {
+	if k.CacheKind == "" {
+		return time.Time{}, false
+	}
+	cached, refreshed, ok := listcache.Load[T](k.CacheKind, currentInfo.Project, currentInfo.Region)
+	if !ok {
+		return time.Time{}, false
+	}
+	k.Load(cached)
+	k.table.SetStale(refreshed)
+	return refreshed, true
+}
+
+// Save caches the loaded resources of the project and region, e.g. to be shown on the next startup.
+func (k *Kind[T]) Save(currentInfo info.Info) {
+	if k.CacheKind != "" {
+		listcache.Save(k.CacheKind, currentInfo.Project, currentInfo.Region, k.items)
+	}
+}
+
+// SetFailedRegions shows a warning badge listing the regions which could not be listed, if any.
+func (k *Kind[T]) SetFailedRegions(regions []string) {
+	k.table.SetFailedRegions(regions)
+}
+
+// Reload loads the first page of the resources of the project and region, showing the cached ones
+// meanwhile, and the next pages as the user scrolls. onResult is called with the result of each load,
+// a partial error when some regions could not be listed, or a *listcache.StaleError when the cached
+// resources could not be refreshed. The results are dropped once ctx is cancelled.
+func (k *Kind[T]) Reload(ctx context.Context, app *tview.Application, currentInfo info.Info, onResult func(error)) {
+	k.table.Table.Clear()
+	k.setHeaders()
+	k.table.Table.SetTitle(fmt.Sprintf(" %s loading ", k.Title))
+	k.table.SetLoadMore(nil)
+
+	// The cached resources are shown while they are refreshed.
+	refreshed, stale := k.LoadCached(currentInfo)
+
+	app.SetFocus(k.table.Table)
+
+	go func() {
+		result, next, err := k.Fetch(ctx, currentInfo.Project, currentInfo.Region, "")
+
+		app.QueueUpdateDraw(func() {
+			// The result of a cancelled load is stale, e.g. of the previous project.
+			if ctx.Err() != nil {
+				return
+			}
+			// The cached resources are kept when they can't be refreshed, e.g. while offline.
+			if err != nil && stale && api_region.Failed(err) == nil {
+				onResult(&listcache.StaleError{Err: err, Refreshed: refreshed})
+				return
+			}
+			k.items = result
+			if err == nil {
+				k.Save(currentInfo)
+			}
+
+			defer func() {
+				if len(k.items) == 0 {
+					k.table.Table.Clear()
+					k.setHeaders()
+				}
+				onResult(err)
+			}()
+
+			// The resources of the regions which could be listed are still rendered.
+			failed := api_region.Failed(err)
+			if err != nil && failed == nil {
+				return
+			}
+
+			k.render()
+			k.table.SetFailedRegions(failed)
+			k.LoadMore(ctx, app, currentInfo, next, onResult)
+		})
+	}()
+}
+
+// LoadMore loads the next pages of resources as the user scrolls, from the token of the next page,
+// empty when all the resources are loaded.
+func (k *Kind[T]) LoadMore(ctx context.Context, app *tview.Application, currentInfo info.Info, pageToken string, onResult func(error)) {
+	if pageToken == "" {
+		k.table.SetLoadMore(nil)
+		return
+	}
+	// The lists don't return their total, only whether there are more pages to load.
+	k.table.Table.SetTitle(fmt.Sprintf(" %s (%s) ", k.Title, table.Count(len(k.items), 0, true)))
+	k.table.SetLoadMore(func() {
+		go k.loadPage(ctx, app, currentInfo, pageToken, onResult)
+	})
+}
+
+// loadPage loads the page of resources of the token and appends it to the table.
+func (k *Kind[T]) loadPage(ctx context.Context, app *tview.Application, currentInfo info.Info, pageToken string, onResult func(error)) {
+	result, next, err := k.Fetch(ctx, currentInfo.Project, currentInfo.Region, pageToken)
+
+	app.QueueUpdateDraw(func() {
+		// The result of a cancelled load is stale, e.g. of the previous project.
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			onResult(err)
+			return
+		}
+
+		k.items = append(k.items, result...)
+		k.render()
+		k.Save(currentInfo)
+		k.LoadMore(ctx, app, currentInfo, next, onResult)
+		onResult(nil)
+	})
+}
+
+// Shortcuts shows the shared shortcuts and the ones of the actions in the footer.
+func (k *Kind[T]) Shortcuts() {
+	shortcuts := []string{shortcut("r", "Refresh")}
+	if k.Describe {
+		shortcuts = append(shortcuts, shortcut("d", "Describe"))
+	}
+	if k.Logs != "" {
+		shortcuts = append(shortcuts, shortcut("l", "Logs"))
+	}
+	for _, a := range k.Actions {
+		shortcuts = append(shortcuts, shortcut(a.Key, a.Label))
+	}
+
+	footer.ContextShortcutView.Clear()
+	footer.ContextShortcutView.SetText(strings.Join(shortcuts, "  "))
+}
+
+// HandleKey runs the shared action or the one of the kind of a key on the selected resource.
+func (k *Kind[T]) HandleKey(event *tcell.EventKey, h Host) bool {
+	key := keyName(event)
+	switch {
+	case key == "":
+		return false
+	case key == "r":
+		h.SwitchTo(k.PageID)
+		return true
+	case key == "d" && k.Describe:
+		if item := k.Selected(); item != nil {
+			name := k.Name(*item)
+			h.OpenModal(describe.MODAL_PAGE_ID, func(close func()) tview.Primitive {
+				return describe.DescribeModal(h.App(), item, name, close)
+			})
+		}
+		return true
+	case key == "l" && k.Logs != "":
+		if item := k.Selected(); item != nil {
+			k.openLogs(h, *item)
+		}
+		return true
+	}
+
+	for _, a := range k.Actions {
+		if a.Key == key {
+			if item := k.Selected(); item != nil {
+				a.Run(h, item)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// openLogs opens the logs of a resource.
+func (k *Kind[T]) openLogs(h Host, item T) {
+	name := k.Name(item)
+	filter, err := api_log.Filter(k.Logs, name, k.Region(item))
+	if err != nil {
+		h.ShowError(err)
+		return
+	}
+	h.OpenModal(log.MODAL_PAGE_ID, func(close func()) tview.Primitive {
+		return log.LogModal(h.App(), h.Info().Project, filter, name, close)
+	})
+}
+
+// ConsoleURL returns the URL of the selected resource in the console, or of the list when none is
+// selected, empty when the kind has none.
+func (k *Kind[T]) ConsoleURL(project string) string {
+	if k.Console == nil {
+		return ""
+	}
+	return k.Console(project, k.Selected())
+}
+
+func (k *Kind[T]) setHeaders() {
+	headers := make([]string, len(k.Columns))
+	expansions := make([]int, len(k.Columns))
+	for i, c := range k.Columns {
+		headers[i], expansions[i] = c.Header, c.Expansion
+	}
+	k.table.SetHeadersWithExpansions(headers, expansions)
+}
+
+func (k *Kind[T]) render() {
+	k.table.Table.Clear()
+	k.setHeaders()
+
+	for i, item := range k.items {
+		for j, c := range k.Columns {
+			k.table.Table.SetCell(i+1, j, tview.NewTableCell(c.Value(item)))
+		}
+	}
+
+	k.table.Table.SetTitle(fmt.Sprintf(" %s (%d) ", k.Title, len(k.items)))
+}
+
+func shortcut(key, label string) string {
+	return fmt.Sprintf("[dodgerblue]<%s> [white]%s", key, label)
+}
+
+// keyName returns the name of the key of an event, as in Action.Key, empty for the other keys.
+func keyName(event *tcell.EventKey) string {
+	switch event.Key() {
+	case tcell.KeyEnter:
+		return "enter"
+	case tcell.KeyRune:
+		return string(event.Rune())
+	}
+	return ""
+}
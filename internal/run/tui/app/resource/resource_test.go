@@ -0,0 +1,353 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
+	api_region "github.com/JulienBreux/run-cli/internal/run/api/region"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/describe"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/log"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource/resourcetest"
+	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+type thing struct {
+	Name   string
+	Region string
+}
+
+func newKind(fetch func(ctx context.Context, project, region, pageToken string) ([]thing, string, error)) *Kind[thing] {
+	return &Kind[thing]{
+		Title:    "Things",
+		PageID:   "things-list",
+		Shortcut: tcell.KeyCtrlT,
+		Columns: []Column[thing]{
+			{Header: "NAME", Expansion: 2, Value: func(t thing) string { return t.Name }},
+			{Header: "REGION", Expansion: 1, Value: func(t thing) string { return t.Region }},
+		},
+		Fetch:     fetch,
+		CacheKind: "things",
+		Name:      func(t thing) string { return t.Name },
+		Region:    func(t thing) string { return t.Region },
+		Describe:  true,
+		Logs:      api_log.KindService,
+		Console: func(project string, t *thing) string {
+			if t == nil {
+				return "list/" + project
+			}
+			return "detail/" + project + "/" + t.Name
+		},
+		Actions: []Action[thing]{
+			{Key: "o", Label: "Open URL", Run: func(h Host, t *thing) { h.OpenURL("https://" + t.Name) }},
+			{Key: "enter", Label: "Details", Run: func(h Host, t *thing) { h.SwitchTo("thing-dashboard") }},
+		},
+	}
+}
+
+var _ Host = &resourcetest.Host{}
+
+func newApp(t *testing.T) *tview.Application {
+	app := tview.NewApplication()
+	simScreen := tcell.NewSimulationScreen("UTF-8")
+	if err := simScreen.Init(); err != nil {
+		t.Fatalf("failed to init sim screen: %v", err)
+	}
+	app.SetScreen(simScreen)
+	return app
+}
+
+// reload reloads the kind and returns the result of the first load.
+func reload(t *testing.T, k *Kind[thing], currentInfo info.Info) error {
+	app := newApp(t)
+	var result error
+	k.Reload(context.Background(), app, currentInfo, func(err error) {
+		result = err
+		app.Stop()
+	})
+
+	go func() {
+		time.Sleep(2 * time.Second)
+		app.Stop()
+	}()
+	assert.NoError(t, app.Run())
+	return result
+}
+
+func TestBuild(t *testing.T) {
+	k := newKind(nil)
+	assert.NotNil(t, k.Build(tview.NewApplication()))
+	assert.Equal(t, "Things", k.Table().Title)
+	assert.Equal(t, "NAME", k.Table().Table.GetCell(0, 0).Text)
+	assert.Equal(t, "things-list", k.ID())
+	assert.Equal(t, tcell.KeyCtrlT, k.Key())
+}
+
+func TestLoadAndSelected(t *testing.T) {
+	k := newKind(nil)
+	k.Build(tview.NewApplication())
+
+	k.Load([]thing{{Name: "t1", Region: "r1"}, {Name: "t2", Region: "r2"}})
+	assert.Equal(t, 3, k.Table().Table.GetRowCount())
+	assert.Equal(t, "r2", k.Table().Table.GetCell(2, 1).Text)
+	assert.Contains(t, k.Table().Table.GetTitle(), "Things (2)")
+	assert.Len(t, k.Items(), 2)
+
+	k.Table().Table.Select(2, 0)
+	assert.Equal(t, "t2", k.Selected().Name)
+
+	// The header isn't a resource.
+	k.Table().Table.Select(0, 0)
+	assert.Nil(t, k.Selected())
+
+	k.Load(nil)
+	k.Table().Table.Select(1, 0)
+	assert.Nil(t, k.Selected())
+}
+
+func TestReload(t *testing.T) {
+	k := newKind(func(ctx context.Context, project, region, pageToken string) ([]thing, string, error) {
+		return []thing{{Name: "t1"}}, "", nil
+	})
+	k.Build(tview.NewApplication())
+
+	assert.NoError(t, reload(t, k, info.Info{}))
+	assert.Equal(t, 2, k.Table().Table.GetRowCount())
+	assert.Equal(t, "t1", k.Table().Table.GetCell(1, 0).Text)
+}
+
+func TestReload_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fetched := make(chan struct{})
+	k := newKind(func(ctx context.Context, project, region, pageToken string) ([]thing, string, error) {
+		defer close(fetched)
+		cancel()
+		return []thing{{Name: "stale"}}, "", nil
+	})
+	app := newApp(t)
+	k.Build(app)
+
+	called := false
+	k.Reload(ctx, app, info.Info{}, func(err error) {
+		called = true
+	})
+
+	go func() {
+		<-fetched
+		time.Sleep(100 * time.Millisecond)
+		app.QueueUpdate(app.Stop)
+	}()
+	assert.NoError(t, app.Run())
+
+	assert.False(t, called)
+	assert.Empty(t, k.Items())
+	assert.Equal(t, 1, k.Table().Table.GetRowCount())
+}
+
+func TestReload_Error(t *testing.T) {
+	k := newKind(func(ctx context.Context, project, region, pageToken string) ([]thing, string, error) {
+		return nil, "", errors.New("fetch error")
+	})
+	k.Build(tview.NewApplication())
+
+	assert.Error(t, reload(t, k, info.Info{}))
+	assert.Equal(t, 1, k.Table().Table.GetRowCount())
+}
+
+func TestReload_Partial(t *testing.T) {
+	k := newKind(func(ctx context.Context, project, region, pageToken string) ([]thing, string, error) {
+		return []thing{{Name: "t1"}}, "", &api_region.PartialError{
+			Errors: map[string]error{"europe-west1": errors.New("permission denied")},
+			Total:  2,
+		}
+	})
+	k.Build(tview.NewApplication())
+
+	assert.Error(t, reload(t, k, info.Info{}))
+
+	// The resources of the other regions are rendered, with a warning badge
+	assert.Equal(t, 2, k.Table().Table.GetRowCount())
+	assert.Contains(t, k.Table().Table.GetTitle(), "⚠ europe-west1")
+}
+
+func TestReload_Cached(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	listcache.SetEnabled(true)
+	defer listcache.SetEnabled(false)
+
+	// The refreshed resources are cached.
+	var fetch func() ([]thing, error)
+	k := newKind(func(ctx context.Context, project, region, pageToken string) ([]thing, string, error) {
+		things, err := fetch()
+		return things, "", err
+	})
+	k.Build(tview.NewApplication())
+	fetch = func() ([]thing, error) {
+		return []thing{{Name: "t1"}, {Name: "t2"}}, nil
+	}
+	assert.NoError(t, reload(t, k, info.Info{Project: "p", Region: "r"}))
+
+	// The cached resources are shown at once, and kept when they can't be refreshed.
+	k.Load(nil)
+	refreshing := make(chan struct{})
+	fetch = func() ([]thing, error) {
+		<-refreshing
+		return nil, errors.New("network is unreachable")
+	}
+	app := newApp(t)
+	var result error
+	k.Reload(context.Background(), app, info.Info{Project: "p", Region: "r"}, func(err error) {
+		result = err
+		app.Stop()
+	})
+	assert.Equal(t, 3, k.Table().Table.GetRowCount())
+	assert.Contains(t, k.Table().Table.GetTitle(), "refreshed")
+	close(refreshing)
+
+	go func() {
+		time.Sleep(2 * time.Second)
+		app.Stop()
+	}()
+	assert.NoError(t, app.Run())
+
+	var stale *listcache.StaleError
+	assert.ErrorAs(t, result, &stale)
+	assert.Equal(t, 3, k.Table().Table.GetRowCount())
+	assert.Equal(t, "t2", k.Table().Table.GetCell(2, 0).Text)
+
+	// A list which isn't cached has nothing to show.
+	k.CacheKind = ""
+	_, ok := k.LoadCached(info.Info{Project: "p", Region: "r"})
+	assert.False(t, ok)
+}
+
+func TestLoadMore(t *testing.T) {
+	k := newKind(func(ctx context.Context, project, region, pageToken string) ([]thing, string, error) {
+		if pageToken == "" {
+			return []thing{{Name: "t1"}}, "page-2", nil
+		}
+		return []thing{{Name: "t2"}}, "", nil
+	})
+	app := newApp(t)
+	k.Build(app)
+
+	// The next page is loaded as the user scrolls, at once with a few rows.
+	var results []error
+	var titles []string
+	k.Reload(context.Background(), app, info.Info{}, func(err error) {
+		results = append(results, err)
+		titles = append(titles, k.Table().Table.GetTitle())
+		if len(results) == 2 {
+			app.Stop()
+		}
+	})
+	go func() {
+		time.Sleep(2 * time.Second)
+		app.Stop()
+	}()
+	assert.NoError(t, app.Run())
+
+	assert.Equal(t, []error{nil, nil}, results)
+	// The total isn't known while there are more pages to load.
+	assert.Equal(t, " Things (loaded 1, scroll for more) ", titles[0])
+	assert.Contains(t, k.Table().Table.GetTitle(), "Things (2)")
+	assert.Len(t, k.Items(), 2)
+	assert.Equal(t, "t2", k.Table().Table.GetCell(2, 0).Text)
+}
+
+func TestShortcuts(t *testing.T) {
+	_ = footer.New()
+	k := newKind(nil)
+
+	k.Shortcuts()
+	assert.Equal(t, "<r> Refresh  <d> Describe  <l> Logs  <o> Open URL  <enter> Details", footer.ContextShortcutView.GetText(true))
+
+	// The shared actions are left out when the kind doesn't support them.
+	k.Describe, k.Logs = false, ""
+	k.Shortcuts()
+	assert.Equal(t, "<r> Refresh  <o> Open URL  <enter> Details", footer.ContextShortcutView.GetText(true))
+}
+
+func TestHandleKey(t *testing.T) {
+	k := newKind(nil)
+	h := &resourcetest.Host{Application: tview.NewApplication()}
+	k.Build(h.Application)
+	k.Load([]thing{{Name: "t1", Region: "us-central1"}})
+	k.Table().Table.Select(1, 0)
+
+	key := func(r rune) *tcell.EventKey { return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone) }
+
+	assert.True(t, k.HandleKey(key('r'), h))
+	assert.True(t, k.HandleKey(key('d'), h))
+	assert.True(t, k.HandleKey(key('l'), h))
+	assert.True(t, k.HandleKey(key('o'), h))
+	assert.True(t, k.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), h))
+	assert.False(t, k.HandleKey(key('z'), h))
+	assert.False(t, k.HandleKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), h))
+
+	assert.Equal(t, []string{"things-list", "thing-dashboard"}, h.Switched)
+	assert.Equal(t, []string{describe.MODAL_PAGE_ID, log.MODAL_PAGE_ID}, h.Modals)
+	assert.Equal(t, []string{"https://t1"}, h.URLs)
+
+	// The keys of the kind are consumed without a selection, but do nothing.
+	h = &resourcetest.Host{Application: h.Application}
+	k.Table().Table.Select(0, 0)
+	assert.True(t, k.HandleKey(key('d'), h))
+	assert.True(t, k.HandleKey(key('o'), h))
+	assert.Empty(t, h.Modals)
+	assert.Empty(t, h.URLs)
+
+	// The describe and logs keys are left to the app when the kind has none.
+	k.Describe, k.Logs = false, ""
+	assert.False(t, k.HandleKey(key('d'), h))
+	assert.False(t, k.HandleKey(key('l'), h))
+
+	// A log filter which can't be built is reported.
+	k.Logs = "unknown"
+	k.Table().Table.Select(1, 0)
+	assert.True(t, k.HandleKey(key('l'), h))
+	assert.Len(t, h.Errors, 1)
+}
+
+func TestConsoleURL(t *testing.T) {
+	k := newKind(nil)
+	k.Build(tview.NewApplication())
+
+	assert.Equal(t, "list/p", k.ConsoleURL("p"))
+	k.Load([]thing{{Name: "t1"}})
+	k.Table().Table.Select(1, 0)
+	assert.Equal(t, "detail/p/t1", k.ConsoleURL("p"))
+
+	k.Console = nil
+	assert.Empty(t, k.ConsoleURL("p"))
+}
+
+func TestRegistry(t *testing.T) {
+	things := newKind(nil)
+	others := newKind(nil)
+	others.PageID, others.Shortcut = "others-list", tcell.KeyCtrlU
+	r := Registry{things, others}
+
+	assert.Same(t, others, r.Lookup("others-list"))
+	assert.Nil(t, r.Lookup("dashboard"))
+	assert.Same(t, things, r.ByKey(tcell.KeyCtrlT))
+	assert.Nil(t, r.ByKey(tcell.KeyCtrlA))
+}
+
+func TestAll(t *testing.T) {
+	fetch := All(func(ctx context.Context, project, region string) ([]thing, error) {
+		return []thing{{Name: project + "/" + region}}, nil
+	})
+
+	things, next, err := fetch(context.Background(), "p", "r", "")
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Equal(t, []thing{{Name: "p/r"}}, things)
+}
@@ -0,0 +1,41 @@
+// Package resourcetest provides a fake host for the tests of the kinds of resources, see resource.Host.
+package resourcetest
+
+import (
+	"context"
+
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
+	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
+	"github.com/rivo/tview"
+)
+
+// Host records the actions run on it, in project p. The operations are started at once.
+type Host struct {
+	Application *tview.Application
+	// Modals are the IDs of the opened modals, Modal is the last one.
+	Modals []string
+	Modal  tview.Primitive
+	// Switched are the IDs of the pages switched to.
+	Switched []string
+	URLs     []string
+	Errors   []error
+}
+
+func (h *Host) App() *tview.Application { return h.Application }
+
+func (h *Host) Info() info.Info { return info.Info{Project: "p"} }
+
+func (h *Host) OpenModal(id string, build func(close func()) tview.Primitive) {
+	h.Modals = append(h.Modals, id)
+	h.Modal = build(func() {})
+}
+
+func (h *Host) SwitchTo(pageID string) { h.Switched = append(h.Switched, pageID) }
+
+func (h *Host) OpenURL(u string) { h.URLs = append(h.URLs, u) }
+
+func (h *Host) ShowError(err error) { h.Errors = append(h.Errors, err) }
+
+func (h *Host) Start(start func(ctx context.Context) (*api_operation.Operation, error)) {
+	_, _ = start(context.Background())
+}
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	api_revision "github.com/JulienBreux/run-cli/internal/run/api/service/revision"
@@ -41,7 +43,7 @@ var (
 	tabs      = []string{"Revisions", "Observability", "Networking", "Security"}
 )
 
-var listRevisionsFunc = api_revision.List
+var listRevisionsPageFunc = api_revision.ListPage
 
 // Dashboard returns the dashboard primitive.
 func Dashboard(app *tview.Application) *tview.Flex {
@@ -100,7 +102,7 @@ func buildRevisionsTab() tview.Primitive {
 		SetWrap(true)
 	revisionsDetail.SetBorder(true).SetTitle(" Revision Details ")
 
-	revisionsTable.Table.SetSelectionChangedFunc(func(row, column int) {
+	revisionsTable.SetSelectionChangedFunc(func(row, column int) {
 		updateRevisionDetail(row)
 	})
 
@@ -324,7 +326,7 @@ func updateRevisionDetail(row int) {
 }
 
 // DashboardReload reloads the dashboard for a specific service.
-func DashboardReload(app *tview.Application, currentInfo info.Info, service *model_service.Service, onResult func(error)) {
+func DashboardReload(ctx context.Context, app *tview.Application, currentInfo info.Info, service *model_service.Service, onResult func(error)) {
 	dashboardService = service
 	dashboardHeader.SetText(fmt.Sprintf("[lightcyan]Service: [white]%s", service.Name))
 	activeTab = 0
@@ -332,61 +334,98 @@ func DashboardReload(app *tview.Application, currentInfo info.Info, service *mod
 	updateNetworkingTab()
 	updateSecurityTab()
 
-	go func() {
-		var err error
-		dashboardRevisions, err = listRevisionsFunc(currentInfo.Project, service.Region, service.Name)
+	revisionsTable.SetLoadMore(nil)
+	go loadRevisions(ctx, app, currentInfo, service, "", onResult)
+}
+
+// loadRevisions loads a page of revisions of a service, the first one for an empty token, and the next
+// ones as the user scrolls.
+func loadRevisions(ctx context.Context, app *tview.Application, currentInfo info.Info, service *model_service.Service, pageToken string, onResult func(error)) {
+	revisions, next, err := listRevisionsPageFunc(ctx, currentInfo.Project, service.Region, service.Name, pageToken)
+
+	app.QueueUpdateDraw(func() {
+		// The result of a cancelled load is stale, e.g. of the previous service.
+		if ctx.Err() != nil {
+			return
+		}
 
-		app.QueueUpdateDraw(func() {
+		first := pageToken == ""
+		if first {
+			dashboardRevisions = nil
 			revisionsTable.Table.Clear()
 			revisionsTable.SetHeadersWithExpansions(
 				[]string{"NAME", "TRAFFIC", "DEPLOYED", "REVISION TAGS"},
 				[]int{2, 1, 1, 2},
 			)
+		}
 
-			if err != nil {
-				onResult(err)
-				return
-			}
+		if err != nil {
+			onResult(err)
+			return
+		}
+
+		from := len(dashboardRevisions)
+		dashboardRevisions = append(dashboardRevisions, revisions...)
+		renderRevisions(service, from)
+
+		revisionsTable.Table.SetTitle(fmt.Sprintf(" Revisions (%s) ", table.Count(len(dashboardRevisions), revisionNumber(service.LatestCreatedRevision), next != "")))
+		if first && len(dashboardRevisions) > 0 {
+			revisionsTable.Table.Select(1, 0)
+			updateRevisionDetail(1)
+		}
+		if next != "" {
+			revisionsTable.SetLoadMore(func() {
+				go loadRevisions(ctx, app, currentInfo, service, next, onResult)
+			})
+		}
+		onResult(nil)
+	})
+}
 
-			for i, rev := range dashboardRevisions {
-				row := i + 1
-
-				traffic := "0%"
-				tags := ""
-				for _, ts := range service.TrafficStatuses {
-					isLatestMatch := ts.Type == "TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST" && rev.Name == service.LatestReadyRevision
-					isNamedMatch := ts.Revision == rev.Name
-
-					if isLatestMatch || isNamedMatch {
-						if ts.Percent > 0 {
-							traffic = fmt.Sprintf("%d%%", ts.Percent)
-							if isLatestMatch {
-								traffic += " (to latest)"
-							}
-						}
-						if ts.Tag != "" {
-							if tags != "" {
-								tags += ", "
-							}
-							tags += ts.Tag
-						}
+// renderRevisions renders the rows of the revisions from the given index.
+func renderRevisions(service *model_service.Service, from int) {
+	for i := from; i < len(dashboardRevisions); i++ {
+		rev := dashboardRevisions[i]
+		row := i + 1
+
+		traffic := "0%"
+		tags := ""
+		for _, ts := range service.TrafficStatuses {
+			isLatestMatch := ts.Type == "TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST" && rev.Name == service.LatestReadyRevision
+			isNamedMatch := ts.Revision == rev.Name
+
+			if isLatestMatch || isNamedMatch {
+				if ts.Percent > 0 {
+					traffic = fmt.Sprintf("%d%%", ts.Percent)
+					if isLatestMatch {
+						traffic += " (to latest)"
 					}
 				}
-
-				revisionsTable.Table.SetCell(row, 0, tview.NewTableCell(rev.Name))
-				revisionsTable.Table.SetCell(row, 1, tview.NewTableCell(traffic))
-				revisionsTable.Table.SetCell(row, 2, tview.NewTableCell(humanize.Time(rev.CreateTime)))
-				revisionsTable.Table.SetCell(row, 3, tview.NewTableCell(tags))
+				if ts.Tag != "" {
+					if tags != "" {
+						tags += ", "
+					}
+					tags += ts.Tag
+				}
 			}
+		}
 
-			revisionsTable.Table.SetTitle(fmt.Sprintf(" Revisions (%d) ", len(dashboardRevisions)))
-			if len(dashboardRevisions) > 0 {
-				revisionsTable.Table.Select(1, 0)
-				updateRevisionDetail(1)
-			}
-			onResult(nil)
-		})
-	}()
+		revisionsTable.Table.SetCell(row, 0, tview.NewTableCell(rev.Name))
+		revisionsTable.Table.SetCell(row, 1, tview.NewTableCell(traffic))
+		revisionsTable.Table.SetCell(row, 2, tview.NewTableCell(humanize.Time(rev.CreateTime)))
+		revisionsTable.Table.SetCell(row, 3, tview.NewTableCell(tags))
+	}
+}
+
+// revisionNumber returns the number of a revision from its generated name, e.g. 42 for api-00042-abc,
+// 0 if it has none. It estimates the number of revisions of a service.
+func revisionNumber(name string) int {
+	parts := strings.Split(name, "-")
+	if len(parts) < 3 {
+		return 0
+	}
+	n, _ := strconv.Atoi(parts[len(parts)-2])
+	return n
 }
 
 // DashboardShortcuts sets the shortcuts for the dashboard.
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -60,15 +61,15 @@ func TestUpdateTabs(t *testing.T) {
 
 func TestDashboardReload(t *testing.T) {
 	// Setup Mocks
-	origList := listRevisionsFunc
-	defer func() { listRevisionsFunc = origList }()
+	origList := listRevisionsPageFunc
+	defer func() { listRevisionsPageFunc = origList }()
 	
 	called := false
-	listRevisionsFunc = func(project, region, service string) ([]model_revision.Revision, error) {
+	listRevisionsPageFunc = func(ctx context.Context, project, region, service, pageToken string) ([]model_revision.Revision, string, error) {
 		called = true
 		return []model_revision.Revision{
 			{Name: "rev1", CreateTime: time.Now()},
-		}, nil
+		}, "", nil
 	}
 	
 	// Init
@@ -84,14 +85,14 @@ func TestDashboardReload(t *testing.T) {
 	svc := &model_service.Service{Name: "s1", Region: "r1"}
 	done := make(chan struct{})
 	
-	DashboardReload(app, info.Info{Project: "p"}, svc, func(err error) {
+	DashboardReload(context.Background(), app, info.Info{Project: "p"}, svc, func(err error) {
 		assert.NoError(t, err)
 		close(done)
 	})
 	
 	// Since QueueUpdateDraw might not execute without running App, 
-	// we might need to run app or rely on the fact that we mocked listRevisionsFunc.
-	// Wait, listRevisionsFunc is called synchronously? No, inside goroutine.
+	// we might need to run app or rely on the fact that we mocked listRevisionsPageFunc.
+	// Wait, listRevisionsPageFunc is called synchronously? No, inside goroutine.
 	
 	// To test this properly without race conditions or hanging, we should use the SimulationScreen pattern 
 	// similar to Spinner test if we really want to execute the callback.
@@ -142,11 +143,11 @@ func TestDashboardInputCapture(t *testing.T) {
 }
 
 func TestDashboardReload_Error(t *testing.T) {
-	origList := listRevisionsFunc
-	defer func() { listRevisionsFunc = origList }()
+	origList := listRevisionsPageFunc
+	defer func() { listRevisionsPageFunc = origList }()
 	
-	listRevisionsFunc = func(project, region, service string) ([]model_revision.Revision, error) {
-		return nil, assert.AnError
+	listRevisionsPageFunc = func(ctx context.Context, project, region, service, pageToken string) ([]model_revision.Revision, string, error) {
+		return nil, "", assert.AnError
 	}
 	
 	app := tview.NewApplication()
@@ -160,7 +161,7 @@ func TestDashboardReload_Error(t *testing.T) {
 	svc := &model_service.Service{Name: "s1"}
 	done := make(chan struct{})
 	
-	DashboardReload(app, info.Info{}, svc, func(err error) {
+	DashboardReload(context.Background(), app, info.Info{}, svc, func(err error) {
 		assert.Error(t, err)
 		close(done)
 	})
@@ -2,10 +2,13 @@ package scale
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_service "github.com/JulienBreux/run-cli/internal/run/api/service"
 	model_service "github.com/JulienBreux/run-cli/internal/run/model/service"
 	"github.com/JulienBreux/run-cli/internal/run/tui/component/spinner"
@@ -33,6 +36,13 @@ func Modal(app *tview.Application, service *model_service.Service, pages *tview.
 	statusSpinner := spinner.New(app)
 	statusSpinner.SetTextAlign(tview.AlignCenter)
 
+	// Three-way diff of the fields changed concurrently, shown on conflict
+	conflictView := tview.NewTextView().SetDynamicColors(true)
+	conflictRows := 0
+
+	// Scaling the change is made from, to detect the concurrent changes
+	base := service.Scaling
+
 	// Container for Form + Status
 	container := tview.NewFlex().SetDirection(tview.FlexRow)
 	container.SetBorder(true).
@@ -81,16 +91,31 @@ func Modal(app *tview.Application, service *model_service.Service, pages *tview.
 
 	// Assemble Container
 	container.AddItem(form, 0, 1, true)
+	container.AddItem(conflictView, 0, 0, false)
 	container.AddItem(statusSpinner, 1, 0, false)
 
 	// Centering with Grid
 	// Columns: auto, 50, auto (Centered width 50)
 	// Rows: auto, 10, auto (Centered height 10)
+	formRows := 10
 	grid := tview.NewGrid().
 		SetColumns(0, 50, 0).
-		SetRows(0, 10, 0).
+		SetRows(0, formRows, 0).
 		AddItem(container, 1, 1, 1, 1, 0, 0, true)
 
+	// resize fits the grid to the form and the conflicts
+	resize := func() {
+		grid.SetRows(0, formRows+conflictRows, 0)
+	}
+
+	// showConflict shows the three-way diff of a conflict
+	showConflict := func(conflict *client.ConflictError) {
+		conflictRows = len(conflict.Fields)
+		conflictView.SetText(tview.Escape(conflict.Diff()))
+		container.ResizeItem(conflictView, conflictRows, 0)
+		resize()
+	}
+
 	// Capture escape key on the Container
 	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEscape {
@@ -109,13 +134,14 @@ func Modal(app *tview.Application, service *model_service.Service, pages *tview.
 		if mode == "Manual" {
 			form.AddFormItem(manualInstancesField)
 			// Rows: auto, 10, auto (Centered height 10)
-			grid.SetRows(0, 10, 0)
+			formRows = 10
 		} else { // Automatic
 			form.AddFormItem(minInstancesField)
 			form.AddFormItem(maxInstancesField)
 			// Rows: auto, 12, auto (Centered height 12)
-			grid.SetRows(0, 12, 0)
+			formRows = 12
 		}
+		resize()
 	}
 
 	// Add buttons
@@ -130,18 +156,30 @@ func Modal(app *tview.Application, service *model_service.Service, pages *tview.
 		}
 
 		// Start Animation
-		statusSpinner.Start("[yellow]Operation in progress... (Please wait)")
+		statusSpinner.Start("[yellow]Starting the update...")
 
 		// Call API
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute*2)
 			defer cancel()
 
-			_, err := api_service.UpdateScaling(ctx, service.Project, service.Region, service.Name, int32(min), int32(max), int32(manual))
+			op, err := api_service.UpdateScaling(ctx, service.Project, service.Region, service.Name, base, int32(min), int32(max), int32(manual))
+			if err == nil {
+				// The rollout is followed in the operations panel, tracked before queueing the update
+				// as it queues one too.
+				api_operation.Track(op)
+			}
 			app.QueueUpdateDraw(func() {
-				if err != nil {
+				var conflict *client.ConflictError
+				if errors.As(err, &conflict) {
+					// Once the diff is reviewed, saving again overwrites the concurrent change.
+					base = nil
+					showConflict(conflict)
+					statusSpinner.Stop("[red]Changed concurrently, save again to overwrite")
+				} else if err != nil {
 					statusSpinner.Stop(fmt.Sprintf("[red]Error: %v", err))
 				} else {
+					// The modal is closed at once.
 					statusSpinner.Stop("")
 					onCompletion()
 				}
@@ -2,10 +2,13 @@ package scale
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/JulienBreux/run-cli/internal/run/api/client"
+	api_operation "github.com/JulienBreux/run-cli/internal/run/api/operation"
 	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
 	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
 	"github.com/JulienBreux/run-cli/internal/run/tui/component/spinner"
@@ -32,6 +35,12 @@ func Modal(app *tview.Application, workerPool *model_workerpool.WorkerPool, page
 	statusSpinner := spinner.New(app)
 	statusSpinner.SetTextAlign(tview.AlignCenter)
 
+	// Three-way diff of the fields changed concurrently, shown on conflict
+	conflictView := tview.NewTextView().SetDynamicColors(true)
+
+	// Scaling the change is made from, to detect the concurrent changes
+	base := workerPool.Scaling
+
 	// Container for Form + Status
 	container := tview.NewFlex().SetDirection(tview.FlexRow)
 	container.SetBorder(true).
@@ -61,6 +70,9 @@ func Modal(app *tview.Application, workerPool *model_workerpool.WorkerPool, page
 
 	form.AddFormItem(instanceCountField)
 
+	// Set once the layout is built
+	var showConflict func(conflict *client.ConflictError)
+
 	// Add buttons
 	form.AddButton("Save", func() {
 		// Get values from fields
@@ -71,18 +83,30 @@ func Modal(app *tview.Application, workerPool *model_workerpool.WorkerPool, page
 		}
 
 		// Start Animation
-		statusSpinner.Start("[yellow]Operation in progress... (Please wait)")
+		statusSpinner.Start("[yellow]Starting the update...")
 
 		// Call API
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 			defer cancel()
 
-			_, err := api_workerpool.UpdateScaling(ctx, workerPool.Project, workerPool.Region, workerPool.DisplayName, int32(count))
+			op, err := api_workerpool.UpdateScaling(ctx, workerPool.Project, workerPool.Region, workerPool.DisplayName, base, int32(count))
+			if err == nil {
+				// The rollout is followed in the operations panel, tracked before queueing the update
+				// as it queues one too.
+				api_operation.Track(op)
+			}
 			app.QueueUpdateDraw(func() {
-				if err != nil {
+				var conflict *client.ConflictError
+				if errors.As(err, &conflict) {
+					// Once the diff is reviewed, saving again overwrites the concurrent change.
+					base = nil
+					showConflict(conflict)
+					statusSpinner.Stop("[red]Changed concurrently, save again to overwrite")
+				} else if err != nil {
 					statusSpinner.Stop(fmt.Sprintf("[red]Error: %v", err))
 				} else {
+					// The modal is closed at once.
 					statusSpinner.Stop("")
 					onCompletion()
 				}
@@ -110,6 +134,7 @@ func Modal(app *tview.Application, workerPool *model_workerpool.WorkerPool, page
 
 	// Assemble Container
 	container.AddItem(form, 0, 1, true)
+	container.AddItem(conflictView, 0, 0, false)
 	container.AddItem(statusSpinner, 1, 0, false)
 
 	// Centering with Grid
@@ -120,6 +145,13 @@ func Modal(app *tview.Application, workerPool *model_workerpool.WorkerPool, page
 		SetRows(0, 8, 0).
 		AddItem(container, 1, 1, 1, 1, 0, 0, true)
 
+	// showConflict shows the three-way diff of a conflict
+	showConflict = func(conflict *client.ConflictError) {
+		conflictView.SetText(tview.Escape(conflict.Diff()))
+		container.ResizeItem(conflictView, len(conflict.Fields), 0)
+		grid.SetRows(0, 8+len(conflict.Fields), 0)
+	}
+
 	// Capture escape key on the Container
 	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEscape {
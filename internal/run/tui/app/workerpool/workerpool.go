@@ -1,148 +1,78 @@
-// TODO: Refactor with service and job
 package workerpool
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	api_log "github.com/JulienBreux/run-cli/internal/run/api/log"
 	api_workerpool "github.com/JulienBreux/run-cli/internal/run/api/workerpool"
-	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
 	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
-	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
-	"github.com/JulienBreux/run-cli/internal/run/tui/component/table"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/listcache"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/workerpool/scale"
 	"github.com/dustin/go-humanize"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-var (
-	listHeaders = []string{
-		"NAME",
-		"REGION",
-		"LAST UPDATED",
-		"SCALING",
-		"MODIFIED BY",
-		"LABELS"}
-
-	listExpansions = []int{
-		2, // NAME
-		1, // REGION
-		2, // LAST UPDATED
-		2, // SCALING
-		2, // MODIFIED BY
-		3, // LABELS
-	}
-
-	listTable *table.Table
-	workers   []model_workerpool.WorkerPool
-)
-
 const (
 	LIST_PAGE_TITLE     = "Worker Pools"
 	LIST_PAGE_ID        = "workers-list"
 	LIST_PAGE_SHORTCUT  = tcell.KeyCtrlW
 	SCALE_MODAL_PAGE_ID = "scale-workerpool"
+
+	CONSOLE_LIST_URL   = "https://console.cloud.google.com/run/workerpools?project=%s"
+	CONSOLE_DETAIL_URL = "https://console.cloud.google.com/run/workerpools/details/%s/%s?project=%s"
 )
 
 var listWorkerPoolsFunc = api_workerpool.List
 
-// List returns a list of workers.
-func List(app *tview.Application) *table.Table {
-	listTable = table.New(LIST_PAGE_TITLE)
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-
-	app.SetFocus(listTable.Table)
-
-	return listTable
-}
-
-// Load populates the table with the provided list of worker pools.
-func Load(newWorkers []model_workerpool.WorkerPool) {
-	workers = newWorkers
-	render(workers)
-}
-
-func ListReload(app *tview.Application, currentInfo info.Info, onResult func(error)) {
-	listTable.Table.Clear()
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-	listTable.Table.SetTitle(fmt.Sprintf(" %s loading ", LIST_PAGE_TITLE))
-
-	app.SetFocus(listTable.Table)
-
-	go func() {
-		// Fetch real data
-		var err error
-		workers, err = listWorkerPoolsFunc(currentInfo.Project, currentInfo.Region)
-
-		app.QueueUpdateDraw(func() {
-			defer func() {
-				if len(workers) == 0 {
-					listTable.Table.Clear()
-					listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-				}
-				onResult(err)
-			}()
-
-			if err != nil {
-				return
+// Kind is the kind of the worker pools.
+var Kind = &resource.Kind[model_workerpool.WorkerPool]{
+	Title:    LIST_PAGE_TITLE,
+	PageID:   LIST_PAGE_ID,
+	Shortcut: LIST_PAGE_SHORTCUT,
+	Columns: []resource.Column[model_workerpool.WorkerPool]{
+		{Header: "NAME", Expansion: 2, Value: func(w model_workerpool.WorkerPool) string { return w.DisplayName }},
+		{Header: "REGION", Expansion: 1, Value: func(w model_workerpool.WorkerPool) string { return w.Region }},
+		{Header: "LAST UPDATED", Expansion: 2, Value: func(w model_workerpool.WorkerPool) string { return humanize.Time(w.UpdateTime) }},
+		{Header: "SCALING", Expansion: 2, Value: func(w model_workerpool.WorkerPool) string {
+			if w.Scaling == nil {
+				return "n/a"
 			}
-
-			render(workers)
-		})
-	}()
-}
-
-func render(workers []model_workerpool.WorkerPool) {
-	listTable.Table.Clear()
-	listTable.SetHeadersWithExpansions(listHeaders, listExpansions)
-
-	for i, w := range workers {
-		var labels []string
-		for k, v := range w.Labels {
-			labels = append(labels, fmt.Sprintf("%s: %s", k, v))
-		}
-
-		scaling := "n/a"
-		if w.Scaling != nil {
-			scaling = fmt.Sprintf("Manual: %d", w.Scaling.ManualInstanceCount)
+			return fmt.Sprintf("Manual: %d", w.Scaling.ManualInstanceCount)
+		}},
+		{Header: "MODIFIED BY", Expansion: 2, Value: func(w model_workerpool.WorkerPool) string { return w.LastModifier }},
+		{Header: "LABELS", Expansion: 3, Value: func(w model_workerpool.WorkerPool) string {
+			var labels []string
+			for k, v := range w.Labels {
+				labels = append(labels, fmt.Sprintf("%s: %s", k, v))
+			}
+			return strings.Join(labels, ", ")
+		}},
+	},
+	Fetch: resource.All(func(ctx context.Context, project, region string) ([]model_workerpool.WorkerPool, error) {
+		return listWorkerPoolsFunc(ctx, project, region)
+	}),
+	CacheKind: listcache.KindWorkerPools,
+	Name:      func(w model_workerpool.WorkerPool) string { return w.DisplayName },
+	Region:    func(w model_workerpool.WorkerPool) string { return w.Region },
+	Describe:  true,
+	Logs:      api_log.KindWorkerPool,
+	Console: func(project string, w *model_workerpool.WorkerPool) string {
+		if w == nil {
+			return fmt.Sprintf(CONSOLE_LIST_URL, project)
 		}
-
-		row := i + 1 // +1 for header row
-		listTable.Table.SetCell(row, 0, tview.NewTableCell(w.DisplayName))
-		listTable.Table.SetCell(row, 1, tview.NewTableCell(w.Region))
-		listTable.Table.SetCell(row, 2, tview.NewTableCell(humanize.Time(w.UpdateTime)))
-		listTable.Table.SetCell(row, 3, tview.NewTableCell(scaling))
-		listTable.Table.SetCell(row, 4, tview.NewTableCell(w.LastModifier))
-		listTable.Table.SetCell(row, 5, tview.NewTableCell(strings.Join(labels, ", ")))
-	}
-
-	// Refresh title
-	listTable.Table.SetTitle(fmt.Sprintf(" %s (%d) ", LIST_PAGE_TITLE, len(workers)))
-}
-
-// GetSelectedWorkerPool returns the Name and Region of the selected worker pool.
-func GetSelectedWorkerPool() (string, string) {
-	row, _ := listTable.Table.GetSelection()
-	if row < 1 { // Header row or no selection
-		return "", ""
-	}
-	// 0: Name (DisplayName), 1: Region
-	name := listTable.Table.GetCell(row, 0).Text
-	region := listTable.Table.GetCell(row, 1).Text
-	return name, region
-}
-
-// GetSelectedWorkerPoolFull returns the full workerpool object for the selected row.
-func GetSelectedWorkerPoolFull() *model_workerpool.WorkerPool {
-	row, _ := listTable.Table.GetSelection()
-	if row < 1 || len(workers) == 0 {
-		return nil
-	}
-	return &workers[row-1]
+		return fmt.Sprintf(CONSOLE_DETAIL_URL, w.Region, w.DisplayName, project)
+	},
+	Actions: []resource.Action[model_workerpool.WorkerPool]{
+		{Key: "s", Label: "Scale", Run: openScaleModal},
+	},
 }
 
-func Shortcuts() {
-	footer.ContextShortcutView.Clear()
-	shortcuts := `[dodgerblue]<r> [white]Refresh  [dodgerblue]<d> [white]Describe  [dodgerblue]<s> [white]Scale`
-	footer.ContextShortcutView.SetText(shortcuts)
+func openScaleModal(h resource.Host, w *model_workerpool.WorkerPool) {
+	h.OpenModal(scale.MODAL_PAGE_ID, func(close func()) tview.Primitive {
+		return scale.Modal(h.App(), w, nil, close)
+	})
 }
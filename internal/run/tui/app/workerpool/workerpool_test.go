@@ -1,194 +1,65 @@
 package workerpool
 
 import (
-	"errors"
 	"testing"
 	"time"
 
-	"github.com/JulienBreux/run-cli/internal/run/model/common/info"
 	model_workerpool "github.com/JulienBreux/run-cli/internal/run/model/workerpool"
 	model_scaling "github.com/JulienBreux/run-cli/internal/run/model/workerpool/scaling"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/resource/resourcetest"
+	"github.com/JulienBreux/run-cli/internal/run/tui/app/workerpool/scale"
 	"github.com/JulienBreux/run-cli/internal/run/tui/component/footer"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestList(t *testing.T) {
+func TestKind(t *testing.T) {
 	app := tview.NewApplication()
-	tbl := List(app)
-	assert.NotNil(t, tbl)
-	assert.Equal(t, LIST_PAGE_TITLE, tbl.Title)
-}
-
-func TestLoad(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
+	assert.NotNil(t, Kind.Build(app))
+	assert.Equal(t, LIST_PAGE_TITLE, Kind.Table().Title)
 
-	newWorkers := []model_workerpool.WorkerPool{
+	Kind.Load([]model_workerpool.WorkerPool{
 		{
-			Name:        "projects/p/locations/r/workerPools/pool-1",
-			DisplayName: "pool-1",
-			Region:      "us-central1",
-			UpdateTime:  time.Now(),
-			Scaling:     &model_scaling.Scaling{ManualInstanceCount: 2},
+			Name:         "projects/p/locations/us-central1/workerPools/pool-1",
+			DisplayName:  "pool-1",
+			Region:       "us-central1",
+			LastModifier: "user@example.com",
+			UpdateTime:   time.Now(),
+			Scaling:      &model_scaling.Scaling{ManualInstanceCount: 5},
+			Labels:       map[string]string{"env": "prod"},
 		},
-	}
-
-	Load(newWorkers)
-
-	assert.Equal(t, newWorkers, workers)
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "pool-1", listTable.Table.GetCell(1, 0).Text)
-}
-
-func TestListReload(t *testing.T) {
-	app := tview.NewApplication()
-	simScreen := tcell.NewSimulationScreen("UTF-8")
-	if err := simScreen.Init(); err != nil {
-		t.Fatalf("failed to init sim screen: %v", err)
-	}
-	app.SetScreen(simScreen)
-
-	List(app)
-
-	// Mock listWorkerPoolsFunc
-	originalListWorkerPoolsFunc := listWorkerPoolsFunc
-	defer func() { listWorkerPoolsFunc = originalListWorkerPoolsFunc }()
-
-	expectedWorkers := []model_workerpool.WorkerPool{
-		{DisplayName: "pool-reloaded"},
-	}
-	listWorkerPoolsFunc = func(projectID, region string) ([]model_workerpool.WorkerPool, error) {
-		return expectedWorkers, nil
-	}
-
-	ListReload(app, info.Info{}, func(err error) {
-		assert.NoError(t, err)
-		app.Stop()
+		{DisplayName: "pool-2"},
 	})
 
-	go func() {
-		time.Sleep(2 * time.Second)
-		app.Stop()
-	}()
-
-	err := app.Run()
-	assert.NoError(t, err)
-
-	assert.Equal(t, expectedWorkers, workers)
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "pool-reloaded", listTable.Table.GetCell(1, 0).Text)
-}
-
-func TestListReload_Error(t *testing.T) {
-	app := tview.NewApplication()
-	simScreen := tcell.NewSimulationScreen("UTF-8")
-	if err := simScreen.Init(); err != nil {
-		t.Fatalf("failed to init sim screen: %v", err)
-	}
-	app.SetScreen(simScreen)
-
-	List(app)
-
-	originalListWorkerPoolsFunc := listWorkerPoolsFunc
-	defer func() { listWorkerPoolsFunc = originalListWorkerPoolsFunc }()
-
-	listWorkerPoolsFunc = func(projectID, region string) ([]model_workerpool.WorkerPool, error) {
-		return nil, errors.New("fetch error")
-	}
-
-	ListReload(app, info.Info{}, func(err error) {
-		assert.Error(t, err)
-		app.Stop()
-	})
-
-	go func() {
-		time.Sleep(2 * time.Second)
-		app.Stop()
-	}()
-
-	err := app.Run()
-	assert.NoError(t, err)
+	tbl := Kind.Table().Table
+	assert.Equal(t, 3, tbl.GetRowCount())
+	assert.Equal(t, "pool-1", tbl.GetCell(1, 0).Text)
+	assert.Equal(t, "us-central1", tbl.GetCell(1, 1).Text)
+	assert.Equal(t, "Manual: 5", tbl.GetCell(1, 3).Text)
+	assert.Equal(t, "user@example.com", tbl.GetCell(1, 4).Text)
+	assert.Equal(t, "env: prod", tbl.GetCell(1, 5).Text)
+	assert.Equal(t, "n/a", tbl.GetCell(2, 3).Text)
+
+	// The console shows the list of worker pools, or the selected one.
+	tbl.Select(0, 0)
+	assert.Equal(t, "https://console.cloud.google.com/run/workerpools?project=p", Kind.ConsoleURL("p"))
+	tbl.Select(1, 0)
+	assert.Equal(t, "https://console.cloud.google.com/run/workerpools/details/us-central1/pool-1?project=p", Kind.ConsoleURL("p"))
 }
 
-func TestGetSelectedWorkerPool(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-
-	// Manually populate internal state
-	workers = []model_workerpool.WorkerPool{
-		{
-			Name:        "projects/p/locations/r/workerPools/pool-1",
-			DisplayName: "pool-1",
-			Region:      "us-central1",
-			UpdateTime:  time.Now(),
-			Scaling:     &model_scaling.Scaling{ManualInstanceCount: 2},
-		},
-	}
-
-	// Manually populate table
-	row := 1
-	listTable.Table.SetCell(row, 0, tview.NewTableCell("pool-1"))
-	listTable.Table.SetCell(row, 1, tview.NewTableCell("us-central1"))
-
-	// Select Row 1
-	listTable.Table.Select(row, 0)
+func TestActions(t *testing.T) {
+	h := &resourcetest.Host{Application: tview.NewApplication()}
+	Kind.Build(h.Application)
+	Kind.Load([]model_workerpool.WorkerPool{{DisplayName: "pool-1", Region: "us-central1"}})
+	Kind.Table().Table.Select(1, 0)
 
-	name, region := GetSelectedWorkerPool()
-	assert.Equal(t, "pool-1", name)
-	assert.Equal(t, "us-central1", region)
-
-	w := GetSelectedWorkerPoolFull()
-	assert.NotNil(t, w)
-	assert.Equal(t, "pool-1", w.DisplayName)
-
-	// Test header selection
-	listTable.Table.Select(0, 0)
-	name, region = GetSelectedWorkerPool()
-	assert.Equal(t, "", name)
-	assert.Equal(t, "", region)
-
-	wFull := GetSelectedWorkerPoolFull()
-	assert.Nil(t, wFull)
-}
-
-func TestGetSelectedWorkerPoolFull_Empty(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-	workers = []model_workerpool.WorkerPool{} // Ensure empty
+	assert.True(t, Kind.HandleKey(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone), h))
+	assert.False(t, Kind.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), h))
 
-	w := GetSelectedWorkerPoolFull()
-	assert.Nil(t, w)
-}
+	assert.Equal(t, []string{scale.MODAL_PAGE_ID}, h.Modals)
 
-func TestShortcuts(t *testing.T) {
 	_ = footer.New()
-
-	assert.NotPanics(t, func() {
-		Shortcuts()
-	})
-
-	assert.Contains(t, footer.ContextShortcutView.GetText(true), "Refresh")
-}
-
-func TestRender(t *testing.T) {
-	app := tview.NewApplication()
-	_ = List(app)
-
-	testWorkers := []model_workerpool.WorkerPool{
-		{
-			DisplayName: "pool-1",
-			Region:      "us-central1",
-			Scaling:     &model_scaling.Scaling{ManualInstanceCount: 5},
-			Labels:      map[string]string{"env": "prod"},
-		},
-	}
-
-	render(testWorkers)
-
-	assert.Equal(t, 2, listTable.Table.GetRowCount())
-	assert.Equal(t, "pool-1", listTable.Table.GetCell(1, 0).Text)
-	assert.Contains(t, listTable.Table.GetCell(1, 3).Text, "Manual: 5")
-	assert.Contains(t, listTable.Table.GetCell(1, 5).Text, "env: prod")
+	Kind.Shortcuts()
+	assert.Equal(t, "<r> Refresh  <d> Describe  <l> Logs  <s> Scale", footer.ContextShortcutView.GetText(true))
 }
@@ -0,0 +1,28 @@
+package confirm
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	MODAL_PAGE_ID = "confirm"
+)
+
+// New returns a modal asking to confirm an action, e.g. the deletion of a resource.
+// The modal is closed by close once answered, onConfirm is then called if the action is confirmed.
+// Esc answers no.
+func New(text string, onConfirm func(), close func()) *tview.Modal {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Yes", "No"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			close()
+			if buttonLabel == "Yes" {
+				onConfirm()
+			}
+		})
+	modal.SetBackgroundColor(tcell.ColorBlack)
+	modal.SetBorderColor(tcell.ColorYellow)
+	return modal
+}
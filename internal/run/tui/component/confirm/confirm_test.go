@@ -0,0 +1,35 @@
+package confirm
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     *tcell.EventKey
+		confirmed bool
+	}{
+		{"Yes", tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), true},
+		{"Esc", tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), false},
+	}
+	// focus focuses a primitive, and the primitives it delegates the focus to
+	var focus func(p tview.Primitive)
+	focus = func(p tview.Primitive) { p.Focus(focus) }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var confirmed, closed bool
+			modal := New("Delete?", func() { confirmed = true }, func() { closed = true })
+			focus(modal)
+
+			modal.InputHandler()(tt.event, focus)
+			assert.True(t, closed)
+			assert.Equal(t, tt.confirmed, confirmed)
+		})
+	}
+}
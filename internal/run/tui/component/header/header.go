@@ -27,6 +27,14 @@ func New(currentInfo info.Info) *tview.Flex {
 func UpdateInfo(currentInfo info.Info) {
 	infoView.Clear()
 
+	context := currentInfo.Context
+	if context == "" {
+		context = "-"
+	}
+	if currentInfo.ReadOnly {
+		context += " [red](read-only)"
+	}
+	_, _ = fmt.Fprintf(infoView, "[white]Context:        [#bd93f9]%s\n", context)
 	_, _ = fmt.Fprintf(infoView, "[white]Project:        [#bd93f9]%s\n", currentInfo.Project)
 	_, _ = fmt.Fprintf(infoView, "[white]Region:         [#bd93f9]%s\n", currentInfo.Region)
 	_, _ = fmt.Fprintf(infoView, "[white]User:           [#bd93f9]%s\n", currentInfo.User)
@@ -44,7 +52,8 @@ func columnInfo(currentInfo info.Info) *tview.TextView {
 func columnShortcuts() *tview.Flex {
 	col1 := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignLeft)
 	_, _ = fmt.Fprintf(col1, "[dodgerblue]<ctrl-p> [white]Project\n")
-	_, _ = fmt.Fprintf(col1, "[dodgerblue]<ctrl-r> [white]Region\n\n")
+	_, _ = fmt.Fprintf(col1, "[dodgerblue]<ctrl-r> [white]Region\n")
+	_, _ = fmt.Fprintf(col1, "[dodgerblue]<ctrl-x> [white]Context\n")
 	_, _ = fmt.Fprintf(col1, "[dodgerblue]<ctrl-z> [white]Console\n")
 	_, _ = fmt.Fprintf(col1, "[dodgerblue]<ctrl-l> [white]Releases\n")
 
@@ -53,6 +62,7 @@ func columnShortcuts() *tview.Flex {
 	_, _ = fmt.Fprintf(col2, "[dodgerblue]<ctrl-j> [white]Jobs\n")
 	_, _ = fmt.Fprintf(col2, "[dodgerblue]<ctrl-w> [white]Worker Pools\n")
 	_, _ = fmt.Fprintf(col2, "[dodgerblue]<ctrl-d> [white]Domain Mappings\n")
+	_, _ = fmt.Fprintf(col2, "[dodgerblue]<ctrl-o> [white]Operations\n")
 
 	return tview.NewFlex().
 		AddItem(col1, 20, 1, false).
@@ -32,9 +32,11 @@ func TestUpdateInfo(t *testing.T) {
 
 	// Now update it
 	newInfo := info.Info{
-		Project: "p2",
-		Region:  "r2",
-		User:    "u2",
+		Project:  "p2",
+		Region:   "r2",
+		User:     "u2",
+		Context:  "prod-eu",
+		ReadOnly: true,
 	}
 	
 	// This function modifies the global infoView. 
@@ -1,14 +1,28 @@
 package table
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+// loadMoreThreshold is the number of rows left below the selection when the next rows are loaded.
+const loadMoreThreshold = 10
+
 // Table represents a Table.
 type Table struct {
 	Title string
 	Table *tview.Table
+
+	// selectionChanged is called when the selection changes, see SetSelectionChangedFunc.
+	selectionChanged func(row, column int)
+	// loadMore loads the next rows, nil when all the rows are loaded, see SetLoadMore.
+	loadMore func()
 }
 
 // New creates a new table.
@@ -30,10 +44,71 @@ func New(title string) *Table {
 	table.SetTitleColor(tcell.ColorLightCyan)
 	table.SetTitleAlign(tview.AlignCenter)
 
-	return &Table{
+	t := &Table{
 		Title: title,
 		Table: table,
 	}
+	table.SetSelectionChangedFunc(t.onSelectionChanged)
+	return t
+}
+
+// SetSelectionChangedFunc sets the function called when the selection changes.
+// It must be used instead of the one of the tview table, which would disable SetLoadMore.
+func (t *Table) SetSelectionChangedFunc(fn func(row, column int)) {
+	t.selectionChanged = fn
+}
+
+// SetLoadMore sets the function loading the next rows, called once the selection gets close to the
+// last row, nil when all the rows are loaded. fn is called once, SetLoadMore must be called again
+// after each load.
+func (t *Table) SetLoadMore(fn func()) {
+	t.loadMore = fn
+	row, _ := t.Table.GetSelection()
+	t.checkLoadMore(row)
+}
+
+func (t *Table) onSelectionChanged(row, column int) {
+	if t.selectionChanged != nil {
+		t.selectionChanged(row, column)
+	}
+	t.checkLoadMore(row)
+}
+
+// checkLoadMore loads the next rows when the selected row is close to the last one.
+func (t *Table) checkLoadMore(row int) {
+	if t.loadMore == nil || row < t.Table.GetRowCount()-1-loadMoreThreshold {
+		return
+	}
+	load := t.loadMore
+	t.loadMore = nil
+	load()
+}
+
+// Count returns the number of rows shown in the title: n once all the rows are loaded, otherwise
+// how many are loaded out of total, an estimate, or 0 when it is unknown.
+func Count(n, total int, more bool) string {
+	switch {
+	case !more:
+		return strconv.Itoa(n)
+	case total > n:
+		return fmt.Sprintf("loaded %d of ~%d", n, total)
+	default:
+		return fmt.Sprintf("loaded %d, scroll for more", n)
+	}
+}
+
+// SetFailedRegions appends a warning badge listing the regions which could not be listed to the title, if any.
+func (t *Table) SetFailedRegions(regions []string) {
+	if len(regions) == 0 {
+		return
+	}
+	t.Table.SetTitle(fmt.Sprintf("%s[yellow]⚠ %s[-] ", t.Table.GetTitle(), strings.Join(regions, ", ")))
+}
+
+// SetStale appends a badge telling when the rows were refreshed to the title, e.g. for the rows
+// shown from the cache while they are refreshed.
+func (t *Table) SetStale(refreshed time.Time) {
+	t.Table.SetTitle(fmt.Sprintf("%s[gray]⟳ refreshed %s[-] ", t.Table.GetTitle(), humanize.Time(refreshed)))
 }
 
 // SetHeaders sets the table headers.
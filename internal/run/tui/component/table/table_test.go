@@ -1,7 +1,11 @@
 package table
 
 import (
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/rivo/tview"
 )
 
 func TestNew(t *testing.T) {
@@ -52,3 +56,73 @@ func TestSetHeaders(t *testing.T) {
 		t.Errorf("Expected header 'A', got '%s'", cell.Text)
 	}
 }
+
+func TestSetFailedRegions(t *testing.T) {
+	tbl := New("Services")
+
+	tbl.SetFailedRegions(nil)
+	if got := tbl.Table.GetTitle(); got != " Services (0) " {
+		t.Errorf("Expected unchanged title, got '%s'", got)
+	}
+
+	tbl.SetFailedRegions([]string{"europe-west1", "us-central1"})
+	if got := tbl.Table.GetTitle(); got != " Services (0) [yellow]⚠ europe-west1, us-central1[-] " {
+		t.Errorf("Expected warning badge, got '%s'", got)
+	}
+}
+
+func TestSetStale(t *testing.T) {
+	tbl := New("Services")
+
+	tbl.SetStale(time.Now().Add(-5 * time.Minute))
+	if got := tbl.Table.GetTitle(); got != " Services (0) [gray]⟳ refreshed 5 minutes ago[-] " {
+		t.Errorf("Expected stale badge, got '%s'", got)
+	}
+}
+
+func TestSetLoadMore(t *testing.T) {
+	tbl := New("Revisions")
+	tbl.SetHeaders([]string{"NAME"})
+	for i := 1; i <= 30; i++ {
+		tbl.Table.SetCell(i, 0, tview.NewTableCell(strconv.Itoa(i)))
+	}
+
+	var selected, loads int
+	tbl.SetSelectionChangedFunc(func(row, column int) { selected = row })
+	tbl.SetLoadMore(func() { loads++ })
+
+	tbl.Table.Select(5, 0)
+	if selected != 5 || loads != 0 {
+		t.Errorf("Expected no load far from the last row, got selected %d and %d loads", selected, loads)
+	}
+
+	tbl.Table.Select(25, 0)
+	tbl.Table.Select(26, 0)
+	if selected != 26 || loads != 1 {
+		t.Errorf("Expected a single load close to the last row, got selected %d and %d loads", selected, loads)
+	}
+
+	// The rows are loaded at once when the selection is already close to the last row.
+	tbl.SetLoadMore(func() { loads++ })
+	if loads != 2 {
+		t.Errorf("Expected a load when setting the function, got %d loads", loads)
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		n, total int
+		more     bool
+		want     string
+	}{
+		{50, 0, false, "50"},
+		{50, 1200, true, "loaded 50 of ~1200"},
+		{50, 0, true, "loaded 50, scroll for more"},
+		{50, 40, true, "loaded 50, scroll for more"},
+	}
+	for _, tt := range tests {
+		if got := Count(tt.n, tt.total, tt.more); got != tt.want {
+			t.Errorf("Count(%d, %d, %t) = %q, want %q", tt.n, tt.total, tt.more, got, tt.want)
+		}
+	}
+}
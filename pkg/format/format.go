@@ -3,6 +3,8 @@ package format
 import (
 	"fmt"
 	"io"
+	"strings"
+	"text/template"
 )
 
 // Format represents a format.
@@ -18,32 +20,133 @@ const (
 	YAML
 	// CUSTOM represents a custom format and use callback.
 	CUSTOM
+	// TABLE represents the aligned columns format.
+	TABLE
+	// CSV represents the comma separated values format.
+	CSV
+	// TEMPLATE represents the Go template format, e.g. go-template={{.name}}.
+	TEMPLATE
+	// JSONPATH represents the JSONPath format, e.g. jsonpath={.name}.
+	JSONPATH
+)
+
+// Prefixes of the formats taking an argument.
+const (
+	TemplatePrefix = "go-template="
+	JSONPathPrefix = "jsonpath="
 )
 
 // Print prints a formatted values.
+// Errors are silenced, use a Printer to get them.
 func Print(w io.Writer, f Format, v any, c Callback) {
-	switch f {
-	case JSON:
-		if b, err := ToJSON(v); err == nil {
-			_, _ = fmt.Fprint(w, string(b))
-		}
-	case YAML:
-		if b, err := ToYAML(v); err == nil {
-			_, _ = fmt.Fprint(w, string(b))
-		}
-	case CUSTOM:
-		c(w)
-	}
+	_ = (&Printer{Format: f}).Print(w, v, nil, c)
 }
 
 // StringToFormat converts string format to typed format.
 func StringToFormat(f string) Format {
-	switch f {
-	case "json":
+	switch {
+	case f == "json":
 		return JSON
-	case "yaml":
+	case f == "yaml":
 		return YAML
+	case f == "table":
+		return TABLE
+	case f == "csv":
+		return CSV
+	case strings.HasPrefix(f, TemplatePrefix):
+		return TEMPLATE
+	case strings.HasPrefix(f, JSONPathPrefix):
+		return JSONPATH
 	default:
 		return CUSTOM
 	}
 }
+
+// Printer prints values in a format.
+type Printer struct {
+	Format Format
+
+	template *template.Template
+	jsonPath *JSONPath
+}
+
+// NewPrinter returns the printer of a string format, parsing its template if any.
+func NewPrinter(f string) (*Printer, error) {
+	p := &Printer{Format: StringToFormat(f)}
+
+	var err error
+	switch p.Format {
+	case TEMPLATE:
+		p.template, err = parseTemplate(strings.TrimPrefix(f, TemplatePrefix))
+	case JSONPATH:
+		p.jsonPath, err = ParseJSONPath(strings.TrimPrefix(f, JSONPathPrefix))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Print prints v, or t for the table and CSV formats, or calls c for the custom format.
+// Without t, the table format calls c too, as it did before tables were printed.
+func (p *Printer) Print(w io.Writer, v any, t *Table, c Callback) error {
+	switch p.Format {
+	case JSON:
+		b, err := ToJSON(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(w, string(b))
+		return err
+	case YAML:
+		b, err := ToYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(w, string(b))
+		return err
+	case TABLE, CSV:
+		if t == nil && p.Format == TABLE && c != nil {
+			c(w)
+			return nil
+		}
+		if t == nil {
+			return fmt.Errorf("the %s format is not supported", p.name())
+		}
+		if p.Format == CSV {
+			return ToCSV(w, *t)
+		}
+		return ToTable(w, *t)
+	case TEMPLATE, JSONPATH:
+		if p.template == nil && p.jsonPath == nil {
+			return fmt.Errorf("the %s format requires a template", p.name())
+		}
+		data, err := toGeneric(v)
+		if err != nil {
+			return err
+		}
+		if p.template != nil {
+			return p.template.Execute(w, data)
+		}
+		return p.jsonPath.Execute(w, data)
+	case CUSTOM:
+		if c != nil {
+			c(w)
+		}
+	}
+	return nil
+}
+
+func (p *Printer) name() string {
+	switch p.Format {
+	case TABLE:
+		return "table"
+	case CSV:
+		return "csv"
+	case TEMPLATE:
+		return "go-template"
+	case JSONPATH:
+		return "jsonpath"
+	}
+	return fmt.Sprintf("%d", p.Format)
+}
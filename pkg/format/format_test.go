@@ -54,5 +54,51 @@ func TestPrintError(t *testing.T) {
 func TestStringToFormat(t *testing.T) {
 	assert.Equal(t, format.StringToFormat("yaml"), format.YAML)
 	assert.Equal(t, format.StringToFormat("json"), format.JSON)
+	assert.Equal(t, format.StringToFormat("table"), format.TABLE)
+	assert.Equal(t, format.StringToFormat("csv"), format.CSV)
+	assert.Equal(t, format.StringToFormat("go-template={{.name}}"), format.TEMPLATE)
+	assert.Equal(t, format.StringToFormat("jsonpath={.name}"), format.JSONPATH)
 	assert.Equal(t, format.StringToFormat("breux"), format.CUSTOM)
 }
+
+func TestPrinter(t *testing.T) {
+	w := &bytes.Buffer{}
+	v := map[string]string{"name": "s1"}
+	tbl := &format.Table{Headers: []string{"NAME"}, Rows: [][]string{{"s1"}}}
+
+	p, err := format.NewPrinter("table")
+	assert.NoError(t, err)
+	assert.NoError(t, p.Print(w, v, tbl, nil))
+	assert.Equal(t, "NAME\ns1\n", w.String())
+	w.Reset()
+
+	p, err = format.NewPrinter("csv")
+	assert.NoError(t, err)
+	assert.NoError(t, p.Print(w, v, tbl, nil))
+	assert.Equal(t, "NAME\ns1\n", w.String())
+	w.Reset()
+
+	p, err = format.NewPrinter("json")
+	assert.NoError(t, err)
+	assert.NoError(t, p.Print(w, v, tbl, nil))
+	assert.Equal(t, `{"name":"s1"}`, w.String())
+	w.Reset()
+
+	assert.Error(t, p.Print(w, make(chan int), nil, nil))
+
+	// Table formats require a table
+	p, _ = format.NewPrinter("csv")
+	assert.EqualError(t, p.Print(w, v, nil, nil), "the csv format is not supported")
+	p, _ = format.NewPrinter("table")
+	assert.EqualError(t, p.Print(w, v, nil, nil), "the table format is not supported")
+}
+
+func TestPrint_TableCallback(t *testing.T) {
+	w := &bytes.Buffer{}
+
+	// Without a table, the table format falls back to the callback.
+	format.Print(w, format.TABLE, map[string]string{"name": "s1"}, func(w io.Writer) {
+		_, _ = fmt.Fprint(w, "NAME s1")
+	})
+	assert.Equal(t, "NAME s1", w.String())
+}
@@ -0,0 +1,294 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath represents a parsed JSONPath template, e.g. {.items[*].name}.
+//
+// Supported expressions are fields (.name or ['name']), indexes ([0], [-1]),
+// wildcards ([*] or .*), string literals ({"\n"}) and {range ...}{end} blocks.
+// Missing fields produce no value. The values of an expression are separated
+// by spaces, strings are printed unquoted and other values as JSON.
+type JSONPath struct {
+	nodes []jsonPathNode
+}
+
+type jsonPathNode struct {
+	text  string
+	path  []jsonPathStep
+	isExp bool
+	// body is set on range blocks.
+	body []jsonPathNode
+}
+
+type jsonPathStep struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// ParseJSONPath parses a JSONPath template.
+// An expression without braces, e.g. .name, is wrapped in braces.
+func ParseJSONPath(text string) (*JSONPath, error) {
+	if text == "" {
+		return nil, fmt.Errorf("the jsonpath format requires a template")
+	}
+	if !strings.Contains(text, "{") {
+		text = "{" + text + "}"
+	}
+
+	p := &jsonPathParser{text: text}
+	nodes, err := p.parse(false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q: %w", text, err)
+	}
+	return &JSONPath{nodes: nodes}, nil
+}
+
+// Execute writes the template applied to data.
+func (j *JSONPath) Execute(w io.Writer, data any) error {
+	var b strings.Builder
+	if err := execute(&b, j.nodes, data); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func execute(b *strings.Builder, nodes []jsonPathNode, data any) error {
+	for _, n := range nodes {
+		switch {
+		case n.body != nil:
+			for _, v := range evaluate(n.path, data) {
+				if err := execute(b, n.body, v); err != nil {
+					return err
+				}
+			}
+		case n.isExp:
+			for i, v := range evaluate(n.path, data) {
+				if i > 0 {
+					b.WriteString(" ")
+				}
+				s, err := toText(v)
+				if err != nil {
+					return err
+				}
+				b.WriteString(s)
+			}
+		default:
+			b.WriteString(n.text)
+		}
+	}
+	return nil
+}
+
+// evaluate returns the values matching path in data.
+func evaluate(path []jsonPathStep, data any) []any {
+	values := []any{data}
+	for _, step := range path {
+		var next []any
+		for _, v := range values {
+			next = append(next, step.apply(v)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func (s jsonPathStep) apply(v any) []any {
+	switch {
+	case s.wildcard:
+		switch t := v.(type) {
+		case []any:
+			return t
+		case map[string]any:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			values := make([]any, 0, len(t))
+			for _, k := range keys {
+				values = append(values, t[k])
+			}
+			return values
+		}
+	case s.isIndex:
+		if t, ok := v.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(t)
+			}
+			if i >= 0 && i < len(t) {
+				return []any{t[i]}
+			}
+		}
+	default:
+		if t, ok := v.(map[string]any); ok {
+			if f, ok := t[s.field]; ok {
+				return []any{f}
+			}
+		}
+	}
+	return nil
+}
+
+func toText(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+type jsonPathParser struct {
+	text string
+	pos  int
+}
+
+// parse parses nodes until the end of the text, or until {end} when inRange is set.
+func (p *jsonPathParser) parse(inRange bool) ([]jsonPathNode, error) {
+	nodes := []jsonPathNode{}
+	for p.pos < len(p.text) {
+		start := strings.IndexByte(p.text[p.pos:], '{')
+		if start < 0 {
+			nodes = append(nodes, jsonPathNode{text: p.text[p.pos:]})
+			p.pos = len(p.text)
+			break
+		}
+		if start > 0 {
+			nodes = append(nodes, jsonPathNode{text: p.text[p.pos : p.pos+start]})
+		}
+		p.pos += start + 1
+
+		exp, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case exp == "end":
+			if !inRange {
+				return nil, fmt.Errorf("unexpected {end}")
+			}
+			return nodes, nil
+		case strings.HasPrefix(exp, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(exp, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			body, err := p.parse(true)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, body: body})
+		case strings.HasPrefix(exp, `"`):
+			text, err := strconv.Unquote(exp)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", exp)
+			}
+			nodes = append(nodes, jsonPathNode{text: text})
+		default:
+			path, err := parsePath(exp)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, isExp: true})
+		}
+	}
+
+	if inRange {
+		return nil, fmt.Errorf("missing {end}")
+	}
+	return nodes, nil
+}
+
+// expression returns the trimmed content of the braces opened before pos.
+func (p *jsonPathParser) expression() (string, error) {
+	quote := byte(0)
+	for i := p.pos; i < len(p.text); i++ {
+		c := p.text[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			exp := strings.TrimSpace(p.text[p.pos:i])
+			p.pos = i + 1
+			return exp, nil
+		}
+	}
+	return "", fmt.Errorf("unclosed expression")
+}
+
+func parsePath(exp string) ([]jsonPathStep, error) {
+	exp = strings.TrimPrefix(exp, "$")
+
+	var steps []jsonPathStep
+	for i := 0; i < len(exp); {
+		switch exp[i] {
+		case '.':
+			i++
+			end := i
+			for end < len(exp) && exp[end] != '.' && exp[end] != '[' {
+				end++
+			}
+			switch name := exp[i:end]; name {
+			case "":
+				if end < len(exp) && exp[end] == '.' {
+					return nil, fmt.Errorf("recursive descent is not supported")
+				}
+			case "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			default:
+				steps = append(steps, jsonPathStep{field: name})
+			}
+			i = end
+		case '[':
+			end := strings.IndexByte(exp[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ in %s", exp)
+			}
+			step, err := parseSubscript(exp[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("unexpected %q in %s, fields start with a dot", exp[i], exp)
+		}
+	}
+	return steps, nil
+}
+
+func parseSubscript(s string) (jsonPathStep, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "*":
+		return jsonPathStep{wildcard: true}, nil
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return jsonPathStep{field: s[1 : len(s)-1]}, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return jsonPathStep{}, fmt.Errorf("unsupported subscript [%s]", s)
+	}
+	return jsonPathStep{index: i, isIndex: true}, nil
+}
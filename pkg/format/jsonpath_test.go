@@ -0,0 +1,84 @@
+package format_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/JulienBreux/run-cli/pkg/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPath(t *testing.T) {
+	data := map[string]any{
+		"name":    "s1",
+		"uri":     "https://s1.run.app",
+		"ready":   true,
+		"traffic": []any{map[string]any{"revision": "s1-00002", "percent": 90.0}, map[string]any{"revision": "s1-00001", "percent": 10.0}},
+		"labels":  map[string]any{"team": "a", "env": "prod"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"Field", "{.uri}", "https://s1.run.app"},
+		{"Without braces", ".name", "s1"},
+		{"Root", "{$.name}", "s1"},
+		{"Bool", "{.ready}", "true"},
+		{"Index", "{.traffic[0].revision}", "s1-00002"},
+		{"Negative index", "{.traffic[-1].percent}", "10"},
+		{"Wildcard", "{.traffic[*].revision}", "s1-00002 s1-00001"},
+		{"Map wildcard", "{.labels.*}", "prod a"},
+		{"Quoted field", "{.labels['team']}", "a"},
+		{"Object", "{.labels}", `{"env":"prod","team":"a"}`},
+		{"Text", "name={.name}", "name=s1"},
+		{"Range", `{range .traffic[*]}{.revision}:{.percent}{"\n"}{end}`, "s1-00002:90\ns1-00001:10\n"},
+		{"Missing", "{.missing.field}", ""},
+		{"Out of range", "{.traffic[5]}", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := format.ParseJSONPath(tt.template)
+			assert.NoError(t, err)
+
+			w := &bytes.Buffer{}
+			assert.NoError(t, j.Execute(w, data))
+			assert.Equal(t, tt.expected, w.String())
+		})
+	}
+}
+
+func TestParseJSONPath_Errors(t *testing.T) {
+	for _, template := range []string{
+		"",
+		"{.name",
+		"{name}",
+		"{..name}",
+		"{.items[a]}",
+		"{.items[0}",
+		"{range .items[*]}{.name}",
+		"{.name}{end}",
+		`{"\q"}`,
+	} {
+		_, err := format.ParseJSONPath(template)
+		assert.Error(t, err, template)
+	}
+}
+
+func TestPrinter_JSONPath(t *testing.T) {
+	v := struct {
+		URI string `json:"uri"`
+	}{URI: "https://s1.run.app"}
+
+	p, err := format.NewPrinter("jsonpath={.uri}")
+	assert.NoError(t, err)
+	assert.Equal(t, format.JSONPATH, p.Format)
+
+	w := &bytes.Buffer{}
+	assert.NoError(t, p.Print(w, v, nil, nil))
+	assert.Equal(t, "https://s1.run.app", w.String())
+
+	_, err = format.NewPrinter("jsonpath={.uri")
+	assert.Error(t, err)
+}
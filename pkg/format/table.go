@@ -0,0 +1,41 @@
+package format
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table represents rows of columns.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// ToTable writes the table as aligned columns.
+func ToTable(w io.Writer, t Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if len(t.Headers) > 0 {
+		_, _ = fmt.Fprintln(tw, strings.Join(t.Headers, "\t"))
+	}
+	for _, row := range t.Rows {
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// ToCSV writes the table as comma separated values.
+func ToCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	if len(t.Headers) > 0 {
+		if err := cw.Write(t.Headers); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,31 @@
+package format_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/JulienBreux/run-cli/pkg/format"
+	"github.com/stretchr/testify/assert"
+)
+
+var table = format.Table{
+	Headers: []string{"NAME", "URL"},
+	Rows: [][]string{
+		{"hello", "https://hello.run.app"},
+		{"a, b", "https://b.run.app"},
+	},
+}
+
+func TestToTable(t *testing.T) {
+	w := &bytes.Buffer{}
+
+	assert.NoError(t, format.ToTable(w, table))
+	assert.Equal(t, "NAME    URL\nhello   https://hello.run.app\na, b    https://b.run.app\n", w.String())
+}
+
+func TestToCSV(t *testing.T) {
+	w := &bytes.Buffer{}
+
+	assert.NoError(t, format.ToCSV(w, table))
+	assert.Equal(t, "NAME,URL\nhello,https://hello.run.app\n\"a, b\",https://b.run.app\n", w.String())
+}
@@ -0,0 +1,38 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+// parseTemplate parses a Go template executed on the JSON document of values.
+func parseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, fmt.Errorf("the go-template format requires a template")
+	}
+	t, err := template.New("output").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid go-template: %w", err)
+	}
+	return t, nil
+}
+
+// toGeneric returns the JSON document of v as maps, slices and scalars,
+// so that templates use the same field names as the JSON format.
+func toGeneric(v any) (any, error) {
+	b, err := ToJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
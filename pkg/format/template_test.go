@@ -0,0 +1,42 @@
+package format_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/JulienBreux/run-cli/pkg/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter_Template(t *testing.T) {
+	v := []struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}{{Name: "s1", Port: 8080}, {Name: "s2", Port: 9090}}
+
+	p, err := format.NewPrinter(`go-template={{range .}}{{.name}}={{.port}} {{end}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, format.TEMPLATE, p.Format)
+
+	w := &bytes.Buffer{}
+	assert.NoError(t, p.Print(w, v, nil, nil))
+	assert.Equal(t, "s1=8080 s2=9090 ", w.String())
+
+	p, err = format.NewPrinter(`go-template={{json (index . 0)}}`)
+	assert.NoError(t, err)
+	w.Reset()
+	assert.NoError(t, p.Print(w, v, nil, nil))
+	assert.Equal(t, `{"name":"s1","port":8080}`, w.String())
+}
+
+func TestPrinter_TemplateErrors(t *testing.T) {
+	_, err := format.NewPrinter("go-template=")
+	assert.EqualError(t, err, "the go-template format requires a template")
+
+	_, err = format.NewPrinter("go-template={{.name")
+	assert.ErrorContains(t, err, "invalid go-template")
+
+	p, err := format.NewPrinter("go-template={{.name}}")
+	assert.NoError(t, err)
+	assert.Error(t, p.Print(&bytes.Buffer{}, make(chan int), nil, nil))
+}
@@ -35,7 +35,7 @@ func Date() (time.Time, error) {
 }
 
 // Print prints the version
-func Print(w io.Writer, f string) {
+func Print(w io.Writer, f string) error {
 	var c format.Callback = func(w io.Writer) {
 		const format = "%-15s %s\n"
 		_, _ = fmt.Fprintf(w, format, "Version:", Version)
@@ -47,5 +47,14 @@ func Print(w io.Writer, f string) {
 		Commit:  Commit,
 		Date:    RawDate,
 	}
-	format.Print(w, format.StringToFormat(f), v, c)
+	var t = &format.Table{
+		Headers: []string{"VERSION", "COMMIT", "DATE"},
+		Rows:    [][]string{{Version, Commit, RawDate}},
+	}
+
+	p, err := format.NewPrinter(f)
+	if err != nil {
+		return err
+	}
+	return p.Print(w, v, t, c)
 }
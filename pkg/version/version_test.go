@@ -66,3 +66,23 @@ func TestPrintVersionText(t *testing.T) {
 	r = regexp.MustCompile(`Version:\s+dev\nCommit:\s+n/a\nBuild date:\s+[0-9T:Z-]+\n`)
 	assert.Regexp(t, r, w.String())
 }
+
+func TestPrintVersionJSONPath(t *testing.T) {
+	w := &bytes.Buffer{}
+
+	assert.NoError(t, version.Print(w, "jsonpath={.version}"))
+	assert.Equal(t, "dev", w.String())
+}
+
+func TestPrintVersionTable(t *testing.T) {
+	var r *regexp.Regexp
+	w := &bytes.Buffer{}
+
+	assert.NoError(t, version.Print(w, "table"))
+	r = regexp.MustCompile(`VERSION\s+COMMIT\s+DATE\ndev\s+n/a\s+[0-9T:Z-]+\n`)
+	assert.Regexp(t, r, w.String())
+}
+
+func TestPrintVersionError(t *testing.T) {
+	assert.Error(t, version.Print(&bytes.Buffer{}, "go-template={{"))
+}